@@ -1,67 +1,268 @@
 package main
 
 import (
-	"log"
+	"context"
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/api"
+	"github.com/yourusername/firefly-garden/internal/chat"
 	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/level"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/internal/netplay"
+	"github.com/yourusername/firefly-garden/internal/prefs"
+	"github.com/yourusername/firefly-garden/internal/preset"
+	"github.com/yourusername/firefly-garden/internal/profiling"
 	"github.com/yourusername/firefly-garden/internal/render"
+	"github.com/yourusername/firefly-garden/internal/script"
+	"github.com/yourusername/firefly-garden/internal/theme"
+	"github.com/yourusername/firefly-garden/internal/tracing"
 )
 
+const banner = `===========================================
+  🌙 JARDÍN DE LUCIÉRNAGAS
+  Proyecto de Programación Concurrente
+===========================================
+
+Patrones de Concurrencia implementados:
+  • Fan-out/Fan-in: Luciérnagas → Agregador
+  • Productor-Consumidor: Manager → UI
+  • Worker Pool: Procesamiento paralelo
+
+Mecanismos de Sincronización:
+  • sync.Mutex / sync.RWMutex
+  • sync.WaitGroup
+  • context.Context
+  • Canales buffered
+
+Controles:
+  Click Izquierdo - Atraer luciérnagas
+  L - Colocar farol
+  W - Cambiar dirección del viento
+  P - Pausar/Reanudar
+  ESC - Terminar partida / Salir desde el menú
+===========================================
+
+`
+
 func main() {
+	configFlags := config.BindFlags(flag.CommandLine)
+	logFlags := logging.BindFlags(flag.CommandLine)
+	recordPath := flag.String("record", "", "grabar comandos y eventos de la partida en este archivo (JSONL)")
+	replayPath := flag.String("replay", "", "reproducir una partida grabada con -record")
+	replaySpeed := flag.Int("replay-speed", 1, "velocidad inicial de la reproducción (1, 2 o 4)")
+	pprofAddr := flag.String("pprof", "", "exponer net/http/pprof y expvar (/debug/vars) en esta dirección (por ejemplo :6060)")
+	apiAddr := flag.String("api", "", "exponer la API HTTP de control en esta dirección (por ejemplo :8080)")
+	grpcAddr := flag.String("grpc", "", "exponer la API gRPC de control en esta dirección (por ejemplo :9090)")
+	hostAddr := flag.String("host", "", "ser anfitrión de una partida en red en esta dirección (por ejemplo :7777)")
+	joinAddr := flag.String("join", "", "unirse al jardín de un anfitrión (por ejemplo 192.168.1.10:7777)")
+	spectateAddr := flag.String("spectate", "", "mirar sin intervenir el jardín de un anfitrión (por ejemplo para proyectarlo)")
+	chatSpec := flag.String("chat", "", "órdenes desde un chat: twitch:CANAL, youtube:LIVE_CHAT_ID o stdin")
+	chatOpts := chat.DefaultOptions()
+	flag.DurationVar(&chatOpts.UserCooldown, "chat-cooldown", chatOpts.UserCooldown, "tiempo mínimo entre órdenes de un mismo usuario del chat")
+	flag.IntVar(&chatOpts.PerMinute, "chat-rate", chatOpts.PerMinute, "máximo de órdenes del chat por minuto")
+	scriptPath := flag.String("script", "", "conducir la partida con este escenario (ver scenarios/)")
+	mapPath := flag.String("map", "", "jugar en este mapa: tamaño del mundo, obstáculos, estanques, faroles y eventos (ver maps/)")
+	levelsPath := flag.String("levels", "", "archivo JSON con los niveles del modo niveles (por defecto los del juego)")
+	themesPath := flag.String("themes", "", "directorio con temas visuales extra (*.json, ver internal/theme/skins)")
+	presetName := flag.String("preset", "", "arrancar con un escenario listo: "+strings.Join(preset.Names(), ", "))
+	demo := flag.Bool("demo", false, "arrancar en modo demostración: el jardín juega solo hasta que haya input")
+	flag.Parse()
+
+	cfg, err := configFlags.Load()
+	if err != nil {
+		logging.Fatal("configuración inválida", "err", err)
+	}
+	config.Set(cfg)
+	config.SetSource(configFlags.Source())
+
+	logFile, err := logFlags.Setup(cfg.LogLevel)
+	if err != nil {
+		logging.Fatal("no se pudo configurar el log", "err", err)
+	}
+	defer logFile.Close()
+	log := logging.For("main")
+
+	if *pprofAddr != "" {
+		profiling.StartServer(*pprofAddr)
+	}
+
+	shutdownTracing, err := tracing.Setup(cfg.Tracing, "firefly-garden")
+	if err != nil {
+		logging.Fatal("no se pudo iniciar el trazado", "err", err)
+	}
+	defer shutdownTracing()
+
+	session := render.SessionOptions{RecordPath: *recordPath, ReplaySpeed: *replaySpeed, Demo: *demo}
+	if *replayPath != "" {
+		replay, err := manager.LoadReplay(*replayPath)
+		if err != nil {
+			logging.Fatal("no se pudo leer la repetición", "path", *replayPath, "err", err)
+		}
+		session.Replay = replay
+		log.Info("repetición cargada", "path", *replayPath, "events", len(replay.Events), "duration", replay.Duration().Round(time.Second))
+	}
+	if *scriptPath != "" {
+		scenario, err := script.Load(*scriptPath)
+		if err != nil {
+			logging.Fatal("escenario inválido", "path", *scriptPath, "err", err)
+		}
+		if session.Replay != nil {
+			log.Warn("-script se ignora durante una repetición")
+		}
+		session.Script = scenario
+	}
+	if *mapPath != "" {
+		gardenMap, err := manager.LoadMap(*mapPath)
+		if err != nil {
+			logging.Fatal("mapa inválido", "path", *mapPath, "err", err)
+		}
+		events, err := script.FromMap(gardenMap)
+		if err != nil {
+			logging.Fatal("eventos del mapa inválidos", "path", *mapPath, "err", err)
+		}
+		// Un -script explícito reemplaza a los eventos del mapa
+		if events != nil && session.Script == nil && session.Replay == nil {
+			session.Script = events
+		}
+		session.Map = gardenMap
+		session.MapPath = *mapPath
+		log.Info("mapa cargado", "path", *mapPath, "map", gardenMap.Name)
+	}
+	if *presetName != "" {
+		p, ok := preset.Find(*presetName)
+		if !ok {
+			logging.Fatal("-preset desconocido", "preset", *presetName, "presets", strings.Join(preset.Names(), ", "))
+		}
+		if _, err := p.Config(cfg); err != nil {
+			logging.Fatal("preset inválido con esta configuración", "err", err)
+		}
+		// App aplica su configuración al arrancar la partida, así el menú
+		// vuelve a la de siempre
+		session.Preset = p
+	}
+	if *levelsPath != "" {
+		campaign, err := level.Load(*levelsPath)
+		if err != nil {
+			logging.Fatal("niveles inválidos", "path", *levelsPath, "err", err)
+		}
+		session.Levels = campaign
+		log.Info("niveles cargados", "path", *levelsPath, "levels", len(campaign.Levels))
+	}
+	if *themesPath != "" {
+		n, err := theme.LoadSkins(*themesPath)
+		if err != nil {
+			logging.Fatal("temas inválidos", "path", *themesPath, "err", err)
+		}
+		log.Info("temas cargados", "path", *themesPath, "themes", n)
+	}
+
+	// El anfitrión comparte su jardín por la sesión gRPC de la API de control
+	if *hostAddr != "" {
+		if *joinAddr != "" || *spectateAddr != "" || (*grpcAddr != "" && *grpcAddr != *hostAddr) {
+			logging.Fatal("-host no se puede combinar con -join, -spectate ni con otra dirección -grpc")
+		}
+		*grpcAddr = *hostAddr
+		log.Info("modo anfitrión: los jugadores se unen con -join", "addr", *hostAddr)
+	}
+
+	if *joinAddr != "" && *spectateAddr != "" {
+		logging.Fatal("-join y -spectate no se pueden combinar")
+	}
+	if *joinAddr != "" || *spectateAddr != "" {
+		if session.Replay != nil || session.Script != nil || session.RecordPath != "" {
+			log.Warn("-record, -replay y -script se ignoran al unirse a otro jardín")
+		}
+
+		var client *netplay.Client
+		if *joinAddr != "" {
+			client, err = netplay.Dial(*joinAddr)
+		} else {
+			client, err = netplay.Spectate(*spectateAddr)
+		}
+		if err != nil {
+			logging.Fatal("no se pudo unir al jardín", "addr", *joinAddr+*spectateAddr, "err", err)
+		}
+		session.Join = client
+	}
+
+	if *chatSpec != "" {
+		src, err := chat.ParseSource(*chatSpec)
+		if err != nil {
+			logging.Fatal("-chat inválido", "spec", *chatSpec, "err", err)
+		}
+		if session.Join != nil {
+			log.Warn("-chat se ignora al unirse a otro jardín")
+		}
+		chatCtx, cancelChat := context.WithCancel(context.Background())
+		defer cancelChat()
+		session.Chat = chat.NewBridge(chatOpts)
+		go session.Chat.Run(chatCtx, src)
+	}
+
+	if *apiAddr != "" || *grpcAddr != "" {
+		session.API = api.NewServer(*apiAddr, *grpcAddr)
+		session.API.Start()
+	}
+
+	userPrefs, err := prefs.Load(cfg.Render.Quality)
+	if err != nil {
+		log.Warn("no se pudieron leer las preferencias", "err", err)
+	}
+
 	ebiten.SetWindowSize(config.ScreenWidth, config.ScreenHeight)
+	if w := userPrefs.Window; w.Width > 0 && w.Height > 0 {
+		ebiten.SetWindowSize(w.Width, w.Height)
+		ebiten.SetWindowPosition(w.X, w.Y)
+	}
+	ebiten.SetFullscreen(userPrefs.Window.Fullscreen)
 	ebiten.SetWindowTitle("🌙 Jardín de Luciérnagas - Programación Concurrente")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetWindowClosingHandled(true)
 	ebiten.SetVsyncEnabled(true)
-	ebiten.SetTPS(config.TargetFPS)
+	ebiten.SetTPS(config.Get().TargetFPS)
 	
-	game := render.NewGame()
+	app := render.NewApp(userPrefs, session)
 	
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	
 	go func() {
 		<-sigChan
-		log.Println("Señal de interrupción recibida, cerrando limpiamente...")
-		game.Shutdown()
+		log.Info("señal de interrupción recibida, cerrando limpiamente")
+		app.Shutdown()
 		os.Exit(0)
 	}()
 	
-	log.Println("===========================================")
-	log.Println("  🌙 JARDÍN DE LUCIÉRNAGAS")
-	log.Println("  Proyecto de Programación Concurrente")
-	log.Println("===========================================")
-	log.Println()
-	log.Println("Patrones de Concurrencia implementados:")
-	log.Println("  • Fan-out/Fan-in: Luciérnagas → Agregador")
-	log.Println("  • Productor-Consumidor: Manager → UI")
-	log.Println("  • Worker Pool: Procesamiento paralelo")
-	log.Println()
-	log.Println("Mecanismos de Sincronización:")
-	log.Println("  • sync.Mutex / sync.RWMutex")
-	log.Println("  • sync.WaitGroup")
-	log.Println("  • context.Context")
-	log.Println("  • Canales buffered")
-	log.Println()
-	log.Println("Controles:")
-	log.Println("  Click Izquierdo - Atraer luciérnagas")
-	log.Println("  L - Colocar farol")
-	log.Println("  W - Cambiar dirección del viento")
-	log.Println("  P - Pausar/Reanudar")
-	log.Println("  ESC - Salir")
-	log.Println("===========================================")
-	log.Println()
-	log.Println("Ejecutando juego...")
-	log.Println("Verifica ausencia de race conditions con: go run -race cmd/game/main.go")
-	log.Println()
+	if !logFlags.Quiet() {
+		fmt.Fprint(os.Stderr, banner)
+	}
+	log.Info("ejecutando juego", "tip", "verifica ausencia de race conditions con: go run -race cmd/game/main.go")
 	
-	if err := ebiten.RunGame(game); err != nil {
-		log.Fatal(err)
+	if err := ebiten.RunGame(app); err != nil {
+		logging.Fatal("error en el bucle del juego", "err", err)
 	}
 	
-	game.Shutdown()
-	log.Println("Juego cerrado correctamente. ¡Adiós!")
+	app.Shutdown()
+
+	if session.API != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		if err := session.API.Shutdown(ctx); err != nil {
+			log.Warn("la API de control no se cerró a tiempo", "err", err)
+		}
+		cancel()
+	}
+
+	if err := app.Prefs().Save(); err != nil {
+		log.Warn("no se pudieron guardar las preferencias", "err", err)
+	}
+	log.Info("juego cerrado correctamente")
 }
\ No newline at end of file
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/yourusername/firefly-garden/internal/golden"
+	"github.com/yourusername/firefly-garden/internal/logging"
+)
+
+// golden corre los casos fijos del jardín en modo sincrónico y compara la
+// huella del mundo cada N ticks con los archivos del repositorio; sale con
+// código 1 si alguna difiere. Con -update los reescribe.
+func main() {
+	dir := flag.String("dir", "golden", "directorio de los archivos de huellas")
+	update := flag.Bool("update", false, "reescribir los archivos con el resultado actual")
+	only := flag.String("case", "", "correr solo este caso")
+	repeat := flag.Int("repeat", 2, "veces que se corre cada caso; todas deben dar lo mismo")
+	logFlags := logging.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	logFile, err := logFlags.Setup("warn")
+	if err != nil {
+		logging.Fatal("no se pudo configurar el log", "err", err)
+	}
+	defer logFile.Close()
+
+	cases := golden.Cases
+	if *only != "" {
+		c, ok := golden.Find(*only)
+		if !ok {
+			logging.Fatal("caso desconocido", "case", *only)
+		}
+		cases = []golden.Case{c}
+	}
+
+	failed := 0
+	for _, c := range cases {
+		if err := check(c, *dir, *update, max(*repeat, 1)); err != nil {
+			fmt.Printf("%-12s FALLÓ  %v\n", c.Name, err)
+			failed++
+			continue
+		}
+		if *update {
+			fmt.Printf("%-12s escrito en %s\n", c.Name, golden.Path(*dir, c))
+		} else {
+			fmt.Printf("%-12s OK\n", c.Name)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n%d de %d casos difieren; si el cambio es buscado, correr con -update\n", failed, len(cases))
+		os.Exit(1)
+	}
+}
+
+// check corre el caso repeat veces y compara cada corrida con la primera,
+// y la primera con el archivo (o lo reescribe con update)
+func check(c golden.Case, dir string, update bool, repeat int) error {
+	got, err := golden.Run(c)
+	if err != nil {
+		return err
+	}
+
+	// Una diferencia entre corridas es no determinismo, no un cambio de
+	// comportamiento: se reporta aparte
+	for range repeat - 1 {
+		again, err := golden.Run(c)
+		if err != nil {
+			return err
+		}
+		if first, other, differs := golden.Diff(got, again); differs {
+			return fmt.Errorf("no determinista en el tick %d: %s / %s", max(first.Tick, other.Tick), first, other)
+		}
+	}
+
+	path := golden.Path(dir, c)
+	if update {
+		return golden.Save(path, c, got)
+	}
+
+	want, err := golden.Load(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no existe %s (correr con -update)", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	if w, g, differs := golden.Diff(want, got); differs {
+		return fmt.Errorf("tick %d: esperado %q, obtenido %q", max(w.Tick, g.Tick), w, g)
+	}
+	return nil
+}
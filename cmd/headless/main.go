@@ -0,0 +1,365 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/api"
+	"github.com/yourusername/firefly-garden/internal/chat"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/internal/preset"
+	"github.com/yourusername/firefly-garden/internal/profiling"
+	"github.com/yourusername/firefly-garden/internal/script"
+	"github.com/yourusername/firefly-garden/internal/tracing"
+	"github.com/yourusername/firefly-garden/pkg/garden"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// headless ejecuta la simulación sin ventana (CI, servidores) y reporta
+// población y estados descartados; al terminar verifica que no queden goroutines vivas
+func main() {
+	duration := flag.Duration("duration", 30*time.Second, "tiempo de simulación")
+	ticks := flag.Int("ticks", 0, "número de ticks a simular (si es > 0 reemplaza a -duration)")
+	report := flag.Duration("report", time.Second, "intervalo entre reportes")
+	lanterns := flag.Int("lanterns", 0, "faroles colocados al azar al iniciar")
+	pprofAddr := flag.String("pprof", "", "exponer net/http/pprof y expvar (/debug/vars) en esta dirección (por ejemplo :6060)")
+	apiAddr := flag.String("api", "", "exponer la API HTTP de control en esta dirección (por ejemplo :8080)")
+	grpcAddr := flag.String("grpc", "", "exponer la API gRPC de control en esta dirección (por ejemplo :9090)")
+	chatSpec := flag.String("chat", "", "órdenes desde un chat: twitch:CANAL, youtube:LIVE_CHAT_ID o stdin")
+	chatOpts := chat.DefaultOptions()
+	flag.DurationVar(&chatOpts.UserCooldown, "chat-cooldown", chatOpts.UserCooldown, "tiempo mínimo entre órdenes de un mismo usuario del chat")
+	flag.IntVar(&chatOpts.PerMinute, "chat-rate", chatOpts.PerMinute, "máximo de órdenes del chat por minuto")
+	fakeClock := flag.Bool("fake-clock", false, "avanzar la simulación con un reloj simulado, sin esperar entre ticks")
+	scriptPath := flag.String("script", "", "ejecutar este escenario; sin -duration ni -ticks la simulación dura lo que el escenario")
+	mapPath := flag.String("map", "", "simular en este mapa; sus eventos corren como escenario si no se indica -script")
+	presetName := flag.String("preset", "", "simular un escenario listo ("+strings.Join(preset.Names(), ", ")+"); sus eventos corren si no hay -script ni mapa con eventos")
+	configFlags := config.BindFlags(flag.CommandLine)
+	logFlags := logging.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	cfg, err := configFlags.Load()
+	if err != nil {
+		logging.Fatal("configuración inválida", "err", err)
+	}
+	config.Set(cfg)
+	config.SetSource(configFlags.Source())
+
+	// El preset va como capa: una recarga del archivo no lo deshace
+	var selected *preset.Preset
+	if *presetName != "" {
+		var ok bool
+		if selected, ok = preset.Find(*presetName); !ok {
+			logging.Fatal("-preset desconocido", "preset", *presetName, "presets", strings.Join(preset.Names(), ", "))
+		}
+		if err := config.SetOverlay(selected.Config); err != nil {
+			logging.Fatal("preset inválido con esta configuración", "err", err)
+		}
+		cfg = config.Get()
+	}
+
+	logFile, err := logFlags.Setup(cfg.LogLevel)
+	if err != nil {
+		logging.Fatal("no se pudo configurar el log", "err", err)
+	}
+	defer logFile.Close()
+	log := logging.For("headless")
+
+	if *pprofAddr != "" {
+		profiling.StartServer(*pprofAddr)
+	}
+
+	shutdownTracing, err := tracing.Setup(cfg.Tracing, "firefly-garden-headless")
+	if err != nil {
+		logging.Fatal("no se pudo iniciar el trazado", "err", err)
+	}
+
+	var scenario *script.Script
+	if *scriptPath != "" {
+		scenario, err = script.Load(*scriptPath)
+		if err != nil {
+			logging.Fatal("escenario inválido", "path", *scriptPath, "err", err)
+		}
+	}
+
+	var gardenMap *manager.GardenMap
+	if *mapPath != "" {
+		gardenMap, err = manager.LoadMap(*mapPath)
+		if err != nil {
+			logging.Fatal("mapa inválido", "path", *mapPath, "err", err)
+		}
+		events, err := script.FromMap(gardenMap)
+		if err != nil {
+			logging.Fatal("eventos del mapa inválidos", "path", *mapPath, "err", err)
+		}
+		if scenario == nil {
+			scenario = events
+		}
+	}
+	// Los eventos del preset acompañan la simulación pero no fijan cuánto dura
+	fromPreset := false
+	if selected != nil && scenario == nil {
+		fromPreset = true
+		if scenario, err = selected.Script(); err != nil {
+			logging.Fatal("eventos del preset inválidos", "preset", selected.Name, "err", err)
+		}
+	}
+
+	// La fuente se crea antes de medir la línea base: la de stdin deja una
+	// goroutine leyendo hasta el fin de la entrada
+	var chatSource chat.Source
+	if *chatSpec != "" {
+		chatSource, err = chat.ParseSource(*chatSpec)
+		if err != nil {
+			logging.Fatal("-chat inválido", "spec", *chatSpec, "err", err)
+		}
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	baseline := runtime.NumGoroutine()
+
+	// El manager se crea aquí, como en la partida: el resumen final lee
+	// contadores que pkg/garden no expone
+	fm := manager.NewFireflyManager()
+	g := garden.Wrap(fm)
+	var clock garden.Clock = core.RealClock
+	var fake *garden.FakeClock
+	if *fakeClock {
+		fake = garden.NewFakeClock(time.Now())
+		clock = fake
+		g.SetClock(fake)
+	}
+	fm.ApplyMap(gardenMap)
+	g.Start()
+	if selected != nil && selected.Election {
+		go fm.SetElection(true)
+	}
+
+	width, height := config.WorldSize()
+	for i := 0; i < *lanterns; i++ {
+		pos := utils.RandomVector2D(0, width, 0, height)
+		if err := g.Command(garden.Command{Kind: garden.AddLantern, Position: pos}); err != nil {
+			log.Warn("no se pudo colocar farol", "x", pos.X, "y", pos.Y, "err", err)
+		}
+	}
+
+	var server *api.Server
+	if *apiAddr != "" || *grpcAddr != "" {
+		server = api.NewServer(*apiAddr, *grpcAddr)
+		server.Attach(g)
+		server.Start()
+	}
+
+	// El escenario y el chat corren en sus propias goroutines y solo usan g.Command
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var bridge *chat.Bridge
+	chatDone := make(chan struct{})
+	if chatSource != nil {
+		bridge = chat.NewBridge(chatOpts)
+		bridge.Attach(g, nil)
+		go func() {
+			defer close(chatDone)
+			bridge.Run(ctx, chatSource)
+		}()
+	}
+
+	var runner *script.Runner
+	var scriptDone <-chan struct{}
+	if scenario != nil {
+		runner = script.NewRunner(scenario)
+		runner.Start(ctx, g)
+		if !fromPreset {
+			scriptDone = runner.Done()
+		}
+	}
+
+	tickDuration := time.Second / time.Duration(config.Get().SimulationTPS)
+	ticker := time.NewTicker(tickDuration)
+	// El reporte va con el reloj de la simulación: con el simulado sale
+	// cada *report de tiempo simulado
+	reportTicker := clock.NewTicker(*report)
+
+	// Con el reloj simulado no se espera al ticker: cada vuelta avanza el
+	// reloj un tick y espera a que las luciérnagas lo publiquen. El reporte
+	// se revisa justo después, así cae siempre en el mismo tick.
+	tickC := ticker.C
+	reportC := reportTicker.C()
+	if fake != nil {
+		ready := make(chan time.Time)
+		close(ready)
+		tickC = ready
+		reportC = nil
+	}
+
+	total := *ticks
+	if total <= 0 {
+		total = int(duration.Seconds() * float64(config.Get().SimulationTPS))
+	}
+	if scriptDone != nil && !flagSet("duration") && !flagSet("ticks") {
+		total = math.MaxInt
+	}
+
+	// Con -quiet solo se imprime el resumen final
+	quiet := logFlags.Quiet()
+	if !quiet {
+		fmt.Printf("%8s %8s %10s %12s %10s\n", "Tick", "Tiempo", "Población", "Descartados", "Goroutines")
+	}
+
+	start := clock.Now()
+	peak := 0
+	tick := 0
+
+	printReport := func() {
+		if quiet {
+			return
+		}
+		snap := g.Snapshot()
+		fmt.Printf("%8d %8s %10d %12d %10d\n",
+			tick, clock.Now().Sub(start).Round(time.Second), len(snap.Fireflies), snap.Dropped, runtime.NumGoroutine())
+	}
+
+loop:
+	for tick < total {
+		select {
+		case <-sigChan:
+			log.Info("señal de interrupción recibida, cerrando limpiamente")
+			break loop
+
+		case <-scriptDone:
+			break loop
+
+		case <-tickC:
+			if fake != nil {
+				g.Advance(tickDuration)
+			}
+			g.Tick(tickDuration.Seconds())
+			if server != nil {
+				server.Refresh(g)
+			}
+			tick++
+			if count := len(g.Snapshot().Fireflies); count > peak {
+				peak = count
+			}
+			if fake != nil {
+				select {
+				case <-reportTicker.C():
+					printReport()
+				default:
+				}
+			}
+
+		case <-reportC:
+			printReport()
+		}
+	}
+
+	ticker.Stop()
+	reportTicker.Stop()
+
+	if server != nil {
+		server.Attach(nil)
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), time.Second)
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Warn("la API de control no se cerró a tiempo", "err", err)
+		}
+		cancelShutdown()
+	}
+
+	var scriptErr error
+	if runner != nil {
+		cancel()
+		<-runner.Done()
+		if err := runner.Err(); err != nil && !errors.Is(err, context.Canceled) {
+			scriptErr = err
+		}
+	}
+
+	if bridge != nil {
+		cancel()
+		<-chatDone
+	}
+
+	snap := g.Snapshot()
+	finalCount := len(snap.Fireflies)
+	dropped := snap.Dropped
+	chaos := fm.GetChaosCounts()
+	spawns := fm.GetSpawnCounts()
+	capacity := fm.GetCarryingCapacity()
+	batch := fm.GetBatchStats()
+	applied := fm.Flow().States
+	g.Stop()
+	// Las trazas salen antes de contar goroutines: el exportador tiene las suyas
+	shutdownTracing()
+
+	// Dar tiempo al runtime para terminar goroutines auxiliares
+	time.Sleep(100 * time.Millisecond)
+	leaked := runtime.NumGoroutine() - baseline
+
+	fmt.Println()
+	fmt.Printf("Ticks: %d  Tiempo: %v\n", tick, clock.Now().Sub(start).Round(time.Millisecond))
+	fmt.Printf("Población final: %d  Pico: %d  Objetivo: %d (%s)\n", finalCount, peak, config.Get().Spawn.Objective, config.Get().Spawn.Policy)
+	fmt.Printf("Estados descartados: %d\n", dropped)
+	fmt.Printf("Spawns: %d admitidos, %d rechazados por el límite (%d)\n", spawns.Admitted, spawns.Rejected, spawns.Cap)
+	fmt.Printf("Goroutines tras Stop: %d por encima de la línea base\n", leaked)
+
+	if config.Get().Ecology.Enabled {
+		fmt.Printf("Ecología: capacidad final %d (reemplaza a spawn.policy)\n", capacity)
+	}
+
+	if batch.Enabled {
+		fmt.Printf("Lotes: %d shards, %d lotes, %d estados aplicados, %d coalescidos\n",
+			batch.Shards, batch.Batches, applied, batch.Coalesced)
+	}
+
+	if chaos.Enabled {
+		fmt.Printf("Caos: %d estados demorados, %d descartados, %d goroutines tiradas (%d limpiadas), %d workers trabados\n",
+			chaos.Delayed, chaos.Dropped, chaos.Crashed, chaos.Reaped, chaos.Stalled)
+	}
+
+	if bridge != nil {
+		accepted, rejected := bridge.Counts()
+		fmt.Printf("Órdenes del chat: %d aplicadas, %d rechazadas\n", accepted, rejected)
+	}
+
+	if scenario != nil {
+		if scriptErr != nil {
+			fmt.Printf("Escenario: FALLÓ (%v)\n", scriptErr)
+		} else {
+			fmt.Println("Escenario: OK")
+		}
+	}
+
+	if leaked > 0 {
+		log.Error("posible fuga de goroutines", "leaked", leaked)
+		os.Exit(1)
+	}
+	if scriptErr != nil {
+		os.Exit(1)
+	}
+}
+
+// flagSet indica si el flag se pasó explícitamente
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
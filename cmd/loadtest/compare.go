@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/metrics"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// architecture es una forma de mover las luciérnagas: start las pone a
+// correr hasta que se cancele ctx y retorna cuántas goroutines lanzó y la
+// función que espera a que terminen
+type architecture struct {
+	name  string
+	start func(ctx context.Context, fireflies []*core.Firefly, stateCh chan<- core.FireflyState, tick time.Duration, dt float64) (int, func())
+}
+
+// architectures son las dos que compara -compare: la del juego, una
+// goroutine con su ticker por luciérnaga, y un ticker único que reparte
+// las luciérnagas en lotes entre los workers de un WorkerPool
+func architectures(workers int) []architecture {
+	return []architecture{
+		{name: "goroutines", start: startPerFirefly},
+		{name: "lotes", start: func(ctx context.Context, fireflies []*core.Firefly, stateCh chan<- core.FireflyState, tick time.Duration, dt float64) (int, func()) {
+			return startBatched(ctx, fireflies, stateCh, tick, dt, workers)
+		}},
+	}
+}
+
+// startPerFirefly es la arquitectura del juego: cada luciérnaga en su
+// goroutine, publicando al agregador en cada tick de su ticker
+func startPerFirefly(ctx context.Context, fireflies []*core.Firefly, stateCh chan<- core.FireflyState, tick time.Duration, dt float64) (int, func()) {
+	var wg sync.WaitGroup
+	for _, firefly := range fireflies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			firefly.Run(ctx, stateCh, nil, tick, dt)
+		}()
+	}
+	return len(fireflies), wg.Wait
+}
+
+// startBatched avanza todas las luciérnagas desde un solo ticker: en cada
+// tick las parte en un lote por worker, los encola en el pool y espera a
+// que terminen antes del siguiente. Las que mueren de viejas salen del lote.
+func startBatched(ctx context.Context, fireflies []*core.Firefly, stateCh chan<- core.FireflyState, tick time.Duration, dt float64, workers int) (int, func()) {
+	pool := manager.NewWorkerPool(workers, workers, workers)
+	pool.Start()
+
+	alive := append([]*core.Firefly(nil), fireflies...)
+	dead := make([]bool, len(alive))
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer pool.Stop()
+
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+
+		var wg sync.WaitGroup
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			size := (len(alive) + workers - 1) / workers
+			for from := 0; from < len(alive); from += size {
+				to := min(from+size, len(alive))
+				wg.Add(1)
+				job := manager.Job{ID: from, Task: func() interface{} {
+					defer wg.Done()
+					for i := from; i < to; i++ {
+						dead[i] = alive[i].Step(stateCh, dt)
+					}
+					return nil
+				}}
+				for !pool.Submit(job) {
+					runtime.Gosched()
+				}
+			}
+			wg.Wait()
+
+			kept := alive[:0]
+			for i, firefly := range alive {
+				if !dead[i] {
+					kept = append(kept, firefly)
+				}
+				dead[i] = false
+			}
+			alive = kept
+		}
+	}()
+
+	// El ticker y los workers del pool
+	return workers + 1, func() { <-done }
+}
+
+// architectureResult es una fila de la tabla de -compare
+type architectureResult struct {
+	name       string
+	fireflies  int
+	goroutines int
+	cpu        float64
+	allocBytes uint64
+	mallocs    uint64
+	statesPS   float64
+	droppedPS  float64
+	dropPct    float64
+	frameP50   time.Duration
+	frameP99   time.Duration
+	frameMax   time.Duration
+}
+
+// runComparison corre el mismo escenario sembrado con cada arquitectura y
+// cada cantidad de luciérnagas, e imprime la tabla comparativa
+func runComparison(sizes []int, duration time.Duration, seed int64, workers int) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	fmt.Printf("\nComparación de arquitecturas: semilla %d, %v por escenario, %d workers en lotes\n", seed, duration, workers)
+	fmt.Printf("%-11s %9s %7s %8s %10s %10s %11s %11s %8s %9s %9s %9s\n",
+		"Arquitect.", "Luciérn.", "Gorout.", "CPU (s)", "Asignado", "Allocs", "Estados/s", "Descart./s", "Drop %", "Frame p50", "Frame p99", "Frame máx")
+
+	for _, n := range sizes {
+		var results []architectureResult
+		for _, arch := range architectures(workers) {
+			r := runArchitecture(arch, n, duration, seed)
+			results = append(results, r)
+			fmt.Printf("%-11s %9d %7d %8.2f %8.1f MB %10d %11.0f %11.0f %7.2f%% %9v %9v %9v\n",
+				r.name, r.fireflies, r.goroutines, r.cpu, float64(r.allocBytes)/1e6, r.mallocs,
+				r.statesPS, r.droppedPS, r.dropPct,
+				r.frameP50.Round(time.Microsecond), r.frameP99.Round(time.Microsecond), r.frameMax.Round(time.Microsecond))
+		}
+		base, batched := results[0], results[1]
+		fmt.Printf("  lotes / goroutines: CPU %.2fx  asignado %.2fx  allocs %.2fx  estados/s %.2fx  frame p99 %.2fx\n",
+			ratio(batched.cpu, base.cpu), ratio(float64(batched.allocBytes), float64(base.allocBytes)),
+			ratio(float64(batched.mallocs), float64(base.mallocs)), ratio(batched.statesPS, base.statesPS),
+			ratio(float64(batched.frameP99), float64(base.frameP99)))
+	}
+}
+
+// runArchitecture siembra el generador, crea las mismas n luciérnagas y el
+// mismo farol que cualquier otra corrida con esa semilla, y mide una ventana
+// de duration con un consumidor que toma un snapshot por frame
+func runArchitecture(arch architecture, n int, duration time.Duration, seed int64) architectureResult {
+	utils.Seed(seed)
+
+	aggregator := manager.NewStateAggregator(config.Get().Channels.StateBuffer)
+	aggregator.Start()
+
+	lanterns := []*core.Lantern{core.NewLantern(1, config.ScreenWidth/2, config.ScreenHeight/2)}
+	fireflies := make([]*core.Firefly, n)
+	for i := range fireflies {
+		pos := utils.RandomVector2D(0, config.ScreenWidth, 0, config.ScreenHeight)
+		fireflies[i] = core.NewFirefly(i+1, pos.X, pos.Y)
+		fireflies[i].SetLanterns(lanterns)
+	}
+	tick := time.Second / time.Duration(config.Get().SimulationTPS)
+	dt := 1.0 / float64(config.Get().SimulationTPS)
+
+	cpuBefore := usedCPU()
+	var memBefore, memAfter runtime.MemStats
+	runtime.ReadMemStats(&memBefore)
+	droppedBefore := core.GetDroppedStates()
+	processedBefore := aggregator.GetProcessedCount()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	goroutines, wait := arch.start(ctx, fireflies, aggregator.GetStateChannel(), tick, dt)
+
+	// El consumidor de la UI: cada frame es lo que pasó desde el anterior,
+	// con la toma del snapshot incluida
+	var frames []time.Duration
+	ticker := time.NewTicker(time.Second / time.Duration(config.Get().TargetFPS))
+	deadline := time.After(duration)
+	last := time.Now()
+
+loop:
+	for {
+		select {
+		case <-deadline:
+			break loop
+		case <-ticker.C:
+			manager.ReleaseStates(aggregator.GetSnapshot())
+			now := time.Now()
+			frames = append(frames, now.Sub(last))
+			last = now
+		}
+	}
+	ticker.Stop()
+
+	processed := aggregator.GetProcessedCount() - processedBefore
+	dropped := core.GetDroppedStates() - droppedBefore
+	runtime.ReadMemStats(&memAfter)
+	cpu := usedCPU() - cpuBefore
+
+	cancel()
+	wait()
+	aggregator.Stop()
+
+	seconds := duration.Seconds()
+	r := architectureResult{
+		name:       arch.name,
+		fireflies:  n,
+		goroutines: goroutines,
+		cpu:        cpu,
+		allocBytes: memAfter.TotalAlloc - memBefore.TotalAlloc,
+		mallocs:    memAfter.Mallocs - memBefore.Mallocs,
+		statesPS:   float64(processed) / seconds,
+		droppedPS:  float64(dropped) / seconds,
+	}
+	if processed+dropped > 0 {
+		r.dropPct = 100 * float64(dropped) / float64(processed+dropped)
+	}
+	if len(frames) > 0 {
+		sort.Slice(frames, func(a, b int) bool { return frames[a] < frames[b] })
+		r.frameP50 = frames[len(frames)/2]
+		r.frameP99 = frames[len(frames)*99/100]
+		r.frameMax = frames[len(frames)-1]
+	}
+	return r
+}
+
+// cpuMetrics son los contadores de runtime/metrics con los que usedCPU
+// estima el tiempo de CPU del proceso: todo lo disponible menos lo ocioso.
+// El runtime los actualiza en cada ciclo de GC.
+var cpuMetrics = []metrics.Sample{
+	{Name: "/cpu/classes/total:cpu-seconds"},
+	{Name: "/cpu/classes/idle:cpu-seconds"},
+}
+
+// usedCPU retorna los segundos de CPU usados por el proceso (Go y GC)
+// desde que arrancó
+func usedCPU() float64 {
+	runtime.GC()
+	metrics.Read(cpuMetrics)
+	return cpuMetrics[0].Value.Float64() - cpuMetrics[1].Value.Float64()
+}
+
+// ratio divide sin fallar cuando la base es 0
+func ratio(value, base float64) float64 {
+	if base == 0 {
+		return 0
+	}
+	return value / base
+}
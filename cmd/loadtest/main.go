@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// loadtest ejercita el núcleo concurrente sin Ebiten y reporta:
+//   - throughput del StateAggregator (Fan-in)
+//   - latencia de despacho del WorkerPool
+//   - ticks de simulación completos con 100/1k/10k luciérnagas
+//   - presión de GC de snapshots con y sin sync.Pool
+//
+// Con -compare, en cambio, corre el mismo escenario sembrado con cada
+// arquitectura (ver compare.go) y reporta CPU, asignaciones, descartes y
+// tiempos de frame lado a lado.
+func main() {
+	sizesFlag := flag.String("sizes", "100,1000,10000", "cantidades de luciérnagas separadas por coma")
+	duration := flag.Duration("duration", 5*time.Second, "duración de cada escenario end-to-end")
+	compare := flag.Bool("compare", false, "comparar goroutine por luciérnaga contra lotes en un worker pool con el mismo escenario")
+	seed := flag.Int64("seed", 1, "semilla del escenario de -compare")
+	workers := flag.Int("workers", 0, "workers del modo por lotes de -compare (0 usa GOMAXPROCS)")
+	configFlags := config.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	cfg, err := configFlags.Load()
+	if err != nil {
+		logging.Fatal("configuración inválida", "err", err)
+	}
+	config.Set(cfg)
+
+	sizes, err := parseSizes(*sizesFlag)
+	if err != nil {
+		logging.Fatal("valor inválido en -sizes", "err", err)
+	}
+
+	fmt.Println("===========================================")
+	fmt.Println("  🌙 LOAD TEST - Núcleo concurrente")
+	fmt.Printf("  GOMAXPROCS=%d  SimulationTPS=%d\n", runtime.GOMAXPROCS(0), config.Get().SimulationTPS)
+	fmt.Println("===========================================")
+
+	if *compare {
+		runComparison(sizes, *duration, *seed, *workers)
+		return
+	}
+
+	benchAggregator(1_000_000)
+	benchWorkerPool(20_000)
+	benchSnapshots(2000, 5000)
+
+	fmt.Println()
+	fmt.Printf("%-10s %12s %12s %10s %12s %10s %8s\n", "Luciérn.", "Estados/s", "Descart./s", "Drop %", "Snapshot", "Gorout.", "GCs")
+	for _, n := range sizes {
+		runScenario(n, *duration)
+	}
+}
+
+func parseSizes(value string) ([]int, error) {
+	var sizes []int
+	for _, part := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, n)
+	}
+	return sizes, nil
+}
+
+// benchAggregator mide cuántos estados por segundo consume el agregador
+func benchAggregator(total int) {
+	aggregator := manager.NewStateAggregator(config.Get().Channels.StateBuffer)
+	aggregator.Start()
+	defer aggregator.Stop()
+
+	stateCh := aggregator.GetStateChannel()
+	start := time.Now()
+
+	for i := 0; i < total; i++ {
+		stateCh <- core.FireflyState{ID: i % 1000, IsAlive: true}
+	}
+	for aggregator.GetProcessedCount() < uint64(total) {
+		runtime.Gosched()
+	}
+
+	elapsed := time.Since(start)
+	fmt.Printf("\nStateAggregator: %d estados en %v (%.0f estados/s)\n", total, elapsed.Round(time.Millisecond), float64(total)/elapsed.Seconds())
+}
+
+// benchWorkerPool mide la latencia entre Submit y el inicio de ejecución del trabajo
+func benchWorkerPool(jobs int) {
+	pool := manager.NewWorkerPool(4, 100, 100)
+	pool.Start()
+	defer pool.Stop()
+
+	latencies := make([]time.Duration, jobs)
+	var wg sync.WaitGroup
+
+	for i := 0; i < jobs; i++ {
+		i := i
+		submitted := time.Now()
+		wg.Add(1)
+		job := manager.Job{
+			ID: i,
+			Task: func() interface{} {
+				latencies[i] = time.Since(submitted)
+				wg.Done()
+				return nil
+			},
+		}
+		for !pool.Submit(job) {
+			runtime.Gosched()
+		}
+	}
+	wg.Wait()
+
+	sort.Slice(latencies, func(a, b int) bool { return latencies[a] < latencies[b] })
+	fmt.Printf("WorkerPool: %d trabajos  p50=%v  p99=%v  max=%v\n",
+		jobs, latencies[jobs/2], latencies[jobs*99/100], latencies[jobs-1])
+}
+
+// benchSnapshots compara asignaciones de memoria de snapshots con y sin pool
+func benchSnapshots(fireflies, frames int) {
+	aggregator := manager.NewStateAggregator(config.Get().Channels.StateBuffer)
+	aggregator.Start()
+	defer aggregator.Stop()
+
+	for i := 0; i < fireflies; i++ {
+		aggregator.GetStateChannel() <- core.FireflyState{ID: i, IsAlive: true}
+	}
+	for aggregator.GetProcessedCount() < uint64(fireflies) {
+		runtime.Gosched()
+	}
+
+	measure := func(release bool) (uint64, uint32) {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		for i := 0; i < frames; i++ {
+			states := aggregator.GetSnapshot()
+			if release {
+				manager.ReleaseStates(states)
+			}
+		}
+		runtime.ReadMemStats(&after)
+		return after.TotalAlloc - before.TotalAlloc, after.NumGC - before.NumGC
+	}
+
+	plainBytes, plainGC := measure(false)
+	pooledBytes, pooledGC := measure(true)
+
+	fmt.Printf("Snapshots (%d luciérnagas, %d frames):\n", fireflies, frames)
+	fmt.Printf("  sin pool: %8.1f MB asignados  %3d GCs\n", float64(plainBytes)/1e6, plainGC)
+	fmt.Printf("  con pool: %8.1f MB asignados  %3d GCs\n", float64(pooledBytes)/1e6, pooledGC)
+}
+
+// runScenario lanza n luciérnagas publicando al agregador y mide una ventana de tiempo
+func runScenario(n int, duration time.Duration) {
+	aggregator := manager.NewStateAggregator(config.Get().Channels.StateBuffer)
+	aggregator.Start()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	lanterns := []*core.Lantern{core.NewLantern(1, config.ScreenWidth/2, config.ScreenHeight/2)}
+	tick := time.Second / time.Duration(config.Get().SimulationTPS)
+	dt := 1.0 / float64(config.Get().SimulationTPS)
+
+	for i := 0; i < n; i++ {
+		pos := utils.RandomVector2D(0, config.ScreenWidth, 0, config.ScreenHeight)
+		firefly := core.NewFirefly(i+1, pos.X, pos.Y)
+		firefly.SetLanterns(lanterns)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Sin manager no hay canal de control: nil nunca recibe
+			firefly.Run(ctx, aggregator.GetStateChannel(), nil, tick, dt)
+		}()
+	}
+
+	var memBefore, memAfter runtime.MemStats
+	runtime.ReadMemStats(&memBefore)
+	droppedBefore := core.GetDroppedStates()
+	processedBefore := aggregator.GetProcessedCount()
+
+	// Simula el consumidor de la UI tomando un snapshot por frame
+	var snapshotTime time.Duration
+	frames := 0
+	ticker := time.NewTicker(time.Second / time.Duration(config.Get().TargetFPS))
+	deadline := time.After(duration)
+	goroutines := 0
+
+loop:
+	for {
+		select {
+		case <-deadline:
+			break loop
+		case <-ticker.C:
+			start := time.Now()
+			states := aggregator.GetSnapshot()
+			snapshotTime += time.Since(start)
+			manager.ReleaseStates(states)
+			frames++
+			if g := runtime.NumGoroutine(); g > goroutines {
+				goroutines = g
+			}
+		}
+	}
+	ticker.Stop()
+
+	processed := aggregator.GetProcessedCount() - processedBefore
+	dropped := core.GetDroppedStates() - droppedBefore
+	runtime.ReadMemStats(&memAfter)
+
+	cancel()
+	wg.Wait()
+	aggregator.Stop()
+
+	seconds := duration.Seconds()
+	dropPct := 0.0
+	if processed+dropped > 0 {
+		dropPct = 100 * float64(dropped) / float64(processed+dropped)
+	}
+	avgSnapshot := time.Duration(0)
+	if frames > 0 {
+		avgSnapshot = snapshotTime / time.Duration(frames)
+	}
+
+	fmt.Printf("%-10d %12.0f %12.0f %9.2f%% %12v %10d %8d\n",
+		n, float64(processed)/seconds, float64(dropped)/seconds, dropPct,
+		avgSnapshot.Round(time.Microsecond), goroutines, memAfter.NumGC-memBefore.NumGC)
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/pkg/garden"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+	"golang.org/x/term"
+)
+
+const (
+	frameRate  = 15
+	statusRows = 3
+)
+
+// Paleta ANSI 256 colores de tenue a brillante
+var fireflyShades = []struct {
+	char  rune
+	color int
+}{
+	{'.', 22},
+	{'·', 28},
+	{'*', 106},
+	{'✶', 148},
+	{'✦', 229},
+}
+
+// tui dibuja el jardín como caracteres de colores en la terminal usando pkg/garden
+func main() {
+	configFlags := config.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	cfg, err := configFlags.Load()
+	if err != nil {
+		logging.Fatal("configuración inválida", "err", err)
+	}
+	config.Set(cfg)
+	config.SetSource(configFlags.Source())
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		logging.Fatal("cmd/tui necesita una terminal interactiva")
+	}
+
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		logging.Fatal("no se pudo activar el modo raw", "err", err)
+	}
+
+	g, err := garden.New(garden.Options{})
+	if err != nil {
+		logging.Fatal("configuración inválida", "err", err)
+	}
+	g.Start()
+
+	restore := func() {
+		g.Stop()
+		term.Restore(fd, oldState)
+		fmt.Print("\x1b[0m\x1b[2J\x1b[H\x1b[?25h")
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	keys := make(chan []byte, 16)
+	go readKeys(keys)
+
+	ui := &tuiState{cursor: utils.Vector2D{X: config.ScreenWidth / 2, Y: config.ScreenHeight / 2}}
+	ticker := time.NewTicker(time.Second / frameRate)
+	defer ticker.Stop()
+
+	fmt.Print("\x1b[?25l\x1b[2J")
+	last := time.Now()
+
+	for {
+		select {
+		case <-sigChan:
+			restore()
+			return
+
+		case key := <-keys:
+			if !ui.handleKey(g, key) {
+				restore()
+				return
+			}
+
+		case now := <-ticker.C:
+			if !ui.paused {
+				g.Tick(now.Sub(last).Seconds())
+			}
+			last = now
+			draw(g, ui)
+		}
+	}
+}
+
+// tuiState guarda el cursor virtual y los toggles de la interfaz
+type tuiState struct {
+	cursor    utils.Vector2D
+	attracted bool
+	paused    bool
+	message   string
+}
+
+// readKeys lee bytes crudos de la entrada estándar
+func readKeys(keys chan<- []byte) {
+	buf := make([]byte, 8)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return
+		}
+		key := make([]byte, n)
+		copy(key, buf[:n])
+		keys <- key
+	}
+}
+
+// handleKey traduce las teclas a comandos del jardín; retorna false para salir
+func (ui *tuiState) handleKey(g *garden.Garden, key []byte) bool {
+	step := 24.0
+
+	// Flechas: ESC [ A/B/C/D
+	if len(key) == 3 && key[0] == 0x1b && key[1] == '[' {
+		switch key[2] {
+		case 'A':
+			ui.cursor.Y -= step
+		case 'B':
+			ui.cursor.Y += step
+		case 'C':
+			ui.cursor.X += step
+		case 'D':
+			ui.cursor.X -= step
+		}
+		ui.cursor.X = utils.Clamp(ui.cursor.X, 0, config.ScreenWidth)
+		ui.cursor.Y = utils.Clamp(ui.cursor.Y, 0, config.ScreenHeight)
+		if ui.attracted {
+			ui.send(g, garden.Command{Kind: garden.SetAttraction, Position: ui.cursor}, "")
+		}
+		return true
+	}
+
+	switch key[0] {
+	case 0x1b, 'q', 'Q', 3:
+		return false
+	case ' ':
+		ui.attracted = !ui.attracted
+		if ui.attracted {
+			ui.send(g, garden.Command{Kind: garden.SetAttraction, Position: ui.cursor}, "Atrayendo al cursor")
+		} else {
+			ui.send(g, garden.Command{Kind: garden.ClearAttraction}, "Atracción liberada")
+		}
+	case 'l', 'L':
+		ui.send(g, garden.Command{Kind: garden.AddLantern, Position: ui.cursor}, "Farol colocado")
+	case 'k', 'K':
+		ui.send(g, garden.Command{Kind: garden.SpawnBurst, Position: ui.cursor}, "Ráfaga generada")
+	case 'w', 'W':
+		ui.send(g, garden.Command{Kind: garden.CycleWind}, "Viento cambiado")
+	case 'p', 'P':
+		ui.paused = !ui.paused
+	}
+
+	return true
+}
+
+func (ui *tuiState) send(g *garden.Garden, cmd garden.Command, message string) {
+	if err := g.Command(cmd); err != nil {
+		ui.message = err.Error()
+		return
+	}
+	if message != "" {
+		ui.message = message
+	}
+}
+
+// draw compone el frame completo y lo escribe de una sola vez
+func draw(g *garden.Garden, ui *tuiState) {
+	cols, rows, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || cols < 10 || rows <= statusRows {
+		return
+	}
+	rows -= statusRows
+
+	snap := g.Snapshot()
+	width, height := g.Size()
+
+	// Cada celda guarda la luciérnaga más brillante que cae en ella
+	brightness := make([]float64, cols*rows)
+	for i := range brightness {
+		brightness[i] = -1
+	}
+	cell := func(p utils.Vector2D) (int, bool) {
+		cx := int(p.X / width * float64(cols))
+		cy := int(p.Y / height * float64(rows))
+		if cx < 0 || cx >= cols || cy < 0 || cy >= rows {
+			return 0, false
+		}
+		return cy*cols + cx, true
+	}
+
+	for _, state := range snap.Fireflies {
+		if idx, ok := cell(state.Position); ok && state.Brightness > brightness[idx] {
+			brightness[idx] = state.Brightness
+		}
+	}
+
+	lanterns := make(map[int]bool, len(snap.Lanterns))
+	for _, lantern := range snap.Lanterns {
+		if idx, ok := cell(lantern.Position); ok {
+			lanterns[idx] = true
+		}
+	}
+	cursorIdx, _ := cell(ui.cursor)
+
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			idx := y*cols + x
+			switch {
+			case idx == cursorIdx:
+				b.WriteString("\x1b[38;5;117m+")
+			case lanterns[idx]:
+				b.WriteString("\x1b[38;5;214mO")
+			case brightness[idx] >= 0:
+				shade := fireflyShades[int(brightness[idx]*float64(len(fireflyShades)-1)+0.5)]
+				fmt.Fprintf(&b, "\x1b[38;5;%dm%c", shade.color, shade.char)
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\x1b[0m\r\n")
+	}
+
+	status := fmt.Sprintf("Luciérnagas: %d/%d  Faroles: %d/%d  Viento: %s  Descartados: %d",
+		len(snap.Fireflies), snap.SpawnCap, len(snap.Lanterns), config.Get().Lanterns.Max, snap.Wind.Direction, snap.Dropped)
+	if ui.paused {
+		status += "  ⏸ PAUSADO"
+	}
+	fmt.Fprintf(&b, "\x1b[2K\x1b[38;5;229m%s\x1b[0m\r\n", status)
+	fmt.Fprintf(&b, "\x1b[2K\x1b[38;5;250mFlechas: cursor  Espacio: atraer  L: farol  K: ráfaga  W: viento  P: pausa  Q/ESC: salir\x1b[0m\r\n")
+	fmt.Fprintf(&b, "\x1b[2K\x1b[38;5;244m%s\x1b[0m", ui.message)
+
+	os.Stdout.WriteString(b.String())
+}
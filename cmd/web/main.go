@@ -0,0 +1,116 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/logging"
+)
+
+// gamePackage es este mismo paquete, compilado para el navegador
+const gamePackage = "github.com/yourusername/firefly-garden/cmd/web"
+
+//go:embed index.html
+var indexHTML []byte
+
+// Fuera del navegador cmd/web compila el juego a WebAssembly y sirve la
+// página (o la exporta con -out para publicarla en un hosting estático)
+func main() {
+	addr := flag.String("addr", "localhost:8000", "dirección donde servir el juego")
+	out := flag.String("out", "", "solo exportar index.html, wasm_exec.js y game.wasm a este directorio")
+	logFlags := logging.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	logFile, err := logFlags.Setup("info")
+	if err != nil {
+		logging.Fatal("no se pudo configurar el log", "err", err)
+	}
+	defer logFile.Close()
+	log := logging.For("web")
+
+	dir := *out
+	if dir == "" {
+		dir, err = os.MkdirTemp("", "firefly-web-")
+		if err != nil {
+			logging.Fatal("no se pudo crear el directorio temporal", "err", err)
+		}
+		defer os.RemoveAll(dir)
+	}
+
+	start := time.Now()
+	if err := exportSite(dir); err != nil {
+		logging.Fatal("no se pudo compilar el juego web", "err", err)
+	}
+	log.Info("juego web compilado", "dir", dir, "took", time.Since(start).Round(time.Millisecond))
+
+	if *out != "" {
+		return
+	}
+
+	log.Info("sirviendo el juego", "url", "http://"+*addr)
+	if err := http.ListenAndServe(*addr, noCache(http.FileServer(http.Dir(dir)))); err != nil {
+		logging.Fatal("servidor web detenido", "err", err)
+	}
+}
+
+// exportSite deja en dir todo lo que el navegador necesita
+func exportSite(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), indexHTML, 0o644); err != nil {
+		return err
+	}
+	if err := copyWasmExec(filepath.Join(dir, "wasm_exec.js")); err != nil {
+		return err
+	}
+
+	build := exec.Command("go", "build", "-trimpath", "-ldflags=-s -w", "-o", filepath.Join(dir, "game.wasm"), gamePackage)
+	build.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	build.Stdout = os.Stdout
+	build.Stderr = os.Stderr
+	if err := build.Run(); err != nil {
+		return fmt.Errorf("go build para js/wasm (ejecuta cmd/web desde el repositorio): %w", err)
+	}
+	return nil
+}
+
+// copyWasmExec copia el cargador de WebAssembly de la misma versión de Go
+// que compila el juego (lib/wasm desde Go 1.24, misc/wasm antes)
+func copyWasmExec(dst string) error {
+	goroot, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		return fmt.Errorf("go env GOROOT: %w", err)
+	}
+	root := strings.TrimSpace(string(goroot))
+
+	for _, rel := range []string{"lib/wasm/wasm_exec.js", "misc/wasm/wasm_exec.js"} {
+		data, err := os.ReadFile(filepath.Join(root, rel))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dst, data, 0o644)
+	}
+	return fmt.Errorf("no se encontró wasm_exec.js en %s", root)
+}
+
+// noCache evita que el navegador use un game.wasm viejo tras recompilar
+func noCache(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache")
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,40 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"log/slog"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/internal/prefs"
+	"github.com/yourusername/firefly-garden/internal/render"
+)
+
+// En el navegador el juego arranca con la configuración web (menos
+// luciérnagas, sin bloom) y sin flags: el log va a la consola del navegador
+func main() {
+	cfg := config.Web()
+	config.Set(cfg)
+
+	if _, err := logging.Setup(logging.Options{Level: slog.LevelInfo}); err != nil {
+		logging.Fatal("no se pudo configurar el log", "err", err)
+	}
+	log := logging.For("web")
+
+	// Sin sistema de archivos las preferencias no se pueden leer ni guardar
+	userPrefs, err := prefs.Load(cfg.Render.Quality)
+	if err != nil {
+		log.Info("preferencias no disponibles en el navegador, usando las predeterminadas")
+	}
+
+	ebiten.SetWindowTitle("🌙 Jardín de Luciérnagas")
+	ebiten.SetTPS(cfg.TargetFPS)
+
+	app := render.NewApp(userPrefs, render.SessionOptions{})
+	if err := ebiten.RunGame(app); err != nil {
+		logging.Fatal("error en el bucle del juego", "err", err)
+	}
+	app.Shutdown()
+}
@@ -0,0 +1,408 @@
+// Package api expone el jardín por HTTP y gRPC para herramientas externas,
+// demos, bots y experimentos automatizados. Las órdenes entran por la misma
+// cola de comandos que el teclado; el estado que se sirve es el último
+// snapshot publicado por el dueño del jardín, así los servidores nunca leen
+// la simulación directamente.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
+
+	"github.com/yourusername/firefly-garden/internal/api/gardenpb"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/internal/wire"
+	"github.com/yourusername/firefly-garden/pkg/garden"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// refreshInterval limita cada cuánto Refresh copia el jardín
+const refreshInterval = 100 * time.Millisecond
+
+// maxBodyBytes es el tamaño máximo aceptado en un cuerpo JSON
+const maxBodyBytes = 1 << 16
+
+// binaryContentType es el tipo con que GET /state responde en binario
+const binaryContentType = "application/octet-stream"
+
+var errNoGarden = errors.New("no hay una partida en curso")
+
+// RoleHeader es la metadata gRPC con la que una sesión se declara; una
+// sesión RoleSpectator solo recibe estado y sus órdenes se rechazan
+const (
+	RoleHeader    = "garden-role"
+	RoleSpectator = "spectator"
+)
+
+// Server agrupa los servidores HTTP y gRPC de control. Sobrevive a las
+// partidas: cada una se conecta con Attach al empezar y se desconecta al terminar.
+type Server struct {
+	http        *http.Server
+	grpc        *grpc.Server
+	grpcAddr    string
+	garden      atomic.Pointer[garden.Garden]
+	state       atomic.Pointer[stateResponse]
+	lastRefresh time.Time
+	players     atomic.Int32
+	spectators  atomic.Int32
+	log         *slog.Logger
+}
+
+// NewServer prepara los servidores; una dirección vacía deja ese protocolo apagado
+func NewServer(httpAddr, grpcAddr string) *Server {
+	s := &Server{log: logging.For("api"), grpcAddr: grpcAddr}
+
+	if grpcAddr != "" {
+		s.grpc = grpc.NewServer()
+		gardenpb.RegisterGardenServiceServer(s.grpc, &grpcService{server: s})
+	}
+	if httpAddr == "" {
+		return s
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /state", s.handleState)
+	mux.Handle("GET /stream", websocket.Handler(s.handleStream))
+	mux.HandleFunc("POST /spawn", s.handleSpawn)
+	mux.HandleFunc("POST /lanterns", s.handleAddLantern)
+	mux.HandleFunc("DELETE /lanterns", s.handleRemoveLantern)
+	mux.HandleFunc("PUT /lanterns/{id}", s.handleMoveLantern)
+	mux.HandleFunc("PUT /wind", s.handleWind)
+	mux.HandleFunc("POST /attraction", s.handleAttraction)
+	mux.HandleFunc("DELETE /attraction", s.handleClearAttraction)
+
+	s.http = &http.Server{
+		Addr:              httpAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+// Start escucha cada protocolo en su propia goroutine. Un error al escuchar
+// se reporta en el log y no detiene la simulación.
+func (s *Server) Start() {
+	if s.http != nil {
+		go func() {
+			s.log.Info("API HTTP escuchando", "addr", s.http.Addr)
+			if err := s.http.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				s.log.Error("API HTTP detenida", "addr", s.http.Addr, "err", err)
+			}
+		}()
+	}
+
+	if s.grpc != nil {
+		go func() {
+			lis, err := net.Listen("tcp", s.grpcAddr)
+			if err != nil {
+				s.log.Error("API gRPC detenida", "addr", s.grpcAddr, "err", err)
+				return
+			}
+			s.log.Info("API gRPC escuchando", "addr", s.grpcAddr)
+			if err := s.grpc.Serve(lis); err != nil {
+				s.log.Error("API gRPC detenida", "addr", s.grpcAddr, "err", err)
+			}
+		}()
+	}
+}
+
+// Shutdown espera a que terminen las peticiones en curso; las sesiones gRPC
+// que sigan abiertas cuando ctx vence se cortan
+func (s *Server) Shutdown(ctx context.Context) error {
+	var err error
+	if s.http != nil {
+		err = s.http.Shutdown(ctx)
+	}
+
+	if s.grpc != nil {
+		stopped := make(chan struct{})
+		go func() {
+			s.grpc.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			s.grpc.Stop()
+			err = errors.Join(err, ctx.Err())
+		}
+	}
+
+	return err
+}
+
+// Attach conecta el jardín que reciben las órdenes; nil lo desconecta
+func (s *Server) Attach(g *garden.Garden) {
+	s.garden.Store(g)
+	if g == nil {
+		s.state.Store(nil)
+	}
+}
+
+// Refresh publica el estado del jardín para GET /state. Debe llamarse desde
+// la goroutine dueña del jardín (la misma que llama Tick); copia como mucho
+// una vez cada refreshInterval.
+func (s *Server) Refresh(g *garden.Garden) {
+	now := time.Now()
+	if now.Sub(s.lastRefresh) < refreshInterval {
+		return
+	}
+	s.lastRefresh = now
+
+	state := newStateResponse(g.Snapshot())
+	s.state.Store(&state)
+}
+
+// Players retorna cuántas sesiones gRPC que pueden dar órdenes hay abiertas;
+// en modo anfitrión cada una es un jugador remoto
+func (s *Server) Players() int {
+	return int(s.players.Load())
+}
+
+// Spectators retorna cuántas sesiones de solo lectura hay abiertas
+func (s *Server) Spectators() int {
+	return int(s.spectators.Load())
+}
+
+type point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+func toPoint(v utils.Vector2D) point {
+	return point{X: v.X, Y: v.Y}
+}
+
+type fireflyJSON struct {
+	ID         int     `json:"id"`
+	X          float64 `json:"x"`
+	Y          float64 `json:"y"`
+	Brightness float64 `json:"brightness"`
+}
+
+type lanternJSON struct {
+	ID        int     `json:"id"`
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Radius    float64 `json:"radius"`
+	Intensity float64 `json:"intensity"`
+}
+
+type stateResponse struct {
+	Time       time.Time     `json:"time"`
+	Sequence   uint64        `json:"sequence"`
+	Tick       uint64        `json:"tick"`
+	StateTime  time.Time     `json:"state_time"`
+	Population int           `json:"population"`
+	SpawnCap   int           `json:"spawn_cap"`
+	Dropped    uint64        `json:"dropped"`
+	Wind       windJSON      `json:"wind"`
+	Fireflies  []fireflyJSON `json:"fireflies"`
+	Lanterns   []lanternJSON `json:"lanterns"`
+}
+
+type windJSON struct {
+	Direction string `json:"direction"`
+	Force     point  `json:"force"`
+}
+
+func newStateResponse(snap garden.Snapshot) stateResponse {
+	state := stateResponse{
+		Time:       snap.Time,
+		Sequence:   snap.Sequence,
+		Tick:       snap.Tick,
+		StateTime:  snap.StateTime,
+		Population: len(snap.Fireflies),
+		SpawnCap:   snap.SpawnCap,
+		Dropped:    snap.Dropped,
+		Wind:       windJSON{Direction: snap.Wind.Direction, Force: toPoint(snap.Wind.Force)},
+		Fireflies:  make([]fireflyJSON, 0, len(snap.Fireflies)),
+		Lanterns:   make([]lanternJSON, 0, len(snap.Lanterns)),
+	}
+	for _, f := range snap.Fireflies {
+		state.Fireflies = append(state.Fireflies, fireflyJSON{ID: f.ID, X: f.Position.X, Y: f.Position.Y, Brightness: f.Brightness})
+	}
+	for _, l := range snap.Lanterns {
+		state.Lanterns = append(state.Lanterns, lanternJSON{ID: l.ID, X: l.Position.X, Y: l.Position.Y, Radius: l.Radius, Intensity: l.Intensity})
+	}
+	return state
+}
+
+// fireflyStates agrega a dst las luciérnagas publicadas como estados, para
+// los codificadores binarios
+func (s *stateResponse) fireflyStates(dst []core.FireflyState) []core.FireflyState {
+	for _, f := range s.Fireflies {
+		dst = append(dst, core.FireflyState{ID: f.ID, Position: utils.Vector2D{X: f.X, Y: f.Y}, Brightness: f.Brightness, IsAlive: true})
+	}
+	return dst
+}
+
+// handleState responde JSON o, si el cliente pide application/octet-stream,
+// solo las luciérnagas en el formato binario de wire
+func (s *Server) handleState(w http.ResponseWriter, r *http.Request) {
+	state := s.state.Load()
+	if state == nil {
+		writeError(w, http.StatusServiceUnavailable, errNoGarden)
+		return
+	}
+	if strings.Contains(r.Header.Get("Accept"), binaryContentType) {
+		w.Header().Set("Content-Type", binaryContentType)
+		w.WriteHeader(http.StatusOK)
+		w.Write(wire.AppendStates(nil, state.fireflyStates(nil)))
+		return
+	}
+	writeJSON(w, http.StatusOK, state)
+}
+
+type spawnRequest struct {
+	X     *float64 `json:"x"`
+	Y     *float64 `json:"y"`
+	Count int      `json:"count"`
+}
+
+// POST /spawn {"x": 400, "y": 300, "count": 10}; sin x/y nace en un punto al azar
+func (s *Server) handleSpawn(w http.ResponseWriter, r *http.Request) {
+	var req spawnRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	width, height := config.WorldSize()
+	pos := utils.RandomVector2D(0, width, 0, height)
+	if req.X != nil {
+		pos.X = *req.X
+	}
+	if req.Y != nil {
+		pos.Y = *req.Y
+	}
+
+	s.send(w, spawnCommand(pos, req.Count))
+}
+
+// spawnCommand arma una ráfaga de count luciérnagas (0 usa la configurada)
+func spawnCommand(pos utils.Vector2D, count int) garden.Command {
+	if count == 1 {
+		return garden.Command{Kind: garden.SpawnFirefly, Position: pos}
+	}
+	return garden.Command{Kind: garden.SpawnBurst, Position: pos, Count: count}
+}
+
+// POST /lanterns {"x": 200, "y": 150}
+func (s *Server) handleAddLantern(w http.ResponseWriter, r *http.Request) {
+	var p point
+	if !readJSON(w, r, &p) {
+		return
+	}
+	s.send(w, garden.Command{Kind: garden.AddLantern, Position: utils.Vector2D{X: p.X, Y: p.Y}})
+}
+
+// DELETE /lanterns quita el último farol colocado
+func (s *Server) handleRemoveLantern(w http.ResponseWriter, r *http.Request) {
+	s.send(w, garden.Command{Kind: garden.RemoveLantern})
+}
+
+// PUT /lanterns/{id} {"x": 600, "y": 400}
+func (s *Server) handleMoveLantern(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("id de farol inválido %q", r.PathValue("id")))
+		return
+	}
+
+	var p point
+	if !readJSON(w, r, &p) {
+		return
+	}
+	s.send(w, garden.Command{Kind: garden.MoveLantern, ID: id, Position: utils.Vector2D{X: p.X, Y: p.Y}})
+}
+
+type windRequest struct {
+	Direction string `json:"direction"`
+}
+
+// PUT /wind {"direction": "north"}; "cycle" rota a la siguiente dirección
+func (s *Server) handleWind(w http.ResponseWriter, r *http.Request) {
+	var req windRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	if req.Direction == "cycle" {
+		s.send(w, garden.Command{Kind: garden.CycleWind})
+		return
+	}
+	s.send(w, garden.Command{Kind: garden.SetWind, Wind: req.Direction})
+}
+
+// POST /attraction {"x": 400, "y": 300}
+func (s *Server) handleAttraction(w http.ResponseWriter, r *http.Request) {
+	var p point
+	if !readJSON(w, r, &p) {
+		return
+	}
+	s.send(w, garden.Command{Kind: garden.SetAttraction, Position: utils.Vector2D{X: p.X, Y: p.Y}})
+}
+
+// DELETE /attraction
+func (s *Server) handleClearAttraction(w http.ResponseWriter, r *http.Request) {
+	s.send(w, garden.Command{Kind: garden.ClearAttraction})
+}
+
+// command encola la orden en la partida conectada
+func (s *Server) command(cmd garden.Command) error {
+	g := s.garden.Load()
+	if g == nil {
+		return errNoGarden
+	}
+	return g.Command(cmd)
+}
+
+// send encola la orden. Responde 202 porque el manager la aplica de forma
+// asíncrona; el efecto se ve en GET /state un momento después.
+func (s *Server) send(w http.ResponseWriter, cmd garden.Command) {
+	switch err := s.command(cmd); {
+	case err == nil:
+		w.WriteHeader(http.StatusAccepted)
+	case errors.Is(err, errNoGarden), errors.Is(err, garden.ErrStopped):
+		writeError(w, http.StatusServiceUnavailable, err)
+	case errors.Is(err, garden.ErrCommandQueueFull):
+		w.Header().Set("Retry-After", "1")
+		writeError(w, http.StatusServiceUnavailable, err)
+	default:
+		writeError(w, http.StatusBadRequest, err)
+	}
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("JSON inválido: %w", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/firefly-garden/pkg/garden"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stoppedGarden conecta a un servidor nuevo un jardín ya detenido, como el
+// que queda cuando termina una partida antes que la API
+func stoppedGarden(t *testing.T) *Server {
+	t.Helper()
+	g, err := garden.New(garden.Options{Sync: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Start()
+	g.Stop()
+
+	s := NewServer("127.0.0.1:0", "127.0.0.1:0")
+	s.Attach(g)
+	return s
+}
+
+func TestHTTPCommandAfterStop(t *testing.T) {
+	s := stoppedGarden(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/lanterns", strings.NewReader(`{"x":100,"y":100}`))
+	rec := httptest.NewRecorder()
+	s.http.Handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("POST /lanterns tras Stop respondió %d, se esperaba %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestGRPCCommandAfterStop(t *testing.T) {
+	s := stoppedGarden(t)
+
+	_, err := (&grpcService{server: s}).reply(garden.Command{Kind: garden.CycleWind})
+	if code := status.Code(err); code != codes.Unavailable {
+		t.Fatalf("orden tras Stop respondió %v, se esperaba %v", code, codes.Unavailable)
+	}
+}
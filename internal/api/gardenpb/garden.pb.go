@@ -0,0 +1,1361 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: garden/v1/garden.proto
+
+// API gRPC del jardín: las mismas órdenes que la API HTTP y el teclado, más
+// una sesión bidireccional para bots y controladores de experimentos.
+// Regenerar con: cd proto && buf generate
+
+package gardenpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Point struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             float64                `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	mi := &file_garden_v1_garden_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Point) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Point) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type SpawnRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sin posición nace en un punto al azar
+	Position *Point `protobuf:"bytes,1,opt,name=position,proto3,oneof" json:"position,omitempty"`
+	// 0 usa la ráfaga configurada; 1 crea una sola luciérnaga
+	Count         int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpawnRequest) Reset() {
+	*x = SpawnRequest{}
+	mi := &file_garden_v1_garden_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpawnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpawnRequest) ProtoMessage() {}
+
+func (x *SpawnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpawnRequest.ProtoReflect.Descriptor instead.
+func (*SpawnRequest) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SpawnRequest) GetPosition() *Point {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *SpawnRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type AddLanternRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Position      *Point                 `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddLanternRequest) Reset() {
+	*x = AddLanternRequest{}
+	mi := &file_garden_v1_garden_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddLanternRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddLanternRequest) ProtoMessage() {}
+
+func (x *AddLanternRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddLanternRequest.ProtoReflect.Descriptor instead.
+func (*AddLanternRequest) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AddLanternRequest) GetPosition() *Point {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+// Quita el último farol colocado
+type RemoveLanternRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveLanternRequest) Reset() {
+	*x = RemoveLanternRequest{}
+	mi := &file_garden_v1_garden_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveLanternRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveLanternRequest) ProtoMessage() {}
+
+func (x *RemoveLanternRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveLanternRequest.ProtoReflect.Descriptor instead.
+func (*RemoveLanternRequest) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{3}
+}
+
+type MoveLanternRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Position      *Point                 `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveLanternRequest) Reset() {
+	*x = MoveLanternRequest{}
+	mi := &file_garden_v1_garden_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveLanternRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveLanternRequest) ProtoMessage() {}
+
+func (x *MoveLanternRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveLanternRequest.ProtoReflect.Descriptor instead.
+func (*MoveLanternRequest) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MoveLanternRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MoveLanternRequest) GetPosition() *Point {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+type SetWindRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// north, south, east, west, northeast, northwest, southeast, southwest o cycle
+	Direction     string `protobuf:"bytes,1,opt,name=direction,proto3" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWindRequest) Reset() {
+	*x = SetWindRequest{}
+	mi := &file_garden_v1_garden_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWindRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWindRequest) ProtoMessage() {}
+
+func (x *SetWindRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWindRequest.ProtoReflect.Descriptor instead.
+func (*SetWindRequest) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SetWindRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+type SetAttractionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Position      *Point                 `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAttractionRequest) Reset() {
+	*x = SetAttractionRequest{}
+	mi := &file_garden_v1_garden_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAttractionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAttractionRequest) ProtoMessage() {}
+
+func (x *SetAttractionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAttractionRequest.ProtoReflect.Descriptor instead.
+func (*SetAttractionRequest) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetAttractionRequest) GetPosition() *Point {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+type ClearAttractionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearAttractionRequest) Reset() {
+	*x = ClearAttractionRequest{}
+	mi := &file_garden_v1_garden_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearAttractionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearAttractionRequest) ProtoMessage() {}
+
+func (x *ClearAttractionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearAttractionRequest.ProtoReflect.Descriptor instead.
+func (*ClearAttractionRequest) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{7}
+}
+
+// Las órdenes se aplican de forma asíncrona; accepted indica que entraron a la cola
+type CommandReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandReply) Reset() {
+	*x = CommandReply{}
+	mi := &file_garden_v1_garden_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandReply) ProtoMessage() {}
+
+func (x *CommandReply) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandReply.ProtoReflect.Descriptor instead.
+func (*CommandReply) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CommandReply) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+type GetStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStateRequest) Reset() {
+	*x = GetStateRequest{}
+	mi := &file_garden_v1_garden_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStateRequest) ProtoMessage() {}
+
+func (x *GetStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStateRequest.ProtoReflect.Descriptor instead.
+func (*GetStateRequest) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{9}
+}
+
+type Firefly struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Position      *Point                 `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	Brightness    float64                `protobuf:"fixed64,3,opt,name=brightness,proto3" json:"brightness,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Firefly) Reset() {
+	*x = Firefly{}
+	mi := &file_garden_v1_garden_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Firefly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Firefly) ProtoMessage() {}
+
+func (x *Firefly) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Firefly.ProtoReflect.Descriptor instead.
+func (*Firefly) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Firefly) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Firefly) GetPosition() *Point {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Firefly) GetBrightness() float64 {
+	if x != nil {
+		return x.Brightness
+	}
+	return 0
+}
+
+type Lantern struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Position      *Point                 `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	Radius        float64                `protobuf:"fixed64,3,opt,name=radius,proto3" json:"radius,omitempty"`
+	Intensity     float64                `protobuf:"fixed64,4,opt,name=intensity,proto3" json:"intensity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Lantern) Reset() {
+	*x = Lantern{}
+	mi := &file_garden_v1_garden_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lantern) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lantern) ProtoMessage() {}
+
+func (x *Lantern) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lantern.ProtoReflect.Descriptor instead.
+func (*Lantern) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Lantern) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Lantern) GetPosition() *Point {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Lantern) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *Lantern) GetIntensity() float64 {
+	if x != nil {
+		return x.Intensity
+	}
+	return 0
+}
+
+type Wind struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Direction     string                 `protobuf:"bytes,1,opt,name=direction,proto3" json:"direction,omitempty"`
+	Force         *Point                 `protobuf:"bytes,2,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Wind) Reset() {
+	*x = Wind{}
+	mi := &file_garden_v1_garden_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Wind) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Wind) ProtoMessage() {}
+
+func (x *Wind) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Wind.ProtoReflect.Descriptor instead.
+func (*Wind) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Wind) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *Wind) GetForce() *Point {
+	if x != nil {
+		return x.Force
+	}
+	return nil
+}
+
+type State struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TimeUnixNano  int64                  `protobuf:"varint,1,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	Population    int32                  `protobuf:"varint,2,opt,name=population,proto3" json:"population,omitempty"`
+	SpawnCap      int32                  `protobuf:"varint,3,opt,name=spawn_cap,json=spawnCap,proto3" json:"spawn_cap,omitempty"`
+	Dropped       uint64                 `protobuf:"varint,4,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	Wind          *Wind                  `protobuf:"bytes,5,opt,name=wind,proto3" json:"wind,omitempty"`
+	Fireflies     []*Firefly             `protobuf:"bytes,6,rep,name=fireflies,proto3" json:"fireflies,omitempty"`
+	Lanterns      []*Lantern             `protobuf:"bytes,7,rep,name=lanterns,proto3" json:"lanterns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *State) Reset() {
+	*x = State{}
+	mi := &file_garden_v1_garden_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *State) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*State) ProtoMessage() {}
+
+func (x *State) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use State.ProtoReflect.Descriptor instead.
+func (*State) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *State) GetTimeUnixNano() int64 {
+	if x != nil {
+		return x.TimeUnixNano
+	}
+	return 0
+}
+
+func (x *State) GetPopulation() int32 {
+	if x != nil {
+		return x.Population
+	}
+	return 0
+}
+
+func (x *State) GetSpawnCap() int32 {
+	if x != nil {
+		return x.SpawnCap
+	}
+	return 0
+}
+
+func (x *State) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *State) GetWind() *Wind {
+	if x != nil {
+		return x.Wind
+	}
+	return nil
+}
+
+func (x *State) GetFireflies() []*Firefly {
+	if x != nil {
+		return x.Fireflies
+	}
+	return nil
+}
+
+func (x *State) GetLanterns() []*Lantern {
+	if x != nil {
+		return x.Lanterns
+	}
+	return nil
+}
+
+type SessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// seq se devuelve en el CommandResult correspondiente
+	Seq uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// Types that are valid to be assigned to Command:
+	//
+	//	*SessionRequest_Spawn
+	//	*SessionRequest_AddLantern
+	//	*SessionRequest_RemoveLantern
+	//	*SessionRequest_MoveLantern
+	//	*SessionRequest_SetWind
+	//	*SessionRequest_SetAttraction
+	//	*SessionRequest_ClearAttraction
+	Command       isSessionRequest_Command `protobuf_oneof:"command"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionRequest) Reset() {
+	*x = SessionRequest{}
+	mi := &file_garden_v1_garden_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRequest) ProtoMessage() {}
+
+func (x *SessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRequest.ProtoReflect.Descriptor instead.
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SessionRequest) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SessionRequest) GetCommand() isSessionRequest_Command {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetSpawn() *SpawnRequest {
+	if x != nil {
+		if x, ok := x.Command.(*SessionRequest_Spawn); ok {
+			return x.Spawn
+		}
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetAddLantern() *AddLanternRequest {
+	if x != nil {
+		if x, ok := x.Command.(*SessionRequest_AddLantern); ok {
+			return x.AddLantern
+		}
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetRemoveLantern() *RemoveLanternRequest {
+	if x != nil {
+		if x, ok := x.Command.(*SessionRequest_RemoveLantern); ok {
+			return x.RemoveLantern
+		}
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetMoveLantern() *MoveLanternRequest {
+	if x != nil {
+		if x, ok := x.Command.(*SessionRequest_MoveLantern); ok {
+			return x.MoveLantern
+		}
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetSetWind() *SetWindRequest {
+	if x != nil {
+		if x, ok := x.Command.(*SessionRequest_SetWind); ok {
+			return x.SetWind
+		}
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetSetAttraction() *SetAttractionRequest {
+	if x != nil {
+		if x, ok := x.Command.(*SessionRequest_SetAttraction); ok {
+			return x.SetAttraction
+		}
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetClearAttraction() *ClearAttractionRequest {
+	if x != nil {
+		if x, ok := x.Command.(*SessionRequest_ClearAttraction); ok {
+			return x.ClearAttraction
+		}
+	}
+	return nil
+}
+
+type isSessionRequest_Command interface {
+	isSessionRequest_Command()
+}
+
+type SessionRequest_Spawn struct {
+	Spawn *SpawnRequest `protobuf:"bytes,2,opt,name=spawn,proto3,oneof"`
+}
+
+type SessionRequest_AddLantern struct {
+	AddLantern *AddLanternRequest `protobuf:"bytes,3,opt,name=add_lantern,json=addLantern,proto3,oneof"`
+}
+
+type SessionRequest_RemoveLantern struct {
+	RemoveLantern *RemoveLanternRequest `protobuf:"bytes,4,opt,name=remove_lantern,json=removeLantern,proto3,oneof"`
+}
+
+type SessionRequest_MoveLantern struct {
+	MoveLantern *MoveLanternRequest `protobuf:"bytes,5,opt,name=move_lantern,json=moveLantern,proto3,oneof"`
+}
+
+type SessionRequest_SetWind struct {
+	SetWind *SetWindRequest `protobuf:"bytes,6,opt,name=set_wind,json=setWind,proto3,oneof"`
+}
+
+type SessionRequest_SetAttraction struct {
+	SetAttraction *SetAttractionRequest `protobuf:"bytes,7,opt,name=set_attraction,json=setAttraction,proto3,oneof"`
+}
+
+type SessionRequest_ClearAttraction struct {
+	ClearAttraction *ClearAttractionRequest `protobuf:"bytes,8,opt,name=clear_attraction,json=clearAttraction,proto3,oneof"`
+}
+
+func (*SessionRequest_Spawn) isSessionRequest_Command() {}
+
+func (*SessionRequest_AddLantern) isSessionRequest_Command() {}
+
+func (*SessionRequest_RemoveLantern) isSessionRequest_Command() {}
+
+func (*SessionRequest_MoveLantern) isSessionRequest_Command() {}
+
+func (*SessionRequest_SetWind) isSessionRequest_Command() {}
+
+func (*SessionRequest_SetAttraction) isSessionRequest_Command() {}
+
+func (*SessionRequest_ClearAttraction) isSessionRequest_Command() {}
+
+type CommandResult struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Seq      uint64                 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Accepted bool                   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	// Motivo del rechazo (cola llena, dirección inválida...)
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandResult) Reset() {
+	*x = CommandResult{}
+	mi := &file_garden_v1_garden_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandResult) ProtoMessage() {}
+
+func (x *CommandResult) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandResult.ProtoReflect.Descriptor instead.
+func (*CommandResult) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CommandResult) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *CommandResult) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *CommandResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Event es un manager.Event: spawn, death, lantern_add, lantern_remove,
+// attraction, attraction_clear, wind, settings o restore
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tiempo desde el inicio de la partida
+	TNanos        int64  `protobuf:"varint,1,opt,name=t_nanos,json=tNanos,proto3" json:"t_nanos,omitempty"`
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Id            int32  `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	Position      *Point `protobuf:"bytes,4,opt,name=position,proto3,oneof" json:"position,omitempty"`
+	Wind          string `protobuf:"bytes,5,opt,name=wind,proto3" json:"wind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_garden_v1_garden_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Event) GetTNanos() int64 {
+	if x != nil {
+		return x.TNanos
+	}
+	return 0
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetPosition() *Point {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Event) GetWind() string {
+	if x != nil {
+		return x.Wind
+	}
+	return ""
+}
+
+type SessionUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Update:
+	//
+	//	*SessionUpdate_Result
+	//	*SessionUpdate_Event
+	//	*SessionUpdate_State
+	Update        isSessionUpdate_Update `protobuf_oneof:"update"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionUpdate) Reset() {
+	*x = SessionUpdate{}
+	mi := &file_garden_v1_garden_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionUpdate) ProtoMessage() {}
+
+func (x *SessionUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_garden_v1_garden_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionUpdate.ProtoReflect.Descriptor instead.
+func (*SessionUpdate) Descriptor() ([]byte, []int) {
+	return file_garden_v1_garden_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SessionUpdate) GetUpdate() isSessionUpdate_Update {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *SessionUpdate) GetResult() *CommandResult {
+	if x != nil {
+		if x, ok := x.Update.(*SessionUpdate_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *SessionUpdate) GetEvent() *Event {
+	if x != nil {
+		if x, ok := x.Update.(*SessionUpdate_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+func (x *SessionUpdate) GetState() *State {
+	if x != nil {
+		if x, ok := x.Update.(*SessionUpdate_State); ok {
+			return x.State
+		}
+	}
+	return nil
+}
+
+type isSessionUpdate_Update interface {
+	isSessionUpdate_Update()
+}
+
+type SessionUpdate_Result struct {
+	Result *CommandResult `protobuf:"bytes,1,opt,name=result,proto3,oneof"`
+}
+
+type SessionUpdate_Event struct {
+	Event *Event `protobuf:"bytes,2,opt,name=event,proto3,oneof"`
+}
+
+type SessionUpdate_State struct {
+	State *State `protobuf:"bytes,3,opt,name=state,proto3,oneof"`
+}
+
+func (*SessionUpdate_Result) isSessionUpdate_Update() {}
+
+func (*SessionUpdate_Event) isSessionUpdate_Update() {}
+
+func (*SessionUpdate_State) isSessionUpdate_Update() {}
+
+var File_garden_v1_garden_proto protoreflect.FileDescriptor
+
+const file_garden_v1_garden_proto_rawDesc = "" +
+	"\n" +
+	"\x16garden/v1/garden.proto\x12\tgarden.v1\"#\n" +
+	"\x05Point\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x01R\x01y\"d\n" +
+	"\fSpawnRequest\x121\n" +
+	"\bposition\x18\x01 \x01(\v2\x10.garden.v1.PointH\x00R\bposition\x88\x01\x01\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05countB\v\n" +
+	"\t_position\"A\n" +
+	"\x11AddLanternRequest\x12,\n" +
+	"\bposition\x18\x01 \x01(\v2\x10.garden.v1.PointR\bposition\"\x16\n" +
+	"\x14RemoveLanternRequest\"R\n" +
+	"\x12MoveLanternRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12,\n" +
+	"\bposition\x18\x02 \x01(\v2\x10.garden.v1.PointR\bposition\".\n" +
+	"\x0eSetWindRequest\x12\x1c\n" +
+	"\tdirection\x18\x01 \x01(\tR\tdirection\"D\n" +
+	"\x14SetAttractionRequest\x12,\n" +
+	"\bposition\x18\x01 \x01(\v2\x10.garden.v1.PointR\bposition\"\x18\n" +
+	"\x16ClearAttractionRequest\"*\n" +
+	"\fCommandReply\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\"\x11\n" +
+	"\x0fGetStateRequest\"g\n" +
+	"\aFirefly\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12,\n" +
+	"\bposition\x18\x02 \x01(\v2\x10.garden.v1.PointR\bposition\x12\x1e\n" +
+	"\n" +
+	"brightness\x18\x03 \x01(\x01R\n" +
+	"brightness\"}\n" +
+	"\aLantern\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12,\n" +
+	"\bposition\x18\x02 \x01(\v2\x10.garden.v1.PointR\bposition\x12\x16\n" +
+	"\x06radius\x18\x03 \x01(\x01R\x06radius\x12\x1c\n" +
+	"\tintensity\x18\x04 \x01(\x01R\tintensity\"L\n" +
+	"\x04Wind\x12\x1c\n" +
+	"\tdirection\x18\x01 \x01(\tR\tdirection\x12&\n" +
+	"\x05force\x18\x02 \x01(\v2\x10.garden.v1.PointR\x05force\"\x8b\x02\n" +
+	"\x05State\x12$\n" +
+	"\x0etime_unix_nano\x18\x01 \x01(\x03R\ftimeUnixNano\x12\x1e\n" +
+	"\n" +
+	"population\x18\x02 \x01(\x05R\n" +
+	"population\x12\x1b\n" +
+	"\tspawn_cap\x18\x03 \x01(\x05R\bspawnCap\x12\x18\n" +
+	"\adropped\x18\x04 \x01(\x04R\adropped\x12#\n" +
+	"\x04wind\x18\x05 \x01(\v2\x0f.garden.v1.WindR\x04wind\x120\n" +
+	"\tfireflies\x18\x06 \x03(\v2\x12.garden.v1.FireflyR\tfireflies\x12.\n" +
+	"\blanterns\x18\a \x03(\v2\x12.garden.v1.LanternR\blanterns\"\xff\x03\n" +
+	"\x0eSessionRequest\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x12/\n" +
+	"\x05spawn\x18\x02 \x01(\v2\x17.garden.v1.SpawnRequestH\x00R\x05spawn\x12?\n" +
+	"\vadd_lantern\x18\x03 \x01(\v2\x1c.garden.v1.AddLanternRequestH\x00R\n" +
+	"addLantern\x12H\n" +
+	"\x0eremove_lantern\x18\x04 \x01(\v2\x1f.garden.v1.RemoveLanternRequestH\x00R\rremoveLantern\x12B\n" +
+	"\fmove_lantern\x18\x05 \x01(\v2\x1d.garden.v1.MoveLanternRequestH\x00R\vmoveLantern\x126\n" +
+	"\bset_wind\x18\x06 \x01(\v2\x19.garden.v1.SetWindRequestH\x00R\asetWind\x12H\n" +
+	"\x0eset_attraction\x18\a \x01(\v2\x1f.garden.v1.SetAttractionRequestH\x00R\rsetAttraction\x12N\n" +
+	"\x10clear_attraction\x18\b \x01(\v2!.garden.v1.ClearAttractionRequestH\x00R\x0fclearAttractionB\t\n" +
+	"\acommand\"S\n" +
+	"\rCommandResult\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\bR\baccepted\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\x98\x01\n" +
+	"\x05Event\x12\x17\n" +
+	"\at_nanos\x18\x01 \x01(\x03R\x06tNanos\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\x05R\x02id\x121\n" +
+	"\bposition\x18\x04 \x01(\v2\x10.garden.v1.PointH\x00R\bposition\x88\x01\x01\x12\x12\n" +
+	"\x04wind\x18\x05 \x01(\tR\x04windB\v\n" +
+	"\t_position\"\xa1\x01\n" +
+	"\rSessionUpdate\x122\n" +
+	"\x06result\x18\x01 \x01(\v2\x18.garden.v1.CommandResultH\x00R\x06result\x12(\n" +
+	"\x05event\x18\x02 \x01(\v2\x10.garden.v1.EventH\x00R\x05event\x12(\n" +
+	"\x05state\x18\x03 \x01(\v2\x10.garden.v1.StateH\x00R\x05stateB\b\n" +
+	"\x06update2\xf8\x04\n" +
+	"\rGardenService\x129\n" +
+	"\x05Spawn\x12\x17.garden.v1.SpawnRequest\x1a\x17.garden.v1.CommandReply\x12C\n" +
+	"\n" +
+	"AddLantern\x12\x1c.garden.v1.AddLanternRequest\x1a\x17.garden.v1.CommandReply\x12I\n" +
+	"\rRemoveLantern\x12\x1f.garden.v1.RemoveLanternRequest\x1a\x17.garden.v1.CommandReply\x12E\n" +
+	"\vMoveLantern\x12\x1d.garden.v1.MoveLanternRequest\x1a\x17.garden.v1.CommandReply\x12=\n" +
+	"\aSetWind\x12\x19.garden.v1.SetWindRequest\x1a\x17.garden.v1.CommandReply\x12I\n" +
+	"\rSetAttraction\x12\x1f.garden.v1.SetAttractionRequest\x1a\x17.garden.v1.CommandReply\x12M\n" +
+	"\x0fClearAttraction\x12!.garden.v1.ClearAttractionRequest\x1a\x17.garden.v1.CommandReply\x128\n" +
+	"\bGetState\x12\x1a.garden.v1.GetStateRequest\x1a\x10.garden.v1.State\x12B\n" +
+	"\aSession\x12\x19.garden.v1.SessionRequest\x1a\x18.garden.v1.SessionUpdate(\x010\x01B>Z<github.com/yourusername/firefly-garden/internal/api/gardenpbb\x06proto3"
+
+var (
+	file_garden_v1_garden_proto_rawDescOnce sync.Once
+	file_garden_v1_garden_proto_rawDescData []byte
+)
+
+func file_garden_v1_garden_proto_rawDescGZIP() []byte {
+	file_garden_v1_garden_proto_rawDescOnce.Do(func() {
+		file_garden_v1_garden_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_garden_v1_garden_proto_rawDesc), len(file_garden_v1_garden_proto_rawDesc)))
+	})
+	return file_garden_v1_garden_proto_rawDescData
+}
+
+var file_garden_v1_garden_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_garden_v1_garden_proto_goTypes = []any{
+	(*Point)(nil),                  // 0: garden.v1.Point
+	(*SpawnRequest)(nil),           // 1: garden.v1.SpawnRequest
+	(*AddLanternRequest)(nil),      // 2: garden.v1.AddLanternRequest
+	(*RemoveLanternRequest)(nil),   // 3: garden.v1.RemoveLanternRequest
+	(*MoveLanternRequest)(nil),     // 4: garden.v1.MoveLanternRequest
+	(*SetWindRequest)(nil),         // 5: garden.v1.SetWindRequest
+	(*SetAttractionRequest)(nil),   // 6: garden.v1.SetAttractionRequest
+	(*ClearAttractionRequest)(nil), // 7: garden.v1.ClearAttractionRequest
+	(*CommandReply)(nil),           // 8: garden.v1.CommandReply
+	(*GetStateRequest)(nil),        // 9: garden.v1.GetStateRequest
+	(*Firefly)(nil),                // 10: garden.v1.Firefly
+	(*Lantern)(nil),                // 11: garden.v1.Lantern
+	(*Wind)(nil),                   // 12: garden.v1.Wind
+	(*State)(nil),                  // 13: garden.v1.State
+	(*SessionRequest)(nil),         // 14: garden.v1.SessionRequest
+	(*CommandResult)(nil),          // 15: garden.v1.CommandResult
+	(*Event)(nil),                  // 16: garden.v1.Event
+	(*SessionUpdate)(nil),          // 17: garden.v1.SessionUpdate
+}
+var file_garden_v1_garden_proto_depIdxs = []int32{
+	0,  // 0: garden.v1.SpawnRequest.position:type_name -> garden.v1.Point
+	0,  // 1: garden.v1.AddLanternRequest.position:type_name -> garden.v1.Point
+	0,  // 2: garden.v1.MoveLanternRequest.position:type_name -> garden.v1.Point
+	0,  // 3: garden.v1.SetAttractionRequest.position:type_name -> garden.v1.Point
+	0,  // 4: garden.v1.Firefly.position:type_name -> garden.v1.Point
+	0,  // 5: garden.v1.Lantern.position:type_name -> garden.v1.Point
+	0,  // 6: garden.v1.Wind.force:type_name -> garden.v1.Point
+	12, // 7: garden.v1.State.wind:type_name -> garden.v1.Wind
+	10, // 8: garden.v1.State.fireflies:type_name -> garden.v1.Firefly
+	11, // 9: garden.v1.State.lanterns:type_name -> garden.v1.Lantern
+	1,  // 10: garden.v1.SessionRequest.spawn:type_name -> garden.v1.SpawnRequest
+	2,  // 11: garden.v1.SessionRequest.add_lantern:type_name -> garden.v1.AddLanternRequest
+	3,  // 12: garden.v1.SessionRequest.remove_lantern:type_name -> garden.v1.RemoveLanternRequest
+	4,  // 13: garden.v1.SessionRequest.move_lantern:type_name -> garden.v1.MoveLanternRequest
+	5,  // 14: garden.v1.SessionRequest.set_wind:type_name -> garden.v1.SetWindRequest
+	6,  // 15: garden.v1.SessionRequest.set_attraction:type_name -> garden.v1.SetAttractionRequest
+	7,  // 16: garden.v1.SessionRequest.clear_attraction:type_name -> garden.v1.ClearAttractionRequest
+	0,  // 17: garden.v1.Event.position:type_name -> garden.v1.Point
+	15, // 18: garden.v1.SessionUpdate.result:type_name -> garden.v1.CommandResult
+	16, // 19: garden.v1.SessionUpdate.event:type_name -> garden.v1.Event
+	13, // 20: garden.v1.SessionUpdate.state:type_name -> garden.v1.State
+	1,  // 21: garden.v1.GardenService.Spawn:input_type -> garden.v1.SpawnRequest
+	2,  // 22: garden.v1.GardenService.AddLantern:input_type -> garden.v1.AddLanternRequest
+	3,  // 23: garden.v1.GardenService.RemoveLantern:input_type -> garden.v1.RemoveLanternRequest
+	4,  // 24: garden.v1.GardenService.MoveLantern:input_type -> garden.v1.MoveLanternRequest
+	5,  // 25: garden.v1.GardenService.SetWind:input_type -> garden.v1.SetWindRequest
+	6,  // 26: garden.v1.GardenService.SetAttraction:input_type -> garden.v1.SetAttractionRequest
+	7,  // 27: garden.v1.GardenService.ClearAttraction:input_type -> garden.v1.ClearAttractionRequest
+	9,  // 28: garden.v1.GardenService.GetState:input_type -> garden.v1.GetStateRequest
+	14, // 29: garden.v1.GardenService.Session:input_type -> garden.v1.SessionRequest
+	8,  // 30: garden.v1.GardenService.Spawn:output_type -> garden.v1.CommandReply
+	8,  // 31: garden.v1.GardenService.AddLantern:output_type -> garden.v1.CommandReply
+	8,  // 32: garden.v1.GardenService.RemoveLantern:output_type -> garden.v1.CommandReply
+	8,  // 33: garden.v1.GardenService.MoveLantern:output_type -> garden.v1.CommandReply
+	8,  // 34: garden.v1.GardenService.SetWind:output_type -> garden.v1.CommandReply
+	8,  // 35: garden.v1.GardenService.SetAttraction:output_type -> garden.v1.CommandReply
+	8,  // 36: garden.v1.GardenService.ClearAttraction:output_type -> garden.v1.CommandReply
+	13, // 37: garden.v1.GardenService.GetState:output_type -> garden.v1.State
+	17, // 38: garden.v1.GardenService.Session:output_type -> garden.v1.SessionUpdate
+	30, // [30:39] is the sub-list for method output_type
+	21, // [21:30] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_garden_v1_garden_proto_init() }
+func file_garden_v1_garden_proto_init() {
+	if File_garden_v1_garden_proto != nil {
+		return
+	}
+	file_garden_v1_garden_proto_msgTypes[1].OneofWrappers = []any{}
+	file_garden_v1_garden_proto_msgTypes[14].OneofWrappers = []any{
+		(*SessionRequest_Spawn)(nil),
+		(*SessionRequest_AddLantern)(nil),
+		(*SessionRequest_RemoveLantern)(nil),
+		(*SessionRequest_MoveLantern)(nil),
+		(*SessionRequest_SetWind)(nil),
+		(*SessionRequest_SetAttraction)(nil),
+		(*SessionRequest_ClearAttraction)(nil),
+	}
+	file_garden_v1_garden_proto_msgTypes[16].OneofWrappers = []any{}
+	file_garden_v1_garden_proto_msgTypes[17].OneofWrappers = []any{
+		(*SessionUpdate_Result)(nil),
+		(*SessionUpdate_Event)(nil),
+		(*SessionUpdate_State)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_garden_v1_garden_proto_rawDesc), len(file_garden_v1_garden_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_garden_v1_garden_proto_goTypes,
+		DependencyIndexes: file_garden_v1_garden_proto_depIdxs,
+		MessageInfos:      file_garden_v1_garden_proto_msgTypes,
+	}.Build()
+	File_garden_v1_garden_proto = out.File
+	file_garden_v1_garden_proto_goTypes = nil
+	file_garden_v1_garden_proto_depIdxs = nil
+}
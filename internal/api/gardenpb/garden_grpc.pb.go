@@ -0,0 +1,428 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: garden/v1/garden.proto
+
+// API gRPC del jardín: las mismas órdenes que la API HTTP y el teclado, más
+// una sesión bidireccional para bots y controladores de experimentos.
+// Regenerar con: cd proto && buf generate
+
+package gardenpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GardenService_Spawn_FullMethodName           = "/garden.v1.GardenService/Spawn"
+	GardenService_AddLantern_FullMethodName      = "/garden.v1.GardenService/AddLantern"
+	GardenService_RemoveLantern_FullMethodName   = "/garden.v1.GardenService/RemoveLantern"
+	GardenService_MoveLantern_FullMethodName     = "/garden.v1.GardenService/MoveLantern"
+	GardenService_SetWind_FullMethodName         = "/garden.v1.GardenService/SetWind"
+	GardenService_SetAttraction_FullMethodName   = "/garden.v1.GardenService/SetAttraction"
+	GardenService_ClearAttraction_FullMethodName = "/garden.v1.GardenService/ClearAttraction"
+	GardenService_GetState_FullMethodName        = "/garden.v1.GardenService/GetState"
+	GardenService_Session_FullMethodName         = "/garden.v1.GardenService/Session"
+)
+
+// GardenServiceClient is the client API for GardenService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GardenServiceClient interface {
+	Spawn(ctx context.Context, in *SpawnRequest, opts ...grpc.CallOption) (*CommandReply, error)
+	AddLantern(ctx context.Context, in *AddLanternRequest, opts ...grpc.CallOption) (*CommandReply, error)
+	RemoveLantern(ctx context.Context, in *RemoveLanternRequest, opts ...grpc.CallOption) (*CommandReply, error)
+	MoveLantern(ctx context.Context, in *MoveLanternRequest, opts ...grpc.CallOption) (*CommandReply, error)
+	SetWind(ctx context.Context, in *SetWindRequest, opts ...grpc.CallOption) (*CommandReply, error)
+	SetAttraction(ctx context.Context, in *SetAttractionRequest, opts ...grpc.CallOption) (*CommandReply, error)
+	ClearAttraction(ctx context.Context, in *ClearAttractionRequest, opts ...grpc.CallOption) (*CommandReply, error)
+	GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*State, error)
+	// Session recibe órdenes del cliente y le envía el resultado de cada una,
+	// los eventos del manager y un snapshot periódico del jardín.
+	Session(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SessionRequest, SessionUpdate], error)
+}
+
+type gardenServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGardenServiceClient(cc grpc.ClientConnInterface) GardenServiceClient {
+	return &gardenServiceClient{cc}
+}
+
+func (c *gardenServiceClient) Spawn(ctx context.Context, in *SpawnRequest, opts ...grpc.CallOption) (*CommandReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommandReply)
+	err := c.cc.Invoke(ctx, GardenService_Spawn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gardenServiceClient) AddLantern(ctx context.Context, in *AddLanternRequest, opts ...grpc.CallOption) (*CommandReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommandReply)
+	err := c.cc.Invoke(ctx, GardenService_AddLantern_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gardenServiceClient) RemoveLantern(ctx context.Context, in *RemoveLanternRequest, opts ...grpc.CallOption) (*CommandReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommandReply)
+	err := c.cc.Invoke(ctx, GardenService_RemoveLantern_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gardenServiceClient) MoveLantern(ctx context.Context, in *MoveLanternRequest, opts ...grpc.CallOption) (*CommandReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommandReply)
+	err := c.cc.Invoke(ctx, GardenService_MoveLantern_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gardenServiceClient) SetWind(ctx context.Context, in *SetWindRequest, opts ...grpc.CallOption) (*CommandReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommandReply)
+	err := c.cc.Invoke(ctx, GardenService_SetWind_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gardenServiceClient) SetAttraction(ctx context.Context, in *SetAttractionRequest, opts ...grpc.CallOption) (*CommandReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommandReply)
+	err := c.cc.Invoke(ctx, GardenService_SetAttraction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gardenServiceClient) ClearAttraction(ctx context.Context, in *ClearAttractionRequest, opts ...grpc.CallOption) (*CommandReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommandReply)
+	err := c.cc.Invoke(ctx, GardenService_ClearAttraction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gardenServiceClient) GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*State, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(State)
+	err := c.cc.Invoke(ctx, GardenService_GetState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gardenServiceClient) Session(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SessionRequest, SessionUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GardenService_ServiceDesc.Streams[0], GardenService_Session_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SessionRequest, SessionUpdate]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GardenService_SessionClient = grpc.BidiStreamingClient[SessionRequest, SessionUpdate]
+
+// GardenServiceServer is the server API for GardenService service.
+// All implementations must embed UnimplementedGardenServiceServer
+// for forward compatibility.
+type GardenServiceServer interface {
+	Spawn(context.Context, *SpawnRequest) (*CommandReply, error)
+	AddLantern(context.Context, *AddLanternRequest) (*CommandReply, error)
+	RemoveLantern(context.Context, *RemoveLanternRequest) (*CommandReply, error)
+	MoveLantern(context.Context, *MoveLanternRequest) (*CommandReply, error)
+	SetWind(context.Context, *SetWindRequest) (*CommandReply, error)
+	SetAttraction(context.Context, *SetAttractionRequest) (*CommandReply, error)
+	ClearAttraction(context.Context, *ClearAttractionRequest) (*CommandReply, error)
+	GetState(context.Context, *GetStateRequest) (*State, error)
+	// Session recibe órdenes del cliente y le envía el resultado de cada una,
+	// los eventos del manager y un snapshot periódico del jardín.
+	Session(grpc.BidiStreamingServer[SessionRequest, SessionUpdate]) error
+	mustEmbedUnimplementedGardenServiceServer()
+}
+
+// UnimplementedGardenServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGardenServiceServer struct{}
+
+func (UnimplementedGardenServiceServer) Spawn(context.Context, *SpawnRequest) (*CommandReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Spawn not implemented")
+}
+func (UnimplementedGardenServiceServer) AddLantern(context.Context, *AddLanternRequest) (*CommandReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddLantern not implemented")
+}
+func (UnimplementedGardenServiceServer) RemoveLantern(context.Context, *RemoveLanternRequest) (*CommandReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveLantern not implemented")
+}
+func (UnimplementedGardenServiceServer) MoveLantern(context.Context, *MoveLanternRequest) (*CommandReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveLantern not implemented")
+}
+func (UnimplementedGardenServiceServer) SetWind(context.Context, *SetWindRequest) (*CommandReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWind not implemented")
+}
+func (UnimplementedGardenServiceServer) SetAttraction(context.Context, *SetAttractionRequest) (*CommandReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAttraction not implemented")
+}
+func (UnimplementedGardenServiceServer) ClearAttraction(context.Context, *ClearAttractionRequest) (*CommandReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearAttraction not implemented")
+}
+func (UnimplementedGardenServiceServer) GetState(context.Context, *GetStateRequest) (*State, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetState not implemented")
+}
+func (UnimplementedGardenServiceServer) Session(grpc.BidiStreamingServer[SessionRequest, SessionUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method Session not implemented")
+}
+func (UnimplementedGardenServiceServer) mustEmbedUnimplementedGardenServiceServer() {}
+func (UnimplementedGardenServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeGardenServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GardenServiceServer will
+// result in compilation errors.
+type UnsafeGardenServiceServer interface {
+	mustEmbedUnimplementedGardenServiceServer()
+}
+
+func RegisterGardenServiceServer(s grpc.ServiceRegistrar, srv GardenServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGardenServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GardenService_ServiceDesc, srv)
+}
+
+func _GardenService_Spawn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpawnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GardenServiceServer).Spawn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GardenService_Spawn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GardenServiceServer).Spawn(ctx, req.(*SpawnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GardenService_AddLantern_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddLanternRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GardenServiceServer).AddLantern(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GardenService_AddLantern_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GardenServiceServer).AddLantern(ctx, req.(*AddLanternRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GardenService_RemoveLantern_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveLanternRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GardenServiceServer).RemoveLantern(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GardenService_RemoveLantern_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GardenServiceServer).RemoveLantern(ctx, req.(*RemoveLanternRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GardenService_MoveLantern_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveLanternRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GardenServiceServer).MoveLantern(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GardenService_MoveLantern_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GardenServiceServer).MoveLantern(ctx, req.(*MoveLanternRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GardenService_SetWind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GardenServiceServer).SetWind(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GardenService_SetWind_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GardenServiceServer).SetWind(ctx, req.(*SetWindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GardenService_SetAttraction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAttractionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GardenServiceServer).SetAttraction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GardenService_SetAttraction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GardenServiceServer).SetAttraction(ctx, req.(*SetAttractionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GardenService_ClearAttraction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearAttractionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GardenServiceServer).ClearAttraction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GardenService_ClearAttraction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GardenServiceServer).ClearAttraction(ctx, req.(*ClearAttractionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GardenService_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GardenServiceServer).GetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GardenService_GetState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GardenServiceServer).GetState(ctx, req.(*GetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GardenService_Session_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GardenServiceServer).Session(&grpc.GenericServerStream[SessionRequest, SessionUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GardenService_SessionServer = grpc.BidiStreamingServer[SessionRequest, SessionUpdate]
+
+// GardenService_ServiceDesc is the grpc.ServiceDesc for GardenService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GardenService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "garden.v1.GardenService",
+	HandlerType: (*GardenServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Spawn",
+			Handler:    _GardenService_Spawn_Handler,
+		},
+		{
+			MethodName: "AddLantern",
+			Handler:    _GardenService_AddLantern_Handler,
+		},
+		{
+			MethodName: "RemoveLantern",
+			Handler:    _GardenService_RemoveLantern_Handler,
+		},
+		{
+			MethodName: "MoveLantern",
+			Handler:    _GardenService_MoveLantern_Handler,
+		},
+		{
+			MethodName: "SetWind",
+			Handler:    _GardenService_SetWind_Handler,
+		},
+		{
+			MethodName: "SetAttraction",
+			Handler:    _GardenService_SetAttraction_Handler,
+		},
+		{
+			MethodName: "ClearAttraction",
+			Handler:    _GardenService_ClearAttraction_Handler,
+		},
+		{
+			MethodName: "GetState",
+			Handler:    _GardenService_GetState_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Session",
+			Handler:       _GardenService_Session_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "garden/v1/garden.proto",
+}
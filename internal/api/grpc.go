@@ -0,0 +1,295 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/api/gardenpb"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/pkg/garden"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// sessionEventBuffer es el buffer de eventos de cada sesión; si el
+	// cliente no lee a tiempo los eventos se descartan (ver DroppedEvents)
+	sessionEventBuffer = 256
+	// sessionStateInterval es cada cuánto una sesión recibe el estado
+	sessionStateInterval = 100 * time.Millisecond
+)
+
+var errReadOnly = errors.New("la sesión es de solo lectura")
+
+// grpcService implementa gardenpb.GardenServiceServer sobre el mismo Server
+// que la API HTTP
+type grpcService struct {
+	gardenpb.UnimplementedGardenServiceServer
+	server *Server
+}
+
+// reply traduce el resultado de encolar una orden a un código gRPC
+func (g *grpcService) reply(cmd garden.Command) (*gardenpb.CommandReply, error) {
+	if err := g.server.command(cmd); err != nil {
+		return nil, commandStatus(err)
+	}
+	return &gardenpb.CommandReply{Accepted: true}, nil
+}
+
+func commandStatus(err error) error {
+	switch {
+	case errors.Is(err, errNoGarden), errors.Is(err, garden.ErrStopped):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, garden.ErrCommandQueueFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func (g *grpcService) Spawn(ctx context.Context, req *gardenpb.SpawnRequest) (*gardenpb.CommandReply, error) {
+	return g.reply(spawnRequestCommand(req))
+}
+
+func (g *grpcService) AddLantern(ctx context.Context, req *gardenpb.AddLanternRequest) (*gardenpb.CommandReply, error) {
+	return g.reply(garden.Command{Kind: garden.AddLantern, Position: fromPoint(req.GetPosition())})
+}
+
+func (g *grpcService) RemoveLantern(ctx context.Context, req *gardenpb.RemoveLanternRequest) (*gardenpb.CommandReply, error) {
+	return g.reply(garden.Command{Kind: garden.RemoveLantern})
+}
+
+func (g *grpcService) MoveLantern(ctx context.Context, req *gardenpb.MoveLanternRequest) (*gardenpb.CommandReply, error) {
+	return g.reply(garden.Command{Kind: garden.MoveLantern, ID: int(req.GetId()), Position: fromPoint(req.GetPosition())})
+}
+
+func (g *grpcService) SetWind(ctx context.Context, req *gardenpb.SetWindRequest) (*gardenpb.CommandReply, error) {
+	return g.reply(windCommand(req.GetDirection()))
+}
+
+func (g *grpcService) SetAttraction(ctx context.Context, req *gardenpb.SetAttractionRequest) (*gardenpb.CommandReply, error) {
+	return g.reply(garden.Command{Kind: garden.SetAttraction, Position: fromPoint(req.GetPosition())})
+}
+
+func (g *grpcService) ClearAttraction(ctx context.Context, req *gardenpb.ClearAttractionRequest) (*gardenpb.CommandReply, error) {
+	return g.reply(garden.Command{Kind: garden.ClearAttraction})
+}
+
+func (g *grpcService) GetState(ctx context.Context, req *gardenpb.GetStateRequest) (*gardenpb.State, error) {
+	state := g.server.state.Load()
+	if state == nil {
+		return nil, status.Error(codes.Unavailable, errNoGarden.Error())
+	}
+	return state.proto(), nil
+}
+
+// Session atiende una sesión bidireccional. Una goroutine lee las órdenes del
+// cliente; esta, la única que llama Send, intercala sus resultados con los
+// eventos del manager y el estado periódico. Termina con Unavailable si la
+// partida conectada termina. Las órdenes de una sesión espectadora
+// (RoleHeader) se responden como rechazadas.
+func (g *grpcService) Session(stream grpc.BidiStreamingServer[gardenpb.SessionRequest, gardenpb.SessionUpdate]) error {
+	attached := g.server.garden.Load()
+	if attached == nil {
+		return status.Error(codes.Unavailable, errNoGarden.Error())
+	}
+
+	events, unsubscribe := attached.Subscribe(sessionEventBuffer)
+	defer unsubscribe()
+
+	ctx := stream.Context()
+
+	remote := "desconocido"
+	if p, ok := peer.FromContext(ctx); ok {
+		remote = p.Addr.String()
+	}
+	spectator := slices.Contains(metadata.ValueFromIncomingContext(ctx, RoleHeader), RoleSpectator)
+	counter := &g.server.players
+	if spectator {
+		counter = &g.server.spectators
+	}
+	counter.Add(1)
+	g.server.log.Info("sesión abierta", "peer", remote, "spectator", spectator, "players", g.server.Players(), "spectators", g.server.Spectators())
+	defer func() {
+		counter.Add(-1)
+		g.server.log.Info("sesión cerrada", "peer", remote, "spectator", spectator, "players", g.server.Players(), "spectators", g.server.Spectators())
+	}()
+	results := make(chan *gardenpb.CommandResult)
+	recvErr := make(chan error, 1)
+
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			result := &gardenpb.CommandResult{Seq: req.GetSeq(), Accepted: true}
+			if spectator {
+				result.Accepted = false
+				result.Error = errReadOnly.Error()
+			} else if err := g.server.command(sessionCommand(req)); err != nil {
+				result.Accepted = false
+				result.Error = err.Error()
+			}
+
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(sessionStateInterval)
+	defer ticker.Stop()
+
+	for {
+		var update *gardenpb.SessionUpdate
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case err := <-recvErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+
+		case result := <-results:
+			update = &gardenpb.SessionUpdate{Update: &gardenpb.SessionUpdate_Result{Result: result}}
+
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			update = &gardenpb.SessionUpdate{Update: &gardenpb.SessionUpdate_Event{Event: eventProto(e)}}
+
+		case <-ticker.C:
+			if g.server.garden.Load() != attached {
+				return status.Error(codes.Unavailable, "la partida terminó")
+			}
+			state := g.server.state.Load()
+			if state == nil {
+				continue
+			}
+			update = &gardenpb.SessionUpdate{Update: &gardenpb.SessionUpdate_State{State: state.proto()}}
+		}
+
+		if err := stream.Send(update); err != nil {
+			return err
+		}
+	}
+}
+
+// sessionCommand traduce la orden de una sesión; una orden vacía se rechaza
+// con garden.ErrUnknownCommand
+func sessionCommand(req *gardenpb.SessionRequest) garden.Command {
+	switch cmd := req.GetCommand().(type) {
+	case *gardenpb.SessionRequest_Spawn:
+		return spawnRequestCommand(cmd.Spawn)
+	case *gardenpb.SessionRequest_AddLantern:
+		return garden.Command{Kind: garden.AddLantern, Position: fromPoint(cmd.AddLantern.GetPosition())}
+	case *gardenpb.SessionRequest_RemoveLantern:
+		return garden.Command{Kind: garden.RemoveLantern}
+	case *gardenpb.SessionRequest_MoveLantern:
+		return garden.Command{Kind: garden.MoveLantern, ID: int(cmd.MoveLantern.GetId()), Position: fromPoint(cmd.MoveLantern.GetPosition())}
+	case *gardenpb.SessionRequest_SetWind:
+		return windCommand(cmd.SetWind.GetDirection())
+	case *gardenpb.SessionRequest_SetAttraction:
+		return garden.Command{Kind: garden.SetAttraction, Position: fromPoint(cmd.SetAttraction.GetPosition())}
+	case *gardenpb.SessionRequest_ClearAttraction:
+		return garden.Command{Kind: garden.ClearAttraction}
+	default:
+		return garden.Command{Kind: -1}
+	}
+}
+
+func spawnRequestCommand(req *gardenpb.SpawnRequest) garden.Command {
+	width, height := config.WorldSize()
+	pos := utils.RandomVector2D(0, width, 0, height)
+	if req.Position != nil {
+		pos = fromPoint(req.Position)
+	}
+	return spawnCommand(pos, int(req.GetCount()))
+}
+
+func windCommand(direction string) garden.Command {
+	if direction == "cycle" {
+		return garden.Command{Kind: garden.CycleWind}
+	}
+	return garden.Command{Kind: garden.SetWind, Wind: direction}
+}
+
+func fromPoint(p *gardenpb.Point) utils.Vector2D {
+	return utils.Vector2D{X: p.GetX(), Y: p.GetY()}
+}
+
+func toProtoPoint(v utils.Vector2D) *gardenpb.Point {
+	return &gardenpb.Point{X: v.X, Y: v.Y}
+}
+
+func (s *stateResponse) proto() *gardenpb.State {
+	state := &gardenpb.State{
+		TimeUnixNano: s.Time.UnixNano(),
+		Population:   int32(s.Population),
+		SpawnCap:     int32(s.SpawnCap),
+		Dropped:      s.Dropped,
+		Wind: &gardenpb.Wind{
+			Direction: s.Wind.Direction,
+			Force:     &gardenpb.Point{X: s.Wind.Force.X, Y: s.Wind.Force.Y},
+		},
+		Fireflies: make([]*gardenpb.Firefly, 0, len(s.Fireflies)),
+		Lanterns:  make([]*gardenpb.Lantern, 0, len(s.Lanterns)),
+	}
+	for _, f := range s.Fireflies {
+		state.Fireflies = append(state.Fireflies, &gardenpb.Firefly{
+			Id:         int32(f.ID),
+			Position:   &gardenpb.Point{X: f.X, Y: f.Y},
+			Brightness: f.Brightness,
+		})
+	}
+	for _, l := range s.Lanterns {
+		state.Lanterns = append(state.Lanterns, &gardenpb.Lantern{
+			Id:        int32(l.ID),
+			Position:  &gardenpb.Point{X: l.X, Y: l.Y},
+			Radius:    l.Radius,
+			Intensity: l.Intensity,
+		})
+	}
+	return state
+}
+
+// eventProto resume un manager.Event: la posición sale del campo que traiga
+// según el tipo (punto de atracción, luciérnaga o farol)
+func eventProto(e manager.Event) *gardenpb.Event {
+	event := &gardenpb.Event{
+		TNanos: int64(e.T),
+		Type:   string(e.Type),
+		Id:     int32(e.ID),
+	}
+
+	switch {
+	case e.Position != nil:
+		event.Position = toProtoPoint(*e.Position)
+	case e.Firefly != nil:
+		event.Position = toProtoPoint(e.Firefly.Position)
+	case e.Lantern != nil:
+		event.Position = toProtoPoint(e.Lantern.Position)
+	}
+	if e.Wind != nil {
+		event.Wind = e.Wind.String()
+	}
+
+	return event
+}
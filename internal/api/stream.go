@@ -0,0 +1,63 @@
+package api
+
+import (
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/delta"
+)
+
+// streamKeyframeEvery es cada cuántos cuadros de /stream va uno clave: uno
+// cada 3 segundos al ritmo de Refresh
+const streamKeyframeEvery = 30
+
+// handleStream manda por WebSocket un cuadro binario de delta por cada
+// estado que publica Refresh: primero uno clave y después solo lo que
+// cambió. Termina cuando la partida se desconecta o el cliente se va.
+func (s *Server) handleStream(ws *websocket.Conn) {
+	defer ws.Close()
+
+	// El cliente no manda nada: leer solo sirve para notar que cerró
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard []byte
+		for websocket.Message.Receive(ws, &discard) == nil {
+		}
+	}()
+
+	encoder := delta.NewEncoder(streamKeyframeEvery)
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	var (
+		last   *stateResponse
+		states []core.FireflyState
+		frame  []byte
+	)
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ticker.C:
+		}
+
+		state := s.state.Load()
+		if state == nil {
+			return
+		}
+		if state == last {
+			continue
+		}
+		last = state
+
+		states = state.fireflyStates(states[:0])
+		frame = encoder.Encode(frame[:0], states)
+		if err := websocket.Message.Send(ws, frame); err != nil {
+			s.log.Debug("stream cerrado", "err", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,313 @@
+// Package chat deja que el chat de un stream (Twitch, YouTube) dispare
+// eventos del jardín: "!spawn 5", "!wind north", "!lantern". Las órdenes
+// entran por la misma cola de comandos que el teclado y pasan antes por dos
+// límites, uno por usuario y otro global, para que el chat no pueda inundar
+// la simulación ni pasar el máximo de luciérnagas.
+package chat
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/pkg/garden"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// messageBuffer es cuántos mensajes pueden esperar a la Bridge; si el chat
+// va más rápido los mensajes se descartan en la fuente
+const messageBuffer = 64
+
+// Message es un mensaje del chat
+type Message struct {
+	User string
+	Text string
+}
+
+// Source es una fuente de mensajes. Run publica en out hasta que ctx se
+// cancela; se encarga de reconectarse si la plataforma corta.
+type Source interface {
+	Name() string
+	Run(ctx context.Context, out chan<- Message) error
+}
+
+// Target es lo que la Bridge ve del jardín. *garden.Garden la implementa.
+type Target interface {
+	Command(cmd garden.Command) error
+	Status() garden.Status
+}
+
+// Options son los límites de la Bridge
+type Options struct {
+	// UserCooldown es el tiempo mínimo entre dos órdenes del mismo usuario
+	UserCooldown time.Duration
+	// PerMinute es el máximo de órdenes por minuto de todo el chat
+	PerMinute int
+	// MaxSpawn es el máximo de luciérnagas de un "!spawn N" (0 usa la
+	// ráfaga configurada)
+	MaxSpawn int
+}
+
+// DefaultOptions retorna límites pensados para un chat con muchos usuarios
+func DefaultOptions() Options {
+	return Options{
+		UserCooldown: 10 * time.Second,
+		PerMinute:    30,
+	}
+}
+
+// attachment es la partida conectada y cómo avisarle al jugador
+type attachment struct {
+	target Target
+	notify func(string)
+}
+
+// Bridge traduce mensajes del chat a órdenes del jardín. Sobrevive a las
+// partidas: cada una se conecta con Attach al empezar.
+type Bridge struct {
+	opts     Options
+	attached atomic.Pointer[attachment]
+	log      *slog.Logger
+
+	// Solo los usa la goroutine de Run
+	lastByUser map[string]time.Time
+	tokens     float64
+	refilled   time.Time
+
+	mux      sync.Mutex
+	accepted uint64
+	rejected uint64
+}
+
+// NewBridge crea la Bridge con los límites dados
+func NewBridge(opts Options) *Bridge {
+	if opts.PerMinute <= 0 {
+		opts.PerMinute = DefaultOptions().PerMinute
+	}
+	if opts.MaxSpawn <= 0 {
+		opts.MaxSpawn = max(config.Get().Spawn.BurstCount, 1)
+	}
+	return &Bridge{
+		opts:       opts,
+		log:        logging.For("chat"),
+		lastByUser: make(map[string]time.Time),
+		tokens:     float64(opts.PerMinute),
+		refilled:   time.Now(),
+	}
+}
+
+// Attach conecta la partida que recibe las órdenes; notify (opcional) recibe
+// un texto por cada orden aceptada. Attach(nil, nil) la desconecta.
+func (b *Bridge) Attach(target Target, notify func(string)) {
+	if target == nil {
+		b.attached.Store(nil)
+		return
+	}
+	b.attached.Store(&attachment{target: target, notify: notify})
+}
+
+// Counts retorna cuántas órdenes del chat se aplicaron y cuántas se rechazaron
+func (b *Bridge) Counts() (accepted, rejected uint64) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	return b.accepted, b.rejected
+}
+
+// Run lee la fuente hasta que ctx se cancela; retorna cuando la fuente
+// también terminó
+func (b *Bridge) Run(ctx context.Context, src Source) {
+	messages := make(chan Message, messageBuffer)
+	srcDone := make(chan struct{})
+	go func() {
+		defer close(srcDone)
+		if err := src.Run(ctx, messages); err != nil && ctx.Err() == nil {
+			b.log.Error("fuente de chat detenida", "source", src.Name(), "err", err)
+		}
+	}()
+
+	b.log.Info("chat conectado", "source", src.Name())
+	for {
+		select {
+		case <-ctx.Done():
+			<-srcDone
+			return
+		case msg := <-messages:
+			b.handle(msg, time.Now())
+		}
+	}
+}
+
+// handle aplica un mensaje si es una orden y los límites lo permiten
+func (b *Bridge) handle(msg Message, now time.Time) {
+	cmd, ok := parseCommand(msg.Text)
+	if !ok {
+		return
+	}
+
+	att := b.attached.Load()
+	if att == nil {
+		return
+	}
+
+	if err := b.admit(msg.User, now); err != nil {
+		b.reject(msg, err)
+		return
+	}
+
+	order, err := b.command(&cmd, att.target.Status())
+	if err == nil {
+		err = att.target.Command(order)
+	}
+	if err != nil {
+		b.reject(msg, err)
+		return
+	}
+
+	b.lastByUser[msg.User] = now
+	b.mux.Lock()
+	b.accepted++
+	b.mux.Unlock()
+
+	b.log.Debug("orden del chat aplicada", "user", msg.User, "text", msg.Text)
+	if att.notify != nil {
+		att.notify(fmt.Sprintf("%s: %s", msg.User, cmd))
+	}
+}
+
+func (b *Bridge) reject(msg Message, err error) {
+	b.mux.Lock()
+	b.rejected++
+	b.mux.Unlock()
+
+	b.log.Debug("orden del chat rechazada", "user", msg.User, "text", msg.Text, "err", err)
+}
+
+// admit aplica el enfriamiento por usuario y el balde global de órdenes por
+// minuto; solo consume una ficha si el usuario puede ordenar
+func (b *Bridge) admit(user string, now time.Time) error {
+	if last, ok := b.lastByUser[user]; ok && now.Sub(last) < b.opts.UserCooldown {
+		return fmt.Errorf("%s debe esperar %s", user, (b.opts.UserCooldown - now.Sub(last)).Round(time.Second))
+	}
+
+	perMinute := float64(b.opts.PerMinute)
+	b.tokens = min(perMinute, b.tokens+now.Sub(b.refilled).Minutes()*perMinute)
+	b.refilled = now
+	if b.tokens < 1 {
+		return fmt.Errorf("límite de %d órdenes por minuto", b.opts.PerMinute)
+	}
+	b.tokens--
+
+	// Los usuarios que ya cumplieron el enfriamiento no hace falta recordarlos
+	if len(b.lastByUser) > 1024 {
+		for u, last := range b.lastByUser {
+			if now.Sub(last) >= b.opts.UserCooldown {
+				delete(b.lastByUser, u)
+			}
+		}
+	}
+	return nil
+}
+
+// chatCommand es una orden del chat ya interpretada
+type chatCommand struct {
+	name  string
+	count int
+	wind  string
+}
+
+func (c chatCommand) String() string {
+	switch c.name {
+	case "spawn":
+		return fmt.Sprintf("!spawn %d", c.count)
+	case "wind":
+		return "!wind " + c.wind
+	default:
+		return "!" + c.name
+	}
+}
+
+// windAbbreviations son las direcciones cortas que acepta "!wind"
+var windAbbreviations = map[string]string{
+	"n": "north", "ne": "northeast", "e": "east", "se": "southeast",
+	"s": "south", "sw": "southwest", "w": "west", "nw": "northwest",
+}
+
+// parseCommand reconoce "!spawn [N]", "!wind [DIR]" y "!lantern"; cualquier
+// otro mensaje no es una orden. Sin dirección el viento rota a la siguiente.
+func parseCommand(text string) (chatCommand, bool) {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "!") {
+		return chatCommand{}, false
+	}
+
+	switch name := strings.TrimPrefix(fields[0], "!"); name {
+	case "spawn":
+		cmd := chatCommand{name: name, count: 1}
+		if len(fields) > 1 {
+			n, err := strconv.Atoi(fields[1])
+			if err != nil || n < 1 {
+				return chatCommand{}, false
+			}
+			cmd.count = n
+		}
+		return cmd, true
+
+	case "wind":
+		cmd := chatCommand{name: name, wind: "cycle"}
+		if len(fields) > 1 {
+			dir := fields[1]
+			if full, ok := windAbbreviations[dir]; ok {
+				dir = full
+			}
+			if _, ok := core.ParseWindDirection(dir); !ok {
+				return chatCommand{}, false
+			}
+			cmd.wind = dir
+		}
+		return cmd, true
+
+	case "lantern":
+		return chatCommand{name: name}, true
+	}
+
+	return chatCommand{}, false
+}
+
+// command arma la orden del jardín. Un "!spawn" se recorta al lugar libre
+// bajo el límite de población; el manager igualmente vuelve a verificarlo.
+func (b *Bridge) command(cmd *chatCommand, status garden.Status) (garden.Command, error) {
+	width, height := config.WorldSize()
+	pos := utils.RandomVector2D(0, width, 0, height)
+
+	switch cmd.name {
+	case "spawn":
+		room := status.SpawnCap - status.Population
+		if room <= 0 {
+			return garden.Command{}, fmt.Errorf("el jardín está lleno (%d/%d)", status.Population, status.SpawnCap)
+		}
+		count := min(cmd.count, b.opts.MaxSpawn, room)
+		cmd.count = count
+		if count == 1 {
+			return garden.Command{Kind: garden.SpawnFirefly, Position: pos}, nil
+		}
+		return garden.Command{Kind: garden.SpawnBurst, Position: pos, Count: count}, nil
+
+	case "wind":
+		if cmd.wind == "cycle" {
+			return garden.Command{Kind: garden.CycleWind}, nil
+		}
+		return garden.Command{Kind: garden.SetWind, Wind: cmd.wind}, nil
+
+	default:
+		return garden.Command{Kind: garden.AddLantern, Position: pos}, nil
+	}
+}
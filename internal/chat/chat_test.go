@@ -0,0 +1,25 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/firefly-garden/pkg/garden"
+)
+
+func TestCommandAfterStop(t *testing.T) {
+	g, err := garden.New(garden.Options{Sync: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Start()
+	g.Stop()
+
+	b := NewBridge(DefaultOptions())
+	b.Attach(g, nil)
+	b.handle(Message{User: "ana", Text: "!lantern"}, time.Now())
+
+	if accepted, rejected := b.Counts(); accepted != 0 || rejected != 1 {
+		t.Fatalf("tras Stop: %d aceptadas y %d rechazadas, se esperaba 0 y 1", accepted, rejected)
+	}
+}
@@ -0,0 +1,291 @@
+package chat
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/logging"
+)
+
+const (
+	// twitchAddr es el IRC de Twitch sin TLS; el acceso anónimo solo lee
+	twitchAddr = "irc.chat.twitch.tv:6667"
+	// youtubeMessagesURL es el endpoint de mensajes de un chat en vivo
+	youtubeMessagesURL = "https://www.googleapis.com/youtube/v3/liveChat/messages"
+	// minPollInterval evita consultar a YouTube más seguido de lo que pide
+	minPollInterval = 2 * time.Second
+	// maxBackoff es la espera máxima entre reconexiones
+	maxBackoff = time.Minute
+)
+
+// ParseSource interpreta -chat: "twitch:CANAL", "youtube:LIVE_CHAT_ID" (la
+// clave de la API sale de YOUTUBE_API_KEY) o "stdin" para probar sin stream
+func ParseSource(spec string) (Source, error) {
+	kind, arg, _ := strings.Cut(spec, ":")
+	switch kind {
+	case "twitch":
+		if arg == "" {
+			return nil, errors.New("falta el canal: twitch:CANAL")
+		}
+		return &Twitch{Channel: strings.ToLower(strings.TrimPrefix(arg, "#"))}, nil
+
+	case "youtube":
+		key := os.Getenv("YOUTUBE_API_KEY")
+		if arg == "" || key == "" {
+			return nil, errors.New("youtube necesita youtube:LIVE_CHAT_ID y la variable YOUTUBE_API_KEY")
+		}
+		return &YouTube{LiveChatID: arg, APIKey: key}, nil
+
+	case "stdin":
+		return NewLines(os.Stdin, "stdin"), nil
+	}
+
+	return nil, fmt.Errorf("fuente de chat desconocida %q (twitch:CANAL, youtube:ID o stdin)", spec)
+}
+
+// send publica el mensaje sin bloquear; si la Bridge va atrasada se descarta
+func send(out chan<- Message, msg Message) {
+	select {
+	case out <- msg:
+	default:
+	}
+}
+
+// retry llama a session hasta que ctx se cancela, esperando cada vez más
+// entre intentos; una sesión que llegó a conectarse reinicia la espera
+func retry(ctx context.Context, name string, session func(ctx context.Context) (bool, error)) error {
+	log := logging.For("chat")
+	backoff := time.Second
+
+	for {
+		connected, err := session(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if connected {
+			backoff = time.Second
+		}
+		log.Warn("chat desconectado, reintentando", "source", name, "err", err, "retry", backoff)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// Twitch lee el chat de un canal por IRC con un usuario anónimo (justinfan),
+// que no necesita token porque nunca escribe
+type Twitch struct {
+	Channel string
+}
+
+func (t *Twitch) Name() string {
+	return "twitch:" + t.Channel
+}
+
+func (t *Twitch) Run(ctx context.Context, out chan<- Message) error {
+	return retry(ctx, t.Name(), func(ctx context.Context) (bool, error) {
+		return t.session(ctx, out)
+	})
+}
+
+func (t *Twitch) session(ctx context.Context, out chan<- Message) (bool, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", twitchAddr)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	nick := fmt.Sprintf("justinfan%d", 10000+rand.Intn(90000))
+	if _, err := fmt.Fprintf(conn, "NICK %s\r\nJOIN #%s\r\n", nick, t.Channel); err != nil {
+		return false, err
+	}
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if rest, ok := strings.CutPrefix(line, "PING"); ok {
+			if _, err := fmt.Fprintf(conn, "PONG%s\r\n", rest); err != nil {
+				return true, err
+			}
+			continue
+		}
+		if msg, ok := parsePrivmsg(line); ok {
+			send(out, msg)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return true, err
+	}
+	return true, io.EOF
+}
+
+// parsePrivmsg extrae usuario y texto de
+// ":nick!nick@nick.tmi.twitch.tv PRIVMSG #canal :texto"
+func parsePrivmsg(line string) (Message, bool) {
+	prefix, rest, ok := strings.Cut(line, " PRIVMSG ")
+	if !ok || !strings.HasPrefix(prefix, ":") {
+		return Message{}, false
+	}
+	_, text, ok := strings.Cut(rest, " :")
+	if !ok {
+		return Message{}, false
+	}
+	user, _, _ := strings.Cut(strings.TrimPrefix(prefix, ":"), "!")
+	return Message{User: user, Text: text}, true
+}
+
+// YouTube consulta los mensajes de un chat en vivo con la Data API v3. Los
+// mensajes anteriores a la conexión se ignoran.
+type YouTube struct {
+	LiveChatID string
+	APIKey     string
+}
+
+func (y *YouTube) Name() string {
+	return "youtube:" + y.LiveChatID
+}
+
+type youtubePage struct {
+	NextPageToken         string `json:"nextPageToken"`
+	PollingIntervalMillis int    `json:"pollingIntervalMillis"`
+	Items                 []struct {
+		Snippet struct {
+			DisplayMessage string `json:"displayMessage"`
+		} `json:"snippet"`
+		AuthorDetails struct {
+			DisplayName string `json:"displayName"`
+		} `json:"authorDetails"`
+	} `json:"items"`
+}
+
+func (y *YouTube) Run(ctx context.Context, out chan<- Message) error {
+	return retry(ctx, y.Name(), func(ctx context.Context) (bool, error) {
+		return y.session(ctx, out)
+	})
+}
+
+func (y *YouTube) session(ctx context.Context, out chan<- Message) (bool, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	token := ""
+	first := true
+
+	for {
+		page, err := y.fetch(ctx, client, token)
+		if err != nil {
+			return !first, err
+		}
+
+		if !first {
+			for _, item := range page.Items {
+				send(out, Message{User: item.AuthorDetails.DisplayName, Text: item.Snippet.DisplayMessage})
+			}
+		}
+		first = false
+		token = page.NextPageToken
+
+		wait := max(time.Duration(page.PollingIntervalMillis)*time.Millisecond, minPollInterval)
+		select {
+		case <-ctx.Done():
+			return true, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (y *YouTube) fetch(ctx context.Context, client *http.Client, token string) (*youtubePage, error) {
+	query := url.Values{
+		"liveChatId": {y.LiveChatID},
+		"part":       {"snippet,authorDetails"},
+		"key":        {y.APIKey},
+	}
+	if token != "" {
+		query.Set("pageToken", token)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, youtubeMessagesURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("YouTube respondió %s", resp.Status)
+	}
+
+	var page youtubePage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("respuesta de YouTube inválida: %w", err)
+	}
+	return &page, nil
+}
+
+// Lines lee un mensaje por línea ("usuario: texto", o solo el texto); sirve
+// para probar las órdenes sin un stream. La lectura arranca en NewLines
+// porque un Read bloqueado no se puede cancelar: esa goroutine vive hasta
+// el fin de la entrada y Run solo reenvía lo leído.
+type Lines struct {
+	label string
+	lines chan Message
+	err   error
+}
+
+// NewLines empieza a leer r
+func NewLines(r io.Reader, label string) *Lines {
+	l := &Lines{label: label, lines: make(chan Message, messageBuffer)}
+
+	go func() {
+		defer close(l.lines)
+
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			msg := Message{User: "consola", Text: scanner.Text()}
+			if user, text, ok := strings.Cut(msg.Text, ":"); ok && !strings.HasPrefix(user, "!") {
+				msg = Message{User: strings.TrimSpace(user), Text: strings.TrimSpace(text)}
+			}
+			l.lines <- msg
+		}
+		l.err = scanner.Err()
+	}()
+
+	return l
+}
+
+func (l *Lines) Name() string {
+	return l.label
+}
+
+func (l *Lines) Run(ctx context.Context, out chan<- Message) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-l.lines:
+			if !ok {
+				return l.err
+			}
+			send(out, msg)
+		}
+	}
+}
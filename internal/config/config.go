@@ -0,0 +1,605 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Config reúne todos los parámetros ajustables de la simulación y el render.
+// Se carga desde un archivo JSON (opcional) y se sobreescribe con flags.
+// Los valores que definen el tamaño de la ventana y los enums siguen
+// siendo constantes en constants.go.
+type Config struct {
+	TargetFPS     int    `json:"target_fps"`
+	SimulationTPS int    `json:"simulation_tps"`
+	LogLevel      string `json:"log_level"`
+	// BackgroundTPS son los ticks de simulación y de Update con la ventana
+	// sin foco (bajo consumo); 0 la deja correr a ritmo normal
+	BackgroundTPS int `json:"background_tps"`
+
+	Fireflies FirefliesConfig `json:"fireflies"`
+	Spawn     SpawnConfig     `json:"spawn"`
+	Lanterns  LanternsConfig  `json:"lanterns"`
+	Wind      WindConfig      `json:"wind"`
+	Bats      BatsConfig      `json:"bats"`
+	Camera    CameraConfig    `json:"camera"`
+	Heatmap   HeatmapConfig   `json:"heatmap"`
+	Clusters  ClustersConfig  `json:"clusters"`
+	Capture   CaptureConfig   `json:"capture"`
+	Render    RenderConfig    `json:"render"`
+	Sound     SoundConfig     `json:"sound"`
+	Demo      DemoConfig      `json:"demo"`
+	Channels  ChannelsConfig  `json:"channels"`
+	Batch     BatchConfig     `json:"batch"`
+	Chaos     ChaosConfig     `json:"chaos"`
+	Ecology   EcologyConfig   `json:"ecology"`
+	Storm     StormConfig     `json:"storm"`
+	Tracing   TracingConfig   `json:"tracing"`
+	Colors    ColorsConfig    `json:"colors"`
+}
+
+type FirefliesConfig struct {
+	Max             int      `json:"max"`
+	Initial         int      `json:"initial"`
+	SpawnInterval   Duration `json:"spawn_interval"`
+	Size            float64  `json:"size"`
+	Speed           float64  `json:"speed"`
+	BlinkCycleMin   float64  `json:"blink_cycle_min"`
+	BlinkCycleMax   float64  `json:"blink_cycle_max"`
+	AttractionForce float64  `json:"attraction_force"`
+	FieldForce      float64  `json:"field_force"`
+	WindResistance  float64  `json:"wind_resistance"`
+	LifespanMin     float64  `json:"lifespan_min"`
+	LifespanMax     float64  `json:"lifespan_max"`
+}
+
+// Políticas de fábrica del spawner automático (spawn.policy)
+const (
+	// SpawnObjective repone en ráfagas hasta el objetivo
+	SpawnObjective = "objective"
+	// SpawnSteady suelta una luciérnaga por tick hasta el objetivo
+	SpawnSteady = "steady"
+	// SpawnWaves repone todo lo que falta cada varios ticks, de golpe
+	SpawnWaves = "waves"
+	// SpawnOff no suelta ninguna
+	SpawnOff = "off"
+)
+
+// SpawnPolicies son los valores válidos de spawn.policy
+var SpawnPolicies = []string{SpawnObjective, SpawnSteady, SpawnWaves, SpawnOff}
+
+type SpawnConfig struct {
+	AutoSpawn bool `json:"auto_spawn"`
+	// Policy es la política del spawner automático (ver SpawnPolicies); un
+	// plugin registrado con plugin.RegisterSpawnPolicy la reemplaza
+	Policy         string   `json:"policy"`
+	Objective      int      `json:"objective"`
+	BurstCount     int      `json:"burst_count"`
+	PlayerCooldown Duration `json:"player_cooldown"`
+	// BurstStagger separa los nacimientos de una ráfaga para que se vea
+	// abrirse; "0s" las suelta todas juntas
+	BurstStagger Duration `json:"burst_stagger"`
+	// Zones son las zonas de aparición de los jardines sin mapa o cuyo mapa
+	// no trae spawn_zones; vacío hace nacer las luciérnagas en todo el mundo
+	Zones []SpawnZone `json:"zones,omitempty"`
+}
+
+// SpawnZone es una zona de aparición con los campos de spawn_zones en los
+// mapas: un círculo (X, Y, R) o un rectángulo (X, Y, W, H), la parte de
+// las apariciones que le toca (Weight), cuántas suelta por segundo por su
+// cuenta (Rate) y si las que nacen ahí arrancan hacia el centro (Inward)
+type SpawnZone struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	W      float64 `json:"w,omitempty"`
+	H      float64 `json:"h,omitempty"`
+	R      float64 `json:"r,omitempty"`
+	Weight float64 `json:"weight,omitempty"`
+	Rate   float64 `json:"rate,omitempty"`
+	Inward bool    `json:"inward,omitempty"`
+}
+
+type LanternsConfig struct {
+	Max            int     `json:"max"`
+	Radius         float64 `json:"radius"`
+	InfluenceForce float64 `json:"influence_force"`
+	Size           float64 `json:"size"`
+}
+
+type WindConfig struct {
+	ChangeInterval Duration `json:"change_interval"`
+	Force          float64  `json:"force"`
+	MaxStrength    float64  `json:"max_strength"`
+}
+
+// BatsConfig son los murciélagos de las oleadas: cazan durante Stay, hacen
+// una pausa de Digest después de cada luciérnaga y evitan los faroles
+type BatsConfig struct {
+	Speed     float64  `json:"speed"`
+	EatRadius float64  `json:"eat_radius"`
+	Stay      Duration `json:"stay"`
+	Digest    Duration `json:"digest"`
+	Size      float64  `json:"size"`
+}
+
+type CameraConfig struct {
+	PanSpeed  float64 `json:"pan_speed"`
+	ZoomMin   float64 `json:"zoom_min"`
+	ZoomMax   float64 `json:"zoom_max"`
+	ZoomSpeed float64 `json:"zoom_speed"`
+}
+
+type HeatmapConfig struct {
+	CellSize       int      `json:"cell_size"`
+	SampleInterval Duration `json:"sample_interval"`
+	HalfLife       float64  `json:"half_life"`
+	MaxAlpha       int      `json:"max_alpha"`
+}
+
+// ClustersConfig es la detección de cúmulos (DBSCAN): una luciérnaga con
+// min_points vecinas a menos de radius, contándose, es núcleo de un cúmulo
+type ClustersConfig struct {
+	Interval  Duration `json:"interval"`
+	Radius    float64  `json:"radius"`
+	MinPoints int      `json:"min_points"`
+}
+
+type CaptureConfig struct {
+	Dir              string   `json:"dir"`
+	ExposureDuration Duration `json:"exposure_duration"`
+	ExposureGain     float64  `json:"exposure_gain"`
+	SnapshotFile     string   `json:"snapshot_file"`
+	StatsFile        string   `json:"stats_file"`
+	StatsOnExit      bool     `json:"stats_on_exit"`
+	ProfileDir       string   `json:"profile_dir"`
+}
+
+type RenderConfig struct {
+	Quality        int     `json:"quality"`
+	BloomThreshold float64 `json:"bloom_threshold"`
+	BloomIntensity float64 `json:"bloom_intensity"`
+	BloomPasses    int     `json:"bloom_passes"`
+
+	AutoQuality            bool    `json:"auto_quality"`
+	AutoQualityDropRatio   float64 `json:"auto_quality_drop_ratio"`
+	AutoQualityRaiseRatio  float64 `json:"auto_quality_raise_ratio"`
+	AutoQualityDropAfter   int     `json:"auto_quality_drop_after"`
+	AutoQualityRaiseAfter  int     `json:"auto_quality_raise_after"`
+	AutoQualityMinSpawnCap int     `json:"auto_quality_min_spawn_cap"`
+
+	CullMargin             float64 `json:"cull_margin"`
+	LODBrightnessThreshold float64 `json:"lod_brightness_threshold"`
+	LODMinHaloPixels       float64 `json:"lod_min_halo_pixels"`
+}
+
+// SoundConfig son los efectos de sonido. Volume va de 0 a 1; MaxVoices
+// limita cuántos suenan a la vez y Spacing es la separación mínima entre dos
+// efectos del mismo tipo, así una ráfaga no satura la mezcla. MusicRate y
+// MusicVoices limitan las notas del modo musical (por segundo y a la vez).
+type SoundConfig struct {
+	Enabled     bool     `json:"enabled"`
+	Volume      float64  `json:"volume"`
+	MaxVoices   int      `json:"max_voices"`
+	Spacing     Duration `json:"spacing"`
+	MusicRate   float64  `json:"music_rate"`
+	MusicVoices int      `json:"music_voices"`
+}
+
+// DemoConfig es el modo demostración: tras IdleAfter sin input (0 lo
+// desactiva) un cursor virtual juega solo, con una acción cada Step
+type DemoConfig struct {
+	IdleAfter Duration `json:"idle_after"`
+	Step      Duration `json:"step"`
+}
+
+type ChannelsConfig struct {
+	StateBuffer   int `json:"state_buffer"`
+	CommandBuffer int `json:"command_buffer"`
+}
+
+// BatchConfig es la simulación por lotes: con Enabled las luciérnagas no
+// tienen una goroutine cada una sino que Shards goroutines las avanzan de a
+// lotes con un solo ticker y publican cada lote entero al agregador. Shards
+// 0 usa una por procesador (GOMAXPROCS).
+type BatchConfig struct {
+	Enabled bool `json:"enabled"`
+	Shards  int  `json:"shards"`
+}
+
+// ChaosConfig es el modo caos (-chaos) para probar la capa concurrente:
+// cada estado publicado se demora Latency con probabilidad LatencyRate o se
+// descarta con DropRate; cada KillInterval se cae la goroutine de una
+// luciérnaga al azar y cada StallInterval un worker queda trabado durante
+// Stall. Un intervalo 0 desactiva esa falla.
+type ChaosConfig struct {
+	Enabled       bool     `json:"enabled"`
+	Latency       Duration `json:"latency"`
+	LatencyRate   float64  `json:"latency_rate"`
+	DropRate      float64  `json:"drop_rate"`
+	KillInterval  Duration `json:"kill_interval"`
+	StallInterval Duration `json:"stall_interval"`
+	Stall         Duration `json:"stall"`
+}
+
+// EcologyConfig es el modo ecológico (-ecology): en lugar de spawn.policy
+// los nacimientos siguen un crecimiento logístico con tasa GrowthRate (por
+// segundo) hacia la capacidad del jardín, Capacity más LanternCapacity por
+// farol. Con Predators, cada PredatorInterval llegan PredationRate
+// murciélagos por luciérnaga viva.
+type EcologyConfig struct {
+	Enabled          bool     `json:"enabled"`
+	GrowthRate       float64  `json:"growth_rate"`
+	Capacity         int      `json:"capacity"`
+	LanternCapacity  int      `json:"lantern_capacity"`
+	Predators        bool     `json:"predators"`
+	PredatorInterval Duration `json:"predator_interval"`
+	PredationRate    float64  `json:"predation_rate"`
+}
+
+// StormConfig son las tormentas: cada Interval se avisa y, Warning después,
+// un frente de Width píxeles cruza el jardín a Speed píxeles por segundo en
+// la dirección del viento, empujando con Force a las luciérnagas que
+// alcanza y dejando los faroles a Dim de su intensidad
+type StormConfig struct {
+	Enabled  bool     `json:"enabled"`
+	Interval Duration `json:"interval"`
+	Warning  Duration `json:"warning"`
+	Speed    float64  `json:"speed"`
+	Width    float64  `json:"width"`
+	Force    float64  `json:"force"`
+	Dim      float64  `json:"dim"`
+}
+
+// TracingConfig es el trazado de comandos con OpenTelemetry: con Enabled,
+// los spans se exportan por OTLP/gRPC a Endpoint (sin TLS si Insecure) y se
+// muestrea SampleRatio de los comandos
+type TracingConfig struct {
+	Enabled     bool    `json:"enabled"`
+	Endpoint    string  `json:"endpoint"`
+	Insecure    bool    `json:"insecure"`
+	SampleRatio float64 `json:"sample_ratio"`
+}
+
+// Los colores son RGBA en formato [r, g, b, a]
+type ColorsConfig struct {
+	Background  [4]uint8 `json:"background"`
+	FireflyDim  [4]uint8 `json:"firefly_dim"`
+	FireflyFull [4]uint8 `json:"firefly_full"`
+	Lantern     [4]uint8 `json:"lantern"`
+	Wind        [4]uint8 `json:"wind"`
+	UIText      [4]uint8 `json:"ui_text"`
+}
+
+// Duration se serializa como texto legible ("2s", "150ms")
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duración inválida %s: se espera un texto como \"2s\"", data)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+// Default retorna la configuración con la que el juego fue diseñado
+func Default() *Config {
+	return &Config{
+		TargetFPS:     60,
+		LogLevel:      "info",
+		SimulationTPS: 30,
+		BackgroundTPS: 10,
+
+		Fireflies: FirefliesConfig{
+			Max:             100,
+			Initial:         15,
+			SpawnInterval:   Duration{time.Second * 2},
+			Size:            8.0,
+			Speed:           1.5,
+			BlinkCycleMin:   1.0,
+			BlinkCycleMax:   3.0,
+			AttractionForce: 0.3,
+			FieldForce:      0.6,
+			WindResistance:  0.5,
+			LifespanMin:     12.0,
+			LifespanMax:     30.0,
+		},
+		Spawn: SpawnConfig{
+			AutoSpawn:      true,
+			Policy:         SpawnObjective,
+			Objective:      50,
+			BurstCount:     6,
+			PlayerCooldown: Duration{time.Second},
+			BurstStagger:   Duration{40 * time.Millisecond},
+		},
+		Lanterns: LanternsConfig{
+			Max:            10,
+			Radius:         120.0,
+			InfluenceForce: 0.5,
+			Size:           16.0,
+		},
+		Wind: WindConfig{
+			ChangeInterval: Duration{time.Second * 5},
+			Force:          0.8,
+			MaxStrength:    2.0,
+		},
+		Bats: BatsConfig{
+			Speed:     70.0,
+			EatRadius: 12.0,
+			Stay:      Duration{time.Second * 10},
+			Digest:    Duration{time.Millisecond * 1500},
+			Size:      14.0,
+		},
+		Camera: CameraConfig{
+			PanSpeed:  400.0,
+			ZoomMin:   1.0,
+			ZoomMax:   4.0,
+			ZoomSpeed: 1.5,
+		},
+		Heatmap: HeatmapConfig{
+			CellSize:       16,
+			SampleInterval: Duration{time.Millisecond * 100},
+			HalfLife:       10.0,
+			MaxAlpha:       160,
+		},
+		Clusters: ClustersConfig{
+			Interval:  Duration{time.Millisecond * 500},
+			Radius:    40,
+			MinPoints: 5,
+		},
+		Capture: CaptureConfig{
+			Dir:              "screenshots",
+			ExposureDuration: Duration{time.Second * 6},
+			ExposureGain:     0.06,
+			SnapshotFile:     "saves/garden.json",
+			StatsFile:        "stats/session.csv",
+			ProfileDir:       "profiles",
+		},
+		Render: RenderConfig{
+			Quality:        QualityBloom,
+			BloomThreshold: 0.3,
+			BloomIntensity: 1.5,
+			BloomPasses:    2,
+
+			AutoQuality:            true,
+			AutoQualityDropRatio:   0.85,
+			AutoQualityRaiseRatio:  0.97,
+			AutoQualityDropAfter:   2,
+			AutoQualityRaiseAfter:  5,
+			AutoQualityMinSpawnCap: 40,
+
+			CullMargin:             40.0,
+			LODBrightnessThreshold: 0.2,
+			LODMinHaloPixels:       4.0,
+		},
+		Sound: SoundConfig{
+			Enabled:     true,
+			Volume:      0.5,
+			MaxVoices:   8,
+			Spacing:     Duration{time.Millisecond * 60},
+			MusicRate:   8,
+			MusicVoices: 6,
+		},
+		Demo: DemoConfig{
+			IdleAfter: Duration{time.Second * 90},
+			Step:      Duration{time.Second * 4},
+		},
+		Channels: ChannelsConfig{
+			StateBuffer:   200,
+			CommandBuffer: 50,
+		},
+		Chaos: ChaosConfig{
+			Latency:       Duration{time.Millisecond * 5},
+			LatencyRate:   0.1,
+			DropRate:      0.05,
+			KillInterval:  Duration{time.Second * 2},
+			StallInterval: Duration{time.Second * 3},
+			Stall:         Duration{time.Millisecond * 500},
+		},
+		Ecology: EcologyConfig{
+			GrowthRate:       0.4,
+			Capacity:         15,
+			LanternCapacity:  10,
+			Predators:        true,
+			PredatorInterval: Duration{time.Second * 10},
+			PredationRate:    0.06,
+		},
+		Storm: StormConfig{
+			Interval: Duration{time.Second * 90},
+			Warning:  Duration{time.Second * 5},
+			Speed:    220,
+			Width:    180,
+			Force:    3,
+			Dim:      0.35,
+		},
+		Tracing: TracingConfig{
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			SampleRatio: 1,
+		},
+		Colors: ColorsConfig{
+			Background:  [4]uint8{10, 15, 35, 255},
+			FireflyDim:  [4]uint8{180, 255, 100, 100},
+			FireflyFull: [4]uint8{255, 255, 150, 255},
+			Lantern:     [4]uint8{255, 200, 100, 200},
+			Wind:        [4]uint8{150, 150, 255, 80},
+			UIText:      [4]uint8{255, 255, 255, 255},
+		},
+	}
+}
+
+// Web retorna Default ajustada al navegador (GOOS=js): la simulación y el
+// render comparten un solo hilo, así que arranca con menos luciérnagas y
+// con sprites en lote en lugar de bloom
+func Web() *Config {
+	cfg := Default()
+	cfg.Fireflies.Max = 60
+	cfg.Fireflies.Initial = 10
+	cfg.Spawn.Objective = 30
+	cfg.Render.Quality = QualitySprites
+	cfg.Render.AutoQualityMinSpawnCap = 25
+	return cfg
+}
+
+// Mobile retorna Default ajustada a celulares y tablets: hay varios núcleos
+// para la simulación pero la GPU y la batería piden sprites en lote y una
+// población más chica que en escritorio
+func Mobile() *Config {
+	cfg := Default()
+	cfg.Fireflies.Max = 80
+	cfg.Spawn.Objective = 40
+	cfg.Render.Quality = QualitySprites
+	cfg.Render.AutoQualityMinSpawnCap = 30
+	return cfg
+}
+
+// Load lee un archivo JSON sobre los valores por defecto; los campos
+// ausentes conservan su valor por defecto. Con path vacío retorna Default().
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Save escribe la configuración como JSON indentado
+func (c *Config) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Clone retorna una copia independiente
+func (c *Config) Clone() *Config {
+	clone := *c
+	return &clone
+}
+
+// Validate rechaza valores que romperían la simulación
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.TargetFPS > 0, "target_fps debe ser positivo")
+	check(c.SimulationTPS > 0, "simulation_tps debe ser positivo")
+	check(c.BackgroundTPS >= 0, "background_tps no puede ser negativo")
+	var logLevel slog.Level
+	check(logLevel.UnmarshalText([]byte(c.LogLevel)) == nil, "log_level debe ser debug, info, warn o error")
+	check(c.Fireflies.Max > 0, "fireflies.max debe ser positivo")
+	check(c.Fireflies.Initial >= 0 && c.Fireflies.Initial <= c.Fireflies.Max, "fireflies.initial debe estar entre 0 y fireflies.max")
+	check(c.Fireflies.SpawnInterval.Duration >= MinSpawnInterval, "fireflies.spawn_interval debe ser al menos %v", MinSpawnInterval)
+	check(c.Fireflies.BlinkCycleMin > 0 && c.Fireflies.BlinkCycleMin <= c.Fireflies.BlinkCycleMax, "fireflies.blink_cycle_min debe ser positivo y no mayor que blink_cycle_max")
+	check(c.Fireflies.FieldForce >= 0, "fireflies.field_force no puede ser negativo")
+	check(c.Fireflies.LifespanMin > 0 && c.Fireflies.LifespanMin <= c.Fireflies.LifespanMax, "fireflies.lifespan_min debe ser positivo y no mayor que lifespan_max")
+	check(c.Spawn.Objective >= 0 && c.Spawn.Objective <= c.Fireflies.Max, "spawn.objective debe estar entre 0 y fireflies.max")
+	check(slices.Contains(SpawnPolicies, c.Spawn.Policy), "spawn.policy debe ser uno de %s", strings.Join(SpawnPolicies, ", "))
+	check(c.Spawn.BurstCount > 0, "spawn.burst_count debe ser positivo")
+	check(c.Spawn.BurstStagger.Duration >= 0, "spawn.burst_stagger no puede ser negativo")
+	for i, z := range c.Spawn.Zones {
+		check(z.R > 0 && z.W == 0 && z.H == 0 || z.R == 0 && z.W > 0 && z.H > 0, "spawn.zones %d: necesita r (círculo) o w y h (rectángulo)", i+1)
+		check(z.Weight >= 0, "spawn.zones %d: weight no puede ser negativo", i+1)
+		check(z.Rate >= 0 && z.Rate <= MaxSpawnZoneRate, "spawn.zones %d: rate debe estar entre 0 y %g", i+1, MaxSpawnZoneRate)
+	}
+	check(c.Lanterns.Max >= 0, "lanterns.max no puede ser negativo")
+	check(c.Lanterns.Radius >= LanternRadiusMin && c.Lanterns.Radius <= LanternRadiusMax, "lanterns.radius debe estar entre %.0f y %.0f", LanternRadiusMin, LanternRadiusMax)
+	check(c.Wind.ChangeInterval.Duration > 0, "wind.change_interval debe ser positivo")
+	check(c.Wind.Force >= 0 && c.Wind.Force <= c.Wind.MaxStrength, "wind.force debe estar entre 0 y wind.max_strength")
+	check(c.Bats.Speed > 0, "bats.speed debe ser positivo")
+	check(c.Bats.EatRadius > 0, "bats.eat_radius debe ser positivo")
+	check(c.Bats.Stay.Duration > 0, "bats.stay debe ser positivo")
+	check(c.Bats.Digest.Duration >= 0, "bats.digest no puede ser negativo")
+	check(c.Camera.ZoomMin > 0 && c.Camera.ZoomMin <= c.Camera.ZoomMax, "camera.zoom_min debe ser positivo y no mayor que zoom_max")
+	check(c.Heatmap.CellSize > 0, "heatmap.cell_size debe ser positivo")
+	check(c.Heatmap.SampleInterval.Duration > 0, "heatmap.sample_interval debe ser positivo")
+	check(c.Heatmap.HalfLife > 0, "heatmap.half_life debe ser positivo")
+	check(c.Clusters.Interval.Duration > 0, "clusters.interval debe ser positivo")
+	check(c.Clusters.Radius > 0, "clusters.radius debe ser positivo")
+	check(c.Clusters.MinPoints >= 2, "clusters.min_points debe ser al menos 2")
+	check(c.Capture.ExposureDuration.Duration > 0, "capture.exposure_duration debe ser positivo")
+	check(c.Render.Quality >= QualityCircles && c.Render.Quality <= QualityBloom, "render.quality debe estar entre %d y %d", QualityCircles, QualityBloom)
+	check(c.Render.BloomPasses > 0, "render.bloom_passes debe ser positivo")
+	check(c.Render.AutoQualityMinSpawnCap > 0, "render.auto_quality_min_spawn_cap debe ser positivo")
+	check(c.Sound.Volume >= 0 && c.Sound.Volume <= 1, "sound.volume debe estar entre 0 y 1")
+	check(c.Sound.MaxVoices > 0, "sound.max_voices debe ser positivo")
+	check(c.Sound.Spacing.Duration >= 0, "sound.spacing no puede ser negativo")
+	check(c.Sound.MusicRate > 0, "sound.music_rate debe ser positivo")
+	check(c.Sound.MusicVoices > 0, "sound.music_voices debe ser positivo")
+	check(c.Demo.IdleAfter.Duration >= 0, "demo.idle_after no puede ser negativo")
+	check(c.Demo.Step.Duration > 0, "demo.step debe ser positivo")
+	check(c.Channels.StateBuffer > 0, "channels.state_buffer debe ser positivo")
+	check(c.Channels.CommandBuffer > 0, "channels.command_buffer debe ser positivo")
+	check(c.Batch.Shards >= 0 && c.Batch.Shards <= MaxBatchShards, "batch.shards debe estar entre 0 y %d", MaxBatchShards)
+	check(c.Chaos.Latency.Duration >= 0 && c.Chaos.Latency.Duration <= time.Second, "chaos.latency debe estar entre 0 y 1s")
+	check(c.Chaos.LatencyRate >= 0 && c.Chaos.LatencyRate <= 1, "chaos.latency_rate debe estar entre 0 y 1")
+	check(c.Chaos.DropRate >= 0 && c.Chaos.DropRate <= 1, "chaos.drop_rate debe estar entre 0 y 1")
+	check(c.Chaos.KillInterval.Duration >= 0, "chaos.kill_interval no puede ser negativo")
+	check(c.Chaos.StallInterval.Duration >= 0, "chaos.stall_interval no puede ser negativo")
+	check(c.Chaos.Stall.Duration >= 0, "chaos.stall no puede ser negativo")
+	check(c.Ecology.GrowthRate > 0, "ecology.growth_rate debe ser positivo")
+	check(c.Ecology.Capacity >= 0, "ecology.capacity no puede ser negativo")
+	check(c.Ecology.LanternCapacity >= 0, "ecology.lantern_capacity no puede ser negativo")
+	check(c.Ecology.PredatorInterval.Duration > 0, "ecology.predator_interval debe ser positivo")
+	check(c.Ecology.PredationRate >= 0 && c.Ecology.PredationRate <= 1, "ecology.predation_rate debe estar entre 0 y 1")
+	check(c.Storm.Warning.Duration >= 0 && c.Storm.Interval.Duration > c.Storm.Warning.Duration, "storm.interval debe ser mayor que storm.warning")
+	check(c.Storm.Speed > 0, "storm.speed debe ser positivo")
+	check(c.Storm.Width > 0, "storm.width debe ser positivo")
+	check(c.Storm.Force >= 0, "storm.force no puede ser negativo")
+	check(c.Storm.Dim >= 0 && c.Storm.Dim <= 1, "storm.dim debe estar entre 0 y 1")
+	check(!c.Tracing.Enabled || c.Tracing.Endpoint != "", "tracing.endpoint no puede estar vacío con tracing.enabled")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio debe estar entre 0 y 1")
+
+	return errors.Join(errs...)
+}
+
+var current atomic.Pointer[Config]
+
+func init() {
+	current.Store(Default())
+}
+
+// Get retorna la configuración activa; no debe modificarse
+func Get() *Config {
+	return current.Load()
+}
+
+// Set reemplaza la configuración activa para todos los subsistemas
+func Set(c *Config) {
+	current.Store(c)
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"flag"
+	"strings"
+	"time"
+)
+
+// Flags registra -config y los overrides más usados en un FlagSet.
+// Solo los flags indicados explícitamente sobreescriben el archivo.
+type Flags struct {
+	fs        *flag.FlagSet
+	path      *string
+	overrides map[string]func(*Config)
+}
+
+// BindFlags registra los flags de configuración; llamar antes de fs.Parse
+func BindFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{
+		fs:        fs,
+		path:      fs.String("config", "", "archivo de configuración JSON (opcional)"),
+		overrides: make(map[string]func(*Config)),
+	}
+	d := Default()
+
+	f.intVar("max-fireflies", d.Fireflies.Max, "población máxima de luciérnagas", func(c *Config, v int) { c.Fireflies.Max = v })
+	f.intVar("initial-fireflies", d.Fireflies.Initial, "luciérnagas al iniciar", func(c *Config, v int) { c.Fireflies.Initial = v })
+	f.intVar("objective", d.Spawn.Objective, "población objetivo del auto-spawn", func(c *Config, v int) { c.Spawn.Objective = v })
+	f.intVar("max-lanterns", d.Lanterns.Max, "cantidad máxima de faroles", func(c *Config, v int) { c.Lanterns.Max = v })
+	f.intVar("tps", d.SimulationTPS, "ticks de simulación por segundo", func(c *Config, v int) { c.SimulationTPS = v })
+	f.intVar("fps", d.TargetFPS, "frames por segundo objetivo de la UI", func(c *Config, v int) { c.TargetFPS = v })
+	f.intVar("quality", d.Render.Quality, "calidad de render (0=círculos, 1=sprites, 2=bloom)", func(c *Config, v int) { c.Render.Quality = v })
+	f.floatVar("lantern-radius", d.Lanterns.Radius, "radio de atracción de los faroles", func(c *Config, v float64) { c.Lanterns.Radius = v })
+	f.floatVar("wind-force", d.Wind.Force, "fuerza del viento", func(c *Config, v float64) { c.Wind.Force = v })
+	f.durationVar("spawn-interval", d.Fireflies.SpawnInterval.Duration, "intervalo de aparición de luciérnagas", func(c *Config, v time.Duration) { c.Fireflies.SpawnInterval.Duration = v })
+	f.stringVar("spawn-policy", d.Spawn.Policy, "política del spawner automático ("+strings.Join(SpawnPolicies, ", ")+")", func(c *Config, v string) { c.Spawn.Policy = v })
+	f.boolVar("auto-spawn", d.Spawn.AutoSpawn, "generar luciérnagas automáticamente", func(c *Config, v bool) { c.Spawn.AutoSpawn = v })
+	f.boolVar("auto-quality", d.Render.AutoQuality, "ajustar la calidad según los FPS", func(c *Config, v bool) { c.Render.AutoQuality = v })
+	f.boolVar("ecology", d.Ecology.Enabled, "modo ecológico: crecimiento logístico según los faroles y murciélagos según la población", func(c *Config, v bool) { c.Ecology.Enabled = v })
+	f.boolVar("storms", d.Storm.Enabled, "tormentas periódicas: un frente de viento cruza el jardín y apaga los faroles", func(c *Config, v bool) { c.Storm.Enabled = v })
+	f.boolVar("tracing", d.Tracing.Enabled, "exportar spans de OpenTelemetry del recorrido de cada comando", func(c *Config, v bool) { c.Tracing.Enabled = v })
+	f.stringVar("otlp-endpoint", d.Tracing.Endpoint, "dirección OTLP/gRPC del colector de trazas", func(c *Config, v string) { c.Tracing.Endpoint = v })
+	f.boolVar("batch", d.Batch.Enabled, "simulación por lotes: unas pocas goroutines avanzan a todas las luciérnagas", func(c *Config, v bool) { c.Batch.Enabled = v })
+	f.boolVar("chaos", d.Chaos.Enabled, "modo caos: demora y descarta estados, tira goroutines de luciérnagas y traba workers", func(c *Config, v bool) { c.Chaos.Enabled = v })
+
+	return f
+}
+
+// Path retorna el archivo indicado con -config (vacío si no se indicó)
+func (f *Flags) Path() string {
+	return *f.path
+}
+
+// Load carga el archivo de -config, aplica los flags explícitos y valida
+func (f *Flags) Load() (*Config, error) {
+	cfg, err := Load(f.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	f.Apply(cfg)
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Apply sobreescribe cfg con los flags que se indicaron en la línea de comandos
+func (f *Flags) Apply(cfg *Config) {
+	f.fs.Visit(func(fl *flag.Flag) {
+		if apply, ok := f.overrides[fl.Name]; ok {
+			apply(cfg)
+		}
+	})
+}
+
+func (f *Flags) intVar(name string, value int, usage string, set func(*Config, int)) {
+	p := f.fs.Int(name, value, usage)
+	f.overrides[name] = func(c *Config) { set(c, *p) }
+}
+
+func (f *Flags) floatVar(name string, value float64, usage string, set func(*Config, float64)) {
+	p := f.fs.Float64(name, value, usage)
+	f.overrides[name] = func(c *Config) { set(c, *p) }
+}
+
+func (f *Flags) durationVar(name string, value time.Duration, usage string, set func(*Config, time.Duration)) {
+	p := f.fs.Duration(name, value, usage)
+	f.overrides[name] = func(c *Config) { set(c, *p) }
+}
+
+func (f *Flags) stringVar(name string, value string, usage string, set func(*Config, string)) {
+	p := f.fs.String(name, value, usage)
+	f.overrides[name] = func(c *Config) { set(c, *p) }
+}
+
+func (f *Flags) boolVar(name string, value bool, usage string, set func(*Config, bool)) {
+	p := f.fs.Bool(name, value, usage)
+	f.overrides[name] = func(c *Config) { set(c, *p) }
+}
@@ -0,0 +1,69 @@
+package config
+
+import "sync/atomic"
+
+// Tamaño lógico mínimo y máximo. El render sigue a la ventana dentro de
+// estos límites; fuera de ellos Ebiten escala la imagen manteniendo la
+// proporción.
+const (
+	MinScreenWidth  = 640
+	MinScreenHeight = 480
+	MaxScreenWidth  = 2560
+	MaxScreenHeight = 1600
+
+	// Límites del mundo de un mapa (ver SetWorldSize)
+	MaxWorldWidth  = 4096
+	MaxWorldHeight = 4096
+)
+
+// screenSize es el tamaño lógico actual, con el ancho en los 32 bits altos.
+// Cero es ScreenWidth×ScreenHeight, el tamaño inicial de la ventana.
+var screenSize atomic.Uint64
+
+// worldSize es el tamaño fijo del mundo que pide un mapa, empaquetado como
+// screenSize. Cero es que el mundo sigue a la pantalla.
+var worldSize atomic.Uint64
+
+// ScreenSize retorna el tamaño lógico actual de la pantalla, que sin mapa
+// es también el del mundo: las luciérnagas nacen y dan la vuelta dentro de
+// él. Sin ventana (servidor, headless) es siempre ScreenWidth×ScreenHeight.
+func ScreenSize() (width, height int) {
+	packed := screenSize.Load()
+	if packed == 0 {
+		return ScreenWidth, ScreenHeight
+	}
+	return int(packed >> 32), int(packed & 0xffffffff)
+}
+
+// WorldPixels retorna el tamaño del mundo: el del mapa cargado o, sin
+// mapa, el de la pantalla
+func WorldPixels() (width, height int) {
+	packed := worldSize.Load()
+	if packed == 0 {
+		return ScreenSize()
+	}
+	return int(packed >> 32), int(packed & 0xffffffff)
+}
+
+// WorldSize es WorldPixels en float64, como lo usa la simulación
+func WorldSize() (width, height float64) {
+	w, h := WorldPixels()
+	return float64(w), float64(h)
+}
+
+// SetWorldSize fija el tamaño del mundo independiente de la ventana (lo
+// usan los mapas); 0×0 vuelve a seguir a la pantalla
+func SetWorldSize(width, height int) {
+	if width <= 0 || height <= 0 {
+		worldSize.Store(0)
+		return
+	}
+	worldSize.Store(uint64(width)<<32 | uint64(uint32(height)))
+}
+
+// SetScreenSize cambia el tamaño lógico; lo llama el render al redimensionar
+// la ventana. Retorna true si cambió.
+func SetScreenSize(width, height int) bool {
+	packed := uint64(width)<<32 | uint64(uint32(height))
+	return screenSize.Swap(packed) != packed
+}
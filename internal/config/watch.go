@@ -0,0 +1,204 @@
+package config
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Campos que solo se leen al arrancar; una recarga los conserva
+var restartFields = map[string]bool{
+	"target_fps":              true,
+	"simulation_tps":          true,
+	"heatmap.cell_size":       true,
+	"clusters.interval":       true,
+	"render.quality":          true,
+	"channels.state_buffer":   true,
+	"channels.command_buffer": true,
+	"batch.enabled":           true,
+	"batch.shards":            true,
+	"chaos.enabled":           true,
+	"chaos.kill_interval":     true,
+	"chaos.stall_interval":    true,
+	"ecology.enabled":         true,
+	"ecology.predators":       true,
+	"storm.enabled":           true,
+	"tracing.enabled":         true,
+	"tracing.endpoint":        true,
+	"tracing.insecure":        true,
+	"tracing.sample_ratio":    true,
+}
+
+// Source indica de dónde recargar la configuración: el archivo a vigilar
+// y la función que lo carga (incluyendo los overrides de flags)
+type Source struct {
+	Path string
+	Load func() (*Config, error)
+}
+
+// Source retorna el origen configurado por -config y los flags
+func (f *Flags) Source() Source {
+	return Source{Path: f.Path(), Load: f.Load}
+}
+
+var source atomic.Pointer[Source]
+
+// SetSource registra el archivo que los subsistemas pueden recargar en caliente
+func SetSource(s Source) {
+	source.Store(&s)
+}
+
+// GetSource retorna el origen registrado; ok es false si no hay archivo que vigilar
+func GetSource() (Source, bool) {
+	s := source.Load()
+	if s == nil || s.Path == "" {
+		return Source{}, false
+	}
+	return *s, true
+}
+
+// overlay es una capa puesta sobre la configuración del archivo, como la de
+// un preset: base es la de debajo y apply la arma encima
+type overlay struct {
+	base  *Config
+	apply func(*Config) (*Config, error)
+}
+
+var active atomic.Pointer[overlay]
+
+// SetOverlay publica apply aplicada sobre la configuración de debajo (la
+// actual si no había otra capa, o la de debajo de la anterior, que
+// reemplaza). Mientras esté puesta, Watch aplica cada archivo recargado
+// debajo de ella: la capa sigue puesta y lo recargado aparece al quitarla.
+func SetOverlay(apply func(*Config) (*Config, error)) error {
+	base := Get()
+	if o := active.Load(); o != nil {
+		base = o.base
+	}
+	cfg, err := apply(base)
+	if err != nil {
+		return err
+	}
+	active.Store(&overlay{base: base, apply: apply})
+	Set(cfg)
+	return nil
+}
+
+// ClearOverlay quita la capa y publica la configuración de debajo, con lo
+// que se haya recargado mientras estaba puesta; sin capa no hace nada
+func ClearOverlay() {
+	if o := active.Swap(nil); o != nil {
+		Set(o.base)
+	}
+}
+
+// Reload describe el resultado de aplicar un archivo modificado
+type Reload struct {
+	Config  *Config
+	Changed []string
+	Restart []string
+}
+
+// Watch revisa el archivo cada interval y llama onReload cuando su contenido
+// produce cambios; los errores de carga se reportan con onError y se ignoran.
+// Con una capa puesta (SetOverlay) el archivo va debajo de ella.
+func Watch(ctx context.Context, src Source, interval time.Duration, onReload func(Reload), onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastMod := modTime(src.Path)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			mod := modTime(src.Path)
+			if mod.Equal(lastMod) {
+				continue
+			}
+			lastMod = mod
+
+			next, err := src.Load()
+			if err != nil {
+				onError(err)
+				continue
+			}
+			if next, err = layer(next); err != nil {
+				onError(err)
+				continue
+			}
+
+			reload := Merge(Get(), next)
+			if len(reload.Changed) > 0 || len(reload.Restart) > 0 {
+				onReload(reload)
+			}
+		}
+	}
+}
+
+// layer pone la capa activa sobre la configuración recién cargada y la
+// guarda como la nueva de debajo, con los campos de reinicio de la anterior
+// para que quitar la capa no los cambie en caliente; sin capa retorna file
+// tal cual
+func layer(file *Config) (*Config, error) {
+	o := active.Load()
+	if o == nil {
+		return file, nil
+	}
+	cfg, err := o.apply(file)
+	if err != nil {
+		return nil, err
+	}
+	base := Merge(o.base, file).Config
+	// Si entretanto se quitó o cambió la capa, la recarga no la repone
+	if !active.CompareAndSwap(o, &overlay{base: base, apply: o.apply}) {
+		return file, nil
+	}
+	return cfg, nil
+}
+
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// Merge aplica next sobre current conservando los campos que requieren reinicio
+func Merge(current, next *Config) Reload {
+	merged := next.Clone()
+	reload := Reload{Config: merged}
+
+	var walk func(path string, cur, nxt, dst reflect.Value)
+	walk = func(path string, cur, nxt, dst reflect.Value) {
+		if cur.Kind() == reflect.Struct && cur.Type() != reflect.TypeOf(Duration{}) {
+			for i := 0; i < cur.NumField(); i++ {
+				name := strings.Split(cur.Type().Field(i).Tag.Get("json"), ",")[0]
+				if path != "" {
+					name = path + "." + name
+				}
+				walk(name, cur.Field(i), nxt.Field(i), dst.Field(i))
+			}
+			return
+		}
+
+		if reflect.DeepEqual(cur.Interface(), nxt.Interface()) {
+			return
+		}
+		if restartFields[path] {
+			reload.Restart = append(reload.Restart, path)
+			dst.Set(cur)
+			return
+		}
+		reload.Changed = append(reload.Changed, path)
+	}
+
+	walk("", reflect.ValueOf(current).Elem(), reflect.ValueOf(next).Elem(), reflect.ValueOf(merged).Elem())
+	return reload
+}
@@ -0,0 +1,44 @@
+package config
+
+// El jardín se divide en una grilla fija de zonas de 3×3 para las
+// estadísticas por región. Cada zona tiene un nombre de punto cardinal,
+// que es como la nombran las metas de los niveles.
+const (
+	ZoneCols  = 3
+	ZoneRows  = 3
+	ZoneCount = ZoneCols * ZoneRows
+)
+
+// zoneNames son los nombres de las zonas por fila, de arriba a abajo
+var zoneNames = [ZoneCount]string{
+	"no", "n", "ne",
+	"o", "c", "e",
+	"so", "s", "se",
+}
+
+// ZoneName retorna el nombre de la zona i ("ne", "c"...)
+func ZoneName(i int) string {
+	if i < 0 || i >= ZoneCount {
+		return ""
+	}
+	return zoneNames[i]
+}
+
+// ZoneByName busca una zona por su nombre
+func ZoneByName(name string) (int, bool) {
+	for i, n := range zoneNames {
+		if n == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// ZoneAt retorna la zona del punto (x, y) en el mundo actual; los puntos
+// fuera del mundo caen en la zona del borde más cercano
+func ZoneAt(x, y float64) int {
+	width, height := WorldSize()
+	col := min(max(int(x*ZoneCols/width), 0), ZoneCols-1)
+	row := min(max(int(y*ZoneRows/height), 0), ZoneRows-1)
+	return row*ZoneCols + col
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"math"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// batMargin es cuánto fuera de la pantalla aparecen y se van los murciélagos
+const batMargin = 40.0
+
+// BatState es la foto de un murciélago que se entrega al render
+type BatState struct {
+	ID       int
+	Position utils.Vector2D
+	Heading  float64 // dirección de vuelo en radianes
+	Wing     float64 // fase del aleteo, de 0 a 1
+}
+
+// Bat es un murciélago de una oleada: caza la luciérnaga más cercana
+// durante un tiempo y después sale volando del jardín. Solo lo modifica la
+// goroutine de murciélagos del manager.
+type Bat struct {
+	id       int
+	position utils.Vector2D
+	velocity utils.Vector2D
+	wing     float64
+	hunting  float64 // segundos de caza que le quedan
+	digest   float64 // pausa después de comer
+}
+
+// NewBat crea un murciélago en (x, y), normalmente fuera de la pantalla
+func NewBat(id int, x, y float64) *Bat {
+	return &Bat{
+		id:       id,
+		position: utils.Vector2D{X: x, Y: y},
+		wing:     utils.RandomFloat(0, 1),
+		hunting:  config.Get().Bats.Stay.Seconds(),
+	}
+}
+
+// RandomBatEntry retorna un punto al azar justo fuera de un borde
+func RandomBatEntry() utils.Vector2D {
+	w, h := config.WorldSize()
+	switch int(utils.RandomFloat(0, 4)) {
+	case 0:
+		return utils.Vector2D{X: utils.RandomFloat(0, w), Y: -batMargin}
+	case 1:
+		return utils.Vector2D{X: utils.RandomFloat(0, w), Y: h + batMargin}
+	case 2:
+		return utils.Vector2D{X: -batMargin, Y: utils.RandomFloat(0, h)}
+	default:
+		return utils.Vector2D{X: w + batMargin, Y: utils.RandomFloat(0, h)}
+	}
+}
+
+func (b *Bat) ID() int {
+	return b.id
+}
+
+func (b *Bat) Kind() EntityKind {
+	return KindBat
+}
+
+func (b *Bat) Position() utils.Vector2D {
+	return b.position
+}
+
+// Hungry indica si puede comer: sigue cazando y ya terminó la pausa
+func (b *Bat) Hungry() bool {
+	return b.hunting > 0 && b.digest <= 0
+}
+
+// Leaving indica si terminó de cazar y se está yendo
+func (b *Bat) Leaving() bool {
+	return b.hunting <= 0
+}
+
+// Fly mueve el murciélago hacia prey (nil si no hay presa a la vista) o,
+// terminada la caza, alejándose del centro hasta salir del jardín
+func (b *Bat) Fly(prey *utils.Vector2D, dt float64) {
+	b.hunting -= dt
+	b.digest -= dt
+	b.wing = math.Mod(b.wing+dt*3, 1)
+
+	w, h := config.WorldSize()
+	center := utils.Vector2D{X: w / 2, Y: h / 2}
+	var desired utils.Vector2D
+	switch {
+	case b.Leaving():
+		desired = b.position.Sub(center).Normalize()
+	case prey != nil && b.digest <= 0:
+		desired = prey.Sub(b.position).Normalize()
+	default:
+		// Sin presa da vueltas sobre el jardín
+		desired = center.Sub(b.position).Normalize().Add(utils.RandomUnitVector().Mul(0.8)).Normalize()
+	}
+
+	// Giro suave: la velocidad se acerca a la deseada en ~1/3 de segundo
+	speed := config.Get().Bats.Speed
+	turn := math.Min(dt*3, 1)
+	b.velocity = b.velocity.Add(desired.Mul(speed).Sub(b.velocity).Mul(turn))
+	b.position = b.position.Add(b.velocity.Mul(dt))
+}
+
+// Eat empieza la pausa después de comer
+func (b *Bat) Eat() {
+	b.digest = config.Get().Bats.Digest.Seconds()
+}
+
+// Gone indica si ya se fue: terminó de cazar y salió de la pantalla
+func (b *Bat) Gone() bool {
+	if !b.Leaving() {
+		return false
+	}
+	p := b.position
+	w, h := config.WorldSize()
+	return p.X < -batMargin || p.Y < -batMargin ||
+		p.X > w+batMargin || p.Y > h+batMargin
+}
+
+// State copia lo que el render necesita
+func (b *Bat) State() BatState {
+	return BatState{
+		ID:       b.id,
+		Position: b.position,
+		Heading:  math.Atan2(b.velocity.Y, b.velocity.X),
+		Wing:     b.wing,
+	}
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+)
+
+// Contadores del modo caos: estados demorados y descartados a propósito.
+// Los descartados también cuentan en droppedStates, como cualquier otro.
+var (
+	chaosDelayed uint64
+	chaosDropped uint64
+)
+
+// GetChaosCounts retorna cuántos estados demoró y cuántos descartó el modo
+// caos desde el inicio
+func GetChaosCounts() (delayed, dropped uint64) {
+	return atomic.LoadUint64(&chaosDelayed), atomic.LoadUint64(&chaosDropped)
+}
+
+// chaosSend decide qué pasa con un estado antes de enviarlo: con el modo caos
+// activo puede demorar la goroutine o pedir que se descarte (retorna false).
+// El aviso de muerte nunca se descarta: dejaría un fantasma en el agregador.
+// Usa su propio generador para no alterar la secuencia del compartido.
+func chaosSend(isAlive bool) bool {
+	chaos := config.Get().Chaos
+	if !chaos.Enabled {
+		return true
+	}
+
+	if isAlive && chaos.DropRate > 0 && rand.Float64() < chaos.DropRate {
+		atomic.AddUint64(&chaosDropped, 1)
+		return false
+	}
+	if chaos.Latency.Duration > 0 && rand.Float64() < chaos.LatencyRate {
+		atomic.AddUint64(&chaosDelayed, 1)
+		time.Sleep(chaos.Latency.Duration)
+	}
+	return true
+}
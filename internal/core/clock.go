@@ -0,0 +1,140 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock es la fuente de tiempo de la simulación. Las luciérnagas, el viento,
+// el spawner y las goroutines del manager que influyen en el jardín
+// (murciélagos, muestreos, elección, puntaje) la reciben del manager en vez
+// de llamar a time directamente: en la partida es RealClock y en pruebas o
+// en headless puede ser un FakeClock que avanza solo cuando se le pide.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+	Sleep(d time.Duration)
+}
+
+// Ticker es lo que usa la simulación de time.Ticker
+type Ticker interface {
+	C() <-chan time.Time
+	Reset(d time.Duration)
+	Stop()
+}
+
+// RealClock es el reloj del sistema
+var RealClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                   { return time.Now() }
+func (realClock) Sleep(d time.Duration)            { time.Sleep(d) }
+func (realClock) NewTicker(d time.Duration) Ticker { return realTicker{time.NewTicker(d)} }
+
+type realTicker struct{ *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.Ticker.C }
+
+// FakeClock es un reloj que solo avanza con Advance. Como time.Ticker, cada
+// ticker tiene lugar para un tick: si su goroutine no leyó el anterior, los
+// que se juntan se pierden.
+type FakeClock struct {
+	mux      sync.Mutex
+	now      time.Time
+	tickers  map[*fakeTicker]struct{}
+	sleepers []fakeSleeper
+}
+
+type fakeSleeper struct {
+	until time.Time
+	wake  chan struct{}
+}
+
+// NewFakeClock crea un reloj detenido en start
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start, tickers: make(map[*fakeTicker]struct{})}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("core: intervalo no positivo para FakeClock.NewTicker")
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	t := &fakeTicker{clock: c, period: d, next: c.now.Add(d), ch: make(chan time.Time, 1)}
+	c.tickers[t] = struct{}{}
+	return t
+}
+
+// Sleep bloquea hasta que Advance pase d
+func (c *FakeClock) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	c.mux.Lock()
+	wake := make(chan struct{})
+	c.sleepers = append(c.sleepers, fakeSleeper{until: c.now.Add(d), wake: wake})
+	c.mux.Unlock()
+	<-wake
+}
+
+// Advance mueve el reloj d hacia adelante en el acto: dispara los tickers
+// vencidos y despierta los Sleep cumplidos
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.now = c.now.Add(d)
+	for t := range c.tickers {
+		for !t.next.After(c.now) {
+			select {
+			case t.ch <- t.next:
+			default:
+			}
+			t.next = t.next.Add(t.period)
+		}
+	}
+
+	pending := c.sleepers[:0]
+	for _, s := range c.sleepers {
+		if s.until.After(c.now) {
+			pending = append(pending, s)
+		} else {
+			close(s.wake)
+		}
+	}
+	c.sleepers = pending
+}
+
+type fakeTicker struct {
+	clock  *FakeClock
+	period time.Duration
+	next   time.Time
+	ch     chan time.Time
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	t.clock.mux.Lock()
+	defer t.clock.mux.Unlock()
+	t.period = d
+	t.next = t.clock.now.Add(d)
+	t.clock.tickers[t] = struct{}{}
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mux.Lock()
+	defer t.clock.mux.Unlock()
+	delete(t.clock.tickers, t)
+}
@@ -0,0 +1,62 @@
+package core
+
+import "github.com/yourusername/firefly-garden/pkg/utils"
+
+// ControlKind es el tipo de un mensaje de control para una luciérnaga
+type ControlKind int
+
+const (
+	// ControlAttraction cambia el punto de atracción global (Point nil lo quita)
+	ControlAttraction ControlKind = iota
+	// ControlLanterns reemplaza la lista de faroles que la atraen
+	ControlLanterns
+	// ControlOrder da una orden individual (Order nil la libera)
+	ControlOrder
+	// ControlKill termina la goroutine sin que cuente como muerte natural;
+	// responde por Reply con el último estado antes de salir
+	ControlKill
+	// ControlInspect responde por Reply con el estado actual
+	ControlInspect
+	// ControlGossip le pasa el rumor con su número de ronda (Round 0 se lo
+	// quita)
+	ControlGossip
+	// ControlElection es el mensaje de elección del algoritmo bully: la
+	// luciérnaga contesta por Reply que sigue viva
+	ControlElection
+	// ControlLeader le avisa si quedó como líder de su grupo (Leading)
+	ControlLeader
+	// ControlSync acerca su fase al pico del destello de su líder
+	ControlSync
+	// ControlStep avanza un tick de Dt en el modo sincrónico y responde por
+	// Reply con el estado que quedó
+	ControlStep
+	// ControlCrash termina la goroutine en el acto, sin publicar nada ni
+	// responder: simula una goroutine caída (modo caos)
+	ControlCrash
+)
+
+// Control es un mensaje del manager a la goroutine de una luciérnaga. Cada
+// una tiene su propio canal y lo atiende en el acto, entre ticks: así el
+// manager le habla a una sola luciérnaga o a todas sin compartir punteros con
+// su goroutine. Los valores se copian o no se modifican después de enviarse.
+type Control struct {
+	Kind     ControlKind
+	Point    *utils.Vector2D
+	Lanterns []*Lantern
+	Order    *Order
+	Round    int
+	Leading  bool
+	Dt       float64
+	// Reply recibe el snapshot de ControlKill, ControlInspect,
+	// ControlElection y ControlStep; debe tener buffer para que la
+	// luciérnaga nunca se bloquee al responder
+	Reply chan<- FireflySnapshot
+}
+
+// GossipNote es el aviso de una luciérnaga con el rumor que acaba de
+// destellar: el manager se lo contagia a las vecinas que estén cerca
+type GossipNote struct {
+	From     int
+	Position utils.Vector2D
+	Round    int
+}
@@ -0,0 +1,183 @@
+package core
+
+import "sync"
+
+type EntityKind int
+
+const (
+	KindFirefly EntityKind = iota
+	KindLantern
+	KindBat
+)
+
+// Entity es lo mínimo que toda entidad del mundo debe exponer para
+// registrarse, contarse y recorrerse de forma uniforme
+type Entity interface {
+	ID() int
+	Kind() EntityKind
+}
+
+// Updatable lo implementan las entidades que el manager anima por frame
+type Updatable interface {
+	Update(dt float64)
+}
+
+// World es el registro de entidades vivas, indexado por ID y ordenado por tipo
+type World struct {
+	mux      sync.RWMutex
+	nextID   int
+	entities map[int]Entity
+	order    map[EntityKind][]int
+}
+
+func NewWorld() *World {
+	return &World{
+		nextID:   1,
+		entities: make(map[int]Entity),
+		order:    make(map[EntityKind][]int),
+	}
+}
+
+// PeekID retorna el identificador que recibirá la próxima entidad, sin
+// reservarlo
+func (w *World) PeekID() int {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	return w.nextID
+}
+
+// NextID reserva un identificador único para una nueva entidad
+func (w *World) NextID() int {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	id := w.nextID
+	w.nextID++
+	return id
+}
+
+func (w *World) Add(e Entity) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	w.add(e)
+}
+
+// AddLimited agrega la entidad solo si hay menos de limit entidades de su tipo
+func (w *World) AddLimited(e Entity, limit int) bool {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if len(w.order[e.Kind()]) >= limit {
+		return false
+	}
+	w.add(e)
+	return true
+}
+
+func (w *World) add(e Entity) {
+	if _, exists := w.entities[e.ID()]; exists {
+		return
+	}
+	w.entities[e.ID()] = e
+	w.order[e.Kind()] = append(w.order[e.Kind()], e.ID())
+
+	// Entidades restauradas traen su propio ID
+	if e.ID() >= w.nextID {
+		w.nextID = e.ID() + 1
+	}
+}
+
+func (w *World) Remove(id int) (Entity, bool) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	e, ok := w.entities[id]
+	if !ok {
+		return nil, false
+	}
+	delete(w.entities, id)
+
+	ids := w.order[e.Kind()]
+	for i, other := range ids {
+		if other == id {
+			w.order[e.Kind()] = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+
+	return e, true
+}
+
+func (w *World) Get(id int) (Entity, bool) {
+	w.mux.RLock()
+	defer w.mux.RUnlock()
+
+	e, ok := w.entities[id]
+	return e, ok
+}
+
+// Clear elimina todas las entidades; los IDs siguen creciendo
+func (w *World) Clear() {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	w.entities = make(map[int]Entity)
+	w.order = make(map[EntityKind][]int)
+}
+
+// Last retorna la entidad más reciente del tipo indicado
+func (w *World) Last(kind EntityKind) (Entity, bool) {
+	w.mux.RLock()
+	defer w.mux.RUnlock()
+
+	ids := w.order[kind]
+	if len(ids) == 0 {
+		return nil, false
+	}
+	return w.entities[ids[len(ids)-1]], true
+}
+
+func (w *World) Count(kind EntityKind) int {
+	w.mux.RLock()
+	defer w.mux.RUnlock()
+
+	return len(w.order[kind])
+}
+
+// Each recorre las entidades de un tipo en orden de creación bajo RLock;
+// fn no debe modificar el mundo
+func (w *World) Each(kind EntityKind, fn func(Entity)) {
+	w.mux.RLock()
+	defer w.mux.RUnlock()
+
+	for _, id := range w.order[kind] {
+		fn(w.entities[id])
+	}
+}
+
+// Snapshot copia la lista de entidades de un tipo para recorrerla sin lock
+func (w *World) Snapshot(kind EntityKind) []Entity {
+	w.mux.RLock()
+	defer w.mux.RUnlock()
+
+	ids := w.order[kind]
+	snapshot := make([]Entity, 0, len(ids))
+	for _, id := range ids {
+		snapshot = append(snapshot, w.entities[id])
+	}
+	return snapshot
+}
+
+// UpdateAll llama Update en todas las entidades que lo implementan
+func (w *World) UpdateAll(dt float64) {
+	w.mux.RLock()
+	defer w.mux.RUnlock()
+
+	for _, e := range w.entities {
+		if u, ok := e.(Updatable); ok {
+			u.Update(dt)
+		}
+	}
+}
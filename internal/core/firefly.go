@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/plugin"
 	"github.com/yourusername/firefly-garden/pkg/utils"
 )
 
@@ -15,14 +16,44 @@ type FireflyState struct {
 	Position   utils.Vector2D
 	Brightness float64
 	IsAlive    bool
+	Timestamp  time.Time
+	// Gossip es la ronda en que le llegó el mensaje del modo rumor; 0 si
+	// no lo tiene
+	Gossip int
+	// Leader indica que es la líder elegida de su grupo
+	Leader bool
+	// Age es la fracción de su vida ya vivida, de 0 al nacer a 1 al morir
+	Age float64
+	// Forces son las fuerzas de su último tick; vacías salvo con el
+	// rastreo prendido (SetTraceForces)
+	Forces Forces
+	// Dropped son sus estados descartados desde que nació, contando los
+	// anteriores a éste
+	Dropped uint32
 }
 
 var droppedStates uint64
 
+// syncPull es qué fracción de la distancia a la fase de su líder recorre una
+// luciérnaga con cada aviso
+const syncPull = 0.5
+
+// Neighborhood da a los plugins de comportamiento las vecinas de una posición
+type Neighborhood interface {
+	Near(pos utils.Vector2D, self int, buf []plugin.Neighbor) []plugin.Neighbor
+}
+
 func GetDroppedStates() uint64 {
 	return atomic.LoadUint64(&droppedStates)
 }
 
+// Order es una orden individual para una luciérnaga seleccionada: quieta
+// (Frozen) o atraída a su propio punto (Target), que reemplaza al global
+type Order struct {
+	Frozen bool
+	Target *utils.Vector2D
+}
+
 type Firefly struct {
 	id              int
 	position        utils.Vector2D
@@ -32,79 +63,312 @@ type Firefly struct {
 	blinkCycleDur   float64
 	targetPosition  *utils.Vector2D
 	attractionPoint *utils.Vector2D
-	windForce       *utils.Vector2D
+	wind            *Wind
+	lanterns        []*Lantern
+	// field es el campo de fuerzas pintado del jardín (puede ser nil)
+	field *ForceField
+	// landscape es el terreno del mapa (puede ser nil)
+	landscape *Landscape
+
+	behaviors    []plugin.BehaviorPlugin
+	neighborhood Neighborhood
+	neighborBuf  []plugin.Neighbor
 
 	age      float64
 	lifespan float64
+
+	// order es la orden individual; como el punto de atracción y los
+	// faroles, solo la toca la goroutine (por su canal de control)
+	order *Order
+
+	// gossip es la ronda en que le llegó el rumor (0 si no lo tiene); con
+	// él, cada pico de su destello se avisa por gossipOut
+	gossip    int
+	gossipOut chan<- GossipNote
+
+	// leading indica que es la líder de su grupo: cada pico de su destello
+	// se avisa por leaderOut para que el grupo se sincronice con ella
+	leading   bool
+	leaderOut chan<- int
+
+	// clock marca el ritmo de los ticks y la hora de cada estado publicado
+	clock Clock
+
+	// forces son las fuerzas del último tick, para el overlay de fuerzas
+	forces Forces
+
+	// dropped son sus estados descartados, por la cola llena o el caos
+	dropped uint32
+	// drops, si no es nil, es el contador de su jardín (SetDropCounter)
+	drops *atomic.Uint64
 }
 
 func NewFirefly(id int, spawnX, spawnY float64) *Firefly {
+	cfg := config.Get().Fireflies
 	return &Firefly{
 		id:            id,
 		position:      utils.Vector2D{X: spawnX, Y: spawnY},
-		velocity:      utils.RandomUnitVector().Mul(config.FireflySpeed),
+		velocity:      utils.RandomUnitVector().Mul(cfg.Speed),
 		brightness:    0.0,
 		blinkPhase:    0.0,
-		blinkCycleDur: utils.RandomFloat(config.FireflyBlinkCycleMin, config.FireflyBlinkCycleMax),
+		blinkCycleDur: utils.RandomFloat(cfg.BlinkCycleMin, cfg.BlinkCycleMax),
 		age:           0.0,
-		lifespan:      utils.RandomFloat(config.FireflyLifespanMin, config.FireflyLifespanMax),
+		lifespan:      utils.RandomFloat(cfg.LifespanMin, cfg.LifespanMax),
+		clock:         RealClock,
 	}
 }
 
-func (f *Firefly) Run(ctx context.Context, stateCh chan<- FireflyState, lanterns []*Lantern, dt float64) {
-	ticker := time.NewTicker(time.Second / time.Duration(config.TargetFPS))
-	defer ticker.Stop()
+// Run mueve la luciérnaga cada tick y atiende su canal de control entre
+// ticks; dt es el paso de simulación de cada tick. Con tick 0 no hay ticker
+// (modo sincrónico): solo avanza con ControlStep. Retorna true si murió de
+// vieja; false si se canceló ctx, el manager la quitó con ControlKill o se
+// cayó con ControlCrash.
+func (f *Firefly) Run(ctx context.Context, stateCh chan<- FireflyState, control <-chan Control, tick time.Duration, dt float64) bool {
+	var ticks <-chan time.Time
+	if tick > 0 {
+		ticker := f.clock.NewTicker(tick)
+		defer ticker.Stop()
+		ticks = ticker.C()
+	}
 
 	for {
 		select {
 		case <-ctx.Done():
-			f.publishState(stateCh, false)
-			return
+			// Cancelación (Stop o quiesce): la luciérnaga sigue viva en el
+			// mundo, por eso no se publica su muerte. Lo que quedó en el
+			// canal se aplica igual, así nada se pierde al relanzarla.
+			f.Drain(control)
+			return false
+
+		case msg := <-control:
+			if msg.Kind == ControlCrash {
+				return false
+			}
+			if msg.Kind == ControlStep {
+				died := f.tick(stateCh, msg.Dt)
+				msg.Reply <- f.Snapshot()
+				if died {
+					return true
+				}
+				continue
+			}
+			if f.handle(msg) {
+				f.publishState(stateCh, false)
+				return false
+			}
 
-		case <-ticker.C:
-			f.update(lanterns, dt)
+		case <-ticks:
+			if f.tick(stateCh, dt) {
+				return true
+			}
+		}
+	}
+}
 
-			f.age += dt
-			if f.age > f.lifespan {
-				f.publishState(stateCh, false)
-				return
+// tick avanza un paso de dt y publica el estado; retorna true si murió de
+// vieja
+// Step avanza un tick de dt y publica el estado, igual que un tick de Run.
+// Es para quien mueve la luciérnaga sin darle una goroutine propia (el modo
+// por lotes de cmd/loadtest); no debe llamarse mientras Run está corriendo.
+// Retorna true si murió de vieja.
+func (f *Firefly) Step(stateCh chan<- FireflyState, dt float64) bool {
+	return f.tick(stateCh, dt)
+}
+
+// Advance avanza un tick de dt como Step pero agrega el estado a batch en
+// lugar de enviarlo: es el tick de la simulación por lotes, donde una
+// goroutine mueve muchas luciérnagas y publica el lote entero. Retorna true
+// si murió de vieja.
+func (f *Firefly) Advance(batch *[]FireflyState, dt float64) bool {
+	f.update(dt)
+
+	f.age += dt
+	alive := f.age <= f.lifespan
+	f.appendState(batch, alive)
+	return !alive
+}
+
+// Serve atiende sin esperar los mensajes pendientes de control, como Run
+// entre ticks; si el manager la quita (ControlKill) agrega su muerte a
+// batch. Retorna true si terminó: quitada o caída con ControlCrash.
+func (f *Firefly) Serve(batch *[]FireflyState, control <-chan Control) bool {
+	for {
+		select {
+		case msg := <-control:
+			if msg.Kind == ControlCrash {
+				return true
+			}
+			if f.handle(msg) {
+				f.appendState(batch, false)
+				return true
 			}
+		default:
+			return false
+		}
+	}
+}
 
-			f.publishState(stateCh, true)
+// Drain aplica lo que quedó en control al detenerla (Stop o quiesce), así
+// nada se pierde al relanzarla
+func (f *Firefly) Drain(control <-chan Control) {
+	for {
+		select {
+		case msg := <-control:
+			f.handle(msg)
+		default:
+			return
 		}
 	}
 }
 
+func (f *Firefly) tick(stateCh chan<- FireflyState, dt float64) bool {
+	f.update(dt)
+
+	f.age += dt
+	if f.age > f.lifespan {
+		f.publishState(stateCh, false)
+		return true
+	}
+
+	f.publishState(stateCh, true)
+	return false
+}
+
+// handle aplica un mensaje de control; retorna true si la luciérnaga debe
+// terminar
+func (f *Firefly) handle(msg Control) bool {
+	switch msg.Kind {
+	case ControlAttraction:
+		f.SetAttractionPoint(msg.Point)
+	case ControlLanterns:
+		f.SetLanterns(msg.Lanterns)
+	case ControlOrder:
+		f.order = msg.Order
+	case ControlGossip:
+		f.gossip = msg.Round
+	case ControlElection:
+		msg.Reply <- f.Snapshot()
+	case ControlLeader:
+		f.leading = msg.Leading
+	case ControlSync:
+		f.syncPhase()
+	case ControlKill:
+		msg.Reply <- f.Snapshot()
+		return true
+	case ControlInspect:
+		msg.Reply <- f.Snapshot()
+	}
+	return false
+}
+
 
 func (f *Firefly) publishState(stateCh chan<- FireflyState, isAlive bool) {
+	state, ok := f.state(isAlive)
+	if !ok {
+		return
+	}
+
+	select {
+	case stateCh <- state:
+	default:
+		f.drop()
+	}
+}
+
+// appendState agrega el estado al lote; en un lote no hay cola que se
+// llene, solo el caos lo descarta
+func (f *Firefly) appendState(batch *[]FireflyState, isAlive bool) {
+	if state, ok := f.state(isAlive); ok {
+		*batch = append(*batch, state)
+	}
+}
+
+// state arma el estado a publicar; false si el caos lo descartó (ya
+// contado)
+func (f *Firefly) state(isAlive bool) (FireflyState, bool) {
 	state := FireflyState{
 		ID:         f.id,
 		Position:   f.position,
 		Brightness: f.brightness,
 		IsAlive:    isAlive,
+		Timestamp:  f.clock.Now(),
+		Gossip:     f.gossip,
+		Leader:     f.leading,
+		Age:        utils.Clamp(f.age/f.lifespan, 0, 1),
+		Dropped:    f.dropped,
+	}
+	if traceForces.Load() {
+		state.Forces = f.forces
 	}
 
-	select {
-	case stateCh <- state:
-	default:
-		atomic.AddUint64(&droppedStates, 1)
+	if !chaosSend(isAlive) {
+		f.drop()
+		return state, false
+	}
+	return state, true
+}
+
+// drop cuenta un estado descartado en el total y en el suyo, que viaja en
+// el próximo estado que sí llegue
+func (f *Firefly) drop() {
+	atomic.AddUint64(&droppedStates, 1)
+	if f.drops != nil {
+		f.drops.Add(1)
 	}
+	f.dropped++
 }
 
-func (f *Firefly) update(lanterns []*Lantern, dt float64) {
+func (f *Firefly) update(dt float64) {
+	// El pico del destello está a un cuarto del ciclo
+	before := f.blinkPhase
 	f.updateBlinkPhase(dt)
+	if before < 0.25 && f.blinkPhase >= 0.25 {
+		f.spreadGossip()
+		f.beacon()
+	}
+
+	// Congelada sigue parpadeando y envejeciendo pero no se mueve
+	order := f.order
+	if order != nil && order.Frozen {
+		f.velocity = utils.Vector2D{}
+		f.forces = Forces{}
+		return
+	}
+
+	// Una sola foto del viento por tick: el frente de tormenta y la
+	// atenuación de los faroles salen de la misma
+	var wind WindSnapshot
+	if f.wind != nil {
+		wind = f.wind.Snapshot()
+	}
+	now := f.clock.Now()
 
 	f.applyWandering()
-	f.applyLanternAttraction(lanterns)
-	f.applyAttractionPoint()
-	f.applyWind()
+	lantern := f.applyLanternAttraction(f.lanterns, wind.LanternDim(now))
+	var attraction utils.Vector2D
+	if order != nil && order.Target != nil {
+		attraction = f.attractTo(*order.Target)
+	} else if f.attractionPoint != nil {
+		attraction = f.attractTo(*f.attractionPoint)
+	}
+	windForce := f.applyWind(wind, now).Add(f.applyWindZones())
+	field := f.applyForceField()
+	f.avoidObstacles()
+	f.applyBehaviors(dt)
 
 	f.position = f.position.Add(f.velocity.Mul(dt))
 
-	f.position = utils.WrapAround(f.position, config.ScreenWidth, config.ScreenHeight)
+	width, height := config.WorldSize()
+	f.position = utils.WrapAround(f.position, width, height)
+	f.resolveObstacles()
 
-	if f.velocity.Magnitude() > config.FireflySpeed*2 {
-		f.velocity = f.velocity.Normalize().Mul(config.FireflySpeed * 2)
+	maxSpeed := config.Get().Fireflies.Speed * 2
+	if f.velocity.Magnitude() > maxSpeed {
+		f.velocity = f.velocity.Normalize().Mul(maxSpeed)
+	}
+
+	if traceForces.Load() {
+		f.forces = Forces{Velocity: f.velocity, Wind: windForce, Lantern: lantern, Attraction: attraction, Field: field}
 	}
 }
 
@@ -112,12 +376,44 @@ func (f *Firefly) updateBlinkPhase(dt float64) {
 	f.blinkPhase += dt / f.blinkCycleDur
 	if f.blinkPhase > 1.0 {
 		f.blinkPhase = 0.0
-		f.blinkCycleDur = utils.RandomFloat(config.FireflyBlinkCycleMin, config.FireflyBlinkCycleMax)
+		cfg := config.Get().Fireflies
+		f.blinkCycleDur = utils.RandomFloat(cfg.BlinkCycleMin, cfg.BlinkCycleMax)
 	}
 
 	f.brightness = (math.Sin(f.blinkPhase*2*math.Pi) + 1) / 2
 }
 
+// spreadGossip avisa que destelló con el rumor; si el canal está lleno el
+// aviso se pierde y lo repite en el próximo destello
+func (f *Firefly) spreadGossip() {
+	if f.gossip == 0 || f.gossipOut == nil {
+		return
+	}
+	select {
+	case f.gossipOut <- GossipNote{From: f.id, Position: f.position, Round: f.gossip}:
+	default:
+	}
+}
+
+// beacon avisa que la líder destelló; como el rumor, si el canal está
+// lleno el aviso se pierde
+func (f *Firefly) beacon() {
+	if !f.leading || f.leaderOut == nil {
+		return
+	}
+	select {
+	case f.leaderOut <- f.id:
+	default:
+	}
+}
+
+// syncPhase acerca la fase al pico del destello (un cuarto del ciclo), que es
+// donde está la líder cuando avisa
+func (f *Firefly) syncPhase() {
+	diff := math.Mod(0.25-f.blinkPhase+1.5, 1) - 0.5
+	f.blinkPhase = math.Mod(f.blinkPhase+diff*syncPull+1, 1)
+}
+
 func (f *Firefly) applyWandering() {
 	if utils.RandomFloat(0, 1) < 0.05 { 
 		randomForce := utils.RandomUnitVector().Mul(0.2)
@@ -125,9 +421,12 @@ func (f *Firefly) applyWandering() {
 	}
 }
 
-func (f *Firefly) applyLanternAttraction(lanterns []*Lantern) {
+// applyLanternAttraction suma la atracción de los faroles cercanos y
+// retorna el total
+func (f *Firefly) applyLanternAttraction(lanterns []*Lantern, dim float64) utils.Vector2D {
+	var total utils.Vector2D
 	if lanterns == nil || len(lanterns) == 0 {
-		return
+		return total
 	}
 
 	for _, lantern := range lanterns {
@@ -137,44 +436,178 @@ func (f *Firefly) applyLanternAttraction(lanterns []*Lantern) {
 			direction := lantern.Position.Sub(f.position).Normalize()
 
 			strength := (lantern.Radius - distance) / lantern.Radius
-			force := direction.Mul(config.LanternInfluenceForce * strength)
+			force := direction.Mul(config.Get().Lanterns.InfluenceForce * strength * dim)
 
 			f.velocity = f.velocity.Add(force)
+			total = total.Add(force)
 		}
 	}
+	return total
 }
 
-func (f *Firefly) applyAttractionPoint() {
-	if f.attractionPoint == nil {
-		return
-	}
-
-	distance := utils.Distance(f.position, *f.attractionPoint)
+// attractTo empuja hacia point y retorna el empuje
+func (f *Firefly) attractTo(point utils.Vector2D) utils.Vector2D {
+	distance := utils.Distance(f.position, point)
 
 	if distance > 10 {
-		direction := f.attractionPoint.Sub(f.position).Normalize()
-		force := direction.Mul(config.FireflyAttractionForce)
+		direction := point.Sub(f.position).Normalize()
+		force := direction.Mul(config.Get().Fireflies.AttractionForce)
 		f.velocity = f.velocity.Add(force)
+		return force
 	}
+	return utils.Vector2D{}
 }
 
-func (f *Firefly) applyWind() {
-	if f.windForce == nil {
-		return
+// applyWind suma el viento y el frente de tormenta y retorna el total
+func (f *Firefly) applyWind(wind WindSnapshot, now time.Time) utils.Vector2D {
+	if f.wind == nil {
+		return utils.Vector2D{}
 	}
 
-	windEffect := f.windForce.Mul(config.FireflyWindResistance)
+	windEffect := wind.Force.Mul(config.Get().Fireflies.WindResistance)
 	f.velocity = f.velocity.Add(windEffect)
+
+	// El frente empuja sin resistencia: arrastra a las que alcanza
+	if wind.Storm != nil {
+		storm := wind.Storm.ForceAt(f.position, now)
+		f.velocity = f.velocity.Add(storm)
+		windEffect = windEffect.Add(storm)
+	}
+	return windEffect
+}
+
+func (f *Firefly) applyBehaviors(dt float64) {
+	if len(f.behaviors) == 0 {
+		return
+	}
+
+	if f.neighborhood != nil {
+		f.neighborBuf = f.neighborhood.Near(f.position, f.id, f.neighborBuf[:0])
+	}
+
+	state := plugin.State{
+		ID:         f.id,
+		Position:   f.position,
+		Velocity:   f.velocity,
+		Brightness: f.brightness,
+		Age:        f.age,
+		Lifespan:   f.lifespan,
+	}
+	for _, behavior := range f.behaviors {
+		f.velocity = f.velocity.Add(behavior.Apply(state, f.neighborBuf, dt))
+	}
+}
+
+// SetBehaviors conecta los plugins de comportamiento; debe llamarse antes de Run
+func (f *Firefly) SetBehaviors(behaviors []plugin.BehaviorPlugin, neighborhood Neighborhood) {
+	f.behaviors = behaviors
+	f.neighborhood = neighborhood
 }
 
+// SetAttractionPoint fija el punto de atracción global (nil lo quita) con
+// una copia propia. Solo antes de Run: con la goroutine corriendo se usa
+// ControlAttraction.
 func (f *Firefly) SetAttractionPoint(point *utils.Vector2D) {
-	f.attractionPoint = point
+	f.attractionPoint = nil
+	if point != nil {
+		p := *point
+		f.attractionPoint = &p
+	}
+}
+
+// SetLanterns fija los faroles que la atraen; la lista no debe modificarse
+// después. Solo antes de Run: con la goroutine corriendo se usa
+// ControlLanterns.
+func (f *Firefly) SetLanterns(lanterns []*Lantern) {
+	f.lanterns = lanterns
+}
+
+// SetGossipChannel conecta el canal por el que avisa sus destellos con el
+// rumor; debe llamarse antes de Run
+func (f *Firefly) SetGossipChannel(out chan<- GossipNote) {
+	f.gossipOut = out
+}
+
+// SetLeaderChannel conecta el canal por el que avisa sus destellos cuando es
+// líder; debe llamarse antes de Run
+func (f *Firefly) SetLeaderChannel(out chan<- int) {
+	f.leaderOut = out
+}
+
+// SetDropCounter suma sus descartes también a counter, el de su jardín,
+// además del total del proceso; debe llamarse antes de Run
+func (f *Firefly) SetDropCounter(counter *atomic.Uint64) {
+	f.drops = counter
+}
+
+// SetClock cambia el reloj de la luciérnaga; debe llamarse antes de Run
+func (f *Firefly) SetClock(clock Clock) {
+	f.clock = clock
+}
+
+// SetHeading orienta la velocidad inicial hacia dir, a la velocidad de
+// crucero; debe llamarse antes de Run
+func (f *Firefly) SetHeading(dir utils.Vector2D) {
+	if dir.Magnitude() == 0 {
+		return
+	}
+	f.velocity = dir.Normalize().Mul(config.Get().Fireflies.Speed)
 }
 
-func (f *Firefly) SetWindForce(wind *utils.Vector2D) {
-	f.windForce = wind
+// SetWind conecta la luciérnaga al viento; cada tick lee su foto vigente
+func (f *Firefly) SetWind(wind *Wind) {
+	f.wind = wind
+}
+
+// FireflySnapshot es el estado completo de una luciérnaga para guardarla y recrearla
+type FireflySnapshot struct {
+	ID         int            `json:"id"`
+	Position   utils.Vector2D `json:"position"`
+	Velocity   utils.Vector2D `json:"velocity"`
+	Brightness float64        `json:"brightness"`
+	BlinkPhase float64        `json:"blink_phase"`
+	BlinkCycle float64        `json:"blink_cycle"`
+	Age        float64        `json:"age"`
+	Lifespan   float64        `json:"lifespan"`
+}
+
+// Snapshot copia el estado interno; solo es seguro cuando su goroutine no corre
+func (f *Firefly) Snapshot() FireflySnapshot {
+	return FireflySnapshot{
+		ID:         f.id,
+		Position:   f.position,
+		Velocity:   f.velocity,
+		Brightness: f.brightness,
+		BlinkPhase: f.blinkPhase,
+		BlinkCycle: f.blinkCycleDur,
+		Age:        f.age,
+		Lifespan:   f.lifespan,
+	}
+}
+
+// RestoreFirefly recrea una luciérnaga a partir de un snapshot
+func RestoreFirefly(s FireflySnapshot) *Firefly {
+	return &Firefly{
+		id:            s.ID,
+		position:      s.Position,
+		velocity:      s.Velocity,
+		brightness:    s.Brightness,
+		blinkPhase:    s.BlinkPhase,
+		blinkCycleDur: s.BlinkCycle,
+		age:           s.Age,
+		lifespan:      s.Lifespan,
+		clock:         RealClock,
+	}
 }
 
 func (f *Firefly) GetID() int {
 	return f.id
 }
+
+func (f *Firefly) ID() int {
+	return f.id
+}
+
+func (f *Firefly) Kind() EntityKind {
+	return KindFirefly
+}
@@ -0,0 +1,153 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync/atomic"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// FieldCellSize es el lado en píxeles de las celdas del campo de fuerzas
+const FieldCellSize = 32
+
+// FieldGrid es un campo de fuerzas pintado: una intensidad por celda, de -1
+// (repele) a 1 (atrae). Las luciérnagas suben por el campo: las empuja el
+// gradiente, hacia donde la intensidad crece. Una vez publicado en un
+// ForceField no se modifica.
+type FieldGrid struct {
+	Cols  int       `json:"cols"`
+	Rows  int       `json:"rows"`
+	Cells []float64 `json:"cells"`
+}
+
+// Validate rechaza un campo leído de un archivo que no cierra
+func (g *FieldGrid) Validate() error {
+	if g.Cols <= 0 || g.Rows <= 0 || len(g.Cells) != g.Cols*g.Rows {
+		return fmt.Errorf("campo de fuerzas de %d×%d con %d celdas", g.Cols, g.Rows, len(g.Cells))
+	}
+	for _, v := range g.Cells {
+		if math.IsNaN(v) || v < -1 || v > 1 {
+			return fmt.Errorf("intensidad %v fuera de -1 a 1", v)
+		}
+	}
+	return nil
+}
+
+// At retorna la intensidad de una celda; fuera de la grilla es 0
+func (g *FieldGrid) At(col, row int) float64 {
+	if col < 0 || col >= g.Cols || row < 0 || row >= g.Rows {
+		return 0
+	}
+	return g.Cells[row*g.Cols+col]
+}
+
+// value interpola la intensidad en un punto del mundo entre los centros de
+// las cuatro celdas que lo rodean
+func (g *FieldGrid) value(x, y float64) float64 {
+	fx := x/FieldCellSize - 0.5
+	fy := y/FieldCellSize - 0.5
+	col, row := int(math.Floor(fx)), int(math.Floor(fy))
+	tx, ty := fx-float64(col), fy-float64(row)
+
+	top := utils.Lerp(g.At(col, row), g.At(col+1, row), tx)
+	bottom := utils.Lerp(g.At(col, row+1), g.At(col+1, row+1), tx)
+	return utils.Lerp(top, bottom, ty)
+}
+
+// Gradient retorna hacia dónde crece el campo en p y cuánto, en
+// intensidad por celda
+func (g *FieldGrid) Gradient(p utils.Vector2D) utils.Vector2D {
+	const h = FieldCellSize / 2
+	return utils.Vector2D{
+		X: g.value(p.X+h, p.Y) - g.value(p.X-h, p.Y),
+		Y: g.value(p.X, p.Y+h) - g.value(p.X, p.Y-h),
+	}
+}
+
+// ForceField es el campo de fuerzas que comparten todas las luciérnagas.
+// Cada pincelada publica una grilla nueva, así las goroutines leen sin
+// locks; Paint, Clear y Set los llama un solo hilo (el del juego).
+type ForceField struct {
+	grid atomic.Pointer[FieldGrid]
+}
+
+func NewForceField() *ForceField {
+	return &ForceField{}
+}
+
+// Grid retorna la grilla vigente; nil si no se pintó nada
+func (f *ForceField) Grid() *FieldGrid {
+	return f.grid.Load()
+}
+
+// Set reemplaza el campo, por ejemplo al cargar una partida; nil lo borra
+func (f *ForceField) Set(grid *FieldGrid) {
+	f.grid.Store(grid)
+}
+
+// Clear borra el campo
+func (f *ForceField) Clear() {
+	f.grid.Store(nil)
+}
+
+// Paint suma amount (positivo atrae, negativo repele) en un círculo de
+// radius píxeles alrededor de center, con más intensidad en el centro. La
+// grilla crece si el mundo es más grande que la anterior.
+func (f *ForceField) Paint(center utils.Vector2D, radius, amount float64) {
+	width, height := config.WorldSize()
+	cols := int(math.Ceil(width / FieldCellSize))
+	rows := int(math.Ceil(height / FieldCellSize))
+
+	grid := &FieldGrid{Cols: cols, Rows: rows, Cells: make([]float64, cols*rows)}
+	if old := f.grid.Load(); old != nil {
+		grid.Cols, grid.Rows = max(cols, old.Cols), max(rows, old.Rows)
+		grid.Cells = make([]float64, grid.Cols*grid.Rows)
+		for row := 0; row < old.Rows; row++ {
+			copy(grid.Cells[row*grid.Cols:], old.Cells[row*old.Cols:(row+1)*old.Cols])
+		}
+	}
+
+	minCol := max(int((center.X-radius)/FieldCellSize), 0)
+	maxCol := min(int((center.X+radius)/FieldCellSize), grid.Cols-1)
+	minRow := max(int((center.Y-radius)/FieldCellSize), 0)
+	maxRow := min(int((center.Y+radius)/FieldCellSize), grid.Rows-1)
+	for row := minRow; row <= maxRow; row++ {
+		for col := minCol; col <= maxCol; col++ {
+			cell := utils.Vector2D{X: (float64(col) + 0.5) * FieldCellSize, Y: (float64(row) + 0.5) * FieldCellSize}
+			d := utils.Distance(cell, center)
+			if d >= radius {
+				continue
+			}
+			i := row*grid.Cols + col
+			grid.Cells[i] = utils.Clamp(grid.Cells[i]+amount*(1-d/radius), -1, 1)
+		}
+	}
+
+	if !slices.ContainsFunc(grid.Cells, func(v float64) bool { return v != 0 }) {
+		grid = nil
+	}
+	f.grid.Store(grid)
+}
+
+// applyForceField suma el empuje del campo pintado y lo retorna
+func (f *Firefly) applyForceField() utils.Vector2D {
+	if f.field == nil {
+		return utils.Vector2D{}
+	}
+	grid := f.field.Grid()
+	if grid == nil {
+		return utils.Vector2D{}
+	}
+
+	force := grid.Gradient(f.position).Mul(config.Get().Fireflies.FieldForce)
+	f.velocity = f.velocity.Add(force)
+	return force
+}
+
+// SetForceField conecta la luciérnaga al campo de fuerzas del jardín
+func (f *Firefly) SetForceField(field *ForceField) {
+	f.field = field
+}
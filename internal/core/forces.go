@@ -0,0 +1,33 @@
+package core
+
+import (
+	"sync/atomic"
+
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// Forces es lo que movió a una luciérnaga en su último tick, para el
+// overlay de fuerzas: su velocidad y lo que le sumaron el viento (con el
+// frente de tormenta), los faroles, el punto de atracción o su orden y el
+// campo de fuerzas pintado.
+// FireflyState solo lo trae mientras el rastreo está prendido.
+type Forces struct {
+	Velocity   utils.Vector2D
+	Wind       utils.Vector2D
+	Lantern    utils.Vector2D
+	Attraction utils.Vector2D
+	Field      utils.Vector2D
+}
+
+var traceForces atomic.Bool
+
+// SetTraceForces prende o apaga el rastreo de fuerzas de todas las
+// luciérnagas
+func SetTraceForces(on bool) {
+	traceForces.Store(on)
+}
+
+// TracingForces indica si el rastreo de fuerzas está prendido
+func TracingForces() bool {
+	return traceForces.Load()
+}
@@ -1,33 +1,63 @@
 package core
 
 import (
+	"math"
+	"sync/atomic"
+
 	"github.com/yourusername/firefly-garden/internal/config"
 	"github.com/yourusername/firefly-garden/pkg/utils"
 )
 
+// Lantern es un farol. Position, Radius e Intensity se fijan antes de
+// agregarlo al mundo y después no cambian (moverlo lo reemplaza por otro),
+// así que las luciérnagas, los murciélagos y el render los leen sin lock. Lo
+// único que cambia es el pulso, que avanza Update desde el loop de frames
+// mientras el render lo lee: por eso es atómico.
 type Lantern struct {
+	id        int
 	Position  utils.Vector2D
 	Radius    float64
 	Intensity float64
-	PulsePhase float64
+	// pulse son los bits del float64 de la fase del pulso (0 a 1)
+	pulse atomic.Uint64
 }
 
-func NewLantern(x, y float64) *Lantern {
+func NewLantern(id int, x, y float64) *Lantern {
 	return &Lantern{
+		id:        id,
 		Position:  utils.Vector2D{X: x, Y: y},
-		Radius:    config.LanternRadius,
+		Radius:    config.Get().Lanterns.Radius,
 		Intensity: 1.0,
-		PulsePhase: 0.0,
 	}
 }
 
+func (l *Lantern) ID() int {
+	return l.id
+}
+
+func (l *Lantern) Kind() EntityKind {
+	return KindLantern
+}
+
+// Update avanza el pulso; hay un solo escritor (el loop de frames o Step)
 func (l *Lantern) Update(dt float64) {
-	l.PulsePhase += dt * 2.0
-	if l.PulsePhase > 1.0 {
-		l.PulsePhase = 0.0
+	phase := l.PulsePhase() + dt*2.0
+	if phase > 1.0 {
+		phase = 0.0
 	}
+	l.SetPulsePhase(phase)
+}
+
+// PulsePhase retorna la fase del pulso
+func (l *Lantern) PulsePhase() float64 {
+	return math.Float64frombits(l.pulse.Load())
+}
+
+// SetPulsePhase fija la fase del pulso (al restaurar o reproducir)
+func (l *Lantern) SetPulsePhase(phase float64) {
+	l.pulse.Store(math.Float64bits(phase))
 }
 
 func (l *Lantern) GetIntensity() float64 {
-	return 0.7 + 0.3*l.PulsePhase
-}
\ No newline at end of file
+	return 0.7 + 0.3*l.PulsePhase()
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// StormFront es el frente de una tormenta: una franja de Width píxeles que
+// cruza el mundo en Direction a Speed píxeles por segundo desde Start y
+// empuja hacia adelante a las luciérnagas que alcanza. Mientras cruza, los
+// faroles brillan Dim veces su intensidad. Es inmutable: viaja dentro del
+// WindSnapshot y cada lector calcula dónde está con su propio reloj.
+type StormFront struct {
+	Start     time.Time
+	Direction utils.Vector2D
+	// From y To son las proyecciones sobre Direction del borde por el que
+	// entra y del opuesto
+	From, To float64
+	Speed    float64
+	Width    float64
+	Force    float64
+	Dim      float64
+}
+
+// NewStormFront crea un frente que entra en start por el borde del mundo
+// de width x height opuesto a dir
+func NewStormFront(start time.Time, dir utils.Vector2D, width, height float64, cfg config.StormConfig) *StormFront {
+	dir = dir.Normalize()
+	return &StormFront{
+		Start:     start,
+		Direction: dir,
+		From:      math.Min(0, dir.X*width) + math.Min(0, dir.Y*height),
+		To:        math.Max(0, dir.X*width) + math.Max(0, dir.Y*height),
+		Speed:     cfg.Speed,
+		Width:     cfg.Width,
+		Force:     cfg.Force,
+		Dim:       cfg.Dim,
+	}
+}
+
+// Edge retorna la proyección del borde delantero del frente en now
+func (s *StormFront) Edge(now time.Time) float64 {
+	return s.From + s.Speed*now.Sub(s.Start).Seconds()
+}
+
+// Duration es lo que tarda el frente en cruzar el mundo entero
+func (s *StormFront) Duration() time.Duration {
+	return time.Duration((s.To - s.From + s.Width) / s.Speed * float64(time.Second))
+}
+
+// ForceAt retorna el empuje sobre una luciérnaga en pos: máximo en el borde
+// delantero y nulo al final de la franja
+func (s *StormFront) ForceAt(pos utils.Vector2D, now time.Time) utils.Vector2D {
+	behind := s.Edge(now) - (pos.X*s.Direction.X + pos.Y*s.Direction.Y)
+	if behind < 0 || behind > s.Width {
+		return utils.Vector2D{}
+	}
+	return s.Direction.Mul(s.Force * (1 - behind/s.Width))
+}
+
+// LanternDim retorna el factor de intensidad de los faroles en now: Dim
+// mientras el frente cruza el mundo y 1 antes y después
+func (s *StormFront) LanternDim(now time.Time) float64 {
+	edge := s.Edge(now)
+	if edge < s.From || edge-s.Width > s.To {
+		return 1
+	}
+	return s.Dim
+}
+
+// LanternDim retorna el factor de intensidad de los faroles según la
+// tormenta del snapshot; sin tormenta es 1
+func (s WindSnapshot) LanternDim(now time.Time) float64 {
+	if s.Storm == nil {
+		return 1
+	}
+	return s.Storm.LanternDim(now)
+}
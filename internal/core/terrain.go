@@ -0,0 +1,316 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync/atomic"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// obstacleMargin es la distancia desde la que un obstáculo empieza a
+	// desviar a las luciérnagas
+	obstacleMargin = 24.0
+	// obstacleRepel es el empuje máximo, justo en el borde
+	obstacleRepel = 3.0
+)
+
+// Area es una región de un mapa: si R > 0, el círculo de radio R con centro
+// en (X, Y); si no, el rectángulo con esquina superior izquierda en (X, Y)
+// y tamaño W×H
+type Area struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	W float64 `json:"w,omitempty"`
+	H float64 `json:"h,omitempty"`
+	R float64 `json:"r,omitempty"`
+}
+
+// Circle indica si el área es un círculo
+func (a Area) Circle() bool {
+	return a.R > 0
+}
+
+// Validate rechaza un área sin tamaño o con las dos formas a la vez
+func (a Area) Validate() error {
+	switch {
+	case a.R < 0 || a.W < 0 || a.H < 0:
+		return errors.New("tamaño negativo")
+	case a.Circle() && (a.W > 0 || a.H > 0):
+		return errors.New("lleva r (círculo) y w/h (rectángulo) a la vez")
+	case !a.Circle() && (a.W == 0 || a.H == 0):
+		return errors.New("necesita r o w y h")
+	}
+	return nil
+}
+
+// Center retorna el centro del área
+func (a Area) Center() utils.Vector2D {
+	if a.Circle() {
+		return utils.Vector2D{X: a.X, Y: a.Y}
+	}
+	return utils.Vector2D{X: a.X + a.W/2, Y: a.Y + a.H/2}
+}
+
+// Surface retorna la superficie en píxeles cuadrados
+func (a Area) Surface() float64 {
+	if a.Circle() {
+		return math.Pi * a.R * a.R
+	}
+	return a.W * a.H
+}
+
+// Contains indica si p está dentro del área
+func (a Area) Contains(p utils.Vector2D) bool {
+	if a.Circle() {
+		return utils.Distance(p, a.Center()) <= a.R
+	}
+	return p.X >= a.X && p.X <= a.X+a.W && p.Y >= a.Y && p.Y <= a.Y+a.H
+}
+
+// RandomPoint retorna un punto al azar dentro del área, repartido parejo
+func (a Area) RandomPoint() utils.Vector2D {
+	if a.Circle() {
+		r := a.R * math.Sqrt(utils.RandomFloat(0, 1))
+		return a.Center().Add(utils.RandomUnitVector().Mul(r))
+	}
+	return utils.RandomVector2D(a.X, a.X+a.W, a.Y, a.Y+a.H)
+}
+
+// edge retorna la distancia de p al borde (negativa adentro) y la normal
+// que apunta hacia afuera desde el punto del borde más cercano
+func (a Area) edge(p utils.Vector2D) (float64, utils.Vector2D) {
+	if a.Circle() {
+		d := p.Sub(a.Center())
+		dist := d.Magnitude()
+		if dist == 0 {
+			return -a.R, utils.Vector2D{X: 1}
+		}
+		return dist - a.R, d.Mul(1 / dist)
+	}
+
+	closest := utils.Vector2D{
+		X: utils.Clamp(p.X, a.X, a.X+a.W),
+		Y: utils.Clamp(p.Y, a.Y, a.Y+a.H),
+	}
+	if closest != p {
+		d := p.Sub(closest)
+		dist := d.Magnitude()
+		return dist, d.Mul(1 / dist)
+	}
+
+	// Adentro: sale por el lado más cercano
+	left, right := p.X-a.X, a.X+a.W-p.X
+	top, bottom := p.Y-a.Y, a.Y+a.H-p.Y
+	switch min(left, right, top, bottom) {
+	case left:
+		return -left, utils.Vector2D{X: -1}
+	case right:
+		return -right, utils.Vector2D{X: 1}
+	case top:
+		return -top, utils.Vector2D{Y: -1}
+	default:
+		return -bottom, utils.Vector2D{Y: 1}
+	}
+}
+
+// SpawnZone es un área donde nacen luciérnagas: Weight es la parte que le
+// toca de las que suelta el spawner (sin pesos en ninguna zona se reparten
+// por superficie), Rate cuántas suelta por segundo por su cuenta y, con
+// Inward, las que nacen ahí arrancan volando hacia el centro del mundo: un
+// arbusto en el borde del que salen y se adentran en el jardín
+type SpawnZone struct {
+	Area
+	Weight float64 `json:"weight,omitempty"`
+	Rate   float64 `json:"rate,omitempty"`
+	Inward bool    `json:"inward,omitempty"`
+}
+
+// SpawnZoneFromConfig convierte una zona de spawn.zones
+func SpawnZoneFromConfig(z config.SpawnZone) SpawnZone {
+	return SpawnZone{
+		Area:   Area{X: z.X, Y: z.Y, W: z.W, H: z.H, R: z.R},
+		Weight: z.Weight,
+		Rate:   z.Rate,
+		Inward: z.Inward,
+	}
+}
+
+// Validate rechaza una zona con un área inválida, un peso negativo o un
+// ritmo fuera de rango
+func (z SpawnZone) Validate() error {
+	if err := z.Area.Validate(); err != nil {
+		return err
+	}
+	if z.Weight < 0 {
+		return errors.New("weight negativo")
+	}
+	if z.Rate < 0 || z.Rate > config.MaxSpawnZoneRate {
+		return fmt.Errorf("rate debe estar entre 0 y %g", config.MaxSpawnZoneRate)
+	}
+	return nil
+}
+
+// WindZone es una región del mapa con un viento propio que se suma al del
+// jardín: una corriente por un pasillo, una brisa sobre el estanque
+type WindZone struct {
+	Area
+	// Direction es "north", "southeast"... como el viento del mapa
+	Direction string `json:"direction"`
+	// Strength es la fuerza; 0 usa wind.force
+	Strength float64 `json:"strength,omitempty"`
+}
+
+// Validate rechaza una zona con un área inválida o una dirección desconocida
+func (z WindZone) Validate() error {
+	if err := z.Area.Validate(); err != nil {
+		return err
+	}
+	if dir, ok := ParseWindDirection(z.Direction); !ok || dir == WindNone {
+		return fmt.Errorf("direction %q desconocida (north, northeast, east...)", z.Direction)
+	}
+	if z.Strength < 0 {
+		return errors.New("strength negativa")
+	}
+	return nil
+}
+
+// Force retorna el empuje de la zona, con la fuerza de la configuración si
+// no trae una
+func (z WindZone) Force() utils.Vector2D {
+	dir, _ := ParseWindDirection(z.Direction)
+	strength := z.Strength
+	if strength == 0 {
+		strength = config.Get().Wind.Force
+	}
+	angle := directionToAngle(dir)
+	return utils.Vector2D{X: math.Cos(angle) * strength, Y: math.Sin(angle) * strength}
+}
+
+// Terrain es lo fijo del jardín que trae un mapa: obstáculos que las
+// luciérnagas rodean, estanques sobre los que vuelan pero donde no nacen
+// ni se ponen faroles y zonas de viento. Una vez publicado en un Landscape
+// no se modifica.
+type Terrain struct {
+	Obstacles []Area     `json:"obstacles,omitempty"`
+	Ponds     []Area     `json:"ponds,omitempty"`
+	WindZones []WindZone `json:"wind_zones,omitempty"`
+}
+
+// Blocked indica si en p no puede nacer una luciérnaga ni ir un farol
+func (t *Terrain) Blocked(p utils.Vector2D) bool {
+	for _, a := range t.Obstacles {
+		if a.Contains(p) {
+			return true
+		}
+	}
+	for _, a := range t.Ponds {
+		if a.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Landscape es el terreno que comparten todas las luciérnagas; como el
+// campo de fuerzas, se reemplaza entero y se lee sin locks
+type Landscape struct {
+	terrain atomic.Pointer[Terrain]
+}
+
+func NewLandscape() *Landscape {
+	return &Landscape{}
+}
+
+// Terrain retorna el terreno vigente; nil si el jardín no tiene mapa
+func (l *Landscape) Terrain() *Terrain {
+	return l.terrain.Load()
+}
+
+// Set reemplaza el terreno; nil lo quita
+func (l *Landscape) Set(terrain *Terrain) {
+	l.terrain.Store(terrain)
+}
+
+// Blocked es Terrain.Blocked sin terreno cargado: nada está bloqueado
+func (l *Landscape) Blocked(p utils.Vector2D) bool {
+	t := l.terrain.Load()
+	return t != nil && t.Blocked(p)
+}
+
+// terrain retorna el terreno de la luciérnaga o nil
+func (f *Firefly) terrain() *Terrain {
+	if f.landscape == nil {
+		return nil
+	}
+	return f.landscape.Terrain()
+}
+
+// avoidObstacles desvía a la luciérnaga de los obstáculos cercanos, con más
+// fuerza cuanto más cerca del borde, y retorna el empuje
+func (f *Firefly) avoidObstacles() utils.Vector2D {
+	terrain := f.terrain()
+	if terrain == nil {
+		return utils.Vector2D{}
+	}
+
+	var force utils.Vector2D
+	for _, a := range terrain.Obstacles {
+		dist, normal := a.edge(f.position)
+		if dist >= obstacleMargin {
+			continue
+		}
+		force = force.Add(normal.Mul(obstacleRepel * (1 - max(dist, 0)/obstacleMargin)))
+	}
+	f.velocity = f.velocity.Add(force)
+	return force
+}
+
+// applyWindZones empuja a la luciérnaga con el viento de las zonas en que
+// está, con la misma resistencia que el viento del jardín, y retorna el
+// empuje
+func (f *Firefly) applyWindZones() utils.Vector2D {
+	terrain := f.terrain()
+	if terrain == nil {
+		return utils.Vector2D{}
+	}
+
+	var force utils.Vector2D
+	for _, z := range terrain.WindZones {
+		if z.Contains(f.position) {
+			force = force.Add(z.Force())
+		}
+	}
+	force = force.Mul(config.Get().Fireflies.WindResistance)
+	f.velocity = f.velocity.Add(force)
+	return force
+}
+
+// resolveObstacles saca a la luciérnaga de un obstáculo en el que entró de
+// todos modos (viento fuerte, atracción) y le quita la velocidad hacia
+// adentro, así se desliza por el borde
+func (f *Firefly) resolveObstacles() {
+	terrain := f.terrain()
+	if terrain == nil {
+		return
+	}
+
+	for _, a := range terrain.Obstacles {
+		dist, normal := a.edge(f.position)
+		if dist >= 0 {
+			continue
+		}
+		f.position = f.position.Add(normal.Mul(-dist))
+		if into := f.velocity.X*normal.X + f.velocity.Y*normal.Y; into < 0 {
+			f.velocity = f.velocity.Sub(normal.Mul(into))
+		}
+	}
+}
+
+// SetLandscape conecta la luciérnaga al terreno del mapa
+func (f *Firefly) SetLandscape(landscape *Landscape) {
+	f.landscape = landscape
+}
@@ -3,7 +3,9 @@ package core
 import (
 	"context"
 	"math"
-	"time"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/yourusername/firefly-garden/internal/config"
 	"github.com/yourusername/firefly-garden/pkg/utils"
@@ -23,22 +25,46 @@ const (
 	WindSouthWest
 )
 
+// WindSnapshot es una foto inmutable del viento. Se publica entera en cada
+// cambio, así quien la lee nunca ve una dirección con la fuerza de otra.
+type WindSnapshot struct {
+	Direction WindDirection
+	Force     utils.Vector2D
+	Strength  float64
+	// Storm es el frente de tormenta que está cruzando el jardín, o nil
+	Storm *StormFront
+}
+
+// DirectionName retorna el nombre de la dirección ("North", "SouthEast"...)
+func (s WindSnapshot) DirectionName() string {
+	return s.Direction.String()
+}
+
+// Wind lo modifican la goroutine de cambios automáticos y el manager (por
+// comandos); mux ordena a esos escritores. Los lectores (luciérnagas,
+// render, API) solo leen snap, que se reemplaza de forma atómica.
 type Wind struct {
+	mux       sync.Mutex
 	direction WindDirection
-	force     utils.Vector2D
 	strength  float64
+	storm     *StormFront
+	snap      atomic.Pointer[WindSnapshot]
+	onChange  func(WindDirection)
+	clock     Clock
 }
 
 func NewWind() *Wind {
-	return &Wind{
+	w := &Wind{
 		direction: WindEast,
-		strength:  config.WindForce,
-		force:     utils.Vector2D{X: config.WindForce, Y: 0},
+		strength:  config.Get().Wind.Force,
+		clock:     RealClock,
 	}
+	w.publish()
+	return w
 }
 
 func (w *Wind) Run(ctx context.Context) {
-	ticker := time.NewTicker(config.WindChangeInterval)
+	ticker := w.clock.NewTicker(config.Get().Wind.ChangeInterval.Duration)
 	defer ticker.Stop()
 
 	for {
@@ -46,49 +72,103 @@ func (w *Wind) Run(ctx context.Context) {
 		case <-ctx.Done():
 			return
 			
-		case <-ticker.C:
+		case <-ticker.C():
 			w.changeDirection()
 		}
 	}
 }
 
+// Turn cambia la dirección al azar, como cada tick de Run; el modo
+// sincrónico del manager lo llama con su propio tiempo simulado
+func (w *Wind) Turn() {
+	w.changeDirection()
+}
+
 func (w *Wind) changeDirection() {
 	directions := []WindDirection{
 		WindNorth, WindSouth, WindEast, WindWest,
 		WindNorthEast, WindNorthWest, WindSouthEast, WindSouthWest,
 	}
 	
+	w.mux.Lock()
 	w.direction = directions[int(utils.RandomFloat(0, float64(len(directions))))]
-	w.updateForce()
+	dir := w.publish()
+	w.mux.Unlock()
+
+	if w.onChange != nil {
+		w.onChange(dir)
+	}
+}
+
+// SetOnChange registra una función que se llama en cada cambio automático
+// de dirección. Debe llamarse antes de Run.
+func (w *Wind) SetOnChange(fn func(WindDirection)) {
+	w.onChange = fn
+}
+
+// SetClock cambia el reloj de los cambios automáticos; debe llamarse antes
+// de Run
+func (w *Wind) SetClock(clock Clock) {
+	w.clock = clock
 }
 
 func (w *Wind) SetDirection(dir WindDirection) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
 	w.direction = dir
-	w.updateForce()
+	w.publish()
 }
 
-func (w *Wind) GetDirection() WindDirection {
-	return w.direction
+func (w *Wind) SetStrength(strength float64) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.strength = strength
+	w.publish()
 }
 
-func (w *Wind) GetForce() utils.Vector2D {
-	return w.force
+// SetStorm publica el frente de tormenta que cruza el jardín; nil lo quita
+func (w *Wind) SetStorm(storm *StormFront) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.storm = storm
+	w.publish()
 }
 
-func (w *Wind) GetForcePointer() *utils.Vector2D {
-	return &w.force
+// Snapshot retorna el viento vigente; es seguro desde cualquier goroutine
+func (w *Wind) Snapshot() WindSnapshot {
+	return *w.snap.Load()
 }
 
-func (w *Wind) updateForce() {
-	angle := w.directionToAngle()
-	w.force = utils.Vector2D{
-		X: math.Cos(angle) * w.strength,
-		Y: math.Sin(angle) * w.strength,
-	}
+func (w *Wind) GetStrength() float64 {
+	return w.Snapshot().Strength
+}
+
+func (w *Wind) GetDirection() WindDirection {
+	return w.Snapshot().Direction
+}
+
+func (w *Wind) GetForce() utils.Vector2D {
+	return w.Snapshot().Force
+}
+
+// publish calcula la fuerza y publica una foto nueva; se llama con mux
+// tomado (o antes de compartir el viento) y retorna la dirección publicada
+func (w *Wind) publish() WindDirection {
+	angle := directionToAngle(w.direction)
+	w.snap.Store(&WindSnapshot{
+		Direction: w.direction,
+		Force: utils.Vector2D{
+			X: math.Cos(angle) * w.strength,
+			Y: math.Sin(angle) * w.strength,
+		},
+		Strength: w.strength,
+		Storm:    w.storm,
+	})
+	return w.direction
 }
 
-func (w *Wind) directionToAngle() float64 {
-	switch w.direction {
+func directionToAngle(dir WindDirection) float64 {
+	switch dir {
 	case WindNorth:
 		return -math.Pi / 2
 	case WindSouth:
@@ -111,7 +191,11 @@ func (w *Wind) directionToAngle() float64 {
 }
 
 func (w *Wind) GetDirectionName() string {
-	switch w.direction {
+	return w.Snapshot().DirectionName()
+}
+
+func (d WindDirection) String() string {
+	switch d {
 	case WindNone:
 		return "None"
 	case WindNorth:
@@ -135,12 +219,40 @@ func (w *Wind) GetDirectionName() string {
 	}
 }
 
-func (w *Wind) CycleDirection() {
+// ParseWindDirection acepta los nombres de GetDirectionName sin distinguir
+// mayúsculas ("north", "SouthEast"...)
+func ParseWindDirection(name string) (WindDirection, bool) {
+	for dir := WindNone; dir <= WindSouthWest; dir++ {
+		if strings.EqualFold(name, dir.String()) {
+			return dir, true
+		}
+	}
+	return WindNone, false
+}
+
+// WindFromVector retorna la dirección más cercana a un desplazamiento en
+// pantalla (Y crece hacia abajo)
+func WindFromVector(dx, dy float64) WindDirection {
+	// Ordenadas desde el este en sentido horario, como los ángulos en pantalla
+	octants := []WindDirection{
+		WindEast, WindSouthEast, WindSouth, WindSouthWest,
+		WindWest, WindNorthWest, WindNorth, WindNorthEast,
+	}
+	i := int(math.Round(math.Atan2(dy, dx)/(math.Pi/4))) + len(octants)
+	return octants[i%len(octants)]
+}
+
+// CycleDirection pasa a la dirección siguiente en sentido horario y la
+// retorna
+func (w *Wind) CycleDirection() WindDirection {
 	directions := []WindDirection{
 		WindNorth, WindNorthEast, WindEast, WindSouthEast,
 		WindSouth, WindSouthWest, WindWest, WindNorthWest,
 	}
-	
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
 	currentIndex := -1
 	for i, dir := range directions {
 		if dir == w.direction {
@@ -148,7 +260,7 @@ func (w *Wind) CycleDirection() {
 			break
 		}
 	}
-	
-	nextIndex := (currentIndex + 1) % len(directions)
-	w.SetDirection(directions[nextIndex])
-}
\ No newline at end of file
+
+	w.direction = directions[(currentIndex+1)%len(directions)]
+	return w.publish()
+}
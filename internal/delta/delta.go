@@ -0,0 +1,350 @@
+// Package delta codifica una secuencia de snapshots de luciérnagas
+// mandando solo lo que cambió desde el anterior. La posición viaja
+// cuantizada a int16 (medio píxel) y el brillo a un byte; cada tanto se
+// manda un cuadro clave completo para que un receptor nuevo, o uno que
+// perdió un cuadro, pueda engancharse.
+//
+// Formato de un cuadro (enteros sin signo en varint, int16 en little endian):
+//
+//	tipo (1 byte: 0 clave, 1 delta) · secuencia
+//	clave: cantidad · por luciérnaga: salto de ID, x, y, brillo
+//	delta: cantidad de bajas · saltos de ID de las bajas
+//	       cantidad de cambios · por luciérnaga: salto de ID, máscara
+//	       (1 x, 2 y, 4 brillo) y solo los campos marcados
+//
+// Los IDs van ordenados y se escriben como la diferencia con el anterior,
+// así un jardín de miles de luciérnagas usa uno o dos bytes por ID.
+package delta
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	frameKey   = 0
+	frameDelta = 1
+
+	fieldX          = 1 << 0
+	fieldY          = 1 << 1
+	fieldBrightness = 1 << 2
+
+	// positionScale son las unidades cuantizadas por píxel: medio píxel de
+	// resolución y ±16383 px de alcance
+	positionScale = 2
+)
+
+var (
+	// ErrNoKeyframe indica un delta recibido antes del primer cuadro clave
+	ErrNoKeyframe = errors.New("delta sin cuadro clave previo")
+	// ErrFrameGap indica que se perdió un cuadro: hay que esperar el
+	// próximo cuadro clave
+	ErrFrameGap = errors.New("se perdió un cuadro")
+	// ErrCorrupt indica un cuadro truncado o mal formado
+	ErrCorrupt = errors.New("cuadro delta corrupto")
+)
+
+// quantized es una luciérnaga tal como viaja
+type quantized struct {
+	x, y       int16
+	brightness uint8
+}
+
+func quantize(s core.FireflyState) quantized {
+	return quantized{
+		x:          quantizePosition(s.Position.X),
+		y:          quantizePosition(s.Position.Y),
+		brightness: uint8(math.Round(utils.Clamp(s.Brightness, 0, 1) * 255)),
+	}
+}
+
+func quantizePosition(v float64) int16 {
+	return int16(utils.Clamp(math.Round(v*positionScale), math.MinInt16, math.MaxInt16))
+}
+
+func (q quantized) state(id int) core.FireflyState {
+	return core.FireflyState{
+		ID:         id,
+		Position:   utils.Vector2D{X: float64(q.x) / positionScale, Y: float64(q.y) / positionScale},
+		Brightness: float64(q.brightness) / 255,
+		IsAlive:    true,
+	}
+}
+
+// Encoder arma los cuadros de un receptor. Recuerda lo último que le mandó,
+// así que cada receptor necesita el suyo. No es seguro para uso concurrente.
+type Encoder struct {
+	keyframeEvery int
+	seq           uint64
+	sinceKey      int
+	forceKey      bool
+	last          map[int]quantized
+	ids           []int
+}
+
+// NewEncoder crea un codificador que manda un cuadro clave cada
+// keyframeEvery cuadros (el primero siempre lo es)
+func NewEncoder(keyframeEvery int) *Encoder {
+	return &Encoder{
+		keyframeEvery: max(keyframeEvery, 1),
+		forceKey:      true,
+		last:          make(map[int]quantized),
+	}
+}
+
+// ForceKeyframe hace que el próximo cuadro sea clave, por ejemplo cuando el
+// receptor avisa que perdió uno
+func (e *Encoder) ForceKeyframe() {
+	e.forceKey = true
+}
+
+// Encode agrega a buf el cuadro que lleva al receptor de lo último enviado
+// a states. Las luciérnagas que no están en states se dan de baja.
+func (e *Encoder) Encode(buf []byte, states []core.FireflyState) []byte {
+	key := e.forceKey || e.sinceKey+1 >= e.keyframeEvery
+	e.seq++
+
+	current := make(map[int]quantized, len(states))
+	e.ids = e.ids[:0]
+	for _, s := range states {
+		if _, dup := current[s.ID]; !dup {
+			e.ids = append(e.ids, s.ID)
+		}
+		current[s.ID] = quantize(s)
+	}
+	slices.Sort(e.ids)
+
+	if key {
+		buf = append(buf, frameKey)
+		buf = binary.AppendUvarint(buf, e.seq)
+		buf = binary.AppendUvarint(buf, uint64(len(e.ids)))
+		prev := 0
+		for _, id := range e.ids {
+			q := current[id]
+			buf = binary.AppendUvarint(buf, uint64(id-prev))
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(q.x))
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(q.y))
+			buf = append(buf, q.brightness)
+			prev = id
+		}
+		e.sinceKey = 0
+		e.forceKey = false
+		e.last = current
+		return buf
+	}
+
+	buf = append(buf, frameDelta)
+	buf = binary.AppendUvarint(buf, e.seq)
+
+	var removed []int
+	for id := range e.last {
+		if _, ok := current[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	slices.Sort(removed)
+	buf = binary.AppendUvarint(buf, uint64(len(removed)))
+	prev := 0
+	for _, id := range removed {
+		buf = binary.AppendUvarint(buf, uint64(id-prev))
+		prev = id
+	}
+
+	// La cantidad de cambios se conoce al final: se reserva su lugar con
+	// un varint de ancho fijo
+	countAt := len(buf)
+	buf = append(buf, 0x80, 0x80, 0x80, 0x80, 0)
+	changed := 0
+	prev = 0
+	for _, id := range e.ids {
+		q := current[id]
+		old, seen := e.last[id]
+		var mask byte
+		if !seen || q.x != old.x {
+			mask |= fieldX
+		}
+		if !seen || q.y != old.y {
+			mask |= fieldY
+		}
+		if !seen || q.brightness != old.brightness {
+			mask |= fieldBrightness
+		}
+		if mask == 0 {
+			continue
+		}
+		changed++
+		buf = binary.AppendUvarint(buf, uint64(id-prev))
+		buf = append(buf, mask)
+		if mask&fieldX != 0 {
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(q.x))
+		}
+		if mask&fieldY != 0 {
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(q.y))
+		}
+		if mask&fieldBrightness != 0 {
+			buf = append(buf, q.brightness)
+		}
+		prev = id
+	}
+	putFixedUvarint(buf[countAt:countAt+5], uint64(changed))
+
+	e.sinceKey++
+	e.last = current
+	return buf
+}
+
+// putFixedUvarint escribe v como un varint de exactamente 5 bytes
+func putFixedUvarint(dst []byte, v uint64) {
+	for i := 0; i < 4; i++ {
+		dst[i] = byte(v&0x7f) | 0x80
+		v >>= 7
+	}
+	dst[4] = byte(v)
+}
+
+// Decoder reconstruye los snapshots a partir de los cuadros de un Encoder
+type Decoder struct {
+	seq     uint64
+	synced  bool
+	current map[int]quantized
+}
+
+// NewDecoder crea un decodificador que espera un cuadro clave
+func NewDecoder() *Decoder {
+	return &Decoder{current: make(map[int]quantized)}
+}
+
+// Decode aplica un cuadro y agrega a dst las luciérnagas resultantes,
+// ordenadas por ID. Después de ErrNoKeyframe o ErrFrameGap los deltas se
+// ignoran hasta el próximo cuadro clave.
+func (d *Decoder) Decode(dst []core.FireflyState, frame []byte) ([]core.FireflyState, error) {
+	r := reader{buf: frame}
+	kind := r.byte()
+	seq := r.uvarint()
+	if r.err != nil {
+		return dst, r.err
+	}
+
+	switch kind {
+	case frameKey:
+		current := make(map[int]quantized)
+		n := r.count()
+		id := 0
+		for i := 0; i < n && r.err == nil; i++ {
+			id += int(r.uvarint())
+			current[id] = quantized{x: r.int16(), y: r.int16(), brightness: r.byte()}
+		}
+		if r.err != nil {
+			return dst, r.err
+		}
+		d.current = current
+
+	case frameDelta:
+		if !d.synced {
+			return dst, ErrNoKeyframe
+		}
+		if seq != d.seq+1 {
+			d.synced = false
+			return dst, fmt.Errorf("%w: esperaba %d y llegó %d", ErrFrameGap, d.seq+1, seq)
+		}
+		n := r.count()
+		id := 0
+		for i := 0; i < n && r.err == nil; i++ {
+			id += int(r.uvarint())
+			delete(d.current, id)
+		}
+		n = r.count()
+		id = 0
+		for i := 0; i < n && r.err == nil; i++ {
+			id += int(r.uvarint())
+			mask := r.byte()
+			q := d.current[id]
+			if mask&fieldX != 0 {
+				q.x = r.int16()
+			}
+			if mask&fieldY != 0 {
+				q.y = r.int16()
+			}
+			if mask&fieldBrightness != 0 {
+				q.brightness = r.byte()
+			}
+			d.current[id] = q
+		}
+		if r.err != nil {
+			d.synced = false
+			return dst, r.err
+		}
+
+	default:
+		return dst, fmt.Errorf("%w: tipo %d", ErrCorrupt, kind)
+	}
+
+	d.seq = seq
+	d.synced = true
+
+	start := len(dst)
+	for id, q := range d.current {
+		dst = append(dst, q.state(id))
+	}
+	slices.SortFunc(dst[start:], func(a, b core.FireflyState) int { return a.ID - b.ID })
+	return dst, nil
+}
+
+// reader lee un cuadro y recuerda el primer error
+type reader struct {
+	buf []byte
+	err error
+}
+
+func (r *reader) fail() {
+	if r.err == nil {
+		r.err = ErrCorrupt
+	}
+	r.buf = nil
+}
+
+func (r *reader) byte() byte {
+	if len(r.buf) < 1 {
+		r.fail()
+		return 0
+	}
+	b := r.buf[0]
+	r.buf = r.buf[1:]
+	return b
+}
+
+func (r *reader) int16() int16 {
+	if len(r.buf) < 2 {
+		r.fail()
+		return 0
+	}
+	v := int16(binary.LittleEndian.Uint16(r.buf))
+	r.buf = r.buf[2:]
+	return v
+}
+
+func (r *reader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+// count lee una cantidad; una mayor que los bytes restantes es corrupta
+func (r *reader) count() int {
+	n := r.uvarint()
+	if n > uint64(len(r.buf)) {
+		r.fail()
+		return 0
+	}
+	return int(n)
+}
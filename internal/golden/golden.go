@@ -0,0 +1,249 @@
+// Package golden corre casos fijos del jardín en modo sincrónico y con una
+// semilla conocida, y guarda una huella del estado del mundo cada N ticks.
+// Comparar esas huellas con las del repositorio detecta cambios de
+// comportamiento no buscados al tocar la física o la capa concurrente.
+package golden
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/pkg/garden"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// precision es a cuántos decimales se redondean posiciones, brillos y
+// radios antes de sumarlos a la huella: así el formato de los flotantes no
+// cambia el hash, pero cualquier diferencia real sí
+const precision = 1e6
+
+// epoch es la hora fija del reloj simulado de cada caso
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ErrDropped se retorna si el agregador descartó estados durante un caso:
+// el mundo leído ya no es el que simularon las luciérnagas
+var ErrDropped = errors.New("golden: se descartaron estados durante el caso")
+
+// Case es un escenario fijo. Commands se encolan al empezar el tick indicado
+// (1 es el primero) y Step los aplica antes de avanzar.
+type Case struct {
+	Name     string
+	Seed     int64
+	Ticks    int
+	Every    int
+	Commands map[int][]garden.Command
+}
+
+// Checkpoint es la huella del mundo al terminar un tick
+type Checkpoint struct {
+	Tick       int
+	Population int
+	Hash       string
+}
+
+func (c Checkpoint) String() string {
+	return fmt.Sprintf("%d %d %s", c.Tick, c.Population, c.Hash)
+}
+
+// Cases son los escenarios que se comparan con los archivos del repositorio
+var Cases = []Case{
+	{
+		Name:  "idle",
+		Seed:  1,
+		Ticks: 300,
+		Every: 30,
+	},
+	{
+		Name:  "lanterns",
+		Seed:  2,
+		Ticks: 300,
+		Every: 30,
+		Commands: map[int][]garden.Command{
+			1: {
+				{Kind: garden.SetWind, Wind: "east"},
+				{Kind: garden.AddLantern, Position: utils.NewVector2D(200, 200)},
+				{Kind: garden.AddLantern, Position: utils.NewVector2D(600, 400)},
+			},
+			150: {{Kind: garden.RemoveLantern}},
+		},
+	},
+	{
+		Name:  "attraction",
+		Seed:  3,
+		Ticks: 300,
+		Every: 30,
+		Commands: map[int][]garden.Command{
+			1:   {{Kind: garden.SetAttraction, Position: utils.NewVector2D(400, 300)}},
+			60:  {{Kind: garden.SpawnBurst, Position: utils.NewVector2D(100, 100), Count: 10}},
+			120: {{Kind: garden.CycleWind}},
+			200: {{Kind: garden.ClearAttraction}, {Kind: garden.SpawnFirefly, Position: utils.NewVector2D(50, 500)}},
+		},
+	},
+}
+
+// Find retorna el caso con ese nombre
+func Find(name string) (Case, bool) {
+	for _, c := range Cases {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Case{}, false
+}
+
+// Run corre el caso desde cero con la configuración por defecto y retorna
+// una huella cada c.Every ticks. Cambia la configuración global y reinicia
+// el generador compartido, así que no debe correr junto a otra simulación.
+func Run(c Case) ([]Checkpoint, error) {
+	config.Set(config.Default())
+
+	// Step avanza el reloj simulado junto con las luciérnagas
+	g, err := garden.New(garden.Options{Seed: c.Seed, Clock: garden.NewFakeClock(epoch), Sync: true})
+	if err != nil {
+		return nil, err
+	}
+	g.Start()
+	defer g.Stop()
+
+	dt := (time.Second / time.Duration(config.Get().SimulationTPS)).Seconds()
+	dropped := g.Snapshot().Dropped
+
+	var checkpoints []Checkpoint
+	for i := 1; i <= c.Ticks; i++ {
+		for _, cmd := range c.Commands[i] {
+			if err := g.Command(cmd); err != nil {
+				return nil, fmt.Errorf("tick %d: %w", i, err)
+			}
+		}
+		g.Step(dt)
+
+		if c.Every > 0 && i%c.Every == 0 {
+			snap := g.Snapshot()
+			if snap.Dropped != dropped {
+				return nil, fmt.Errorf("tick %d: %w", i, ErrDropped)
+			}
+			checkpoints = append(checkpoints, Checkpoint{Tick: i, Population: len(snap.Fireflies), Hash: Hash(snap)})
+		}
+	}
+
+	return checkpoints, nil
+}
+
+// Hash resume el estado del mundo: luciérnagas y faroles en orden de ID y la
+// dirección del viento. La hora de cada estado no entra, depende del reloj.
+func Hash(snap garden.Snapshot) string {
+	h := sha256.New()
+	buf := make([]byte, 0, 64)
+
+	fireflies := slices.Clone(snap.Fireflies)
+	slices.SortFunc(fireflies, func(a, b garden.FireflyState) int { return a.ID - b.ID })
+	for _, f := range fireflies {
+		buf = binary.AppendVarint(buf[:0], int64(f.ID))
+		buf = appendFloats(buf, f.Position.X, f.Position.Y, f.Brightness)
+		buf = binary.AppendVarint(buf, int64(f.Gossip))
+		h.Write(buf)
+	}
+
+	lanterns := slices.Clone(snap.Lanterns)
+	slices.SortFunc(lanterns, func(a, b garden.LanternState) int { return a.ID - b.ID })
+	for _, l := range lanterns {
+		buf = binary.AppendVarint(buf[:0], int64(l.ID))
+		buf = appendFloats(buf, l.Position.X, l.Position.Y, l.Radius, l.Intensity)
+		h.Write(buf)
+	}
+
+	h.Write([]byte(snap.Wind.Direction))
+
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+func appendFloats(buf []byte, values ...float64) []byte {
+	for _, v := range values {
+		buf = binary.AppendVarint(buf, int64(math.Round(v*precision)))
+	}
+	return buf
+}
+
+// Path retorna el archivo de huellas del caso dentro de dir
+func Path(dir string, c Case) string {
+	return filepath.Join(dir, c.Name+".golden")
+}
+
+// Load lee un archivo de huellas: una línea "tick población hash" por
+// huella; las líneas vacías y las que empiezan con # se ignoran
+func Load(path string) ([]Checkpoint, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var checkpoints []Checkpoint
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: se esperaban 3 campos", path, line)
+		}
+		tick, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: tick inválido: %w", path, line, err)
+		}
+		population, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: población inválida: %w", path, line, err)
+		}
+		checkpoints = append(checkpoints, Checkpoint{Tick: tick, Population: population, Hash: fields[2]})
+	}
+	return checkpoints, scanner.Err()
+}
+
+// Save escribe las huellas del caso con una cabecera que lo describe
+func Save(path string, c Case, checkpoints []Checkpoint) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s: semilla %d, %d ticks, huella cada %d\n", c.Name, c.Seed, c.Ticks, c.Every)
+	fmt.Fprintln(&b, "# tick población hash")
+	for _, cp := range checkpoints {
+		fmt.Fprintln(&b, cp)
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// Diff retorna la primera huella que no coincide; differs es false si todas
+// son iguales. Si una lista es más corta, la huella que falta queda en cero.
+func Diff(want, got []Checkpoint) (wantCp, gotCp Checkpoint, differs bool) {
+	for i := range max(len(want), len(got)) {
+		var w, g Checkpoint
+		if i < len(want) {
+			w = want[i]
+		}
+		if i < len(got) {
+			g = got[i]
+		}
+		if w != g {
+			return w, g, true
+		}
+	}
+	return Checkpoint{}, Checkpoint{}, false
+}
@@ -0,0 +1,38 @@
+package golden
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// dir es el directorio de huellas del repositorio; cmd/golden -update las
+// reescribe cuando un cambio de comportamiento es buscado
+var dir = filepath.Join("..", "..", "golden")
+
+func TestCases(t *testing.T) {
+	for _, c := range Cases {
+		t.Run(c.Name, func(t *testing.T) {
+			want, err := Load(Path(dir, c))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Run(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if w, g, differs := Diff(want, got); differs {
+				t.Fatalf("tick %d: esperado %q, obtenido %q (si el cambio es buscado, correr cmd/golden -update)", max(w.Tick, g.Tick), w, g)
+			}
+
+			// Una segunda corrida debe dar lo mismo: si no, es no determinismo
+			again, err := Run(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if first, other, differs := Diff(got, again); differs {
+				t.Fatalf("no determinista en el tick %d: %s / %s", max(first.Tick, other.Tick), first, other)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package i18n
+
+// english traduce al inglés los mensajes de la interfaz. Las claves son el
+// texto en español tal como aparece en el código, con sus verbos de formato.
+var english = map[string]string{
+	// Menús
+	"🌙 Jardín de Luciérnagas":              "🌙 Firefly Garden",
+	"Proyecto de Programación Concurrente": "Concurrent Programming Project",
+	"Niveles":                              "Levels",
+	"Jardín libre":                         "Free garden",
+	"Supervivencia":                        "Survival",
+	"Desafío diario":                       "Daily challenge",
+	"Frasco (minijuego)":                   "Jar (minigame)",
+	"Récords":                              "High scores",
+	"Configuración":                        "Settings",
+	"Salir":                                "Quit",
+	"Jugar de nuevo":                       "Play again",
+	"Menú principal":                       "Main menu",
+	"Editor de mapas":                      "Map editor",
+	"Escenarios":                           "Presets",
+	"Volver":                               "Back",
+
+	// Resumen de la partida
+	"Fin de la partida":                  "Game over",
+	"Tiempo jugado":                      "Time played",
+	"Tiempo sobrevivido":                 "Time survived",
+	"¡Se acabó el tiempo!":               "Time's up!",
+	"Luciérnagas atrapadas: %s":          "Fireflies caught: %s",
+	"¡Superaste todos los niveles!":      "You beat every level!",
+	"Niveles superados: %d de %d":        "Levels cleared: %d of %d",
+	"El jardín se apagó":                 "The garden went dark",
+	"Oleadas de murciélagos: %s":         "Bat waves: %s",
+	"¡Tutorial completado!":              "Tutorial complete!",
+	"Desafío del %s":                     "Challenge for %s",
+	"¡Nuevo récord del día!":             "New daily record!",
+	"Mejor del día: %s":                  "Best of the day: %s",
+	"Puntaje: %s  (mejor racha %ds, %s)": "Score: %s  (best streak %ds, %s)",
+	"Resplandor máximo: %.0f  (%s)":      "Peak glow: %.0f  (%s)",
+	"%s destello":                        "%s flash",
+	"%s destellos":                       "%s flashes",
+	"%s logro":                           "%s achievement",
+	"%s logros":                          "%s achievements",
+	"Población máxima: %s":               "Peak population: %s",
+	"Población final: %s":                "Final population: %s",
+	"Faroles colocados: %s":              "Lanterns placed: %s",
+	"Estados descartados: %s":            "Dropped states: %s",
+
+	// Configuración
+	"Luciérnagas máximas":    "Max fireflies",
+	"Intervalo de aparición": "Spawn interval",
+	"Fuerza del viento":      "Wind strength",
+	"Radio de faroles":       "Lantern radius",
+	"Calidad de render":      "Render quality",
+	"Círculos":               "Circles",
+	"Escala de la interfaz":  "Interface scale",
+	"Idioma":                 "Language",
+	"Paleta":                 "Palette",
+	"Tema":                   "Theme",
+	"Clásico":                "Classic",
+	"Noche de invierno":      "Winter night",
+	"Bosque profundo":        "Deep forest",
+	"Deuteranopía":           "Deuteranopia",
+	"Protanopía":             "Protanopia",
+	"Tritanopía":             "Tritanopia",
+	"Alto contraste":         "High contrast",
+	"↑↓: Elegir  ←→ / Arrastrar: Ajustar  R: Restaurar  ESC: Volver": "↑↓: Select  ←→ / Drag: Adjust  R: Reset  ESC: Back",
+	"El radio se aplica a los faroles nuevos":                        "The radius applies to new lanterns",
+
+	// HUD
+	"🌙 JARDÍN DE LUCIÉRNAGAS":   "🌙 FIREFLY GARDEN",
+	"Luciérnagas: %s / %s":      "Fireflies: %s / %s",
+	"Faroles: %s / %s":          "Lanterns: %s / %s",
+	"Viento: %s":                "Wind: %s",
+	"Objetivo: %s":              "Target: %s",
+	"Calidad: %s":               "Quality: %s",
+	"Completa":                  "Full",
+	"Reducida":                  "Reduced",
+	"Baja":                      "Low",
+	"Mínima":                    "Minimal",
+	"Descartados: %s":           "Dropped: %s",
+	"Cúmulos: %s (mayor: %s)":   "Clusters: %s (largest: %s)",
+	"⏸ PAUSADO":                 "⏸ PAUSED",
+	"Calma":                     "Calm",
+	"Norte":                     "North",
+	"Sur":                       "South",
+	"Este":                      "East",
+	"Oeste":                     "West",
+	"Noreste":                   "Northeast",
+	"Noroeste":                  "Northwest",
+	"Sureste":                   "Southeast",
+	"Suroeste":                  "Southwest",
+	"🎯 OBJETIVO":                "🎯 GOAL",
+	"Mantén %s+ luciérnaga":     "Keep %s+ firefly",
+	"Mantén %s+ luciérnagas":    "Keep %s+ fireflies",
+	"Racha: %ds  Destellos: %s": "Streak: %ds  Flashes: %s",
+	"⏸  JUEGO PAUSADO":          "⏸  GAME PAUSED",
+	"Presiona P para continuar": "Press P to continue",
+	"▶ REPETICIÓN x%d  %s / %s  (1/2/4: velocidad)": "▶ REPLAY x%d  %s / %s  (1/2/4: speed)",
+	"■ REPETICIÓN TERMINADA  %s":                    "■ REPLAY FINISHED  %s",
+	"Rumor: %d/%d luciérnagas, ronda %d":            "Gossip: %d/%d fireflies, round %d",
+	"todas en %d rondas (%.1f s)":                   "all in %d rounds (%.1f s)",
+	"Líderes: %d grupos, %d elecciones, %d msj":     "Leaders: %d clusters, %d elections, %d msg",
+	"reelecciones: %d (cayó #%d)":                   "re-elections: %d (#%d fell)",
+
+	// Paneles
+	"JARDÍN":             "GARDEN",
+	"GRÁFICAS":           "GRAPHS",
+	"CONTROLES":          "CONTROLS",
+	"EVENTOS":            "EVENTS",
+	"HERRAMIENTAS":       "TOOLS",
+	"PUNTAJE":            "SCORE",
+	"MINIMAPA":           "MINIMAP",
+	"🛠 HERRAMIENTAS (T)": "🛠 TOOLS (T)",
+	"Soltar 10":          "Drop 10",
+	"Quitar faroles":     "Clear lanterns",
+	"Viento":             "Wind",
+	"Aparición":          "Spawning",
+	"cada %s s":          "every %s s",
+	"Mapa de calor":      "Heatmap",
+	"Gráficas":           "Graphs",
+
+	// Controles
+	"⌨️  CONTROLES":                                "⌨️  CONTROLS",
+	"Click Izq: Atraer luciernagas":                "Left click: Attract fireflies",
+	"L: Colocar farol (genera ráfaga)":             "L: Place lantern (spawns a burst)",
+	"K: Generar ráfaga cerca del cursor":           "K: Burst near the cursor",
+	"B: Forma de la ráfaga":                        "B: Burst shape",
+	"Shift+W: Vista del viento":                    "Shift+W: Wind view",
+	"F7: Fuerzas de cada luciérnaga":               "F7: Per-firefly forces",
+	"F8: Tiempo de dibujo por etapa":               "F8: Draw time per stage",
+	"Z: Luciérnagas por zona":                      "Z: Fireflies per zone",
+	"C: Resaltar cúmulos":                          "C: Highlight clusters",
+	"V: Grabar luciérnaga (fantasma)":              "V: Record firefly (ghost)",
+	"F2: Pintar campo de fuerzas":                  "F2: Paint force field",
+	"`: Consola (label 12,44)":                     "`: Console (label 12,44)",
+	"W: Cambiar direccion viento":                  "W: Change wind direction",
+	"P: Pausar/Reanudar":                           "P: Pause/Resume",
+	"Flechas / + -: Mover cámara / Zoom":           "Arrows / + -: Move camera / Zoom",
+	"H: Mapa de calor":                             "H: Heatmap",
+	"F10: Foto de larga exposición":                "F10: Long exposure photo",
+	"● DEMOSTRACIÓN  (cualquier tecla para jugar)": "● DEMO  (press any key to play)",
+	"M: Modo musical":                              "M: Musical mode",
+	"U: Modo rumor":                                "U: Gossip mode",
+	"E: Elección de líder":                         "E: Leader election",
+	"F12: Captura (Ctrl: sin interfaz)":            "F12: Screenshot (Ctrl: without interface)",
+	"G: Calidad (círculos/sprites/bloom)":          "G: Quality (circles/sprites/bloom)",
+	"F3: Overlay de depuración":                    "F3: Debug overlay",
+	"F4: Gráficas (últimos 60 s)":                  "F4: Graphs (last 60 s)",
+	"F5: Capturar trace + CPU (5 s)":               "F5: Capture trace + CPU (5 s)",
+	"F6: Flujo de canales":                         "F6: Channel flow",
+	"Tab: Registro de eventos":                     "Tab: Event log",
+	"T: Herramientas":                              "T: Tools",
+	"F1: Ocultar interfaz (Ctrl: reordenar)":       "F1: Hide interface (Ctrl: reset layout)",
+	"O: Configuración":                             "O: Settings",
+	"F9: Exportar estadísticas":                    "F9: Export statistics",
+	"ESC: Terminar partida":                        "ESC: End game",
+
+	// Consola de depuración
+	"Órdenes: %s":                           "Commands: %s",
+	"orden desconocida %q (help las lista)": "unknown command %q (help lists them)",
+	"Etiquetas ocultas":                     "Labels hidden",
+	"Etiquetas: todas las luciérnagas":      "Labels: every firefly",
+	"Etiquetas: %d luciérnagas":             "Labels: %d fireflies",
+	"ID inválido %q: usar label 12,44,91":   "invalid ID %q: use label 12,44,91",
+
+	// Fantasma (V y la orden ghost)
+	"● Grabando luciérnaga %d: %ds / %ds":            "● Recording firefly %d: %ds / %ds",
+	"Fantasma de la luciérnaga %d (%ds, V lo borra)": "Ghost of firefly %d (%ds, V clears it)",
+	"Fantasma borrado":                               "Ghost cleared",
+	"usar ghost 12 o ghost off":                      "use ghost 12 or ghost off",
+	"ID inválido %q: usar ghost 12":                  "invalid ID %q: use ghost 12",
+	"Grabando la luciérnaga %d durante %ds":          "Recording firefly %d for %ds",
+
+	// Campo de fuerzas (F2)
+	"Campo de fuerzas: click atrae, derecho repele, rueda %s px, Supr borra, F2 sale": "Force field: click attracts, right click repels, wheel %s px, Del clears, F2 exits",
+
+	// Editor de mapas
+	"Obstáculo":                              "Obstacle",
+	"Estanque":                               "Pond",
+	"Farol":                                  "Lantern",
+	"Zona de aparición":                      "Spawn zone",
+	"Zona de viento":                         "Wind zone",
+	"sí":                                     "yes",
+	"Editor de mapas: %s (%d×%d)":            "Map editor: %s (%d×%d)",
+	"Máximo de faroles alcanzado (%d)":       "Lantern limit reached (%d)",
+	"Mapa inválido: %s":                      "Invalid map: %s",
+	"No se pudo guardar el mapa: %s":         "Could not save the map: %s",
+	"Mapa guardado en %s":                    "Map saved to %s",
+	"Probando el mapa: Esc vuelve al editor": "Testing the map: Esc returns to the editor",
+
+	"Zona de aparición: %s por segundo ([ ]), hacia el centro: %s (I)": "Spawn zone: %s per second ([ ]), inward: %s (I)",
+
+	"Ctrl+S: guardar en %s · Enter: probar · Esc: menú":            "Ctrl+S: save to %s · Enter: test · Esc: menu",
+	"No se puede poner un farol sobre un obstáculo ni un estanque": "A lantern can't go on an obstacle or a pond",
+
+	"%d obstáculos, %d estanques, %d faroles, %d zonas de aparición, %d zonas de viento": "%d obstacles, %d ponds, %d lanterns, %d spawn zones, %d wind zones",
+
+	"Arrastrar: rectángulo · Shift: círculo · Click derecho: borrar · Ctrl+Z: deshacer · Flechas y rueda: cámara": "Drag: rectangle · Shift: circle · Right click: delete · Ctrl+Z: undo · Arrows and wheel: camera",
+
+	// Avisos del manager
+	"Configuración recargada (%d cambios)":          "Configuration reloaded (%d changes)",
+	"Algunos cambios requieren reiniciar: %s":       "Some changes need a restart: %s",
+	"Configuración ignorada: %v":                    "Configuration ignored: %v",
+	"Logro: %s (resplandor %.0f)":                   "Achievement: %s (glow %.0f)",
+	"¡Objetivo alcanzado! (%d luciérnagas)":         "Goal reached! (%d fireflies)",
+	"🦇 Llegan %d murciélagos":                       "🦇 %d bats incoming",
+	"⛈ Se acerca una tormenta":                      "⛈ A storm is coming",
+	"Cola de comandos llena: se descartó una orden": "Command queue full: an order was dropped",
+	"Primer resplandor":                             "First glow",
+	"Jardín encendido":                              "Garden alight",
+	"Noche de fiesta":                               "Party night",
+	"Constelación":                                  "Constellation",
+
+	// Escenarios listos (presets)
+	"Noche tranquila":                      "Calm night",
+	"Tormenta":                             "Storm",
+	"Enjambre (estrés)":                    "Swarm (stress test)",
+	"Multitud (10 000)":                    "Crowd (10,000)",
+	"Acoso de murciélagos":                 "Predator gauntlet",
+	"Sincronía":                            "Sync demo",
+	"Presets: %s (ahora: %s)":              "Presets: %s (current: %s)",
+	"Volviendo al jardín sin preset":       "Back to the garden without a preset",
+	"preset desconocido %q: usar %s u off": "unknown preset %q: use %s or off",
+	"Cambiando a %s":                       "Switching to %s",
+
+	"Brisa suave, pocas luciérnagas que nacen de a una y destellan despacio":             "Gentle breeze, few fireflies born one at a time that blink slowly",
+	"Viento fuerte que rota seguido y frentes de tormenta cada 30 s":                     "Strong wind that shifts often and storm fronts every 30 s",
+	"Miles de luciérnagas para exigir la simulación: F3 muestra los estados descartados": "Thousands of fireflies to push the simulation: F3 shows the dropped states",
+	"Diez mil luciérnagas simuladas por lotes: F3 verifica que no se descarten estados":  "Ten thousand fireflies simulated in batches: F3 checks that no states are dropped",
+	"Ecología con murciélagos cada 6 s: los faroles agrandan el hábitat":                 "Ecology with bats every 6 s: lanterns enlarge the habitat",
+	"Cada grupo elige una líder y destella con ella; sin viento que los separe":          "Each group elects a leader and blinks with it; no wind to split them",
+}
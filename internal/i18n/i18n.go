@@ -0,0 +1,127 @@
+// Package i18n traduce los textos de la interfaz. Los mensajes se escriben
+// en español en el código y son su propia clave, como en gettext: el
+// catálogo de cada idioma los traduce y lo que no esté traducido se muestra
+// en español. El idioma se puede cambiar en cualquier momento; como la
+// interfaz traduce al dibujar, el cambio se ve en el frame siguiente.
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Lang es un idioma de la interfaz (código ISO 639-1)
+type Lang string
+
+const (
+	Spanish Lang = "es"
+	English Lang = "en"
+)
+
+// languages son los idiomas disponibles, en el orden del selector
+var languages = []Lang{Spanish, English}
+
+// catalogs traduce los mensajes en español a cada idioma
+var catalogs = map[Lang]map[string]string{
+	English: english,
+}
+
+var current atomic.Value // Lang
+
+func init() {
+	current.Store(Spanish)
+}
+
+// Languages retorna los idiomas disponibles
+func Languages() []Lang {
+	return languages
+}
+
+// Name retorna el nombre del idioma en ese mismo idioma
+func (l Lang) Name() string {
+	switch l {
+	case Spanish:
+		return "Español"
+	case English:
+		return "English"
+	}
+	return string(l)
+}
+
+// Set cambia el idioma de la interfaz
+func Set(lang string) error {
+	for _, l := range languages {
+		if string(l) == lang {
+			current.Store(l)
+			return nil
+		}
+	}
+	return fmt.Errorf("idioma desconocido %q", lang)
+}
+
+// Current retorna el idioma activo
+func Current() Lang {
+	return current.Load().(Lang)
+}
+
+// T traduce el mensaje al idioma activo y, si hay argumentos, lo usa como
+// formato de fmt.Sprintf
+func T(msg string, args ...any) string {
+	if tr, ok := catalogs[Current()][msg]; ok {
+		msg = tr
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Plural elige la forma singular (one) o plural (other) según n, la
+// traduce y reemplaza su %s por n formateado con Number. Español e inglés
+// comparten la regla: singular solo para 1.
+func Plural(n int, one, other string) string {
+	form := other
+	if n == 1 {
+		form = one
+	}
+	return T(form, Number(n))
+}
+
+// Number formatea un entero con separador de miles: punto en español (solo
+// a partir de cinco cifras, como pide la RAE) y coma en inglés
+func Number(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	sep := ","
+	if Current() == Spanish {
+		if len(digits) <= 4 {
+			return sign + digits
+		}
+		sep = "."
+	}
+
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String()
+}
+
+// Decimal formatea v con la cantidad de decimales indicada y la coma
+// decimal en español
+func Decimal(v float64, decimals int) string {
+	s := strconv.FormatFloat(v, 'f', decimals, 64)
+	if Current() == Spanish {
+		s = strings.Replace(s, ".", ",", 1)
+	}
+	return s
+}
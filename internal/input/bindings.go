@@ -0,0 +1,157 @@
+package input
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Action es una acción del juego asignable a una tecla
+type Action string
+
+const (
+	ActionPause    Action = "pause"
+	ActionLantern  Action = "lantern"
+	ActionBurst    Action = "burst"
+	ActionWind     Action = "wind"
+	ActionHeatmap  Action = "heatmap"
+	ActionQuality  Action = "quality"
+	ActionPhoto    Action = "photo"
+	ActionDebug    Action = "debug"
+	ActionSettings Action = "settings"
+	ActionEndGame  Action = "end_game"
+	ActionStats    Action = "export_stats"
+	ActionGraphs   Action = "graphs"
+	ActionFlow     Action = "flow"
+	ActionProfile  Action = "profile"
+	ActionEventLog Action = "event_log"
+	ActionTools    Action = "tools"
+	ActionHideHUD  Action = "hide_hud"
+
+	// ActionScreenshot guarda una captura; con Ctrl, sin la interfaz
+	ActionScreenshot Action = "screenshot"
+
+	// ActionMusic prende el modo musical: cada destello toca una nota
+	ActionMusic Action = "music"
+
+	// ActionGossip empieza el modo rumor: el mensaje pasa de vecina en vecina
+	ActionGossip Action = "gossip"
+
+	// ActionElection empieza el modo elección de líder por grupos
+	ActionElection Action = "election"
+
+	// Órdenes para las luciérnagas seleccionadas con Shift + arrastrar
+	ActionGroupAttract Action = "group_attract"
+	ActionGroupFreeze  Action = "group_freeze"
+	ActionGroupRelease Action = "group_release"
+
+	// ActionJarRelease suelta el frasco en el minijuego
+	ActionJarRelease Action = "jar_release"
+
+	// ActionBurstPattern cambia la forma de las ráfagas (nube, anillo...)
+	ActionBurstPattern Action = "burst_pattern"
+
+	// ActionForces muestra las fuerzas que mueven a cada luciérnaga
+	ActionForces Action = "forces"
+
+	// ActionConsole abre la consola de depuración
+	ActionConsole Action = "console"
+
+	// ActionFrameTime muestra cuánto tarda cada etapa del dibujo
+	ActionFrameTime Action = "frame_time"
+
+	// ActionZones muestra cuántas luciérnagas hay en cada zona del jardín
+	ActionZones Action = "zones"
+
+	// ActionClusters resalta los cúmulos de luciérnagas detectados
+	ActionClusters Action = "clusters"
+
+	// ActionGhost graba el recorrido de una luciérnaga para verlo de fantasma
+	ActionGhost Action = "ghost"
+
+	// ActionFieldEditor entra al modo de pintar el campo de fuerzas
+	ActionFieldEditor Action = "field_editor"
+)
+
+// Bindings asigna una tecla a cada acción
+type Bindings map[Action]ebiten.Key
+
+func DefaultBindings() Bindings {
+	return Bindings{
+		ActionPause:    ebiten.KeyP,
+		ActionLantern:  ebiten.KeyL,
+		ActionBurst:    ebiten.KeyK,
+		ActionWind:     ebiten.KeyW,
+		ActionHeatmap:  ebiten.KeyH,
+		ActionQuality:  ebiten.KeyG,
+		ActionPhoto:    ebiten.KeyF10,
+		ActionDebug:    ebiten.KeyF3,
+		ActionSettings: ebiten.KeyO,
+		ActionEndGame:  ebiten.KeyEscape,
+		ActionStats:    ebiten.KeyF9,
+		ActionGraphs:   ebiten.KeyF4,
+		ActionFlow:     ebiten.KeyF6,
+		ActionProfile:  ebiten.KeyF5,
+		ActionEventLog: ebiten.KeyTab,
+		ActionTools:    ebiten.KeyT,
+		ActionHideHUD:  ebiten.KeyF1,
+
+		ActionScreenshot: ebiten.KeyF12,
+
+		ActionMusic: ebiten.KeyM,
+
+		ActionGossip: ebiten.KeyU,
+
+		ActionElection: ebiten.KeyE,
+
+		ActionGroupAttract: ebiten.KeyA,
+		ActionGroupFreeze:  ebiten.KeyF,
+		ActionGroupRelease: ebiten.KeyX,
+
+		ActionJarRelease: ebiten.KeyR,
+
+		ActionBurstPattern: ebiten.KeyB,
+
+		ActionForces: ebiten.KeyF7,
+
+		ActionConsole: ebiten.KeyBackquote,
+
+		ActionFrameTime: ebiten.KeyF8,
+
+		ActionZones: ebiten.KeyZ,
+
+		ActionClusters: ebiten.KeyC,
+
+		ActionGhost: ebiten.KeyV,
+
+		ActionFieldEditor: ebiten.KeyF2,
+	}
+}
+
+// ParseBindings parte de las teclas por defecto y aplica los nombres guardados
+// (por ejemplo {"pause": "Space"}); acciones o teclas desconocidas son un error
+func ParseBindings(names map[string]string) (Bindings, error) {
+	bindings := DefaultBindings()
+
+	for action, name := range names {
+		if _, ok := bindings[Action(action)]; !ok {
+			return nil, fmt.Errorf("acción desconocida %q", action)
+		}
+		var key ebiten.Key
+		if err := key.UnmarshalText([]byte(name)); err != nil {
+			return nil, fmt.Errorf("tecla inválida para %q: %w", action, err)
+		}
+		bindings[Action(action)] = key
+	}
+
+	return bindings, nil
+}
+
+// Names retorna las asignaciones como texto para guardarlas
+func (b Bindings) Names() map[string]string {
+	names := make(map[string]string, len(b))
+	for action, key := range b {
+		names[string(action)] = key.String()
+	}
+	return names
+}
@@ -0,0 +1,141 @@
+package input
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/yourusername/firefly-garden/internal/config"
+)
+
+const (
+	// stickDeadZone ignora el juego de la palanca en reposo
+	stickDeadZone = 0.2
+	// cursorSpeed es cuántos píxeles por segundo recorre el cursor virtual
+	// con la palanca a fondo
+	cursorSpeed = 600.0
+)
+
+// gamepadButtons son los botones del mapeo estándar y la acción que disparan
+var gamepadButtons = map[ebiten.StandardGamepadButton]Action{
+	ebiten.StandardGamepadButtonRightBottom: ActionLantern, // A
+	ebiten.StandardGamepadButtonRightRight:  ActionBurst,   // B
+	ebiten.StandardGamepadButtonCenterRight: ActionPause,   // Start
+}
+
+// gamepadState sigue al primer control con mapeo estándar: la palanca
+// izquierda mueve un cursor virtual que atrae mientras está inclinada y los
+// botones se traducen a acciones
+type gamepadState struct {
+	ids    []ebiten.GamepadID
+	id     ebiten.GamepadID
+	active bool
+	name   string
+	used   bool
+
+	cursorX, cursorY float64
+	attracting       bool
+	wasAttracting    bool
+	last             time.Time
+
+	actions map[Action]bool
+
+	// plugged es el cambio de conexión de este frame
+	plugged     bool
+	pluggedName string
+	connected   bool
+}
+
+// updateGamepad lee el control activo; lo llama Update
+func (h *Handler) updateGamepad(now time.Time) {
+	g := &h.gamepad
+	if g.actions == nil {
+		g.actions = make(map[Action]bool)
+		width, height := config.WorldSize()
+		g.cursorX = width / 2
+		g.cursorY = height / 2
+		g.last = now
+	}
+	clear(g.actions)
+	g.plugged = false
+	g.wasAttracting = g.attracting
+	dt := now.Sub(g.last).Seconds()
+	g.last = now
+
+	// Conexión en caliente: si el control activo se desconecta se toma el
+	// siguiente que tenga mapeo estándar
+	if g.active && inpututil.IsGamepadJustDisconnected(g.id) {
+		g.active = false
+		g.used = false
+		g.attracting = false
+		g.plugged, g.pluggedName, g.connected = true, g.name, false
+	}
+	if !g.active {
+		g.ids = ebiten.AppendGamepadIDs(g.ids[:0])
+		i := slices.IndexFunc(g.ids, ebiten.IsStandardGamepadLayoutAvailable)
+		if i >= 0 {
+			g.id, g.active = g.ids[i], true
+			g.name = ebiten.GamepadName(g.id)
+			g.plugged, g.pluggedName, g.connected = true, g.name, true
+		}
+	}
+	if !g.active {
+		return
+	}
+
+	x := ebiten.StandardGamepadAxisValue(g.id, ebiten.StandardGamepadAxisLeftStickHorizontal)
+	y := ebiten.StandardGamepadAxisValue(g.id, ebiten.StandardGamepadAxisLeftStickVertical)
+	magnitude := math.Hypot(x, y)
+	g.attracting = magnitude > stickDeadZone
+	if g.attracting {
+		// Fuera de la zona muerta la velocidad crece desde cero
+		scale := (min(magnitude, 1) - stickDeadZone) / (1 - stickDeadZone) * cursorSpeed * dt / magnitude
+		width, height := config.WorldSize()
+		g.cursorX = max(0, min(width, g.cursorX+x*scale))
+		g.cursorY = max(0, min(height, g.cursorY+y*scale))
+		g.used = true
+		h.touch.used = false
+	}
+
+	for button, action := range gamepadButtons {
+		if inpututil.IsStandardGamepadButtonJustPressed(g.id, button) {
+			g.actions[action] = true
+			g.used = true
+			h.touch.used = false
+		}
+	}
+}
+
+// GamepadName retorna el nombre del control activo
+func (h *Handler) GamepadName() (string, bool) {
+	return h.gamepad.name, h.gamepad.active
+}
+
+// GamepadUsed indica si el jugador está usando el control (lo movió y no
+// usó el mouse ni la pantalla táctil desde entonces)
+func (h *Handler) GamepadUsed() bool {
+	return h.gamepad.active && h.gamepad.used
+}
+
+// GamepadAttracting indica si la palanca está inclinada; mientras tanto el
+// punto de atracción sigue al cursor virtual
+func (h *Handler) GamepadAttracting() bool {
+	return h.gamepad.active && h.gamepad.attracting
+}
+
+func (h *Handler) gamepadJustAttracted() bool {
+	return h.GamepadAttracting() && !h.gamepad.wasAttracting
+}
+
+func (h *Handler) gamepadJustReleased() bool {
+	return h.gamepad.active && !h.gamepad.attracting && h.gamepad.wasAttracting
+}
+
+// JustPluggedGamepad informa si en este frame se conectó o desconectó el
+// control activo
+func (h *Handler) JustPluggedGamepad() (name string, connected, ok bool) {
+	g := &h.gamepad
+	return g.pluggedName, g.connected, g.plugged
+}
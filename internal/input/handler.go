@@ -8,15 +8,49 @@ import (
 type Handler struct {
 	prevKeyState    map[ebiten.Key]bool
 	prevMouseState  map[ebiten.MouseButton]bool
+	bindings        Bindings
+	touch           touchState
+	gamepad         gamepadState
+	idle            idleState
+	captured        bool
 }
 
 func NewHandler() *Handler {
 	return &Handler{
 		prevKeyState:   make(map[ebiten.Key]bool),
 		prevMouseState: make(map[ebiten.MouseButton]bool),
+		bindings:       DefaultBindings(),
 	}
 }
 
+func (h *Handler) SetBindings(bindings Bindings) {
+	h.bindings = bindings
+}
+
+func (h *Handler) GetBindings() Bindings {
+	return h.bindings
+}
+
+// IsActionJustPressed consulta la tecla asignada a la acción; con Ctrl
+// presionado las teclas forman atajos (Ctrl+S, Ctrl+O) y no disparan acciones
+func (h *Handler) IsActionJustPressed(action Action) bool {
+	if h.touch.virtual[action] || h.gamepad.actions[action] {
+		return true
+	}
+	key, ok := h.bindings[action]
+	return ok && !h.IsCtrlPressed() && inpututil.IsKeyJustPressed(key)
+}
+
+// IsCtrlPressed considera Cmd en macOS como Ctrl
+func (h *Handler) IsCtrlPressed() bool {
+	return ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+}
+
+// IsShortcutJustPressed detecta Ctrl+key
+func (h *Handler) IsShortcutJustPressed(key ebiten.Key) bool {
+	return h.IsCtrlPressed() && inpututil.IsKeyJustPressed(key)
+}
+
 func (h *Handler) IsKeyPressed(key ebiten.Key) bool {
 	return ebiten.IsKeyPressed(key)
 }
@@ -29,20 +63,38 @@ func (h *Handler) IsKeyJustReleased(key ebiten.Key) bool {
 	return inpututil.IsKeyJustReleased(key)
 }
 
+// AppendInputChars agrega a buf los caracteres tipeados en este frame
+func (h *Handler) AppendInputChars(buf []rune) []rune {
+	return ebiten.AppendInputChars(buf)
+}
+
+// Los métodos del botón izquierdo y del cursor usan el puntero unificado:
+// un dedo o la palanca del control también cuentan como mouse
+
 func (h *Handler) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	if button == ebiten.MouseButtonLeft {
+		return h.Pointer().Pressed
+	}
 	return ebiten.IsMouseButtonPressed(button)
 }
 
 func (h *Handler) IsMouseButtonJustPressed(button ebiten.MouseButton) bool {
+	if button == ebiten.MouseButtonLeft {
+		return h.Pointer().JustPressed
+	}
 	return inpututil.IsMouseButtonJustPressed(button)
 }
 
 func (h *Handler) IsMouseButtonJustReleased(button ebiten.MouseButton) bool {
+	if button == ebiten.MouseButtonLeft {
+		return h.Pointer().JustReleased
+	}
 	return inpututil.IsMouseButtonJustReleased(button)
 }
 
 func (h *Handler) GetCursorPosition() (int, int) {
-	return ebiten.CursorPosition()
+	p := h.Pointer()
+	return p.X, p.Y
 }
 
 func (h *Handler) GetMouseWheel() (float64, float64) {
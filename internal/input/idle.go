@@ -0,0 +1,69 @@
+package input
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// idleState sigue cuándo hubo input real por última vez: una tecla, el
+// mouse (moverlo, sus botones o la rueda), un dedo o el control
+type idleState struct {
+	started bool
+	active  bool
+	last    time.Time
+
+	mouseX, mouseY int
+	keys           []ebiten.Key
+	buttons        []ebiten.StandardGamepadButton
+}
+
+// updateIdle registra si en este frame hubo input; lo llama Update
+func (h *Handler) updateIdle(now time.Time) {
+	s := &h.idle
+	mx, my := ebiten.CursorPosition()
+	if !s.started {
+		s.started = true
+		s.last = now
+		s.mouseX, s.mouseY = mx, my
+	}
+
+	s.keys = inpututil.AppendPressedKeys(s.keys[:0])
+	wx, wy := ebiten.Wheel()
+	s.active = len(s.keys) > 0 ||
+		mx != s.mouseX || my != s.mouseY ||
+		wx != 0 || wy != 0 ||
+		ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) ||
+		ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) ||
+		len(h.touch.ids) > 0 ||
+		h.gamepadActive()
+	s.mouseX, s.mouseY = mx, my
+	if s.active {
+		s.last = now
+	}
+}
+
+// gamepadActive indica si la palanca está inclinada o hay un botón
+// presionado en el control activo
+func (h *Handler) gamepadActive() bool {
+	g := &h.gamepad
+	if !g.active {
+		return false
+	}
+	if g.attracting {
+		return true
+	}
+	h.idle.buttons = inpututil.AppendPressedStandardGamepadButtons(g.id, h.idle.buttons[:0])
+	return len(h.idle.buttons) > 0
+}
+
+// Active indica si en este frame hubo input real del jugador
+func (h *Handler) Active() bool {
+	return h.idle.active
+}
+
+// IdleFor retorna cuánto pasó desde el último input real
+func (h *Handler) IdleFor() time.Duration {
+	return time.Since(h.idle.last)
+}
@@ -0,0 +1,69 @@
+package input
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// PointerSource es el dispositivo que mueve el puntero
+type PointerSource int
+
+const (
+	PointerMouse PointerSource = iota
+	PointerTouch
+	PointerGamepad
+)
+
+// Pointer es el puntero principal sin importar de dónde venga: el mouse con
+// el botón izquierdo, el primer dedo apoyado o el cursor virtual del control
+// con la palanca. Las escenas lo usan para apuntar y "hacer click".
+type Pointer struct {
+	X, Y   int
+	Source PointerSource
+
+	Pressed      bool
+	JustPressed  bool
+	JustReleased bool
+}
+
+// Pointer retorna el estado del puntero en este frame
+func (h *Handler) Pointer() Pointer {
+	p := Pointer{Source: PointerMouse}
+
+	switch {
+	case h.touch.used:
+		p.Source = PointerTouch
+		if id, ok := h.activeTouch(); ok {
+			h.touch.lastX, h.touch.lastY = ebiten.TouchPosition(id)
+			p.Pressed = true
+		}
+		p.X, p.Y = h.touch.lastX, h.touch.lastY
+		p.JustPressed = h.touchJustPressed()
+		p.JustReleased = h.touchJustReleased()
+
+	case h.GamepadUsed():
+		p.Source = PointerGamepad
+		p.X, p.Y = int(h.gamepad.cursorX), int(h.gamepad.cursorY)
+		p.Pressed = h.GamepadAttracting()
+		p.JustPressed = h.gamepadJustAttracted()
+		p.JustReleased = h.gamepadJustReleased()
+
+	default:
+		p.X, p.Y = ebiten.CursorPosition()
+		p.Pressed = ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+		p.JustPressed = inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+		p.JustReleased = inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft)
+	}
+
+	if h.captured {
+		p.Pressed, p.JustPressed, p.JustReleased = false, false, false
+	}
+	return p
+}
+
+// CapturePointer deja el puntero de este frame para la UI: desde ahí
+// Pointer sigue dando la posición pero sin botones, así la escena no toma
+// como click lo que era para un widget. Se libera en el próximo Update.
+func (h *Handler) CapturePointer() {
+	h.captured = true
+}
@@ -0,0 +1,346 @@
+package input
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	// longPressDuration es cuánto hay que mantener un dedo quieto para
+	// colocar un farol
+	longPressDuration = 600 * time.Millisecond
+	// touchSlop es cuánto se puede mover un dedo sin dejar de estar "quieto"
+	touchSlop = 16
+	// swipeDistance es cuánto tienen que moverse dos dedos juntos para
+	// contar como deslizamiento
+	swipeDistance = 80
+	// pinchThreshold es cuánto tiene que cambiar la distancia entre dos
+	// dedos para contar como pellizco
+	pinchThreshold = 24
+	// twoFingerTapDuration es el tiempo máximo de un toque con dos dedos
+	twoFingerTapDuration = 300 * time.Millisecond
+)
+
+// pairGesture es lo que se reconoció del par de dedos en curso
+type pairGesture int
+
+const (
+	pairUndecided pairGesture = iota
+	pairPinch
+	pairDone
+)
+
+// touchStart es dónde y cuándo se apoyó un dedo
+type touchStart struct {
+	x, y  int
+	at    time.Time
+	moved bool
+	fired bool
+}
+
+// touchState hace que un dedo funcione como el botón izquierdo del mouse
+// (en el navegador y en pantallas táctiles Ebiten no los mezcla), guarda
+// las acciones disparadas por botones en pantalla y reconoce los gestos:
+// mantener presionado, tocar, deslizar y pellizcar con dos dedos
+type touchState struct {
+	ids          []ebiten.TouchID
+	justPressed  []ebiten.TouchID
+	justReleased []ebiten.TouchID
+	consumed     map[ebiten.TouchID]bool
+	lastX, lastY int
+	mouseX       int
+	mouseY       int
+	used         bool
+
+	// virtual son las acciones pulsadas en pantalla en este frame
+	virtual map[Action]bool
+
+	starts map[ebiten.TouchID]*touchStart
+
+	// pair es el par de dedos apoyados; pairX/pairY y pairDist son su
+	// punto medio y su separación al apoyarse
+	pair         [2]ebiten.TouchID
+	pairActive   bool
+	pairGesture  pairGesture
+	pairAt       time.Time
+	pairX, pairY float64
+	pairDist     float64
+	pairMoved    bool
+
+	// tapPending queda activo al levantar un par que puede ser un toque con
+	// dos dedos; se confirma cuando no queda ningún dedo
+	tapPending bool
+	tapX, tapY float64
+	pinchDist  float64
+	pinchX     float64
+	pinchY     float64
+	pinchScale float64
+	pinched    bool
+	swipeX     float64
+	swipeY     float64
+	swiped     bool
+}
+
+// Update lee los toques y el control del frame; debe llamarse una vez por frame antes
+// de consultar el handler
+func (h *Handler) Update() {
+	h.captured = false
+
+	t := &h.touch
+	if t.consumed == nil {
+		t.consumed = make(map[ebiten.TouchID]bool)
+		t.virtual = make(map[Action]bool)
+		t.starts = make(map[ebiten.TouchID]*touchStart)
+	}
+
+	clear(t.virtual)
+	t.swiped = false
+	t.pinched = false
+	t.ids = ebiten.AppendTouchIDs(t.ids[:0])
+	t.justPressed = inpututil.AppendJustPressedTouchIDs(t.justPressed[:0])
+	t.justReleased = inpututil.AppendJustReleasedTouchIDs(t.justReleased[:0])
+
+	for _, id := range t.justReleased {
+		if !t.consumed[id] {
+			t.lastX, t.lastY = inpututil.TouchPositionInPreviousTick(id)
+		}
+	}
+	if len(t.justPressed) > 0 {
+		t.used = true
+		h.gamepad.used = false
+	}
+
+	// Si el mouse se mueve sin dedos apoyados se vuelve al modo mouse
+	mx, my := ebiten.CursorPosition()
+	if (mx != t.mouseX || my != t.mouseY) && len(t.ids) == 0 && len(t.justReleased) == 0 {
+		t.used = false
+		h.gamepad.used = false
+	}
+	t.mouseX, t.mouseY = mx, my
+
+	// Los toques consumidos se olvidan cuando el dedo ya no está
+	for id := range t.consumed {
+		if !slices.Contains(t.ids, id) && !slices.Contains(t.justReleased, id) {
+			delete(t.consumed, id)
+		}
+	}
+
+	now := time.Now()
+	t.updateGestures(now)
+	h.updateGamepad(now)
+	h.updateIdle(now)
+}
+
+// updateGestures reconoce los gestos sobre los dedos que no tocaron un botón
+func (t *touchState) updateGestures(now time.Time) {
+	for _, id := range t.justPressed {
+		x, y := ebiten.TouchPosition(id)
+		t.starts[id] = &touchStart{x: x, y: y, at: now}
+	}
+	for id := range t.starts {
+		if !slices.Contains(t.ids, id) {
+			delete(t.starts, id)
+		}
+	}
+
+	var free []ebiten.TouchID
+	for _, id := range t.ids {
+		if t.consumed[id] {
+			continue
+		}
+		free = append(free, id)
+
+		start, ok := t.starts[id]
+		if !ok {
+			continue
+		}
+		x, y := ebiten.TouchPosition(id)
+		if math.Hypot(float64(x-start.x), float64(y-start.y)) > touchSlop {
+			start.moved = true
+		}
+	}
+
+	// Mantener presionado un solo dedo quieto coloca un farol donde está
+	if len(free) == 1 {
+		if start, ok := t.starts[free[0]]; ok && !start.moved && !start.fired && now.Sub(start.at) >= longPressDuration {
+			start.fired = true
+			t.virtual[ActionLantern] = true
+		}
+	}
+
+	if len(free) == 2 {
+		t.updatePair(free[0], free[1], now)
+		return
+	}
+
+	// Un toque con dos dedos se confirma cuando se levantan los dos a tiempo
+	if t.pairActive {
+		t.pairActive = false
+		t.tapPending = t.pairGesture == pairUndecided && !t.pairMoved
+	}
+	if t.tapPending && (len(free) > 2 || now.Sub(t.pairAt) > twoFingerTapDuration) {
+		t.tapPending = false
+	}
+	if t.tapPending && len(free) == 0 {
+		t.tapPending = false
+		t.lastX, t.lastY = int(t.tapX), int(t.tapY)
+		t.virtual[ActionLantern] = true
+	}
+}
+
+// updatePair reconoce el gesto de dos dedos: si primero cambia la
+// separación es un pellizco (zoom), si primero se mueve el punto medio es un
+// deslizamiento (viento) y si no pasa ninguna de las dos es un toque (farol)
+func (t *touchState) updatePair(a, b ebiten.TouchID, now time.Time) {
+	mx, my := midpoint(a, b)
+	dist := distance(a, b)
+
+	pair := [2]ebiten.TouchID{a, b}
+	if !t.pairActive || t.pair != pair {
+		t.pair = pair
+		t.pairActive = true
+		t.pairGesture = pairUndecided
+		t.pairAt = now
+		t.pairX, t.pairY = mx, my
+		t.pairDist = dist
+		t.pairMoved = false
+		t.tapPending = false
+		t.tapX, t.tapY = mx, my
+		for _, id := range pair {
+			if start, ok := t.starts[id]; ok {
+				start.fired = true
+			}
+		}
+		return
+	}
+
+	t.tapX, t.tapY = mx, my
+	moved := math.Hypot(mx-t.pairX, my-t.pairY)
+	spread := math.Abs(dist - t.pairDist)
+	if moved > touchSlop || spread > touchSlop {
+		t.pairMoved = true
+	}
+
+	switch t.pairGesture {
+	case pairUndecided:
+		if spread >= pinchThreshold {
+			t.pairGesture = pairPinch
+			t.pinchDist = t.pairDist
+			t.updatePinch(dist, mx, my)
+		} else if moved >= swipeDistance {
+			t.pairGesture = pairDone
+			t.swipeX, t.swipeY = mx-t.pairX, my-t.pairY
+			t.swiped = true
+		}
+
+	case pairPinch:
+		t.updatePinch(dist, mx, my)
+	}
+}
+
+// updatePinch publica cuánto cambió la separación desde el frame anterior
+func (t *touchState) updatePinch(dist, mx, my float64) {
+	if t.pinchDist <= 0 || dist == t.pinchDist {
+		return
+	}
+	t.pinchScale = dist / t.pinchDist
+	t.pinchX, t.pinchY = mx, my
+	t.pinched = true
+	t.pinchDist = dist
+}
+
+// distance retorna la separación entre dos dedos
+func distance(a, b ebiten.TouchID) float64 {
+	ax, ay := ebiten.TouchPosition(a)
+	bx, by := ebiten.TouchPosition(b)
+	return math.Hypot(float64(ax-bx), float64(ay-by))
+}
+
+// midpoint retorna el punto medio entre dos dedos
+func midpoint(a, b ebiten.TouchID) (float64, float64) {
+	ax, ay := ebiten.TouchPosition(a)
+	bx, by := ebiten.TouchPosition(b)
+	return float64(ax+bx) / 2, float64(ay+by) / 2
+}
+
+// TouchUsed indica si el jugador está usando la pantalla táctil (tocó y no
+// movió el mouse desde entonces)
+func (h *Handler) TouchUsed() bool {
+	return h.touch.used
+}
+
+// JustTouched retorna las posiciones de los toques que empezaron en este
+// frame y que nadie consumió todavía
+func (h *Handler) JustTouched() map[ebiten.TouchID][2]int {
+	touches := make(map[ebiten.TouchID][2]int)
+	for _, id := range h.touch.justPressed {
+		if !h.touch.consumed[id] {
+			x, y := ebiten.TouchPosition(id)
+			touches[id] = [2]int{x, y}
+		}
+	}
+	return touches
+}
+
+// ConsumeTouch marca un toque como usado por un botón en pantalla: ya no
+// cuenta como click ni mueve el cursor
+func (h *Handler) ConsumeTouch(id ebiten.TouchID) {
+	if h.touch.consumed != nil {
+		h.touch.consumed[id] = true
+	}
+}
+
+// JustSwiped retorna el desplazamiento de un deslizamiento con dos dedos
+// reconocido en este frame
+func (h *Handler) JustSwiped() (dx, dy float64, ok bool) {
+	t := &h.touch
+	return t.swipeX, t.swipeY, t.swiped
+}
+
+// JustPinched retorna cuánto cambió en este frame la separación de dos dedos
+// que pellizcan (mayor que 1 es separarlos) y su punto medio
+func (h *Handler) JustPinched() (scale, x, y float64, ok bool) {
+	t := &h.touch
+	return t.pinchScale, t.pinchX, t.pinchY, t.pinched
+}
+
+// PressAction dispara una acción como si se hubiera presionado su tecla en
+// este frame
+func (h *Handler) PressAction(action Action) {
+	if h.touch.virtual != nil {
+		h.touch.virtual[action] = true
+	}
+}
+
+// activeTouch retorna el primer dedo apoyado que no consumió un botón
+func (h *Handler) activeTouch() (ebiten.TouchID, bool) {
+	for _, id := range h.touch.ids {
+		if !h.touch.consumed[id] {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (h *Handler) touchJustPressed() bool {
+	for _, id := range h.touch.justPressed {
+		if !h.touch.consumed[id] {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) touchJustReleased() bool {
+	for _, id := range h.touch.justReleased {
+		if !h.touch.consumed[id] {
+			_, active := h.activeTouch()
+			return !active
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+// Package level define la progresión del modo niveles: cada nivel fija la
+// dificultad del jardín (población que repone el spawner, ritmo de
+// aparición y viento) y una lista de metas que se cumplen en orden. Los
+// niveles se leen de un archivo JSON; sin archivo se usan los embebidos en
+// levels.json.
+//
+//	{"levels": [{
+//	    "name": "Brisa", "objective": 15, "spawn_interval": "1s", "wind": 0.8,
+//	    "targets": [
+//	        {"kind": "reach", "count": 25},
+//	        {"kind": "hold", "count": 20, "duration": "15s"},
+//	        {"kind": "survive", "count": 15, "bats": 2},
+//	        {"kind": "zone", "count": 8, "zone": "ne", "duration": "10s"},
+//	        {"kind": "cluster", "count": 30}
+//	    ]
+//	}]}
+package level
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+)
+
+//go:embed levels.json
+var defaultLevels []byte
+
+// TargetKind es el tipo de meta
+type TargetKind string
+
+const (
+	// TargetReach se cumple al llegar a Count luciérnagas
+	TargetReach TargetKind = "reach"
+	// TargetHold se cumple al mantener Count o más durante Duration seguidos
+	TargetHold TargetKind = "hold"
+	// TargetSurvive suelta Bats murciélagos y se cumple si cuando se van
+	// quedan Count o más; si no, vuelve a empezar con otra oleada
+	TargetSurvive TargetKind = "survive"
+	// TargetZone se cumple al mantener Count o más en la zona Zone (un
+	// punto cardinal de la grilla 3×3: "ne", "c", "so"...) durante Duration
+	TargetZone TargetKind = "zone"
+	// TargetCluster se cumple al formar un cúmulo de Count o más
+	TargetCluster TargetKind = "cluster"
+)
+
+// Target es una meta de un nivel
+type Target struct {
+	Kind     TargetKind      `json:"kind"`
+	Count    int             `json:"count"`
+	Duration config.Duration `json:"duration"`
+	Bats     int             `json:"bats,omitempty"`
+	Zone     string          `json:"zone,omitempty"`
+}
+
+// Level es un nivel: la dificultad del jardín y sus metas. Los valores en
+// cero dejan el de la configuración.
+type Level struct {
+	Name          string          `json:"name"`
+	Objective     int             `json:"objective"`
+	SpawnInterval config.Duration `json:"spawn_interval"`
+	Wind          float64         `json:"wind"`
+	Targets       []Target        `json:"targets"`
+}
+
+// Campaign es la lista de niveles en el orden en que se juegan
+type Campaign struct {
+	Levels []Level `json:"levels"`
+}
+
+// Load lee y valida un archivo de niveles
+func Load(path string) (*Campaign, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return campaign, nil
+}
+
+// Default retorna los niveles que vienen con el juego
+func Default() *Campaign {
+	campaign, err := Parse(defaultLevels)
+	if err != nil {
+		panic("levels.json embebido inválido: " + err.Error())
+	}
+	return campaign
+}
+
+// Parse decodifica los niveles rechazando campos desconocidos y los valida
+// contra la configuración activa
+func Parse(data []byte) (*Campaign, error) {
+	var campaign Campaign
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&campaign); err != nil {
+		return nil, err
+	}
+
+	if err := campaign.Validate(); err != nil {
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+// Validate rechaza niveles imposibles con la configuración activa
+func (c *Campaign) Validate() error {
+	cfg := config.Get()
+
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(len(c.Levels) > 0, "no hay niveles")
+	for i, l := range c.Levels {
+		n := i + 1
+		check(l.Name != "", "nivel %d: falta name", n)
+		check(l.Objective >= 0 && l.Objective <= cfg.Fireflies.Max, "nivel %d: objective debe estar entre 0 y fireflies.max", n)
+		check(l.SpawnInterval.Duration == 0 || l.SpawnInterval.Duration >= config.MinSpawnInterval, "nivel %d: spawn_interval debe ser al menos %v", n, config.MinSpawnInterval)
+		check(l.Wind >= 0 && l.Wind <= cfg.Wind.MaxStrength, "nivel %d: wind debe estar entre 0 y wind.max_strength", n)
+		check(len(l.Targets) > 0, "nivel %d: no tiene metas", n)
+
+		for j, t := range l.Targets {
+			check(t.Count > 0 && t.Count <= cfg.Fireflies.Max, "nivel %d, meta %d: count debe estar entre 1 y fireflies.max", n, j+1)
+			switch t.Kind {
+			case TargetReach:
+			case TargetHold:
+				check(t.Duration.Duration > 0, "nivel %d, meta %d: hold necesita duration", n, j+1)
+			case TargetSurvive:
+				check(t.Bats > 0, "nivel %d, meta %d: survive necesita bats", n, j+1)
+			case TargetCluster:
+			case TargetZone:
+				check(t.Duration.Duration > 0, "nivel %d, meta %d: zone necesita duration", n, j+1)
+				_, ok := config.ZoneByName(t.Zone)
+				check(ok, "nivel %d, meta %d: zone %q desconocida (no, n, ne, o, c, e, so, s o se)", n, j+1, t.Zone)
+			default:
+				check(false, "nivel %d, meta %d: kind %q desconocido (reach, hold, survive, zone o cluster)", n, j+1, t.Kind)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
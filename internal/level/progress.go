@@ -0,0 +1,191 @@
+package level
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+)
+
+// waveTimeout es cuánto se espera a que aparezcan los murciélagos pedidos
+// antes de volver a pedirlos (el comando pudo descartarse con la cola llena)
+const waveTimeout = 2 * time.Second
+
+// Garden es lo que el progreso necesita del jardín
+type Garden interface {
+	Population() int
+	Bats() int
+	BatWave(count int)
+	// ZonePopulation es cuántas luciérnagas hay en la zona (config.ZoneAt)
+	ZonePopulation(zone int) int
+	// LargestCluster es el tamaño del cúmulo más grande detectado
+	LargestCluster() int
+}
+
+// Progress sigue el avance de un nivel: una meta a la vez, en orden. No es
+// seguro para uso concurrente; lo actualiza el hilo del juego.
+type Progress struct {
+	level  *Level
+	target int
+
+	held time.Duration
+	// inZone es la población de la zona de TargetZone y cluster el cúmulo
+	// más grande en el último Update
+	inZone  int
+	cluster int
+
+	// Estado de la oleada de TargetSurvive
+	waveAsked time.Duration
+	waveSeen  bool
+	waves     int
+}
+
+// NewProgress empieza el nivel desde su primera meta
+func NewProgress(l *Level) *Progress {
+	return &Progress{level: l}
+}
+
+// Level retorna el nivel que se está jugando
+func (p *Progress) Level() *Level {
+	return p.level
+}
+
+// Done indica si se cumplieron todas las metas
+func (p *Progress) Done() bool {
+	return p.target >= len(p.level.Targets)
+}
+
+// Current retorna la meta actual y su número (desde 1); ok es false si ya
+// se cumplieron todas
+func (p *Progress) Current() (Target, int, bool) {
+	if p.Done() {
+		return Target{}, 0, false
+	}
+	return p.level.Targets[p.target], p.target + 1, true
+}
+
+// Update avanza la meta actual con dt de juego (sin contar la pausa);
+// retorna true cuando se cumplió la última
+func (p *Progress) Update(g Garden, dt time.Duration) bool {
+	target, _, ok := p.Current()
+	if !ok {
+		return true
+	}
+	population := g.Population()
+
+	switch target.Kind {
+	case TargetReach:
+		if population >= target.Count {
+			p.next()
+		}
+
+	case TargetHold:
+		if population < target.Count {
+			p.held = 0
+			break
+		}
+		p.held += dt
+		if p.held >= target.Duration.Duration {
+			p.next()
+		}
+
+	case TargetSurvive:
+		p.updateWave(g, target, population, dt)
+
+	case TargetCluster:
+		p.cluster = g.LargestCluster()
+		if p.cluster >= target.Count {
+			p.next()
+		}
+
+	case TargetZone:
+		zone, _ := config.ZoneByName(target.Zone)
+		p.inZone = g.ZonePopulation(zone)
+		if p.inZone < target.Count {
+			p.held = 0
+			break
+		}
+		p.held += dt
+		if p.held >= target.Duration.Duration {
+			p.next()
+		}
+	}
+
+	return p.Done()
+}
+
+// updateWave pide la oleada, espera a que los murciélagos lleguen y se vayan
+// y revisa cuántas luciérnagas quedaron
+func (p *Progress) updateWave(g Garden, target Target, population int, dt time.Duration) {
+	bats := g.Bats()
+
+	switch {
+	case p.waves == 0 || (!p.waveSeen && p.waveAsked >= waveTimeout):
+		g.BatWave(target.Bats)
+		p.waves++
+		p.waveAsked, p.waveSeen = 0, false
+
+	case !p.waveSeen:
+		p.waveAsked += dt
+		p.waveSeen = bats > 0
+
+	case bats == 0 && population >= target.Count:
+		p.next()
+
+	case bats == 0:
+		// No alcanzó: otra oleada
+		p.waves = 0
+	}
+}
+
+// next pasa a la meta siguiente
+func (p *Progress) next() {
+	p.target++
+	p.held, p.inZone, p.cluster = 0, 0, 0
+	p.waveAsked, p.waveSeen, p.waves = 0, false, 0
+}
+
+// Fraction retorna el avance de la meta actual entre 0 y 1
+func (p *Progress) Fraction(population int) float64 {
+	target, _, ok := p.Current()
+	if !ok {
+		return 1
+	}
+
+	switch target.Kind {
+	case TargetHold, TargetZone:
+		return min(p.held.Seconds()/target.Duration.Seconds(), 1)
+	case TargetCluster:
+		return min(float64(p.cluster)/float64(target.Count), 1)
+	default:
+		return min(float64(population)/float64(target.Count), 1)
+	}
+}
+
+// Describe retorna la meta actual en una línea para el panel
+func (p *Progress) Describe(population int) string {
+	target, _, ok := p.Current()
+	if !ok {
+		return "¡Nivel completado!"
+	}
+
+	switch target.Kind {
+	case TargetReach:
+		return fmt.Sprintf("Llega a %d luciérnagas (%d)", target.Count, population)
+	case TargetHold:
+		return fmt.Sprintf("Mantén %d+ durante %s (%s)", target.Count,
+			target.Duration.Duration, p.held.Truncate(time.Second))
+	case TargetSurvive:
+		if p.waveSeen {
+			return fmt.Sprintf("¡Murciélagos! Que queden %d+ (%d)", target.Count, population)
+		}
+		return fmt.Sprintf("Se acercan %d murciélagos: protege %d+", target.Bats, target.Count)
+	case TargetCluster:
+		return fmt.Sprintf("Forma un cúmulo de %d (el mayor: %d)", target.Count, p.cluster)
+	case TargetZone:
+		return fmt.Sprintf("Mantén %d+ en la zona %s durante %s (%d, %s)", target.Count,
+			strings.ToUpper(target.Zone), target.Duration.Duration, p.inZone, p.held.Truncate(time.Second))
+	}
+	return ""
+}
@@ -0,0 +1,166 @@
+package logging
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// level es compartido por todos los handlers; cambiarlo afecta de inmediato
+// a todos los loggers, incluidos los ya creados con For
+var level slog.LevelVar
+
+// Options configura la salida de los logs
+type Options struct {
+	Level      slog.Level
+	Quiet      bool   // solo advertencias y errores por stderr
+	File       string // archivo adicional (vacío = ninguno)
+	MaxSizeMB  int    // tamaño a partir del cual se rota el archivo
+	MaxBackups int    // archivos rotados que se conservan
+	JSON       bool   // formato JSON en lugar de texto clave=valor
+}
+
+// Setup instala el logger por defecto de slog (y con él el del paquete log).
+// El Closer retornado cierra el archivo, si lo hay.
+func Setup(opts Options) (io.Closer, error) {
+	level.Set(opts.Level)
+
+	var out io.Writer = os.Stderr
+	var closer io.Closer = nopCloser{}
+
+	if opts.File != "" {
+		file, err := NewRotatingFile(opts.File, int64(opts.MaxSizeMB)<<20, opts.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		out = io.MultiWriter(os.Stderr, file)
+		closer = file
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: &level}
+	var handler slog.Handler
+	if opts.JSON {
+		handler = slog.NewJSONHandler(out, handlerOpts)
+	} else {
+		handler = slog.NewTextHandler(out, handlerOpts)
+	}
+
+	if opts.Quiet {
+		handler = &quietHandler{Handler: handler}
+	}
+
+	slog.SetDefault(slog.New(handler))
+	return closer, nil
+}
+
+// For retorna un logger con el campo subsystem
+func For(subsystem string) *slog.Logger {
+	return slog.Default().With("subsystem", subsystem)
+}
+
+// SetLevel cambia el nivel en tiempo de ejecución
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+func GetLevel() slog.Level {
+	return level.Level()
+}
+
+// ParseLevel acepta debug, info, warn y error
+func ParseLevel(s string) (slog.Level, error) {
+	var l slog.Level
+	err := l.UnmarshalText([]byte(strings.TrimSpace(s)))
+	return l, err
+}
+
+// Fatal registra el error y termina el proceso (reemplaza a log.Fatalf)
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// quietHandler descarta todo lo que no sea advertencia o error aunque el
+// nivel se baje en caliente (modo silencioso para ejecuciones headless)
+type quietHandler struct {
+	slog.Handler
+}
+
+func (h *quietHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return l >= slog.LevelWarn && h.Handler.Enabled(ctx, l)
+}
+
+func (h *quietHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &quietHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *quietHandler) WithGroup(name string) slog.Handler {
+	return &quietHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+// Flags son los flags de logging comunes a todos los binarios
+type Flags struct {
+	verbose    *bool
+	quiet      *bool
+	level      *string
+	file       *string
+	maxSizeMB  *int
+	maxBackups *int
+	json       *bool
+}
+
+// BindFlags registra -v, -quiet, -log-level, -log-file, -log-max-mb,
+// -log-backups y -log-json
+func BindFlags(fs *flag.FlagSet) *Flags {
+	return &Flags{
+		verbose:    fs.Bool("v", false, "logs detallados (nivel debug)"),
+		quiet:      fs.Bool("quiet", false, "solo advertencias y errores"),
+		level:      fs.String("log-level", "", "nivel de log: debug, info, warn o error (por defecto el de la configuración)"),
+		file:       fs.String("log-file", "", "escribir también los logs en este archivo, con rotación"),
+		maxSizeMB:  fs.Int("log-max-mb", 10, "tamaño en MB a partir del cual se rota -log-file"),
+		maxBackups: fs.Int("log-backups", 3, "archivos rotados que se conservan"),
+		json:       fs.Bool("log-json", false, "logs en formato JSON"),
+	}
+}
+
+// Setup combina los flags con el nivel de la configuración: -v y -quiet
+// tienen prioridad sobre -log-level, y éste sobre configLevel
+func (f *Flags) Setup(configLevel string) (io.Closer, error) {
+	levelName := configLevel
+	if *f.level != "" {
+		levelName = *f.level
+	}
+	l, err := ParseLevel(levelName)
+	if err != nil {
+		return nil, fmt.Errorf("nivel de log inválido %q: %w", levelName, err)
+	}
+
+	switch {
+	case *f.verbose:
+		l = slog.LevelDebug
+	case *f.quiet:
+		l = slog.LevelWarn
+	}
+
+	return Setup(Options{
+		Level:      l,
+		Quiet:      *f.quiet,
+		File:       *f.file,
+		MaxSizeMB:  *f.maxSizeMB,
+		MaxBackups: *f.maxBackups,
+		JSON:       *f.json,
+	})
+}
+
+// Quiet indica si se pidió el modo silencioso (para omitir banners)
+func (f *Flags) Quiet() bool {
+	return *f.quiet
+}
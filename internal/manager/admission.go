@@ -0,0 +1,63 @@
+package manager
+
+import "sync/atomic"
+
+// admission lleva la población viva del jardín y decide cada spawn. El
+// agregador cuenta estados que pueden llegar un tick tarde y el mundo se
+// consulta y se modifica en pasos separados: con ninguno de los dos se
+// puede aplicar el límite sin pasarse cuando spawnean varios a la vez.
+type admission struct {
+	// live son las luciérnagas que están en el mundo o ya tienen lugar
+	// reservado; sube al admitir y baja al sacarlas del mundo
+	live     atomic.Int64
+	admitted atomic.Uint64
+	rejected atomic.Uint64
+}
+
+// SpawnCounts son la población viva según el manager y los spawns
+// admitidos y rechazados por el límite
+type SpawnCounts struct {
+	Live               int
+	Cap                int
+	Admitted, Rejected uint64
+}
+
+// admitFirefly reserva un lugar para una luciérnaga nueva si la población
+// viva está por debajo de GetSpawnCap. Todo spawn pasa por aquí; si retorna
+// true quien la llama debe agregarla al mundo.
+func (fm *FireflyManager) admitFirefly() bool {
+	limit := int64(fm.GetSpawnCap())
+	for {
+		live := fm.admission.live.Load()
+		if live >= limit {
+			fm.admission.rejected.Add(1)
+			return false
+		}
+		if fm.admission.live.CompareAndSwap(live, live+1) {
+			fm.admission.admitted.Add(1)
+			return true
+		}
+	}
+}
+
+// releaseFirefly devuelve el lugar de una luciérnaga que salió del mundo
+func (fm *FireflyManager) releaseFirefly() {
+	fm.admission.live.Add(-1)
+}
+
+// GetLiveFireflies retorna la población viva según el manager: a diferencia
+// de GetFireflyCount no espera a que el agregador reciba los estados
+func (fm *FireflyManager) GetLiveFireflies() int {
+	return int(fm.admission.live.Load())
+}
+
+// GetSpawnCounts retorna la población viva, el límite vigente y cuántos
+// spawns se admitieron y rechazaron
+func (fm *FireflyManager) GetSpawnCounts() SpawnCounts {
+	return SpawnCounts{
+		Live:     fm.GetLiveFireflies(),
+		Cap:      fm.GetSpawnCap(),
+		Admitted: fm.admission.admitted.Load(),
+		Rejected: fm.admission.rejected.Load(),
+	}
+}
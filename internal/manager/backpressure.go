@@ -0,0 +1,103 @@
+package manager
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+const (
+	// dropWindow son los segundos de estadísticas en los que se busca el
+	// pico de descartes
+	dropWindow = 60
+	// topDroppers son las luciérnagas que se listan por descartes
+	topDroppers = 5
+)
+
+// highWater guarda lo más llena que estuvo la cola de un canal. Se anota
+// del lado que lee, contando el elemento recién sacado, así no hace falta
+// otra goroutine muestreando las longitudes.
+type highWater struct {
+	peak atomic.Int64
+}
+
+func (h *highWater) observe(n int) {
+	for {
+		peak := h.peak.Load()
+		if int64(n) <= peak || h.peak.CompareAndSwap(peak, int64(n)) {
+			return
+		}
+	}
+}
+
+func (h *highWater) get() int {
+	return int(h.peak.Load())
+}
+
+// DropCount son los estados descartados de una luciérnaga desde que nació
+type DropCount struct {
+	ID      int
+	Dropped uint32
+}
+
+// Backpressure muestra la contrapresión de la simulación: cuántos estados
+// se descartan por segundo y con cuánta población, qué luciérnagas pierden
+// más y lo más llenos que estuvieron los canales
+type Backpressure struct {
+	// DropRate son los descartes del último segundo, con Population vivas
+	DropRate   uint64
+	Population int
+	// PeakRate es el peor segundo de los últimos dropWindow, con
+	// PeakPopulation vivas
+	PeakRate       uint64
+	PeakPopulation int
+
+	TopDroppers []DropCount
+
+	StatePeak, CommandPeak, JobPeak, ResultPeak int
+}
+
+// backpressure arma la foto desde las estadísticas por segundo, el mapa
+// del agregador y las marcas de los canales
+func (fm *FireflyManager) backpressure() Backpressure {
+	var bp Backpressure
+
+	samples := fm.stats.Recent(dropWindow)
+	if n := len(samples); n > 0 {
+		bp.DropRate, bp.Population = samples[n-1].DroppedStates, samples[n-1].Population
+	}
+	for _, s := range samples {
+		if s.DroppedStates > bp.PeakRate {
+			bp.PeakRate, bp.PeakPopulation = s.DroppedStates, s.Population
+		}
+	}
+
+	bp.TopDroppers = fm.aggregator.TopDroppers(topDroppers)
+	bp.StatePeak = fm.aggregator.GetStatePeak()
+	bp.CommandPeak = fm.commandPeak.get()
+	bp.JobPeak, bp.ResultPeak = fm.workerPool.QueuePeaks()
+	return bp
+}
+
+// TopDroppers retorna hasta n luciérnagas vivas con más estados
+// descartados, de mayor a menor
+func (sa *StateAggregator) TopDroppers(n int) []DropCount {
+	sa.statesMux.RLock()
+	var top []DropCount
+	for id, state := range sa.states {
+		if state.Dropped > 0 {
+			top = append(top, DropCount{ID: id, Dropped: state.Dropped})
+		}
+	}
+	sa.statesMux.RUnlock()
+
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Dropped != top[j].Dropped {
+			return top[i].Dropped > top[j].Dropped
+		}
+		return top[i].ID < top[j].ID
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
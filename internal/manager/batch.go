@@ -0,0 +1,208 @@
+package manager
+
+import (
+	"cmp"
+	"context"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+// batchSim es la simulación por lotes (batch.enabled): en lugar de una
+// goroutine por luciérnaga, unos pocos shards las avanzan con un solo ticker
+// cada uno y publican el lote entero al agregador (StateAggregator.Publish).
+// Con miles de luciérnagas se ahorran miles de goroutines y timers, y ningún
+// estado se descarta por la cola llena. Los shards se lanzan con la primera
+// luciérnaga de cada contexto (Start o resume) y terminan con él.
+type batchSim struct {
+	mux    sync.Mutex
+	ctx    context.Context
+	shards []*simShard
+}
+
+// batchMember es una luciérnaga de un lote con su ruta de control
+type batchMember struct {
+	firefly *core.Firefly
+	route   *route
+}
+
+// simShard es un lote. Las luciérnagas nuevas esperan en incoming hasta el
+// próximo tick; closed indica que el shard ya terminó y no toma más. size
+// cuenta las suyas, para repartir las nuevas al que tiene menos.
+type simShard struct {
+	mux      sync.Mutex
+	incoming []batchMember
+	closed   bool
+	size     atomic.Int64
+}
+
+// push suma una luciérnaga al shard; false si ya terminó
+func (s *simShard) push(m batchMember) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.closed {
+		return false
+	}
+	s.incoming = append(s.incoming, m)
+	s.size.Add(1)
+	return true
+}
+
+// take retorna las luciérnagas que llegaron desde el último tick
+func (s *simShard) take() []batchMember {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	incoming := s.incoming
+	s.incoming = nil
+	return incoming
+}
+
+// close marca el shard como terminado y retorna las que no llegó a tomar
+func (s *simShard) close() []batchMember {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.closed = true
+	incoming := s.incoming
+	s.incoming = nil
+	return incoming
+}
+
+// BatchStats es la foto de la simulación por lotes: cuántos shards corren,
+// cuántas luciérnagas avanzan y el buzón del agregador
+type BatchStats struct {
+	Enabled   bool
+	Shards    int
+	Fireflies int
+	// Pending son los estados que esperan en el buzón, Batches los lotes
+	// recibidos y Coalesced los estados reemplazados por uno más nuevo
+	// antes de aplicarse
+	Pending   int
+	Batches   uint64
+	Coalesced uint64
+}
+
+// GetBatchStats retorna el estado de la simulación por lotes
+func (fm *FireflyManager) GetBatchStats() BatchStats {
+	stats := BatchStats{Enabled: fm.batched()}
+	stats.Pending, stats.Batches, stats.Coalesced = fm.aggregator.GetInboxStats()
+
+	fm.batches.mux.Lock()
+	defer fm.batches.mux.Unlock()
+	if fm.batches.ctx == nil || fm.batches.ctx.Err() != nil {
+		return stats
+	}
+	stats.Shards = len(fm.batches.shards)
+	for _, shard := range fm.batches.shards {
+		stats.Fireflies += int(shard.size.Load())
+	}
+	return stats
+}
+
+// batched indica si las luciérnagas corren por lotes; el modo sincrónico
+// sigue con una goroutine por luciérnaga porque Step las avanza de a una
+func (fm *FireflyManager) batched() bool {
+	return config.Get().Batch.Enabled && !fm.synchronous
+}
+
+// batchShards retorna cuántos shards lanzar: batch.shards o uno por
+// procesador
+func batchShards() int {
+	if n := config.Get().Batch.Shards; n > 0 {
+		return n
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// add suma una luciérnaga al shard con menos, lanzando los shards si ctx es
+// un contexto nuevo. Se llama desde runFirefly, con lifecycleMux tomado.
+func (b *batchSim) add(fm *FireflyManager, ctx context.Context, m batchMember, tick time.Duration, dt float64) {
+	b.mux.Lock()
+	if b.ctx != ctx {
+		b.ctx = ctx
+		b.shards = make([]*simShard, batchShards())
+		for i := range b.shards {
+			b.shards[i] = &simShard{}
+			fm.wg.Add(1)
+			fm.fireflyWG.Add(1)
+			go fm.runShard(ctx, b.shards[i], tick, dt)
+		}
+	}
+	shard := slices.MinFunc(b.shards, func(a, c *simShard) int {
+		return cmp.Compare(a.size.Load(), c.size.Load())
+	})
+	b.mux.Unlock()
+
+	if !shard.push(m) {
+		// El shard ya terminó (Stop): queda en el mundo como una cancelada
+		fm.fireflyDone(ctx, m.firefly, m.route, false)
+	}
+}
+
+// shardExit es una luciérnaga que dejó su lote en este tick
+type shardExit struct {
+	member batchMember
+	died   bool
+}
+
+// runShard avanza las luciérnagas de un shard cada tick: atiende su canal
+// de control, las mueve y publica el lote. Las que terminan se limpian
+// después de publicar, así su muerte llega al agregador antes que el
+// evento. Al cancelarse ctx aplica lo que quedó en sus canales y las deja
+// en el mundo, como Run.
+func (fm *FireflyManager) runShard(ctx context.Context, shard *simShard, tick time.Duration, dt float64) {
+	defer fm.wg.Done()
+	defer fm.fireflyWG.Done()
+	defer fm.goroutines.track(SubsystemBatches)()
+
+	ticker := fm.clock.NewTicker(tick)
+	defer ticker.Stop()
+
+	var (
+		members []batchMember
+		batch   []core.FireflyState
+		exits   []shardExit
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			members = append(members, shard.close()...)
+			for _, m := range members {
+				m.firefly.Drain(m.route.control)
+				fm.fireflyDone(ctx, m.firefly, m.route, false)
+			}
+			return
+
+		case <-ticker.C():
+			members = append(members, shard.take()...)
+			batch, exits = batch[:0], exits[:0]
+
+			kept := members[:0]
+			for _, m := range members {
+				switch {
+				case m.firefly.Serve(&batch, m.route.control):
+					exits = append(exits, shardExit{member: m})
+				case m.firefly.Advance(&batch, dt):
+					exits = append(exits, shardExit{member: m, died: true})
+				default:
+					kept = append(kept, m)
+				}
+			}
+			clear(members[len(kept):])
+			members = kept
+
+			fm.aggregator.Publish(batch)
+			for _, exit := range exits {
+				fm.fireflyDone(ctx, exit.member.firefly, exit.member.route, exit.died)
+			}
+			shard.size.Add(-int64(len(exits)))
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package manager
+
+import (
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// Los murciélagos viven en el mundo como KindBat pero los anima una sola
+// goroutine, no una por murciélago: cazan sobre el snapshot del agregador y
+// dejan sus estados al render en un puntero atómico.
+
+// launchBatWave suelta count murciélagos desde los bordes. Se ejecuta en
+// commandLoop.
+func (fm *FireflyManager) launchBatWave(count int) {
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
+
+	for i := 0; i < count; i++ {
+		entry := core.RandomBatEntry()
+		fm.world.Add(core.NewBat(fm.world.NextID(), entry.X, entry.Y))
+	}
+
+	fm.log.Info("oleada de murciélagos", "bats", count)
+	fm.notify(NoticeWarning, "🦇 Llegan %d murciélagos", count)
+	fm.events.Publish(Event{Type: EventBatWave, Count: count})
+}
+
+// LaunchBatWave pide una oleada por el canal de comandos sin bloquear;
+// retorna false si la cola estaba llena
+func (fm *FireflyManager) LaunchBatWave(count int) bool {
+	return fm.Send(Command{Type: CommandBatWave, Data: count})
+}
+
+func (fm *FireflyManager) batLoop() {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemBats)()
+
+	ticker := fm.clock.NewTicker(time.Second / time.Duration(config.Get().SimulationTPS))
+	defer ticker.Stop()
+
+	dt := 1 / float64(config.Get().SimulationTPS)
+
+	for {
+		select {
+		case <-fm.ctx.Done():
+			return
+
+		case <-ticker.C():
+			fm.updateBats(dt)
+		}
+	}
+}
+
+// updateBats mueve cada murciélago hacia su presa, se come las que alcanza
+// y quita los que ya se fueron
+func (fm *FireflyManager) updateBats(dt float64) {
+	entities := fm.world.Snapshot(core.KindBat)
+	if len(entities) == 0 {
+		fm.bats.Store(nil)
+		return
+	}
+
+	states := fm.aggregator.GetSnapshot()
+	defer ReleaseStates(states)
+	lanterns := fm.getLanternsSnapshot()
+	eatRadius := config.Get().Bats.EatRadius
+
+	eaten := make(map[int]bool)
+	bats := make([]core.BatState, 0, len(entities))
+	for _, e := range entities {
+		bat := e.(*core.Bat)
+
+		prey, found := nearestPrey(bat.Position(), states, lanterns, eaten)
+		if found {
+			bat.Fly(&prey.Position, dt)
+		} else {
+			bat.Fly(nil, dt)
+		}
+
+		if found && bat.Hungry() && utils.Distance(bat.Position(), prey.Position) <= eatRadius {
+			if fm.takeFirefly(prey.ID, EventEaten) {
+				eaten[prey.ID] = true
+				bat.Eat()
+			}
+		}
+
+		if bat.Gone() {
+			fm.world.Remove(bat.ID())
+			continue
+		}
+		bats = append(bats, bat.State())
+	}
+
+	fm.bats.Store(&bats)
+}
+
+// nearestPrey busca la luciérnaga más cercana que no esté bajo un farol:
+// la luz los espanta
+func nearestPrey(from utils.Vector2D, states []core.FireflyState, lanterns []*core.Lantern, eaten map[int]bool) (core.FireflyState, bool) {
+	var best core.FireflyState
+	bestDist, found := 0.0, false
+
+	for _, state := range states {
+		if eaten[state.ID] || underLantern(state.Position, lanterns) {
+			continue
+		}
+		if d := utils.Distance(from, state.Position); !found || d < bestDist {
+			best, bestDist, found = state, d, true
+		}
+	}
+	return best, found
+}
+
+func underLantern(pos utils.Vector2D, lanterns []*core.Lantern) bool {
+	for _, lantern := range lanterns {
+		if utils.Distance(pos, lantern.Position) < lantern.Radius {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBats retorna los murciélagos del último tick; no debe modificarse
+func (fm *FireflyManager) GetBats() []core.BatState {
+	if bats := fm.bats.Load(); bats != nil {
+		return *bats
+	}
+	return nil
+}
+
+// GetBatCount retorna cuántos murciélagos hay en el jardín
+func (fm *FireflyManager) GetBatCount() int {
+	return fm.world.Count(core.KindBat)
+}
@@ -0,0 +1,141 @@
+package manager
+
+import (
+	"math"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// BurstPattern es la forma en que una ráfaga reparte sus luciérnagas
+// alrededor del punto
+type BurstPattern string
+
+const (
+	// BurstCluster es la nube al azar de siempre
+	BurstCluster BurstPattern = "cluster"
+	BurstRing    BurstPattern = "ring"
+	BurstSpiral  BurstPattern = "spiral"
+	// BurstWind es una línea que sale del punto a favor del viento
+	BurstWind BurstPattern = "wind"
+)
+
+// BurstPatterns son las formas en el orden en que las recorre Next
+var BurstPatterns = []BurstPattern{BurstCluster, BurstRing, BurstSpiral, BurstWind}
+
+const (
+	burstSpread     = 40.0
+	burstRingRadius = 40.0
+	burstSpiralStep = 14.0
+	burstLineStep   = 18.0
+	// goldenAngle separa las vueltas de la espiral sin que se alineen
+	goldenAngle = 2.399963229728653
+)
+
+// Next retorna la forma siguiente; después de la última vuelve a la primera.
+// La forma vacía cuenta como la nube.
+func (p BurstPattern) Next() BurstPattern {
+	if p == "" {
+		p = BurstCluster
+	}
+	for i, pattern := range BurstPatterns {
+		if pattern == p {
+			return BurstPatterns[(i+1)%len(BurstPatterns)]
+		}
+	}
+	return BurstCluster
+}
+
+// offset retorna dónde nace la luciérnaga i de count respecto del centro.
+// Salvo la nube, las formas van de adentro hacia afuera, así la ráfaga
+// escalonada se abre. La nube sortea al pedirla: se llama en orden y justo
+// antes de cada spawn para no adelantar el generador compartido.
+func (p BurstPattern) offset(i, count int, wind core.WindSnapshot) utils.Vector2D {
+	switch p {
+	case BurstRing:
+		angle := 2 * math.Pi * float64(i) / float64(max(count, 1))
+		return utils.NewVector2D(math.Cos(angle), math.Sin(angle)).Mul(burstRingRadius)
+
+	case BurstSpiral:
+		angle := goldenAngle * float64(i)
+		radius := burstSpiralStep * math.Sqrt(float64(i+1))
+		return utils.NewVector2D(math.Cos(angle), math.Sin(angle)).Mul(radius)
+
+	case BurstWind:
+		dir := utils.NewVector2D(1, 0)
+		if wind.Force.Magnitude() > 0 {
+			dir = wind.Force.Normalize()
+		}
+		return dir.Mul(burstLineStep * float64(i))
+
+	default:
+		return utils.NewVector2D(utils.RandomFloat(-burstSpread, burstSpread), utils.RandomFloat(-burstSpread, burstSpread))
+	}
+}
+
+// SpawnBurst suelta hasta count luciérnagas en nube alrededor del punto
+func (fm *FireflyManager) SpawnBurst(x, y float64, count int) {
+	fm.SpawnBurstPattern(x, y, count, BurstCluster)
+}
+
+// SpawnBurstPattern suelta hasta count luciérnagas con la forma pedida, una
+// cada spawn.burst_stagger para que la ráfaga se vea abrirse; se corta en
+// cuanto el límite rechaza una o el manager se detiene. Bloquea mientras
+// dura: quien no pueda esperar la llama en su propia goroutine. En modo
+// sincrónico salen todas juntas.
+func (fm *FireflyManager) SpawnBurstPattern(x, y float64, count int, pattern BurstPattern) {
+	wind := fm.wind.Snapshot()
+	stagger := config.Get().Spawn.BurstStagger.Duration
+
+	var tick <-chan time.Time
+	if stagger > 0 && !fm.synchronous && count > 1 {
+		ticker := fm.clock.NewTicker(stagger)
+		defer ticker.Stop()
+		tick = ticker.C()
+	}
+
+	for i := 0; i < count; i++ {
+		if i > 0 && tick != nil {
+			select {
+			case <-fm.ctx.Done():
+				return
+			case <-tick:
+			}
+		}
+		o := pattern.offset(i, count, wind)
+		if !fm.spawnFirefly(x+o.X, y+o.Y) {
+			return
+		}
+	}
+}
+
+// burst aplica un CommandSpawnBurst. Escalonada, la ráfaga corre en su
+// propia goroutine para no trabar el resto de los comandos.
+func (fm *FireflyManager) burst(req BurstRequest) {
+	pattern := req.Pattern
+	if pattern == "" {
+		pattern = BurstCluster
+	}
+
+	if fm.synchronous || config.Get().Spawn.BurstStagger.Duration <= 0 {
+		fm.SpawnBurstPattern(req.Position.X, req.Position.Y, req.Count, pattern)
+		return
+	}
+
+	// Como en spawnFirefly, el Add se hace con stopMux tomado para que no
+	// se cruce con el Wait de Stop
+	fm.stopMux.RLock()
+	defer fm.stopMux.RUnlock()
+	if fm.ctx.Err() != nil {
+		return
+	}
+
+	fm.wg.Add(1)
+	go func() {
+		defer fm.wg.Done()
+		defer fm.goroutines.track(SubsystemSpawner)()
+		fm.SpawnBurstPattern(req.Position.X, req.Position.Y, req.Count, pattern)
+	}()
+}
@@ -0,0 +1,45 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// Una ráfaga pedida mientras el manager se detiene no debe lanzar su
+// goroutine después del Wait de Stop, ni la de un farol después de Stop
+func TestBurstDuringStop(t *testing.T) {
+	prev := config.Get()
+	cfg := config.Default()
+	cfg.Fireflies.Initial = 0
+	cfg.Fireflies.Max = 100000
+	cfg.Spawn.AutoSpawn = false
+	cfg.Spawn.BurstStagger = config.Duration{Duration: time.Millisecond}
+	config.Set(cfg)
+	t.Cleanup(func() { config.Set(prev) })
+
+	fm := NewFireflyManager()
+	fm.Start()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; !fm.Stopped(); i++ {
+			fm.burst(BurstRequest{Position: utils.Vector2D{X: float64(100 + i%400), Y: 300}, Count: 3})
+		}
+	}()
+	time.Sleep(20 * time.Millisecond)
+	fm.Stop()
+	<-done
+
+	count := fm.GetFireflyCount()
+	if fm.spawnFirefly(400, 300) {
+		t.Fatal("spawnFirefly lanzó una luciérnaga después de Stop")
+	}
+	fm.AddLanternWithRadius(400, 300, cfg.Lanterns.Radius)
+	if got := fm.GetFireflyCount(); got != count {
+		t.Fatalf("tras Stop la población pasó de %d a %d", count, got)
+	}
+}
@@ -0,0 +1,134 @@
+package manager
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+// chaosStallJobID identifica los trabajos que traban un worker a propósito
+const chaosStallJobID = -2
+
+// ChaosCounts son las fallas inyectadas por el modo caos y las goroutines
+// caídas que el manager limpió
+type ChaosCounts struct {
+	Enabled bool
+	// Delayed y Dropped son estados demorados y descartados a propósito
+	Delayed, Dropped uint64
+	// Crashed son goroutines de luciérnagas tiradas; Reaped, las que el
+	// manager detectó y sacó del mundo y del agregador
+	Crashed, Reaped uint64
+	// Stalled son workers trabados
+	Stalled uint64
+}
+
+// chaosCounters son los contadores propios del manager; los de los estados
+// los lleva core
+type chaosCounters struct {
+	crashed atomic.Uint64
+	reaped  atomic.Uint64
+	stalled atomic.Uint64
+}
+
+// GetChaosCounts retorna los contadores del modo caos
+func (fm *FireflyManager) GetChaosCounts() ChaosCounts {
+	delayed, dropped := core.GetChaosCounts()
+	return ChaosCounts{
+		Enabled: config.Get().Chaos.Enabled,
+		Delayed: delayed,
+		Dropped: dropped,
+		Crashed: fm.chaos.crashed.Load(),
+		Reaped:  fm.chaos.reaped.Load(),
+		Stalled: fm.chaos.stalled.Load(),
+	}
+}
+
+// chaosLoop tira goroutines de luciérnagas y traba workers a los intervalos
+// de la configuración. La demora y el descarte de estados los hace cada
+// luciérnaga al publicar (ver core.chaosSend).
+func (fm *FireflyManager) chaosLoop() {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemChaos)()
+
+	chaos := config.Get().Chaos
+	var crashC, stallC <-chan time.Time
+	if chaos.KillInterval.Duration > 0 {
+		ticker := fm.clock.NewTicker(chaos.KillInterval.Duration)
+		defer ticker.Stop()
+		crashC = ticker.C()
+	}
+	if chaos.StallInterval.Duration > 0 {
+		ticker := fm.clock.NewTicker(chaos.StallInterval.Duration)
+		defer ticker.Stop()
+		stallC = ticker.C()
+	}
+
+	for {
+		select {
+		case <-fm.ctx.Done():
+			return
+
+		case <-crashC:
+			fm.crashRandomFirefly()
+
+		case <-stallC:
+			fm.stallWorker(config.Get().Chaos.Stall.Duration)
+		}
+	}
+}
+
+// crashRandomFirefly tira la goroutine de una luciérnaga al azar: termina
+// sin publicar su muerte y sin soltar su ruta, como si hubiera fallado
+func (fm *FireflyManager) crashRandomFirefly() {
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
+
+	routes := fm.routes.all()
+	if len(routes) == 0 {
+		return
+	}
+	if routes[rand.IntN(len(routes))].send(core.Control{Kind: core.ControlCrash}) {
+		fm.chaos.crashed.Add(1)
+	}
+}
+
+// stallWorker ocupa un worker durante d; se libera antes si el manager se
+// detiene, así Stop nunca queda esperando
+func (fm *FireflyManager) stallWorker(d time.Duration) {
+	submitted := fm.workerPool.Submit(Job{
+		ID: chaosStallJobID,
+		Task: func() interface{} {
+			select {
+			case <-time.After(d):
+			case <-fm.ctx.Done():
+			}
+			return nil
+		},
+	})
+	if submitted {
+		fm.chaos.stalled.Add(1)
+	}
+}
+
+// reapFirefly limpia una luciérnaga cuya goroutine terminó sin morir de
+// vieja ni ser quitada: la saca del mundo y le manda su muerte al agregador
+// por el mismo canal, así llega después de sus últimos estados. Si ya no
+// estaba en el mundo no hace nada.
+func (fm *FireflyManager) reapFirefly(id int) {
+	if _, ok := fm.world.Remove(id); !ok {
+		return
+	}
+	fm.releaseFirefly()
+	select {
+	case fm.aggregator.GetStateChannel() <- core.FireflyState{ID: id}:
+	case <-fm.ctx.Done():
+		return
+	}
+
+	fm.chaos.reaped.Add(1)
+	fm.events.Publish(Event{Type: EventDeath, ID: id})
+	fm.log.Warn("goroutine de luciérnaga caída, quitada del jardín", "firefly", id)
+}
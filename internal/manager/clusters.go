@@ -0,0 +1,211 @@
+package manager
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// Cluster es un cúmulo de luciérnagas detectado por DetectClusters
+type Cluster struct {
+	Size   int
+	Center utils.Vector2D
+	// Hull es la envolvente convexa, en sentido antihorario
+	Hull []utils.Vector2D
+	// Brightness es el brillo promedio de sus luciérnagas
+	Brightness float64
+}
+
+// ClusterSet es el resultado de una pasada de detección
+type ClusterSet struct {
+	// Clusters van de mayor a menor
+	Clusters []Cluster
+	// Noise son las luciérnagas que no quedaron en ningún cúmulo
+	Noise int
+	At    time.Time
+}
+
+// Largest retorna el tamaño del cúmulo más grande, 0 si no hay ninguno
+func (c *ClusterSet) Largest() int {
+	if c == nil || len(c.Clusters) == 0 {
+		return 0
+	}
+	return c.Clusters[0].Size
+}
+
+// DetectClusters agrupa los estados con DBSCAN: una luciérnaga con al menos
+// minPoints luciérnagas (ella incluida) a menos de radius es núcleo, y los
+// núcleos alcanzables entre sí forman un cúmulo junto con las vecinas de
+// borde. Las vecinas se buscan en un spatialIndex de celdas de lado radius,
+// así que cada consulta mira solo las 9 celdas de alrededor.
+func DetectClusters(states []core.FireflyState, radius float64, minPoints int) ClusterSet {
+	index := newSpatialIndex(states, radius)
+	neighbors := func(dst []int, i int) []int {
+		return index.near(dst, states[i].Position, radius)
+	}
+
+	const (
+		unvisited = -2
+		noise     = -1
+	)
+	labels := make([]int, len(states))
+	for i := range labels {
+		labels[i] = unvisited
+	}
+
+	var (
+		members [][]int
+		near    []int
+		queue   []int
+	)
+	for i := range states {
+		if labels[i] != unvisited {
+			continue
+		}
+		near = neighbors(near[:0], i)
+		if len(near) < minPoints {
+			labels[i] = noise
+			continue
+		}
+
+		id := len(members)
+		labels[i] = id
+		cluster := []int{i}
+		queue = append(queue[:0], near...)
+		for len(queue) > 0 {
+			j := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+
+			switch labels[j] {
+			case noise:
+				// Era ruido pero está al alcance de un núcleo: es borde
+				labels[j] = id
+				cluster = append(cluster, j)
+				continue
+			case unvisited:
+				labels[j] = id
+				cluster = append(cluster, j)
+			default:
+				continue
+			}
+
+			near = neighbors(near[:0], j)
+			if len(near) >= minPoints {
+				for _, k := range near {
+					if labels[k] == unvisited || labels[k] == noise {
+						queue = append(queue, k)
+					}
+				}
+			}
+		}
+		members = append(members, cluster)
+	}
+
+	set := ClusterSet{At: time.Now()}
+	clustered := 0
+	points := make([]utils.Vector2D, 0, 64)
+	for _, m := range members {
+		points = points[:0]
+		var center utils.Vector2D
+		brightness := 0.0
+		for _, i := range m {
+			p := states[i].Position
+			points = append(points, p)
+			center.X += p.X
+			center.Y += p.Y
+			brightness += states[i].Brightness
+		}
+		n := float64(len(m))
+		set.Clusters = append(set.Clusters, Cluster{
+			Size:       len(m),
+			Center:     utils.Vector2D{X: center.X / n, Y: center.Y / n},
+			Hull:       convexHull(points),
+			Brightness: brightness / n,
+		})
+		clustered += len(m)
+	}
+	slices.SortFunc(set.Clusters, func(a, b Cluster) int { return b.Size - a.Size })
+	set.Noise = len(states) - clustered
+	return set
+}
+
+// convexHull retorna la envolvente convexa de points en sentido antihorario
+// (cadena monótona de Andrew); reordena points
+func convexHull(points []utils.Vector2D) []utils.Vector2D {
+	slices.SortFunc(points, func(a, b utils.Vector2D) int {
+		if a.X != b.X {
+			return cmp.Compare(a.X, b.X)
+		}
+		return cmp.Compare(a.Y, b.Y)
+	})
+	points = slices.CompactFunc(points, func(a, b utils.Vector2D) bool { return a == b })
+	if len(points) < 3 {
+		return slices.Clone(points)
+	}
+
+	cross := func(o, a, b utils.Vector2D) float64 {
+		return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+	}
+	hull := make([]utils.Vector2D, 0, 2*len(points))
+	for _, p := range points {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(points) - 2; i >= 0; i-- {
+		p := points[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	return hull[:len(hull)-1]
+}
+
+// GetClusters retorna la última detección de cúmulos; nil antes de la
+// primera. No se debe modificar.
+func (fm *FireflyManager) GetClusters() *ClusterSet {
+	return fm.clusterSet.Load()
+}
+
+// clusterSampler toma un snapshot cada clusters.interval y corre la
+// detección en el worker pool, como el mapa de calor; el resultado queda
+// para GetClusters
+func (fm *FireflyManager) clusterSampler() {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemClusters)()
+
+	ticker := fm.clock.NewTicker(config.Get().Clusters.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-fm.ctx.Done():
+			return
+
+		case <-ticker.C():
+			states := fm.aggregator.GetSnapshot()
+			fm.clusterJobID++
+			submitted := fm.workerPool.Submit(Job{
+				ID: fm.clusterJobID,
+				Task: func() interface{} {
+					cfg := config.Get().Clusters
+					set := DetectClusters(states, cfg.Radius, cfg.MinPoints)
+					set.At = fm.clock.Now()
+					ReleaseStates(states)
+					fm.clusterSet.Store(&set)
+					return nil
+				},
+			})
+			if !submitted {
+				ReleaseStates(states)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package manager
+
+import (
+	"math"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/plugin"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// ecologyPolicyName es la política que usa el spawner en modo ecológico
+	ecologyPolicyName = "ecology"
+	// immigration son las luciérnagas por segundo que llegan de afuera: sin
+	// ellas una población extinguida no vuelve nunca
+	immigration = 0.05
+)
+
+// carryingCapacity es la población que sostiene el jardín con lanterns
+// faroles, sin pasar el límite de población
+func carryingCapacity(lanterns, limit int) int {
+	eco := config.Get().Ecology
+	return min(eco.Capacity+eco.LanternCapacity*lanterns, limit)
+}
+
+// GetCarryingCapacity retorna la capacidad del jardín en modo ecológico, o
+// 0 si el modo está apagado
+func (fm *FireflyManager) GetCarryingCapacity() int {
+	if !config.Get().Ecology.Enabled {
+		return 0
+	}
+	return carryingCapacity(len(fm.getLanternsSnapshot()), fm.GetSpawnCap())
+}
+
+// ecologyPolicy hace nacer r·N·(1 − N/K) luciérnagas por segundo: casi
+// nada con pocas, el máximo a media capacidad y ninguna al llegar a K. Los
+// nacimientos fraccionarios se acumulan de un tick al siguiente. Solo la
+// usa la goroutine del spawner.
+type ecologyPolicy struct {
+	pending float64
+}
+
+func (*ecologyPolicy) Name() string { return ecologyPolicyName }
+
+func (p *ecologyPolicy) Spawn(ctx plugin.SpawnContext) []utils.Vector2D {
+	eco := config.Get().Ecology
+	capacity := carryingCapacity(len(ctx.Lanterns), ctx.Cap)
+	if capacity <= 0 {
+		p.pending = 0
+		return nil
+	}
+
+	n := float64(ctx.Population)
+	rate := eco.GrowthRate*n*(1-n/float64(capacity)) + immigration
+	p.pending += math.Max(rate, 0) * ctx.Interval.Seconds()
+
+	births := int(p.pending)
+	p.pending -= float64(births)
+
+	// Cada farol aporta su parte de la capacidad como hábitat: esa parte de
+	// los nacimientos ocurre bajo su luz
+	habitat := float64(eco.LanternCapacity*len(ctx.Lanterns)) / float64(eco.Capacity+eco.LanternCapacity*len(ctx.Lanterns))
+	radius := config.Get().Lanterns.Radius * 0.8
+
+	positions := make([]utils.Vector2D, 0, births)
+	for range births {
+		if len(ctx.Lanterns) > 0 && utils.RandomFloat(0, 1) < habitat {
+			lantern := ctx.Lanterns[min(int(utils.RandomFloat(0, float64(len(ctx.Lanterns)))), len(ctx.Lanterns)-1)]
+			positions = append(positions, lantern.Add(utils.RandomUnitVector().Mul(utils.RandomFloat(0, radius))))
+			continue
+		}
+		positions = append(positions, randomPoints(ctx, 1)...)
+	}
+	return positions
+}
+
+// predatorLoop suelta murciélagos en proporción a la población cada
+// ecology.predator_interval: muchas luciérnagas atraen muchos murciélagos,
+// que las reducen hasta que vuelven a crecer
+func (fm *FireflyManager) predatorLoop() {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemEcology)()
+
+	ticker := fm.clock.NewTicker(config.Get().Ecology.PredatorInterval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-fm.ctx.Done():
+			return
+
+		case <-ticker.C():
+			bats := int(math.Round(float64(fm.GetLiveFireflies()) * config.Get().Ecology.PredationRate))
+			if bats > 0 {
+				fm.LaunchBatWave(bats)
+			}
+		}
+	}
+}
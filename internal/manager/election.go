@@ -0,0 +1,278 @@
+package manager
+
+import (
+	"slices"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+const (
+	// electionInterval es cada cuánto se rearman los grupos y se revisa que
+	// cada uno tenga su líder
+	electionInterval = 500 * time.Millisecond
+	// electionRadius es la distancia que une a dos luciérnagas en un grupo
+	electionRadius = 90
+	// electionMinCluster es el tamaño mínimo de un grupo con líder
+	electionMinCluster = 3
+	// beaconBuffer es el buffer de avisos de destello de las líderes
+	beaconBuffer = 32
+	// electionEventBuffer es el buffer de la suscripción al bus
+	electionEventBuffer = 32
+)
+
+// ElectionStatus es el estado del modo elección de líder para el HUD
+type ElectionStatus struct {
+	Active bool
+	// Clusters es cuántos grupos tienen líder ahora
+	Clusters int
+	// Elections y Messages cuentan las elecciones hechas y los mensajes
+	// (elección y respuesta) que costaron; Reelections, las que se hicieron
+	// porque una líder murió
+	Elections, Messages, Reelections int
+	// LastFallen es la última líder que murió (-1 si ninguna)
+	LastFallen int
+}
+
+// SetElection empieza o termina el modo elección de líder
+func (fm *FireflyManager) SetElection(on bool) {
+	select {
+	case fm.electCmd <- on:
+	case <-fm.ctx.Done():
+	}
+}
+
+// GetElectionStatus retorna el último estado del modo elección de líder
+func (fm *FireflyManager) GetElectionStatus() ElectionStatus {
+	if status := fm.election.Load(); status != nil {
+		return *status
+	}
+	return ElectionStatus{LastFallen: -1}
+}
+
+// election es el estado que solo toca electionLoop
+type election struct {
+	status ElectionStatus
+	// leaders es el grupo de cada líder, por su ID
+	leaders map[int][]int
+}
+
+// electionLoop es la dueña de las elecciones. Cada electionInterval arma los
+// grupos de luciérnagas cercanas y, en los que no tienen exactamente una
+// líder (es nuevo, se unieron dos o la líder murió), corre el algoritmo bully
+// por los canales de control. La muerte de una líder adelanta la revisión.
+// Cada vez que una líder destella avisa por beaconCh y la goroutine les pide a
+// las de su grupo que acerquen su fase a la de ella.
+func (fm *FireflyManager) electionLoop(events <-chan Event, unsubscribe func()) {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemElection)()
+	defer unsubscribe()
+
+	ticker := fm.clock.NewTicker(electionInterval)
+	defer ticker.Stop()
+
+	e := &election{status: ElectionStatus{LastFallen: -1}, leaders: make(map[int][]int)}
+	for {
+		select {
+		case <-fm.ctx.Done():
+			return
+
+		case on := <-fm.electCmd:
+			fm.lifecycleMux.RLock()
+			fm.broadcast(core.Control{Kind: core.ControlLeader})
+			fm.lifecycleMux.RUnlock()
+			e = &election{status: ElectionStatus{Active: on, LastFallen: -1}, leaders: make(map[int][]int)}
+			if on {
+				fm.elect(e)
+			}
+			fm.publishElection(e)
+
+		case ev := <-events:
+			if _, leader := e.leaders[ev.ID]; !e.status.Active || !leader {
+				continue
+			}
+			switch ev.Type {
+			case EventDeath, EventCapture, EventEaten:
+				fm.elect(e)
+				fm.publishElection(e)
+			}
+
+		case <-ticker.C():
+			if !e.status.Active {
+				continue
+			}
+			fm.elect(e)
+			fm.publishElection(e)
+
+		case id := <-fm.beaconCh:
+			if !e.status.Active {
+				continue
+			}
+			fm.lifecycleMux.RLock()
+			for _, member := range e.leaders[id] {
+				if r, ok := fm.routes.get(member); ok && member != id {
+					r.send(core.Control{Kind: core.ControlSync})
+				}
+			}
+			fm.lifecycleMux.RUnlock()
+		}
+	}
+}
+
+func (fm *FireflyManager) publishElection(e *election) {
+	status := e.status
+	status.Clusters = len(e.leaders)
+	fm.election.Store(&status)
+}
+
+// elect rearma los grupos con el último snapshot y les da una líder a los
+// que no la tienen
+func (fm *FireflyManager) elect(e *election) {
+	clusters, alive := fm.clusters()
+
+	// Las líderes que ya no están murieron (o las atraparon): su grupo
+	// elige otra
+	for id := range e.leaders {
+		if !alive[id] {
+			e.status.Reelections++
+			e.status.LastFallen = id
+			fm.log.Info("la líder murió, reelección", "firefly", id)
+		}
+	}
+
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
+
+	leaders := make(map[int][]int, len(clusters))
+	for _, members := range clusters {
+		var current []int
+		for _, id := range members {
+			if _, leader := e.leaders[id]; leader {
+				current = append(current, id)
+			}
+		}
+		if len(current) == 1 {
+			leaders[current[0]] = members
+			continue
+		}
+		leader, messages := fm.bully(members)
+		e.status.Elections++
+		e.status.Messages += messages
+		if leader >= 0 {
+			leaders[leader] = members
+		}
+	}
+
+	// Avisos a las que dejan de ser líderes y a las nuevas
+	for id := range e.leaders {
+		if _, still := leaders[id]; !still {
+			fm.sendLeader(id, false)
+		}
+	}
+	for id := range leaders {
+		if _, was := e.leaders[id]; !was {
+			fm.sendLeader(id, true)
+		}
+	}
+	e.leaders = leaders
+}
+
+// bully elige la líder de un grupo (IDs en orden creciente). Empieza la de
+// menor ID: le manda un mensaje de elección a cada una de ID mayor y, si
+// alguna contesta, la elección pasa a la mayor que contestó, que repite con
+// las de arriba. Gana la que no recibe respuesta de ninguna mayor. Retorna
+// la ganadora (-1 si ninguna contestó) y cuántos mensajes costó.
+func (fm *FireflyManager) bully(members []int) (int, int) {
+	messages := 0
+	candidate := -1
+	for i, id := range members {
+		if _, ok := fm.electionPing(id); ok {
+			candidate, members = id, members[i+1:]
+			break
+		}
+	}
+	for candidate >= 0 {
+		next := -1
+		for _, id := range members {
+			messages++
+			if _, ok := fm.electionPing(id); ok {
+				messages++
+				next = id
+			}
+		}
+		if next < 0 {
+			return candidate, messages
+		}
+		candidate = next
+		members = members[slices.Index(members, next)+1:]
+	}
+	return -1, messages
+}
+
+// electionPing manda el mensaje de elección; debe llamarse con lifecycleMux
+// tomado en lectura
+func (fm *FireflyManager) electionPing(id int) (core.FireflySnapshot, bool) {
+	r, ok := fm.routes.get(id)
+	if !ok {
+		return core.FireflySnapshot{}, false
+	}
+	return r.request(core.Control{Kind: core.ControlElection})
+}
+
+// sendLeader le avisa a una luciérnaga si es líder; debe llamarse con
+// lifecycleMux tomado en lectura
+func (fm *FireflyManager) sendLeader(id int, leading bool) {
+	if r, ok := fm.routes.get(id); ok {
+		r.send(core.Control{Kind: core.ControlLeader, Leading: leading})
+	}
+}
+
+// clusters arma los grupos de al menos electionMinCluster luciérnagas unidas
+// por cercanía (cada grupo con sus IDs en orden) y retorna también las vivas
+func (fm *FireflyManager) clusters() ([][]int, map[int]bool) {
+	states := fm.aggregator.GetSnapshot()
+	defer ReleaseStates(states)
+
+	alive := make(map[int]bool, len(states))
+	live := states[:0]
+	for _, s := range states {
+		if s.IsAlive {
+			alive[s.ID] = true
+			live = append(live, s)
+		}
+	}
+
+	var (
+		clusters [][]int
+		near     []int
+	)
+	index := newSpatialIndex(live, electionRadius)
+	seen := make([]bool, len(live))
+	queue := make([]int, 0, len(live))
+	for start := range live {
+		if seen[start] {
+			continue
+		}
+		seen[start] = true
+		queue = append(queue[:0], start)
+		for head := 0; head < len(queue); head++ {
+			near = index.near(near[:0], live[queue[head]].Position, electionRadius)
+			for _, j := range near {
+				if !seen[j] {
+					seen[j] = true
+					queue = append(queue, j)
+				}
+			}
+		}
+		if len(queue) < electionMinCluster {
+			continue
+		}
+		members := make([]int, len(queue))
+		for i, idx := range queue {
+			members[i] = live[idx].ID
+		}
+		slices.Sort(members)
+		clusters = append(clusters, members)
+	}
+	return clusters, alive
+}
@@ -0,0 +1,126 @@
+package manager
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+type EventType string
+
+const (
+	EventSpawn           EventType = "spawn"
+	EventDeath           EventType = "death"
+	EventLanternAdd      EventType = "lantern_add"
+	EventLanternRemove   EventType = "lantern_remove"
+	EventAttraction      EventType = "attraction"
+	EventAttractionClear EventType = "attraction_clear"
+	EventWind            EventType = "wind"
+	EventSettings        EventType = "settings"
+	EventRestore         EventType = "restore"
+	EventGroupOrder      EventType = "group_order"
+	EventCapture         EventType = "capture"
+	EventEaten           EventType = "eaten"
+	EventBatWave         EventType = "bat_wave"
+	EventFlashWave       EventType = "flash_wave"
+	EventDropSpike       EventType = "drop_spike"
+	EventStormWarning    EventType = "storm_warning"
+	EventStorm           EventType = "storm"
+	EventStormEnd        EventType = "storm_end"
+)
+
+// Event es un hecho ocurrido en la simulación; T es el tiempo desde Start.
+// Solo se completan los campos que corresponden a Type.
+type Event struct {
+	T        time.Duration         `json:"t"`
+	Type     EventType             `json:"type"`
+	ID       int                   `json:"id,omitempty"`
+	Count    int                   `json:"count,omitempty"`
+	Position *utils.Vector2D       `json:"pos,omitempty"`
+	Firefly  *core.FireflySnapshot `json:"firefly,omitempty"`
+	Lantern  *LanternSnapshot      `json:"lantern,omitempty"`
+	Wind     *core.WindDirection   `json:"wind,omitempty"`
+	Settings *Settings             `json:"settings,omitempty"`
+	Snapshot *GardenSnapshot       `json:"snapshot,omitempty"`
+	Group    *GroupOrder           `json:"group,omitempty"`
+	Storm    *core.StormFront      `json:"storm,omitempty"`
+}
+
+// EventBus reparte los eventos a cada suscriptor por su propio canal.
+// Publish nunca bloquea: si un suscriptor está lleno el evento se descarta
+// para él y se cuenta en Dropped.
+type EventBus struct {
+	mux         sync.RWMutex
+	subscribers map[int]chan Event
+	nextID      int
+	start       time.Time
+	dropped     atomic.Uint64
+	published   atomic.Uint64
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make(map[int]chan Event),
+		start:       time.Now(),
+	}
+}
+
+// Subscribe retorna un canal de eventos y la función para desuscribirse
+// (que cierra el canal)
+func (b *EventBus) Subscribe(buffer int) (<-chan Event, func()) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan Event, buffer)
+	b.subscribers[id] = ch
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mux.Lock()
+			delete(b.subscribers, id)
+			b.mux.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish sella el evento con el tiempo transcurrido y lo envía sin bloquear
+func (b *EventBus) Publish(e Event) {
+	e.T = time.Since(b.start)
+	b.published.Add(1)
+
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+			b.dropped.Add(1)
+		}
+	}
+}
+
+// Reset reinicia el reloj de los eventos (al arrancar el manager)
+func (b *EventBus) Reset() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.start = time.Now()
+}
+
+func (b *EventBus) GetPublished() uint64 {
+	return b.published.Load()
+}
+
+func (b *EventBus) GetDropped() uint64 {
+	return b.dropped.Load()
+}
@@ -2,17 +2,24 @@ package manager
 
 import (
 	"context"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/yourusername/firefly-garden/internal/config"
 	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/internal/plugin"
 	"github.com/yourusername/firefly-garden/pkg/utils"
 )
 
 type Command struct {
 	Type CommandType
 	Data interface{}
+
+	// trace sigue el comando encolado con Send o Enqueue, si se muestrea
+	trace *commandTrace
 }
 
 type CommandType int
@@ -22,71 +29,313 @@ const (
 	CommandSetAttraction
 	CommandClearAttraction
 	CommandUpdateWind
+	CommandSpawnBurst
+	CommandAddLantern
+	CommandRemoveLantern
+	CommandUpdateSettings
+	CommandReloadConfig
+	CommandReplayEvent
+	CommandSetWind
+	CommandMoveLantern
+	CommandGroupOrder
+	CommandBatWave
+	CommandClearLanterns
 )
 
+// BurstRequest pide una ráfaga; sin Pattern sale en nube
+type BurstRequest struct {
+	Position utils.Vector2D
+	Count    int
+	Pattern  BurstPattern
+}
+
+type LanternMove struct {
+	ID       int
+	Position utils.Vector2D
+}
+
 type FireflyManager struct {
-	fireflies      map[int]*core.Firefly
-	firefliesMux   sync.RWMutex
-	nextID         int
+	world          *core.World
 	aggregator     *StateAggregator
 	wind           *core.Wind
-	lanterns       []*core.Lantern
-	lanternsMux    sync.RWMutex
 	commandCh      chan Command
 	ctx            context.Context
 	cancel         context.CancelFunc
 	wg             sync.WaitGroup
 	workerPool     *WorkerPool
+	// dropped cuenta los estados descartados por sus luciérnagas
+	dropped        atomic.Uint64
 	attractionPt   *utils.Vector2D
 	attractionMux  sync.RWMutex
+	heatmap        *Heatmap
+	heatmapJobID   int
+	field          *core.ForceField
+	landscape      *core.Landscape
+	gardenMap      *GardenMap
+	clusterSet     atomic.Pointer[ClusterSet]
+	clusterJobID   int
+	spawnCap       atomic.Int64
+	objective      atomic.Int64
+	bats           atomic.Pointer[[]core.BatState]
+	stopOnce       sync.Once
+	// stopMux ordena los wg.Add de las luciérnagas y las ráfagas nuevas
+	// con el Wait de Stop: se toman en lectura y Stop cancela en escritura
+	stopMux        sync.RWMutex
+	settings       Settings
+	settingsMux    sync.RWMutex
+	events         *EventBus
+	notices        chan Notice
+	stats          *SessionStats
+	score          *Score
+	goroutines     goroutineCounter
+	commandsDone   atomic.Uint64
+	commandPeak    highWater
+	spawned        atomic.Uint64
+	log            *slog.Logger
+	playback       bool
+	fixedWind      bool
+	synchronous    bool
+	// windElapsed es el tiempo simulado por Step desde el último giro del
+	// viento; solo lo usa Step
+	windElapsed    time.Duration
+	behaviors      []plugin.BehaviorPlugin
+	neighbors      neighborhood
+
+	// Las luciérnagas corren bajo su propio contexto para poder detenerlas
+	// (quiesce) sin detener el manager; lifecycleMux excluye los spawns
+	// mientras se guardan o restauran
+	fireflyCtx    context.Context
+	fireflyCancel context.CancelFunc
+	fireflyWG     sync.WaitGroup
+	lifecycleMux  sync.RWMutex
+	timeScale     float64
+	// routes es el canal de control de cada luciérnaga en marcha;
+	// lanternSync ordena los envíos de la lista de faroles
+	routes      *routingTable
+	lanternSync sync.Mutex
+	// lowPower baja el ritmo de las luciérnagas a background_tps mientras
+	// la ventana no tiene el foco. wantLowPower es el pedido más reciente de
+	// SetLowPower; powerLoop lo aplica y powerCh lo despierta.
+	lowPower     atomic.Bool
+	wantLowPower atomic.Bool
+	powerCh      chan struct{}
+	// gossipCh recibe los destellos de las luciérnagas con el rumor;
+	// gossipCmd lo empieza o lo termina (ver gossipLoop)
+	gossipCh  chan core.GossipNote
+	gossipCmd chan bool
+	gossip    atomic.Pointer[GossipStatus]
+	// beaconCh recibe los destellos de las líderes; electCmd empieza o
+	// termina las elecciones (ver electionLoop)
+	beaconCh chan int
+	electCmd chan bool
+	election atomic.Pointer[ElectionStatus]
+	// clock marca el ritmo de las luciérnagas, el viento y el spawner
+	clock core.Clock
+	// chaos cuenta las fallas que inyecta el modo caos (ver chaosLoop)
+	chaos chaosCounters
+	// admission lleva la población viva y aplica el límite a cada spawn
+	admission admission
+	// batches son los lotes de la simulación por lotes (batch.enabled)
+	batches batchSim
 }
 
 func NewFireflyManager() *FireflyManager {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	aggregator := NewStateAggregator(config.StateChannelBuffer)
+	aggregator := NewStateAggregator(config.Get().Channels.StateBuffer)
 	wind := core.NewWind()
 
 	workerPool := NewWorkerPool(4, 100, 100)
 
-	return &FireflyManager{
-		fireflies:  make(map[int]*core.Firefly),
-		nextID:     1,
+	fm := &FireflyManager{
+		world:      core.NewWorld(),
 		aggregator: aggregator,
 		wind:       wind,
-		lanterns:   make([]*core.Lantern, 0, config.MaxLanterns),
-		commandCh:  make(chan Command, config.CommandChannelBuffer),
+		commandCh:  make(chan Command, config.Get().Channels.CommandBuffer),
 		ctx:        ctx,
 		cancel:     cancel,
 		workerPool: workerPool,
+		heatmap:    NewHeatmap(config.ScreenWidth, config.ScreenHeight, config.Get().Heatmap.CellSize, config.Get().Heatmap.HalfLife),
+		field:      core.NewForceField(),
+		landscape:  core.NewLandscape(),
+		settings:   DefaultSettings(),
+		events:     NewEventBus(),
+		notices:    make(chan Notice, noticeBuffer),
+		stats:      &SessionStats{},
+		score:      &Score{state: ScoreState{Multiplier: 1}},
+		timeScale:  1,
+		routes:     newRoutingTable(),
+		gossipCh:   make(chan core.GossipNote, gossipNoteBuffer),
+		gossipCmd:  make(chan bool, 1),
+		beaconCh:   make(chan int, beaconBuffer),
+		electCmd:   make(chan bool, 1),
+		powerCh:    make(chan struct{}, 1),
+		clock:      core.RealClock,
+		log:        logging.For("manager"),
 	}
+	fm.spawnCap.Store(int64(config.Get().Fireflies.Max))
+	fm.objective.Store(-1)
+	fm.fireflyCtx, fm.fireflyCancel = context.WithCancel(ctx)
+
+	return fm
 }
 
 func (fm *FireflyManager) Start() {
+	fm.aggregator.SetOrigin(fm.clock.Now(), time.Second/time.Duration(config.Get().SimulationTPS))
 	fm.aggregator.Start()
+	fm.events.Reset()
+	publishVars(fm)
+
+	// En reproducción el viento, los spawns y los faroles llegan del archivo;
+	// con el viento fijo solo cambia por comandos
+	if !fm.playback && !fm.fixedWind {
+		fm.wind.SetOnChange(func(dir core.WindDirection) {
+			fm.events.Publish(Event{Type: EventWind, Wind: &dir})
+		})
+	}
+	// En modo sincrónico lo gira Step
+	if !fm.playback && !fm.fixedWind && !fm.synchronous {
+		fm.wg.Add(1)
+		go func() {
+			defer fm.goroutines.track(SubsystemWind)()
+			fm.wind.Run(fm.ctx)
+		}()
+		go func() {
+			<-fm.ctx.Done()
+			fm.wg.Done()
+		}()
+	}
+
+	fm.workerPool.Start()
+
+	// En modo sincrónico los comandos los aplica Step antes de cada tick
+	if !fm.synchronous {
+		fm.wg.Add(1)
+		go fm.commandLoop()
+	}
 
 	fm.wg.Add(1)
-	go fm.wind.Run(fm.ctx)
-	go func() {
-		<-fm.ctx.Done()
-		fm.wg.Done()
-	}()
+	go fm.heatmapSampler()
 
-	fm.workerPool.Start()
+	fm.wg.Add(1)
+	go fm.clusterSampler()
+
+	// Los plugins se registran al iniciar; sin ellos no hay costo extra
+	fm.behaviors = plugin.Behaviors()
+	if len(fm.behaviors) > 0 {
+		fm.wg.Add(1)
+		go fm.neighborSampler()
+	}
+
+	statsEvents, unsubscribe := fm.events.Subscribe(statsEventBuffer)
+	fm.wg.Add(1)
+	go fm.statsSampler(statsEvents, unsubscribe)
+
+	scoreEvents, unsubscribeScore := fm.events.Subscribe(scoreEventBuffer)
+	fm.wg.Add(2)
+	go fm.scoreKeeper(scoreEvents, unsubscribeScore)
+	go fm.flashWatcher()
 
 	fm.wg.Add(1)
-	go fm.commandLoop()
+	go fm.gossipLoop()
+
+	fm.wg.Add(1)
+	go fm.powerLoop()
+
+	electionEvents, unsubscribeElection := fm.events.Subscribe(electionEventBuffer)
+	fm.wg.Add(1)
+	go fm.electionLoop(electionEvents, unsubscribeElection)
+
+	if src, ok := config.GetSource(); ok {
+		fm.wg.Add(1)
+		go fm.configWatcher(src)
+	}
 
-	if config.AutoSpawnEnabled {
+	if fm.playback {
+		return
+	}
+
+	// En modo sincrónico solo avanza lo que mueve Step; el modo ecológico
+	// necesita el spawner aunque auto_spawn esté apagado
+	ecology := config.Get().Ecology
+	if (config.Get().Spawn.AutoSpawn || ecology.Enabled) && !fm.synchronous {
 		fm.wg.Add(1)
 		go fm.autoSpawner()
 	}
 
+	// Las zonas con rate sueltan las suyas aparte de la política
+	if config.Get().Spawn.AutoSpawn && !fm.synchronous {
+		fm.wg.Add(1)
+		go fm.zoneSpawner()
+	}
+
+	if ecology.Enabled && ecology.Predators && !fm.synchronous {
+		fm.wg.Add(1)
+		go fm.predatorLoop()
+	}
+
+	if !fm.synchronous {
+		fm.wg.Add(1)
+		go fm.batLoop()
+	}
+
+	if config.Get().Storm.Enabled && !fm.synchronous {
+		fm.wg.Add(1)
+		go fm.stormLoop()
+	}
+
+	if config.Get().Chaos.Enabled && !fm.synchronous {
+		fm.log.Warn("modo caos activo")
+		fm.wg.Add(1)
+		go fm.chaosLoop()
+	}
+
+	fm.placeMapLanterns()
 	fm.spawnInitialFireflies()
 }
 
+// EnablePlayback deja el manager en modo reproducción: sin viento automático
+// ni spawns propios. Debe llamarse antes de Start.
+func (fm *FireflyManager) EnablePlayback() {
+	fm.playback = true
+}
+
+// DisableAutoWind deja el viento quieto salvo por CommandSetWind y
+// CommandUpdateWind (por ejemplo, para seguir un calendario propio). Debe
+// llamarse antes de Start.
+func (fm *FireflyManager) DisableAutoWind() {
+	fm.fixedWind = true
+}
+
+// SetClock cambia el reloj de la simulación (por ejemplo, por un
+// core.FakeClock en pruebas o headless). Debe llamarse antes de Start.
+func (fm *FireflyManager) SetClock(clock core.Clock) {
+	fm.clock = clock
+	fm.wind.SetClock(clock)
+}
+
+// Clock retorna el reloj de la simulación
+func (fm *FireflyManager) Clock() core.Clock {
+	return fm.clock
+}
+
+func (fm *FireflyManager) IsPlayback() bool {
+	return fm.playback
+}
+
+// Stats expone la serie de métricas por segundo de la sesión
+func (fm *FireflyManager) Stats() *SessionStats {
+	return fm.stats
+}
+
+// Events expone el bus de eventos de la simulación
+func (fm *FireflyManager) Events() *EventBus {
+	return fm.events
+}
+
 func (fm *FireflyManager) commandLoop() {
 	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemCommands)()
 
 	for {
 		select {
@@ -94,7 +343,7 @@ func (fm *FireflyManager) commandLoop() {
 			return
 
 		case cmd := <-fm.commandCh:
-			fm.processCommand(cmd)
+			fm.runCommand(cmd)
 		}
 	}
 }
@@ -117,14 +366,120 @@ func (fm *FireflyManager) processCommand(cmd Command) {
 		fm.clearAttractionPoint()
 
 	case CommandUpdateWind:
-		fm.wind.CycleDirection()
+		dir := fm.wind.CycleDirection()
+		fm.events.Publish(Event{Type: EventWind, Wind: &dir})
+
+	case CommandSetWind:
+		dir, ok := cmd.Data.(core.WindDirection)
+		if ok {
+			fm.wind.SetDirection(dir)
+			fm.events.Publish(Event{Type: EventWind, Wind: &dir})
+		}
+
+	case CommandSpawnBurst:
+		req, ok := cmd.Data.(BurstRequest)
+		if ok {
+			fm.burst(req)
+		}
+
+	case CommandAddLantern:
+		pos, ok := cmd.Data.(utils.Vector2D)
+		if ok {
+			fm.AddLantern(pos.X, pos.Y)
+		}
+
+	case CommandRemoveLantern:
+		fm.RemoveLantern()
+
+	case CommandClearLanterns:
+		fm.ClearLanterns()
+
+	case CommandMoveLantern:
+		move, ok := cmd.Data.(LanternMove)
+		if ok {
+			fm.moveLantern(move.ID, move.Position)
+		}
+
+	case CommandUpdateSettings:
+		settings, ok := cmd.Data.(Settings)
+		if ok {
+			fm.applySettings(settings)
+		}
+
+	case CommandReloadConfig:
+		cfg, ok := cmd.Data.(*config.Config)
+		if ok {
+			fm.applyConfig(cfg)
+		}
+
+	case CommandGroupOrder:
+		order, ok := cmd.Data.(GroupOrder)
+		if ok {
+			fm.applyGroupOrder(order)
+		}
+
+	case CommandBatWave:
+		count, ok := cmd.Data.(int)
+		if ok && count > 0 {
+			fm.launchBatWave(count)
+		}
+
+	case CommandReplayEvent:
+		event, ok := cmd.Data.(Event)
+		if ok {
+			fm.applyEvent(event)
+		}
 	}
 }
 
+func (fm *FireflyManager) heatmapSampler() {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemHeatmap)()
+
+	ticker := fm.clock.NewTicker(config.Get().Heatmap.SampleInterval.Duration)
+	defer ticker.Stop()
+
+	dt := config.Get().Heatmap.SampleInterval.Duration.Seconds()
+
+	for {
+		select {
+		case <-fm.ctx.Done():
+			return
+
+		case <-ticker.C():
+			states := fm.aggregator.GetSnapshot()
+			fm.heatmapJobID++
+			fm.workerPool.Submit(Job{
+				ID: fm.heatmapJobID,
+				Task: func() interface{} {
+					// La ventana o el mapa pudieron cambiar el tamaño del
+					// mundo: la grilla lo sigue
+					fm.heatmap.Resize(config.WorldPixels())
+					fm.heatmap.Accumulate(states, dt)
+					ReleaseStates(states)
+					return nil
+				},
+			})
+		}
+	}
+}
+
+// autoSpawner le pide posiciones a la política de spawn en cada tick. Una
+// política de plugin reemplaza a las de fábrica; si no hay, se usa la del
+// modo ecológico o la de spawn.policy, que puede cambiar con una recarga de
+// la configuración.
 func (fm *FireflyManager) autoSpawner() {
 	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemSpawner)()
+
+	policy := plugin.ActiveSpawnPolicy()
+	fromPlugin := policy != nil
+	if fromPlugin {
+		fm.log.Info("spawner con política de plugin", "policy", policy.Name())
+	}
 
-	ticker := time.NewTicker(config.FireflySpawnInterval / 2)
+	interval := fm.GetSettings().SpawnInterval
+	ticker := fm.clock.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
@@ -132,186 +487,428 @@ func (fm *FireflyManager) autoSpawner() {
 		case <-fm.ctx.Done():
 			return
 
-		case <-ticker.C:
-			current := fm.GetFireflyCount()
-			if current < config.ObjectiveCount {
-				missing := config.ObjectiveCount - current
-				toSpawn := config.SpawnBurstCount
-				if missing < toSpawn {
-					toSpawn = missing
-				}
-				for i := 0; i < toSpawn; i++ {
-					x := utils.RandomFloat(0, config.ScreenWidth)
-					y := utils.RandomFloat(0, config.ScreenHeight)
-					fm.spawnFirefly(x, y)
-				}
-				if missing > config.SpawnBurstCount*2 {
-					x := utils.RandomFloat(0, config.ScreenWidth)
-					y := utils.RandomFloat(0, config.ScreenHeight)
-					fm.spawnFirefly(x, y)
-				}
-			} else {
-				if utils.RandomFloat(0, 1) < 0.05 && fm.GetFireflyCount() < config.MaxFireflies {
-					x := utils.RandomFloat(0, config.ScreenWidth)
-					y := utils.RandomFloat(0, config.ScreenHeight)
-					fm.spawnFirefly(x, y)
-				}
+		case <-ticker.C():
+			if next := fm.GetSettings().SpawnInterval; next != interval {
+				interval = next
+				ticker.Reset(interval)
 			}
+
+			name := config.Get().Spawn.Policy
+			if config.Get().Ecology.Enabled {
+				name = ecologyPolicyName
+			}
+			if !fromPlugin && (policy == nil || policy.Name() != name) {
+				policy = newSpawnPolicy(name)
+				fm.log.Info("política de spawn", "policy", policy.Name())
+			}
+			fm.spawnFromPolicy(policy, interval)
+		}
+	}
+}
+
+// spawnFromPolicy crea las luciérnagas que pide la política sin pasar el límite
+func (fm *FireflyManager) spawnFromPolicy(policy plugin.SpawnPolicy, interval time.Duration) {
+	spawn := config.Get().Spawn
+	width, height := config.WorldSize()
+	lanterns := fm.getLanternsSnapshot()
+	positions := make([]utils.Vector2D, len(lanterns))
+	for i, lantern := range lanterns {
+		positions[i] = lantern.Position
+	}
+
+	positions = policy.Spawn(plugin.SpawnContext{
+		Population:  fm.GetLiveFireflies(),
+		Objective:   fm.GetObjective(),
+		Cap:         fm.GetSpawnCap(),
+		BurstCount:  spawn.BurstCount,
+		Width:       width,
+		Height:      height,
+		Interval:    interval,
+		Lanterns:    positions,
+		RandomPoint: fm.spawnPoint,
+	})
+
+	for _, pos := range positions {
+		if !fm.spawnFirefly(pos.X, pos.Y) {
+			return
 		}
 	}
 }
 
 func (fm *FireflyManager) autoSpawnerSimple() {
 	defer fm.wg.Done()
-	ticker := time.NewTicker(config.FireflySpawnInterval)
+	ticker := fm.clock.NewTicker(config.Get().Fireflies.SpawnInterval.Duration)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-fm.ctx.Done():
 			return
-		case <-ticker.C:
-			if fm.GetFireflyCount() < config.MaxFireflies {
-				x := utils.RandomFloat(0, config.ScreenWidth)
-				y := utils.RandomFloat(0, config.ScreenHeight)
-				fm.spawnFirefly(x, y)
-			}
+		case <-ticker.C():
+			p := fm.spawnPoint()
+			fm.spawnFirefly(p.X, p.Y)
 		}
 	}
 }
 
 
 func (fm *FireflyManager) spawnInitialFireflies() {
-	for i := 0; i < config.InitialFireflyCount; i++ {
-		x := utils.RandomFloat(0, config.ScreenWidth)
-		y := utils.RandomFloat(0, config.ScreenHeight)
-		fm.spawnFirefly(x, y)
+	for i := 0; i < fm.initialFireflies(); i++ {
+		if p := fm.spawnPoint(); !fm.spawnFirefly(p.X, p.Y) {
+			return
+		}
 	}
 }
 
-func (fm *FireflyManager) spawnFirefly(x, y float64) {
-	fm.firefliesMux.Lock()
+// randomWorldPoint retorna un punto al azar dentro del tamaño actual del mundo
+func randomWorldPoint() (x, y float64) {
+	width, height := config.WorldSize()
+	return utils.RandomFloat(0, width), utils.RandomFloat(0, height)
+}
+
+// spawnFirefly crea y lanza una luciérnaga si admitFirefly le da lugar;
+// retorna false si la población ya llegó al límite o el manager se detuvo
+func (fm *FireflyManager) spawnFirefly(x, y float64) bool {
+	fm.stopMux.RLock()
+	defer fm.stopMux.RUnlock()
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
 
-	id := fm.nextID
-	fm.nextID++
+	// Stop cancela con stopMux tomado: después ya no nace ninguna
+	if fm.ctx.Err() != nil || !fm.admitFirefly() {
+		return false
+	}
 
+	id := fm.world.NextID()
 	firefly := core.NewFirefly(id, x, y)
-	fm.fireflies[id] = firefly
+	if dir, ok := fm.spawnHeading(utils.Vector2D{X: x, Y: y}); ok {
+		firefly.SetHeading(dir)
+	}
+	fm.world.Add(firefly)
 
-	fm.firefliesMux.Unlock()
+	// El snapshot se toma antes de lanzarla, cuando nadie más la modifica
+	snap := firefly.Snapshot()
+	fm.events.Publish(Event{Type: EventSpawn, ID: id, Firefly: &snap})
+	fm.log.Debug("luciérnaga creada", "firefly", id, "x", x, "y", y, "lifespan", snap.Lifespan)
 
-	firefly.SetWindForce(fm.wind.GetForcePointer())
+	fm.attachFirefly(firefly)
+	fm.runFirefly(firefly)
+	return true
+}
 
-	fm.attractionMux.RLock()
-	if fm.attractionPt != nil {
-		firefly.SetAttractionPoint(fm.attractionPt)
+// attachFirefly conecta la luciérnaga al viento, al campo de fuerzas, al
+// terreno del mapa y a los plugins de comportamiento
+func (fm *FireflyManager) attachFirefly(firefly *core.Firefly) {
+	firefly.SetWind(fm.wind)
+	firefly.SetForceField(fm.field)
+	firefly.SetLandscape(fm.landscape)
+	firefly.SetGossipChannel(fm.gossipCh)
+	firefly.SetLeaderChannel(fm.beaconCh)
+	firefly.SetClock(fm.clock)
+	firefly.SetDropCounter(&fm.dropped)
+
+	if len(fm.behaviors) > 0 {
+		firefly.SetBehaviors(fm.behaviors, &fm.neighbors)
 	}
+}
+
+// runFirefly registra la ruta de control de la luciérnaga, le copia el
+// punto de atracción y los faroles actuales y lanza su goroutine; si muere de
+// vieja la quita del mundo. Se llama con lifecycleMux tomado (lectura o
+// escritura).
+func (fm *FireflyManager) runFirefly(firefly *core.Firefly) {
+	// La ruta se abre antes de leer el estado: un cambio posterior le llega
+	// por el canal y uno anterior ya está en lo que se copia
+	r := fm.routes.open(firefly.ID())
+
+	fm.attractionMux.RLock()
+	firefly.SetAttractionPoint(fm.attractionPt)
 	fm.attractionMux.RUnlock()
+	fm.lanternSync.Lock()
+	firefly.SetLanterns(fm.getLanternsSnapshot())
+	fm.lanternSync.Unlock()
+
+	ctx := fm.fireflyCtx
+	tps := fm.tickRate()
+	dt := fm.timeScale / float64(tps)
+	tick := time.Second / time.Duration(tps)
+	if fm.synchronous {
+		tick = 0
+	}
 
-	lanterns := fm.getLanternsSnapshot()
+	if fm.batched() {
+		fm.spawned.Add(1)
+		fm.batches.add(fm, ctx, batchMember{firefly: firefly, route: r}, tick, dt)
+		return
+	}
 
 	fm.wg.Add(1)
-	go func(ff *core.Firefly, lns []*core.Lantern) {
+	fm.fireflyWG.Add(1)
+	go func(ff *core.Firefly) {
 		defer fm.wg.Done()
-		ff.Run(fm.ctx, fm.aggregator.GetStateChannel(), lns, 1.0/float64(config.TargetFPS))
-	}(firefly, lanterns)
+		defer fm.fireflyWG.Done()
+		defer fm.goroutines.track(SubsystemFireflies)()
+		fm.spawned.Add(1)
+		died := ff.Run(ctx, fm.aggregator.GetStateChannel(), r.control, tick, dt)
+		fm.fireflyDone(ctx, ff, r, died)
+	}(firefly)
+}
+
+// fireflyDone limpia una luciérnaga que dejó de correr, en su goroutine o
+// en su lote. Atrapada o comida ya salió del mundo; cancelada (Stop o
+// quiesce) debe quedar en él. Si terminó sin nada de eso y su ruta sigue
+// registrada, la goroutine se cayó: se limpia como una muerte. La ruta se
+// cierra después: quien espera su done (Step) ya la ve fuera del mundo.
+func (fm *FireflyManager) fireflyDone(ctx context.Context, ff *core.Firefly, r *route, died bool) {
+	if died {
+		if _, ok := fm.world.Remove(ff.ID()); ok {
+			fm.releaseFirefly()
+		}
+		pos := ff.Snapshot().Position
+		fm.events.Publish(Event{Type: EventDeath, ID: ff.ID(), Position: &pos})
+		fm.log.Debug("luciérnaga murió", "firefly", ff.ID())
+	} else if current, ok := fm.routes.get(ff.ID()); ok && current == r && ctx.Err() == nil {
+		fm.reapFirefly(ff.ID())
+	}
+	fm.routes.close(ff.ID(), r)
 }
 
-func (fm *FireflyManager) SpawnBurst(x, y float64, count int) {
-	fm.firefliesMux.Lock()
-	defer fm.firefliesMux.Unlock()
+// tickRate retorna los ticks por segundo de las luciérnagas: los de la
+// configuración o, en bajo consumo, background_tps
+func (fm *FireflyManager) tickRate() int {
+	if tps := config.Get().BackgroundTPS; fm.lowPower.Load() && tps > 0 {
+		return tps
+	}
+	return config.Get().SimulationTPS
+}
+
+// SetLowPower pide relanzar las luciérnagas al ritmo de bajo consumo (o al
+// normal) con el paso escalado, así el jardín avanza a la misma velocidad
+// con menos ticks. No bloquea: powerLoop aplica el último pedido, así dos
+// cambios de foco seguidos nunca quedan en el orden equivocado.
+func (fm *FireflyManager) SetLowPower(on bool) {
+	fm.wantLowPower.Store(on)
+	select {
+	case fm.powerCh <- struct{}{}:
+	default:
+	}
+}
+
+// powerLoop aplica los pedidos de SetLowPower de a uno, repitiendo hasta que
+// el ritmo aplicado coincide con el último pedido
+func (fm *FireflyManager) powerLoop() {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemPower)()
 
-	for i := 0; i < count; i++ {
-		if len(fm.fireflies) >= config.MaxFireflies {
+	for {
+		select {
+		case <-fm.ctx.Done():
 			return
+		case <-fm.powerCh:
+			for fm.applyLowPower() {
+			}
 		}
-		dx := utils.RandomFloat(-40, 40)
-		dy := utils.RandomFloat(-40, 40)
-		id := fm.nextID
-		fm.nextID++
-
-		firefly := core.NewFirefly(id, x+dx, y+dy)
-		firefly.SetWindForce(fm.wind.GetForcePointer())
-		if fm.attractionPt != nil {
-			firefly.SetAttractionPoint(fm.attractionPt)
-		}
+	}
+}
 
-		fm.fireflies[id] = firefly
+// applyLowPower relanza las luciérnagas si el ritmo aplicado no es el
+// pedido; retorna false cuando ya coinciden o el manager se detuvo
+func (fm *FireflyManager) applyLowPower() bool {
+	fm.lifecycleMux.Lock()
+	defer fm.lifecycleMux.Unlock()
 
-		fm.wg.Add(1)
-		go func(ff *core.Firefly) {
-			defer fm.wg.Done()
-			ff.Run(fm.ctx, fm.aggregator.GetStateChannel(), fm.getLanternsSnapshot(), 1.0/float64(config.TargetFPS))
-		}(firefly)
+	on := fm.wantLowPower.Load()
+	if fm.ctx.Err() != nil || fm.lowPower.Swap(on) == on {
+		return false
 	}
+	fm.quiesce()
+	fm.resume()
+	fm.log.Debug("ritmo de simulación", "low_power", on, "tps", fm.tickRate())
+	return true
 }
 
-func (fm *FireflyManager) setAttractionPoint(point *utils.Vector2D) {
-	fm.attractionMux.Lock()
-	fm.attractionPt = point
-	fm.attractionMux.Unlock()
+// quiesce detiene las goroutines de las luciérnagas sin quitarlas del mundo.
+// Se llama con lifecycleMux tomado en escritura.
+func (fm *FireflyManager) quiesce() {
+	fm.fireflyCancel()
+	fm.fireflyWG.Wait()
+}
 
-	fm.firefliesMux.RLock()
-	defer fm.firefliesMux.RUnlock()
+// resume relanza una goroutine por cada luciérnaga del mundo.
+// Se llama con lifecycleMux tomado en escritura.
+func (fm *FireflyManager) resume() {
+	fm.fireflyCtx, fm.fireflyCancel = context.WithCancel(fm.ctx)
 
-	for _, firefly := range fm.fireflies {
-		firefly.SetAttractionPoint(point)
+	for _, e := range fm.world.Snapshot(core.KindFirefly) {
+		fm.runFirefly(e.(*core.Firefly))
 	}
 }
 
+func (fm *FireflyManager) setAttractionPoint(point *utils.Vector2D) {
+	fm.publishAttraction(point)
+	fm.events.Publish(Event{Type: EventAttraction, Position: point})
+}
+
 func (fm *FireflyManager) clearAttractionPoint() {
+	fm.publishAttraction(nil)
+	fm.events.Publish(Event{Type: EventAttractionClear})
+}
+
+// publishAttraction guarda el punto de atracción y se lo envía a cada
+// luciérnaga por su canal; attractionMux queda tomado durante el envío para
+// que dos cambios seguidos lleguen en orden
+func (fm *FireflyManager) publishAttraction(point *utils.Vector2D) {
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
 	fm.attractionMux.Lock()
-	fm.attractionPt = nil
-	fm.attractionMux.Unlock()
+	defer fm.attractionMux.Unlock()
+
+	fm.attractionPt = point
+	fm.broadcast(core.Control{Kind: core.ControlAttraction, Point: point})
+}
 
-	fm.firefliesMux.RLock()
-	defer fm.firefliesMux.RUnlock()
+// CaptureFirefly atrapa una luciérnaga: sale del mundo en el acto y su
+// goroutine termina en el próximo tick. Retorna false si ya no existía.
+func (fm *FireflyManager) CaptureFirefly(id int) bool {
+	return fm.takeFirefly(id, EventCapture)
+}
+
+// takeFirefly quita una luciérnaga viva del mundo sin que cuente como muerte
+// natural (atrapada en el frasco o comida por un murciélago) y publica event.
+// Le envía ControlKill por su ruta y espera que su goroutine termine; si
+// murió de vieja antes retorna false y cuenta como muerte natural.
+func (fm *FireflyManager) takeFirefly(id int, event EventType) bool {
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
+
+	r, ok := fm.routes.take(id)
+	if !ok {
+		return false
+	}
+	if _, ok := r.request(core.Control{Kind: core.ControlKill}); !ok {
+		// Si no murió de vieja, su goroutine se cayó con la ruta ya tomada
+		// y nadie más la va a limpiar
+		fm.reapFirefly(id)
+		return false
+	}
 
-	for _, firefly := range fm.fireflies {
-		firefly.SetAttractionPoint(nil)
+	if _, ok := fm.world.Remove(id); ok {
+		fm.releaseFirefly()
 	}
+	fm.events.Publish(Event{Type: event, ID: id})
+	fm.log.Debug("luciérnaga quitada del jardín", "firefly", id, "event", event)
+	return true
 }
 
 func (fm *FireflyManager) AddLantern(x, y float64) bool {
-	fm.lanternsMux.Lock()
-	defer fm.lanternsMux.Unlock()
+	return fm.AddLanternWithRadius(x, y, fm.GetSettings().LanternRadius)
+}
 
-	if len(fm.lanterns) >= config.MaxLanterns {
+// AddLanternWithRadius coloca un farol con su propio radio de influencia,
+// acotado a los límites de la configuración, y suelta una ráfaga a su
+// alrededor
+func (fm *FireflyManager) AddLanternWithRadius(x, y, radius float64) bool {
+	if !fm.placeLantern(x, y, radius) {
 		return false
 	}
 
-	lantern := core.NewLantern(x, y)
-	fm.lanterns = append(fm.lanterns, lantern)
+	// Como una ráfaga pedida por comando: en modo sincrónico sale antes de
+	// retornar y escalonada corre en una goroutine que Stop espera
+	fm.burst(BurstRequest{Position: utils.Vector2D{X: x, Y: y}, Count: config.Get().Spawn.BurstCount})
+	return true
+}
+
+func (fm *FireflyManager) placeLantern(x, y, radius float64) bool {
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
 
-	go fm.SpawnBurst(x, y, config.SpawnBurstCount)
+	if fm.landscape.Blocked(utils.Vector2D{X: x, Y: y}) {
+		fm.notify(NoticeWarning, "No se puede poner un farol sobre un obstáculo ni un estanque")
+		return false
+	}
+
+	lantern := core.NewLantern(fm.world.NextID(), x, y)
+	lantern.Radius = utils.Clamp(radius, config.LanternRadiusMin, config.LanternRadiusMax)
+	if !fm.world.AddLimited(lantern, config.Get().Lanterns.Max) {
+		fm.notify(NoticeWarning, "Máximo de faroles alcanzado (%d)", config.Get().Lanterns.Max)
+		return false
+	}
+
+	fm.broadcastLanterns()
+	fm.log.Debug("farol colocado", "lantern", lantern.ID(), "x", x, "y", y, "radius", lantern.Radius)
+	fm.events.Publish(Event{Type: EventLanternAdd, ID: lantern.ID(), Lantern: &LanternSnapshot{
+		ID:         lantern.ID(),
+		Position:   lantern.Position,
+		Radius:     lantern.Radius,
+		PulsePhase: lantern.PulsePhase(),
+	}})
 
 	return true
 }
 
 func (fm *FireflyManager) RemoveLantern() {
-	fm.lanternsMux.Lock()
-	defer fm.lanternsMux.Unlock()
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
 
-	if len(fm.lanterns) > 0 {
-		fm.lanterns = fm.lanterns[:len(fm.lanterns)-1]
+	if lantern, ok := fm.world.Last(core.KindLantern); ok {
+		fm.world.Remove(lantern.ID())
+		fm.broadcastLanterns()
+		fm.events.Publish(Event{Type: EventLanternRemove, ID: lantern.ID()})
 	}
 }
 
-func (fm *FireflyManager) UpdateLanterns(dt float64) {
-	fm.lanternsMux.RLock()
-	defer fm.lanternsMux.RUnlock()
+// ClearLanterns quita todos los faroles; las luciérnagas siguen en el jardín
+func (fm *FireflyManager) ClearLanterns() {
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
 
-	for _, lantern := range fm.lanterns {
-		lantern.Update(dt)
+	for _, lantern := range fm.world.Snapshot(core.KindLantern) {
+		fm.world.Remove(lantern.ID())
+		fm.events.Publish(Event{Type: EventLanternRemove, ID: lantern.ID()})
 	}
+	fm.broadcastLanterns()
+}
+
+// moveLantern reemplaza el farol por uno nuevo en otra posición con el mismo
+// ID y radio; el render sigue leyendo el anterior hasta su próximo snapshot
+func (fm *FireflyManager) moveLantern(id int, pos utils.Vector2D) bool {
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
+
+	entity, ok := fm.world.Get(id)
+	if !ok {
+		return false
+	}
+	old, ok := entity.(*core.Lantern)
+	if !ok {
+		return false
+	}
+
+	lantern := core.NewLantern(id, pos.X, pos.Y)
+	lantern.Radius = old.Radius
+	snapshot := &LanternSnapshot{ID: id, Position: lantern.Position, Radius: lantern.Radius}
+
+	fm.world.Remove(id)
+	fm.world.Add(lantern)
+	fm.broadcastLanterns()
+
+	fm.events.Publish(Event{Type: EventLanternRemove, ID: id})
+	fm.events.Publish(Event{Type: EventLanternAdd, ID: id, Lantern: snapshot})
+
+	return true
+}
+
+func (fm *FireflyManager) UpdateLanterns(dt float64) {
+	fm.world.UpdateAll(dt)
 }
 
 func (fm *FireflyManager) getLanternsSnapshot() []*core.Lantern {
-	fm.lanternsMux.RLock()
-	defer fm.lanternsMux.RUnlock()
+	entities := fm.world.Snapshot(core.KindLantern)
 
-	snapshot := make([]*core.Lantern, len(fm.lanterns))
-	copy(snapshot, fm.lanterns)
+	snapshot := make([]*core.Lantern, 0, len(entities))
+	for _, e := range entities {
+		snapshot = append(snapshot, e.(*core.Lantern))
+	}
 
 	return snapshot
 }
@@ -328,8 +925,40 @@ func (fm *FireflyManager) GetFireflyStates() []core.FireflyState {
 	return fm.aggregator.GetSnapshot()
 }
 
-func (fm *FireflyManager) GetWind() *core.Wind {
-	return fm.wind
+// GetSnapshotWithMeta retorna los estados con el tick del agregador y la
+// hora del más nuevo, para alinear snapshots tomados en distintos lugares
+// (red, repeticiones, gráficas). El slice se devuelve con ReleaseStates.
+func (fm *FireflyManager) GetSnapshotWithMeta() ([]core.FireflyState, SnapshotMeta) {
+	return fm.aggregator.GetSnapshotWithMeta()
+}
+
+// GetZoneStats retorna cuántas luciérnagas hay en cada zona del jardín y
+// su brillo promedio, como las lleva el agregador
+func (fm *FireflyManager) GetZoneStats() ZoneStats {
+	return fm.aggregator.GetZoneStats()
+}
+
+// GetGlow retorna el resplandor del jardín: el brillo sumado de todas las
+// luciérnagas con un bono por las que brillan juntas
+func (fm *FireflyManager) GetGlow() Glow {
+	return fm.aggregator.GetGlow()
+}
+
+// GetInterpolatedStates retorna los estados retrasados un tick de simulación,
+// de modo que siempre existan dos estados reales entre los que interpolar
+func (fm *FireflyManager) GetInterpolatedStates(now time.Time) []core.FireflyState {
+	delay := time.Second / time.Duration(fm.tickRate())
+	return fm.aggregator.GetInterpolatedSnapshot(now.Add(-delay))
+}
+
+// ReleaseStates devuelve al pool un snapshot que ya no se usará
+func (fm *FireflyManager) ReleaseStates(states []core.FireflyState) {
+	ReleaseStates(states)
+}
+
+// GetWindSnapshot retorna el viento vigente sin tocar el que se modifica
+func (fm *FireflyManager) GetWindSnapshot() core.WindSnapshot {
+	return fm.wind.Snapshot()
 }
 
 func (fm *FireflyManager) GetCommandChannel() chan<- Command {
@@ -340,17 +969,79 @@ func (fm *FireflyManager) GetWorkerPool() *WorkerPool {
 	return fm.workerPool
 }
 
-func (fm *FireflyManager) GetDroppedStates() uint64 {
-	return core.GetDroppedStates()
+// SetSpawnCap limita la población máxima (nunca por encima de MaxFireflies)
+func (fm *FireflyManager) SetSpawnCap(limit int) {
+	if limit > config.Get().Fireflies.Max {
+		limit = config.Get().Fireflies.Max
+	}
+	fm.spawnCap.Store(int64(limit))
 }
 
-func (fm *FireflyManager) Stop() {
-	fm.cancel()
+// GetSpawnCap combina el límite del gobernador de calidad con el del jugador
+func (fm *FireflyManager) GetSpawnCap() int {
+	limit := int(fm.spawnCap.Load())
+	if max := fm.GetSettings().MaxFireflies; max < limit {
+		limit = max
+	}
+	return limit
+}
 
-	fm.wg.Wait()
+// SetObjective fija la población a la que repone el spawner automático; con
+// un valor negativo vuelve a usar spawn.objective de la configuración
+func (fm *FireflyManager) SetObjective(objective int) {
+	fm.objective.Store(int64(min(objective, config.Get().Fireflies.Max)))
+}
 
-	fm.aggregator.Stop()
-	fm.workerPool.Stop()
+// GetObjective retorna la población objetivo vigente
+func (fm *FireflyManager) GetObjective() int {
+	if objective := fm.objective.Load(); objective >= 0 {
+		return int(objective)
+	}
+	return config.Get().Spawn.Objective
+}
+
+// GetWorld expone el registro de entidades vivas
+func (fm *FireflyManager) GetWorld() *core.World {
+	return fm.world
+}
+
+func (fm *FireflyManager) GetHeatmap() *Heatmap {
+	return fm.heatmap
+}
+
+// ForceField retorna el campo de fuerzas pintado que siguen todas las
+// luciérnagas; se guarda con el jardín
+func (fm *FireflyManager) ForceField() *core.ForceField {
+	return fm.field
+}
+
+// GetDroppedStates retorna los estados descartados por las luciérnagas de
+// este manager; core.GetDroppedStates suma los de todo el proceso
+func (fm *FireflyManager) GetDroppedStates() uint64 {
+	return fm.dropped.Load()
+}
+
+// Stop es idempotente: la señal de interrupción y el cierre de la ventana
+// pueden llegar a llamarlo ambos
+func (fm *FireflyManager) Stop() {
+	fm.stopOnce.Do(func() {
+		unpublishVars(fm)
+		// Con stopMux tomado nadie está a mitad de lanzar una luciérnaga o
+		// una ráfaga: todo wg.Add queda antes de Wait
+		fm.stopMux.Lock()
+		fm.cancel()
+		fm.stopMux.Unlock()
+
+		fm.wg.Wait()
+
+		fm.aggregator.Stop()
+		fm.workerPool.Stop()
+		// commandCh no se cierra: la API o el chat pueden seguir llamando a
+		// Enqueue después de Stop, que desde ahí retorna false
+	})
+}
 
-	close(fm.commandCh)
+// Stopped indica si ya se llamó a Stop
+func (fm *FireflyManager) Stopped() bool {
+	return fm.ctx.Err() != nil
 }
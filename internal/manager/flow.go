@@ -0,0 +1,31 @@
+package manager
+
+// Flow son contadores acumulados de mensajes en cada tramo de la arquitectura.
+// Cada uno es un atomic que se incrementa junto a la operación que mide, así
+// el costo de la instrumentación es una suma atómica por mensaje.
+type Flow struct {
+	States    uint64 // luciérnagas → agregador (stateCh)
+	Dropped   uint64 // estados descartados por canal lleno
+	Snapshots uint64 // agregador → lectores (UI, mapa de calor)
+	Commands  uint64 // UI → manager (commandCh)
+	Spawns    uint64 // manager → goroutines de luciérnagas
+	Jobs      uint64 // manager → worker pool (jobsCh)
+	JobsDone  uint64 // worker pool → resultsCh
+	Events    uint64 // manager → bus de eventos
+}
+
+// Flow lee todos los contadores; la UI calcula las tasas por diferencia
+func (fm *FireflyManager) Flow() Flow {
+	jobs, done := fm.workerPool.GetJobCounts()
+
+	return Flow{
+		States:    fm.aggregator.GetProcessedCount(),
+		Dropped:   fm.GetDroppedStates(),
+		Snapshots: fm.aggregator.GetServedCount(),
+		Commands:  fm.commandsDone.Load(),
+		Spawns:    fm.spawned.Load(),
+		Jobs:      jobs,
+		JobsDone:  done,
+		Events:    fm.events.GetPublished(),
+	}
+}
@@ -0,0 +1,250 @@
+package manager
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// GardenMap es un jardín armado a mano (-map): el tamaño del mundo, el
+// terreno, los faroles y las luciérnagas con que empieza, dónde nacen las
+// demás, el campo de fuerzas y los eventos programados. Los valores en
+// cero dejan los de la configuración.
+//
+//	{
+//	    "name": "Estanque", "width": 1600, "height": 900, "wind": "east",
+//	    "obstacles": [{"x": 300, "y": 200, "w": 120, "h": 60}],
+//	    "ponds": [{"x": 800, "y": 450, "r": 150}],
+//	    "lanterns": [{"x": 500, "y": 450}],
+//	    "spawn_zones": [{"x": 0, "y": 0, "w": 1600, "h": 80, "rate": 0.5, "inward": true}],
+//	    "wind_zones": [{"x": 0, "y": 400, "w": 1600, "h": 100, "direction": "west"}],
+//	    "events": ["wait 20s", "wind cycle"]
+//	}
+type GardenMap struct {
+	Name string `json:"name"`
+	// Width y Height fijan el tamaño del mundo; en cero sigue a la ventana
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+	// Fireflies son las luciérnagas al empezar (0 usa fireflies.initial)
+	Fireflies int `json:"fireflies,omitempty"`
+	// Wind es la dirección inicial del viento ("east", "northwest"...)
+	Wind      string       `json:"wind,omitempty"`
+	Obstacles []core.Area  `json:"obstacles,omitempty"`
+	Ponds     []core.Area  `json:"ponds,omitempty"`
+	Lanterns  []MapLantern `json:"lanterns,omitempty"`
+	// SpawnZones son las regiones donde nacen las luciérnagas, con su peso,
+	// su ritmo propio y si salen hacia el centro; sin zonas se usan las de
+	// spawn.zones o nacen en cualquier lugar
+	SpawnZones []core.SpawnZone `json:"spawn_zones,omitempty"`
+	// WindZones son regiones con un viento propio que se suma al del jardín
+	WindZones []core.WindZone `json:"wind_zones,omitempty"`
+	Field     *core.FieldGrid `json:"field,omitempty"`
+	// Events es un escenario (ver internal/script), una orden por línea,
+	// que conduce la partida; lo interpreta quien carga el mapa
+	Events []string `json:"events,omitempty"`
+}
+
+// MapLantern es un farol que el mapa pone al empezar; sin radio usa el de
+// la configuración
+type MapLantern struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Radius float64 `json:"radius,omitempty"`
+}
+
+// LoadMap lee y valida un mapa
+func LoadMap(path string) (*GardenMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := ParseMap(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// ParseMap decodifica un mapa rechazando campos desconocidos y lo valida
+// contra la configuración activa
+func ParseMap(data []byte) (*GardenMap, error) {
+	var m GardenMap
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Validate rechaza mapas imposibles con la configuración activa
+func (m *GardenMap) Validate() error {
+	cfg := config.Get()
+
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	sized := m.Width != 0 || m.Height != 0
+	check(!sized || m.Width >= config.MinScreenWidth && m.Width <= config.MaxWorldWidth,
+		"width debe estar entre %d y %d (o 0 con height 0)", config.MinScreenWidth, config.MaxWorldWidth)
+	check(!sized || m.Height >= config.MinScreenHeight && m.Height <= config.MaxWorldHeight,
+		"height debe estar entre %d y %d (o 0 con width 0)", config.MinScreenHeight, config.MaxWorldHeight)
+	check(m.Fireflies >= 0 && m.Fireflies <= cfg.Fireflies.Max, "fireflies debe estar entre 0 y fireflies.max")
+	check(len(m.Lanterns) <= cfg.Lanterns.Max, "hay %d faroles y lanterns.max es %d", len(m.Lanterns), cfg.Lanterns.Max)
+	if m.Wind != "" {
+		_, ok := core.ParseWindDirection(m.Wind)
+		check(ok, "wind %q desconocido (north, northeast, east... o none)", m.Wind)
+	}
+
+	// Las áreas tienen que caer dentro del mundo; sin tamaño fijo el mundo
+	// es la ventana y no se puede saber de antemano
+	inside := func(p utils.Vector2D) bool {
+		return !sized || p.X >= 0 && p.X <= float64(m.Width) && p.Y >= 0 && p.Y <= float64(m.Height)
+	}
+	areas := func(name string, list []core.Area) {
+		for i, a := range list {
+			if err := a.Validate(); err != nil {
+				check(false, "%s %d: %v", name, i+1, err)
+				continue
+			}
+			check(inside(a.Center()), "%s %d: fuera del mundo", name, i+1)
+		}
+	}
+	areas("obstacles", m.Obstacles)
+	areas("ponds", m.Ponds)
+	for i, z := range m.SpawnZones {
+		if err := z.Validate(); err != nil {
+			check(false, "spawn_zones %d: %v", i+1, err)
+			continue
+		}
+		check(inside(z.Center()), "spawn_zones %d: fuera del mundo", i+1)
+	}
+	for i, z := range m.WindZones {
+		if err := z.Validate(); err != nil {
+			check(false, "wind_zones %d: %v", i+1, err)
+			continue
+		}
+		check(inside(z.Center()), "wind_zones %d: fuera del mundo", i+1)
+	}
+
+	terrain := m.Terrain()
+	for i, l := range m.Lanterns {
+		p := utils.Vector2D{X: l.X, Y: l.Y}
+		check(inside(p), "lanterns %d: fuera del mundo", i+1)
+		check(!terrain.Blocked(p), "lanterns %d: sobre un obstáculo o un estanque", i+1)
+		check(l.Radius == 0 || l.Radius >= config.LanternRadiusMin && l.Radius <= config.LanternRadiusMax,
+			"lanterns %d: radius debe estar entre %g y %g", i+1, config.LanternRadiusMin, config.LanternRadiusMax)
+	}
+
+	if m.Field != nil {
+		if err := m.Field.Validate(); err != nil {
+			check(false, "field: %v", err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Terrain retorna los obstáculos, estanques y zonas de viento del mapa
+func (m *GardenMap) Terrain() *core.Terrain {
+	return &core.Terrain{Obstacles: m.Obstacles, Ponds: m.Ponds, WindZones: m.WindZones}
+}
+
+// Clone retorna una copia del mapa que se puede editar sin tocar el
+// original; el campo de fuerzas se comparte porque no se modifica
+func (m *GardenMap) Clone() *GardenMap {
+	c := *m
+	c.Obstacles = slices.Clone(m.Obstacles)
+	c.Ponds = slices.Clone(m.Ponds)
+	c.Lanterns = slices.Clone(m.Lanterns)
+	c.SpawnZones = slices.Clone(m.SpawnZones)
+	c.WindZones = slices.Clone(m.WindZones)
+	c.Events = slices.Clone(m.Events)
+	return &c
+}
+
+// WriteMap guarda el mapa como JSON indentado, el mismo formato que lee
+// LoadMap
+func WriteMap(path string, m *GardenMap) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// ApplyMap arma el jardín del mapa: fija el tamaño del mundo, el terreno,
+// el campo de fuerzas y el viento inicial; los faroles y las luciérnagas
+// del mapa los pone Start. nil deja el jardín sin mapa, con el mundo del
+// tamaño de la ventana. Debe llamarse antes de Start.
+func (fm *FireflyManager) ApplyMap(m *GardenMap) {
+	fm.gardenMap = m
+	if m == nil {
+		config.SetWorldSize(0, 0)
+		fm.landscape.Set(nil)
+		return
+	}
+
+	config.SetWorldSize(m.Width, m.Height)
+	fm.landscape.Set(m.Terrain())
+	fm.field.Set(m.Field)
+	if dir, ok := core.ParseWindDirection(m.Wind); ok {
+		fm.wind.SetDirection(dir)
+	}
+	fm.log.Info("mapa cargado", "map", m.Name, "width", m.Width, "height", m.Height,
+		"obstacles", len(m.Obstacles), "ponds", len(m.Ponds), "spawn_zones", len(m.SpawnZones),
+		"wind_zones", len(m.WindZones))
+}
+
+// GardenMap retorna el mapa de la partida; nil si no hay
+func (fm *FireflyManager) GardenMap() *GardenMap {
+	return fm.gardenMap
+}
+
+// Landscape retorna el terreno que rodean las luciérnagas
+func (fm *FireflyManager) Landscape() *core.Landscape {
+	return fm.landscape
+}
+
+// placeMapLanterns pone los faroles del mapa, sin ráfaga
+func (fm *FireflyManager) placeMapLanterns() {
+	if fm.gardenMap == nil {
+		return
+	}
+	for _, l := range fm.gardenMap.Lanterns {
+		radius := l.Radius
+		if radius == 0 {
+			radius = fm.GetSettings().LanternRadius
+		}
+		fm.placeLantern(l.X, l.Y, radius)
+	}
+}
+
+// initialFireflies son las luciérnagas con que empieza la partida
+func (fm *FireflyManager) initialFireflies() int {
+	if fm.gardenMap != nil && fm.gardenMap.Fireflies > 0 {
+		return fm.gardenMap.Fireflies
+	}
+	return config.Get().Fireflies.Initial
+}
@@ -0,0 +1,156 @@
+package manager
+
+import (
+	"math"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+const (
+	// glowCellSize es el lado en píxeles de las celdas donde se buscan
+	// luciérnagas juntas
+	glowCellSize = 64.0
+	// Una luciérnaga que comparte celda suma además glowClusterBonus veces
+	// su brillo, en proporción a cuántas compañeras tiene hasta
+	// glowClusterCap: brillar en grupo rinde hasta el doble
+	glowClusterBonus = 1.0
+	glowClusterCap   = 4
+)
+
+// Glow es el resplandor del jardín: la suma del brillo de todas las
+// luciérnagas más un bono por brillar agrupadas. Es la base del puntaje
+// y de los logros (ver scoreKeeper).
+type Glow struct {
+	Value float64
+	// Brightness es la suma del brillo y Cluster el bono por grupos;
+	// Value = Brightness + Cluster
+	Brightness float64
+	Cluster    float64
+	Fireflies  int
+}
+
+// Intensity es Value sobre el máximo posible con esa población (todas al
+// máximo brillo y bien agrupadas), de 0 a 1
+func (g Glow) Intensity() float64 {
+	if g.Fireflies == 0 {
+		return 0
+	}
+	return min(g.Value/(float64(g.Fireflies)*(1+glowClusterBonus)), 1)
+}
+
+// glowCell es lo que hay en una celda
+type glowCell struct {
+	count      int
+	brightness float64
+}
+
+// bonus es lo que aporta la celda por tener luciérnagas juntas
+func (c glowCell) bonus() float64 {
+	if c.count < 2 {
+		return 0
+	}
+	return c.brightness * glowClusterBonus * float64(min(c.count-1, glowClusterCap)) / glowClusterCap
+}
+
+// glowKey identifica una celda
+type glowKey struct{ col, row int }
+
+// glowEntry es dónde y con cuánto brillo se contó una luciérnaga
+type glowEntry struct {
+	cell       glowKey
+	brightness float64
+}
+
+// glowTracker mantiene el resplandor a medida que llegan los estados: cada
+// estado saca lo que aportaba el anterior y suma lo nuevo en su celda, así
+// el valor está al día en cada tick sin recorrer todas las luciérnagas. Lo
+// protege el statesMux del agregador.
+type glowTracker struct {
+	of         map[int]glowEntry
+	cells      map[glowKey]glowCell
+	brightness float64
+	cluster    float64
+}
+
+func newGlowTracker() glowTracker {
+	return glowTracker{
+		of:    make(map[int]glowEntry),
+		cells: make(map[glowKey]glowCell),
+	}
+}
+
+func (g *glowTracker) update(state core.FireflyState) {
+	g.remove(state.ID)
+
+	entry := glowEntry{
+		cell: glowKey{
+			col: int(math.Floor(state.Position.X / glowCellSize)),
+			row: int(math.Floor(state.Position.Y / glowCellSize)),
+		},
+		brightness: state.Brightness,
+	}
+	g.of[state.ID] = entry
+
+	cell := g.cells[entry.cell]
+	g.cluster -= cell.bonus()
+	cell.count++
+	cell.brightness += entry.brightness
+	g.cluster += cell.bonus()
+	g.cells[entry.cell] = cell
+	g.brightness += entry.brightness
+}
+
+func (g *glowTracker) remove(id int) {
+	entry, ok := g.of[id]
+	if !ok {
+		return
+	}
+	delete(g.of, id)
+
+	cell := g.cells[entry.cell]
+	g.cluster -= cell.bonus()
+	cell.count--
+	cell.brightness -= entry.brightness
+	if cell.count == 0 {
+		delete(g.cells, entry.cell)
+	} else {
+		g.cluster += cell.bonus()
+		g.cells[entry.cell] = cell
+	}
+	g.brightness -= entry.brightness
+
+	if len(g.of) == 0 {
+		// Vacío vuelve a cero exacto, sin el error de redondeo de las restas
+		g.brightness, g.cluster = 0, 0
+	}
+}
+
+func (g *glowTracker) reset() {
+	*g = newGlowTracker()
+}
+
+func (g *glowTracker) value() Glow {
+	brightness, cluster := max(g.brightness, 0), max(g.cluster, 0)
+	return Glow{
+		Value:      brightness + cluster,
+		Brightness: brightness,
+		Cluster:    cluster,
+		Fireflies:  len(g.of),
+	}
+}
+
+// glowAchievement es un logro que se desbloquea la primera vez que el
+// resplandor llega a glow; suma bonus puntos
+type glowAchievement struct {
+	glow  float64
+	name  string
+	bonus int
+}
+
+// glowAchievements son los logros de resplandor, de menor a mayor
+var glowAchievements = []glowAchievement{
+	{glow: 20, name: "Primer resplandor", bonus: 50},
+	{glow: 60, name: "Jardín encendido", bonus: 150},
+	{glow: 150, name: "Noche de fiesta", bonus: 400},
+	{glow: 400, name: "Constelación", bonus: 1000},
+}
@@ -0,0 +1,201 @@
+package manager
+
+import (
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// gossipNoteBuffer es el buffer de avisos de destello con el rumor; si
+	// se llena, la luciérnaga lo intenta en su próximo destello
+	gossipNoteBuffer = 64
+	// gossipRadius es la distancia a la que un destello contagia el rumor
+	gossipRadius = 150
+	// gossipStatusInterval es cada cuánto se recuenta la cobertura
+	gossipStatusInterval = 250 * time.Millisecond
+)
+
+// GossipStatus es el estado del modo rumor para el HUD
+type GossipStatus struct {
+	Active bool
+	// Infected de Alive luciérnagas tienen el rumor; Round es la ronda más
+	// alta a la que llegó
+	Infected, Alive int
+	Round           int
+	// Complete indica que en algún momento todas lo tuvieron: a cuántas
+	// rondas y cuánto tiempo después de empezar
+	Complete      bool
+	CompleteRound int
+	CompleteAfter time.Duration
+}
+
+// SetGossip empieza el modo rumor con una luciérnaga al azar o lo termina y
+// se lo quita a todas
+func (fm *FireflyManager) SetGossip(on bool) {
+	select {
+	case fm.gossipCmd <- on:
+	case <-fm.ctx.Done():
+	}
+}
+
+// GetGossipStatus retorna el último recuento del modo rumor
+func (fm *FireflyManager) GetGossipStatus() GossipStatus {
+	if status := fm.gossip.Load(); status != nil {
+		return *status
+	}
+	return GossipStatus{}
+}
+
+// gossipLoop es la dueña del rumor. Las luciérnagas que lo tienen avisan por
+// gossipCh cada vez que destellan; la goroutine busca a las vecinas que lo
+// vieron y se lo pasa por su canal de control con la ronda siguiente. Nadie
+// más toca el registro de quién lo tiene: todo pasa por canales.
+func (fm *FireflyManager) gossipLoop() {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemGossip)()
+
+	ticker := time.NewTicker(gossipStatusInterval)
+	defer ticker.Stop()
+
+	var (
+		active  bool
+		started time.Time
+		// infected es la ronda de cada luciérnaga a la que ya se le envió
+		infected = make(map[int]int)
+		status   GossipStatus
+	)
+
+	for {
+		select {
+		case <-fm.ctx.Done():
+			return
+
+		case on := <-fm.gossipCmd:
+			clear(infected)
+			status = GossipStatus{}
+			active = false
+			fm.lifecycleMux.RLock()
+			fm.broadcast(core.Control{Kind: core.ControlGossip})
+			if on {
+				if id, ok := fm.gossipSeed(); ok {
+					active = true
+					started = time.Now()
+					infected[id] = 1
+					fm.sendGossip(id, 1)
+				}
+			}
+			fm.lifecycleMux.RUnlock()
+			status.Active = active
+			fm.gossip.Store(&status)
+
+		case note := <-fm.gossipCh:
+			if !active {
+				continue
+			}
+			// Los avisos que se juntaron se resuelven con un solo snapshot
+			notes := []core.GossipNote{note}
+			for drained := false; !drained; {
+				select {
+				case n := <-fm.gossipCh:
+					notes = append(notes, n)
+				default:
+					drained = true
+				}
+			}
+			fm.spreadGossip(notes, infected)
+
+		case <-ticker.C:
+			if !active {
+				continue
+			}
+			status = fm.countGossip(status, infected)
+			if !status.Complete && status.Alive > 0 && status.Infected == status.Alive {
+				status.Complete = true
+				status.CompleteRound = status.Round
+				status.CompleteAfter = time.Since(started)
+				fm.log.Info("rumor propagado", "rondas", status.Round, "tiempo", status.CompleteAfter)
+			}
+			next := status
+			fm.gossip.Store(&next)
+		}
+	}
+}
+
+// gossipSeed elige la luciérnaga viva que empieza el rumor
+func (fm *FireflyManager) gossipSeed() (int, bool) {
+	states := fm.aggregator.GetSnapshot()
+	defer ReleaseStates(states)
+
+	alive := states[:0]
+	for _, s := range states {
+		if s.IsAlive {
+			alive = append(alive, s)
+		}
+	}
+	if len(alive) == 0 {
+		return 0, false
+	}
+	i := min(int(utils.RandomFloat(0, float64(len(alive)))), len(alive)-1)
+	return alive[i].ID, true
+}
+
+// spreadGossip contagia a las vecinas de cada destello que todavía no lo
+// tienen
+func (fm *FireflyManager) spreadGossip(notes []core.GossipNote, infected map[int]int) {
+	states := fm.aggregator.GetSnapshot()
+	defer ReleaseStates(states)
+
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
+
+	index := newSpatialIndex(states, gossipRadius)
+	var near []int
+	for _, note := range notes {
+		near = index.near(near[:0], note.Position, gossipRadius)
+		for _, i := range near {
+			s := states[i]
+			if _, has := infected[s.ID]; has || !s.IsAlive {
+				continue
+			}
+			infected[s.ID] = note.Round + 1
+			fm.sendGossip(s.ID, note.Round+1)
+		}
+	}
+}
+
+// sendGossip le pasa el rumor a una luciérnaga en marcha; debe llamarse con
+// lifecycleMux tomado en lectura
+func (fm *FireflyManager) sendGossip(id, round int) {
+	if r, ok := fm.routes.get(id); ok {
+		r.send(core.Control{Kind: core.ControlGossip, Round: round})
+	}
+}
+
+// countGossip recuenta la cobertura con lo que publican las luciérnagas y
+// olvida a las que ya no están
+func (fm *FireflyManager) countGossip(status GossipStatus, infected map[int]int) GossipStatus {
+	states := fm.aggregator.GetSnapshot()
+	defer ReleaseStates(states)
+
+	status.Alive, status.Infected, status.Round = 0, 0, 0
+	present := make(map[int]bool, len(states))
+	for _, s := range states {
+		if !s.IsAlive {
+			continue
+		}
+		present[s.ID] = true
+		status.Alive++
+		if s.Gossip > 0 {
+			status.Infected++
+			status.Round = max(status.Round, s.Gossip)
+		}
+	}
+	for id := range infected {
+		if !present[id] {
+			delete(infected, id)
+		}
+	}
+	return status
+}
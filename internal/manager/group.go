@@ -0,0 +1,57 @@
+package manager
+
+import (
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// GroupOrderKind es lo que se le ordena a un grupo de luciérnagas
+type GroupOrderKind string
+
+const (
+	// GroupAttract las atrae a Position sin importar el punto de atracción global
+	GroupAttract GroupOrderKind = "attract"
+	// GroupFreeze las deja quietas donde están
+	GroupFreeze GroupOrderKind = "freeze"
+	// GroupRelease les quita la orden y vuelven al comportamiento de todas
+	GroupRelease GroupOrderKind = "release"
+)
+
+// GroupOrder es una orden para las luciérnagas seleccionadas por ID. Se envía
+// con CommandGroupOrder; IDs no debe modificarse después de enviarla.
+type GroupOrder struct {
+	Kind     GroupOrderKind `json:"kind"`
+	IDs      []int          `json:"ids"`
+	Position utils.Vector2D `json:"pos"`
+}
+
+// applyGroupOrder entrega la orden por la ruta de cada luciérnaga que siga
+// viva; las que ya murieron se ignoran. Se ejecuta en commandLoop.
+func (fm *FireflyManager) applyGroupOrder(order GroupOrder) int {
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
+
+	var individual *core.Order
+	switch order.Kind {
+	case GroupAttract:
+		target := order.Position
+		individual = &core.Order{Target: &target}
+	case GroupFreeze:
+		individual = &core.Order{Frozen: true}
+	case GroupRelease:
+		individual = nil
+	default:
+		return 0
+	}
+
+	applied := 0
+	for _, id := range order.IDs {
+		if r, ok := fm.routes.get(id); ok && r.send(core.Control{Kind: core.ControlOrder, Order: individual}) {
+			applied++
+		}
+	}
+
+	fm.log.Debug("orden de grupo", "kind", order.Kind, "fireflies", applied, "requested", len(order.IDs))
+	fm.events.Publish(Event{Type: EventGroupOrder, Group: &order})
+	return applied
+}
@@ -0,0 +1,140 @@
+package manager
+
+import (
+	"math"
+	"sync"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+// Heatmap acumula en una grilla el tiempo que las luciérnagas pasan en cada celda.
+// La acumulación corre en el worker pool; el render solo copia el resultado difuminado.
+type Heatmap struct {
+	cols     int
+	rows     int
+	cellSize int
+	halfLife float64
+	grid     []float64
+	blurred  []float64
+	maxValue float64
+	mux      sync.RWMutex
+}
+
+func NewHeatmap(width, height, cellSize int, halfLife float64) *Heatmap {
+	cols := width / cellSize
+	rows := height / cellSize
+
+	return &Heatmap{
+		cols:     cols,
+		rows:     rows,
+		cellSize: cellSize,
+		halfLife: halfLife,
+		grid:     make([]float64, cols*rows),
+		blurred:  make([]float64, cols*rows),
+	}
+}
+
+func (h *Heatmap) Accumulate(states []core.FireflyState, dt float64) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	decay := math.Pow(0.5, dt/h.halfLife)
+	for i := range h.grid {
+		h.grid[i] *= decay
+	}
+
+	for _, state := range states {
+		col := int(state.Position.X) / h.cellSize
+		row := int(state.Position.Y) / h.cellSize
+		if col < 0 || col >= h.cols || row < 0 || row >= h.rows {
+			continue
+		}
+		h.grid[row*h.cols+col] += dt
+	}
+
+	h.blur()
+}
+
+// blur aplica un box blur 3x3 sobre la grilla y guarda el máximo para normalizar
+func (h *Heatmap) blur() {
+	h.maxValue = 0
+
+	for row := 0; row < h.rows; row++ {
+		for col := 0; col < h.cols; col++ {
+			sum := 0.0
+			n := 0
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					r, c := row+dy, col+dx
+					if r < 0 || r >= h.rows || c < 0 || c >= h.cols {
+						continue
+					}
+					sum += h.grid[r*h.cols+c]
+					n++
+				}
+			}
+
+			value := sum / float64(n)
+			h.blurred[row*h.cols+col] = value
+			if value > h.maxValue {
+				h.maxValue = value
+			}
+		}
+	}
+}
+
+// Snapshot copia la grilla difuminada en dst y retorna el valor máximo
+func (h *Heatmap) Snapshot(dst []float64) ([]float64, float64) {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+
+	if cap(dst) < len(h.blurred) {
+		dst = make([]float64, len(h.blurred))
+	}
+	dst = dst[:len(h.blurred)]
+	copy(dst, h.blurred)
+
+	return dst, h.maxValue
+}
+
+// Resize ajusta la grilla a un mundo de width×height conservando lo
+// acumulado en las celdas que siguen existiendo
+func (h *Heatmap) Resize(width, height int) {
+	cols := width / h.cellSize
+	rows := height / h.cellSize
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	if cols == h.cols && rows == h.rows {
+		return
+	}
+
+	grid := make([]float64, cols*rows)
+	for row := 0; row < min(rows, h.rows); row++ {
+		copy(grid[row*cols:row*cols+min(cols, h.cols)], h.grid[row*h.cols:])
+	}
+
+	h.cols, h.rows = cols, rows
+	h.grid = grid
+	h.blurred = make([]float64, cols*rows)
+	h.blur()
+}
+
+func (h *Heatmap) Size() (int, int) {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+
+	return h.cols, h.rows
+}
+
+func (h *Heatmap) Clear() {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	for i := range h.grid {
+		h.grid[i] = 0
+		h.blurred[i] = 0
+	}
+	h.maxValue = 0
+}
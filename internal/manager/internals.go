@@ -0,0 +1,133 @@
+package manager
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Subsistemas con goroutines propias, en el orden en que se muestran
+const (
+	SubsystemFireflies  = "luciérnagas"
+	SubsystemAggregator = "agregador"
+	SubsystemWorkers    = "worker pool"
+	SubsystemCommands   = "comandos"
+	SubsystemWind       = "viento"
+	SubsystemSpawner    = "spawner"
+	SubsystemHeatmap    = "mapa de calor"
+	SubsystemStats      = "estadísticas"
+	SubsystemConfig     = "config"
+	SubsystemPlayback   = "repetición"
+	SubsystemNeighbors  = "vecindario"
+	SubsystemBats       = "murciélagos"
+	SubsystemScore      = "puntaje"
+	SubsystemGossip     = "rumor"
+	SubsystemElection   = "elección"
+	SubsystemChaos      = "caos"
+	SubsystemEcology    = "ecología"
+	SubsystemStorm      = "tormenta"
+	SubsystemClusters   = "cúmulos"
+	SubsystemSpawnZones = "zonas de aparición"
+	SubsystemBatches    = "lotes"
+	SubsystemPower      = "bajo consumo"
+)
+
+var subsystemOrder = []string{
+	SubsystemFireflies, SubsystemBatches, SubsystemAggregator, SubsystemWorkers, SubsystemCommands,
+	SubsystemWind, SubsystemSpawner, SubsystemHeatmap, SubsystemStats,
+	SubsystemConfig, SubsystemPlayback, SubsystemNeighbors, SubsystemBats,
+	SubsystemScore, SubsystemGossip, SubsystemElection, SubsystemChaos,
+	SubsystemEcology, SubsystemStorm, SubsystemClusters, SubsystemSpawnZones,
+	SubsystemPower,
+}
+
+// goroutineCounter cuenta las goroutines vivas de cada subsistema
+type goroutineCounter struct {
+	mux    sync.Mutex
+	counts map[string]int
+}
+
+// track anota una goroutine del subsistema; la función retornada la descuenta
+// y se usa con defer al inicio de la goroutine
+func (c *goroutineCounter) track(subsystem string) func() {
+	c.add(subsystem, 1)
+	return func() { c.add(subsystem, -1) }
+}
+
+func (c *goroutineCounter) add(subsystem string, delta int) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.counts == nil {
+		c.counts = make(map[string]int)
+	}
+	c.counts[subsystem] += delta
+}
+
+func (c *goroutineCounter) get(subsystem string) int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.counts[subsystem]
+}
+
+type GoroutineCount struct {
+	Subsystem string
+	Count     int
+}
+
+// Internals es una foto de la maquinaria concurrente del manager
+type Internals struct {
+	Goroutines      []GoroutineCount
+	TotalGoroutines int
+
+	StateQueue, StateCap     int
+	CommandQueue, CommandCap int
+	JobQueue, JobCap         int
+	ResultQueue, ResultCap   int
+
+	AggregatorStates    int
+	AggregatorPrevious  int
+	AggregatorProcessed uint64
+	DroppedStates       uint64
+	DroppedEvents       uint64
+
+	Spawns       SpawnCounts
+	Chaos        ChaosCounts
+	Backpressure Backpressure
+	Batch        BatchStats
+}
+
+// Internals lee longitudes de canales y contadores sin bloquear la simulación
+func (fm *FireflyManager) Internals() Internals {
+	in := Internals{TotalGoroutines: runtime.NumGoroutine()}
+
+	for _, subsystem := range subsystemOrder {
+		count := fm.goroutines.get(subsystem)
+		switch subsystem {
+		case SubsystemAggregator:
+			count = fm.aggregator.GetGoroutineCount()
+		case SubsystemWorkers:
+			count = fm.workerPool.GetWorkerCount()
+		}
+		if count > 0 {
+			in.Goroutines = append(in.Goroutines, GoroutineCount{Subsystem: subsystem, Count: count})
+		}
+	}
+
+	stateCh := fm.aggregator.GetStateChannel()
+	in.StateQueue, in.StateCap = len(stateCh), cap(stateCh)
+	in.CommandQueue, in.CommandCap = len(fm.commandCh), cap(fm.commandCh)
+	in.JobQueue, in.JobCap = fm.workerPool.JobQueue()
+	in.ResultQueue, in.ResultCap = fm.workerPool.ResultQueue()
+
+	in.AggregatorStates, in.AggregatorPrevious = fm.aggregator.GetMapSizes()
+	in.AggregatorProcessed = fm.aggregator.GetProcessedCount()
+	in.DroppedStates = fm.GetDroppedStates()
+	in.DroppedEvents = fm.events.GetDropped()
+	in.Spawns = fm.GetSpawnCounts()
+	in.Chaos = fm.GetChaosCounts()
+	in.Backpressure = fm.backpressure()
+	in.Batch = fm.GetBatchStats()
+
+	return in
+}
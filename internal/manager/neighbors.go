@@ -0,0 +1,89 @@
+package manager
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/plugin"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// neighborInterval es cada cuánto se reconstruye la grilla de vecinas
+const neighborInterval = 100 * time.Millisecond
+
+type cellKey struct{ x, y int }
+
+// neighborGrid es inmutable una vez publicada: las luciérnagas la leen sin lock
+type neighborGrid struct {
+	cells map[cellKey][]plugin.Neighbor
+}
+
+// neighborhood publica, desde el último snapshot del agregador, una grilla
+// con celdas de plugin.NeighborRadius para que cada luciérnaga mire solo las
+// 9 celdas que la rodean
+type neighborhood struct {
+	grid atomic.Pointer[neighborGrid]
+}
+
+func cellOf(pos utils.Vector2D) cellKey {
+	return cellKey{
+		x: int(math.Floor(pos.X / plugin.NeighborRadius)),
+		y: int(math.Floor(pos.Y / plugin.NeighborRadius)),
+	}
+}
+
+func (n *neighborhood) rebuild(states []core.FireflyState) {
+	grid := &neighborGrid{cells: make(map[cellKey][]plugin.Neighbor)}
+	for _, s := range states {
+		key := cellOf(s.Position)
+		grid.cells[key] = append(grid.cells[key], plugin.Neighbor{
+			ID:         s.ID,
+			Position:   s.Position,
+			Brightness: s.Brightness,
+		})
+	}
+	n.grid.Store(grid)
+}
+
+// Near implementa core.Neighborhood
+func (n *neighborhood) Near(pos utils.Vector2D, self int, buf []plugin.Neighbor) []plugin.Neighbor {
+	grid := n.grid.Load()
+	if grid == nil {
+		return buf
+	}
+
+	center := cellOf(pos)
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			for _, nb := range grid.cells[cellKey{center.x + dx, center.y + dy}] {
+				if nb.ID != self && utils.Distance(pos, nb.Position) <= plugin.NeighborRadius {
+					buf = append(buf, nb)
+				}
+			}
+		}
+	}
+	return buf
+}
+
+// neighborSampler solo corre si hay plugins de comportamiento registrados
+func (fm *FireflyManager) neighborSampler() {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemNeighbors)()
+
+	ticker := fm.clock.NewTicker(neighborInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-fm.ctx.Done():
+			return
+
+		case <-ticker.C():
+			states := fm.aggregator.GetSnapshot()
+			fm.neighbors.rebuild(states)
+			ReleaseStates(states)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package manager
+
+// noticeBuffer es cuántos avisos esperan a que el render los lea; si nadie
+// los lee (headless, TUI) los siguientes se descartan
+const noticeBuffer = 32
+
+// NoticeLevel es la importancia de un aviso, para elegir su color
+type NoticeLevel int
+
+const (
+	NoticeInfo NoticeLevel = iota
+	NoticeSuccess
+	NoticeWarning
+)
+
+// Notice es un aviso para el jugador: un límite alcanzado, una orden
+// descartada, una meta cumplida. Viaja sin formatear, en español: quien lo
+// muestra lo traduce con i18n.T(Format, Args...) al idioma del momento.
+type Notice struct {
+	Level  NoticeLevel
+	Format string
+	Args   []any
+}
+
+// Notices retorna el canal de avisos del manager
+func (fm *FireflyManager) Notices() <-chan Notice {
+	return fm.notices
+}
+
+// notify envía un aviso sin bloquear; puede llamarse con locks tomados
+func (fm *FireflyManager) notify(level NoticeLevel, format string, args ...any) {
+	select {
+	case fm.notices <- Notice{Level: level, Format: format, Args: args}:
+	default:
+	}
+}
+
+// Send encola un comando sin bloquear; si la cola está llena lo descarta y
+// avisa en lugar de perderlo en silencio
+func (fm *FireflyManager) Send(cmd Command) bool {
+	if !fm.Enqueue(cmd) {
+		fm.notify(NoticeWarning, "Cola de comandos llena: se descartó una orden")
+		return false
+	}
+	return true
+}
+
+// Enqueue encola un comando sin bloquear y sin avisar; retorna false si la
+// cola está llena o el manager ya se detuvo. Los comandos encolados aquí se
+// trazan.
+func (fm *FireflyManager) Enqueue(cmd Command) bool {
+	cmd.trace = traceCommand(cmd)
+	if fm.Stopped() {
+		cmd.trace.end("manager detenido")
+		return false
+	}
+	select {
+	case fm.commandCh <- cmd:
+		return true
+	default:
+		cmd.trace.end("cola de comandos llena")
+		return false
+	}
+}
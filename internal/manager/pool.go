@@ -0,0 +1,49 @@
+package manager
+
+import (
+	"sync"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+// statePool reutiliza los slices de snapshots entre frames para no
+// generar basura en cada Draw. Devolver un slice es opcional: si el
+// llamador no lo libera simplemente lo recoge el GC.
+var statePool = sync.Pool{
+	New: func() interface{} {
+		s := make([]core.FireflyState, 0, 256)
+		return &s
+	},
+}
+
+// holderPool guarda los punteros vacíos que statePool necesita para
+// guardar un slice: ReleaseStates reusa uno en lugar de asignar otro en
+// cada frame
+var holderPool = sync.Pool{
+	New: func() interface{} {
+		return new([]core.FireflyState)
+	},
+}
+
+func acquireStates(capacity int) []core.FireflyState {
+	p := statePool.Get().(*[]core.FireflyState)
+	states := *p
+	*p = nil
+	holderPool.Put(p)
+
+	if cap(states) < capacity {
+		return make([]core.FireflyState, 0, capacity)
+	}
+	return states[:0]
+}
+
+// ReleaseStates devuelve al pool un slice obtenido de GetSnapshot o
+// GetInterpolatedSnapshot. No debe usarse el slice después de liberarlo.
+func ReleaseStates(states []core.FireflyState) {
+	if cap(states) == 0 {
+		return
+	}
+	p := holderPool.Get().(*[]core.FireflyState)
+	*p = states[:0]
+	statePool.Put(p)
+}
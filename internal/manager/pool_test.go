@@ -0,0 +1,44 @@
+package manager
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+// newFilledAggregator arranca un agregador con n luciérnagas vivas ya
+// aplicadas; se detiene al terminar el benchmark
+func newFilledAggregator(tb testing.TB, n int) *StateAggregator {
+	tb.Helper()
+	sa := NewStateAggregator(n)
+	sa.Start()
+	tb.Cleanup(sa.Stop)
+
+	for i := range n {
+		sa.GetStateChannel() <- core.FireflyState{ID: i, IsAlive: true}
+	}
+	for sa.GetCount() < n {
+		runtime.Gosched()
+	}
+	return sa
+}
+
+// BenchmarkSnapshot compara un frame con 2000 luciérnagas devolviendo el
+// snapshot al pool (como el render) y sin devolverlo: pooled no asigna nada
+func BenchmarkSnapshot(b *testing.B) {
+	sa := newFilledAggregator(b, 2000)
+
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			ReleaseStates(sa.GetSnapshot())
+		}
+	})
+	b.Run("unpooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = sa.GetSnapshot()
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package manager
+
+import (
+	"strings"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/logging"
+)
+
+// configWatcher vigila el archivo de configuración y envía los cambios
+// por el canal de comandos para que se apliquen en commandLoop
+func (fm *FireflyManager) configWatcher(src config.Source) {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemConfig)()
+
+	log := logging.For("config")
+
+	config.Watch(fm.ctx, src, config.ConfigPollInterval,
+		func(reload config.Reload) {
+			if len(reload.Changed) > 0 {
+				log.Info("configuración recargada", "path", src.Path, "changed", reload.Changed)
+				fm.notify(NoticeInfo, "Configuración recargada (%d cambios)", len(reload.Changed))
+			}
+			if len(reload.Restart) > 0 {
+				log.Warn("requieren reiniciar para aplicarse", "path", src.Path, "fields", reload.Restart)
+				fm.notify(NoticeWarning, "Algunos cambios requieren reiniciar: %s", strings.Join(reload.Restart, ", "))
+			}
+			if len(reload.Changed) == 0 {
+				return
+			}
+
+			select {
+			case fm.commandCh <- Command{Type: CommandReloadConfig, Data: reload.Config}:
+			case <-fm.ctx.Done():
+			}
+		},
+		func(err error) {
+			log.Error("configuración ignorada", "path", src.Path, "err", err)
+			fm.notify(NoticeWarning, "Configuración ignorada: %v", err)
+		},
+	)
+}
+
+// applyConfig publica la nueva configuración y actualiza los ajustes en vivo
+// que derivan de ella (los subsistemas leen el resto con config.Get)
+func (fm *FireflyManager) applyConfig(cfg *config.Config) {
+	old := config.Get()
+	config.Set(cfg)
+
+	if cfg.LogLevel != old.LogLevel {
+		if level, err := logging.ParseLevel(cfg.LogLevel); err == nil {
+			logging.SetLevel(level)
+		}
+	}
+
+	settings := fm.GetSettings()
+	if cfg.Fireflies.Max != old.Fireflies.Max {
+		settings.MaxFireflies = cfg.Fireflies.Max
+	}
+	if cfg.Fireflies.SpawnInterval != old.Fireflies.SpawnInterval {
+		settings.SpawnInterval = cfg.Fireflies.SpawnInterval.Duration / 2
+	}
+	if cfg.Wind.Force != old.Wind.Force {
+		settings.WindStrength = cfg.Wind.Force
+	}
+	if cfg.Lanterns.Radius != old.Lanterns.Radius {
+		settings.LanternRadius = cfg.Lanterns.Radius
+	}
+	fm.applySettings(settings)
+}
@@ -0,0 +1,373 @@
+package manager
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	replayVersion      = 1
+	recorderBuffer     = 4096
+	playbackResolution = 10 * time.Millisecond
+)
+
+// ReplayHeader es la primera línea de un archivo de repetición; el resto
+// son eventos, uno por línea
+type ReplayHeader struct {
+	Version   int       `json:"version"`
+	Seed      int64     `json:"seed"`
+	StartedAt time.Time `json:"started_at"`
+	Settings  Settings  `json:"settings"`
+}
+
+type Replay struct {
+	Header ReplayHeader
+	Events []Event
+}
+
+// Duration retorna el instante del último evento
+func (r *Replay) Duration() time.Duration {
+	if len(r.Events) == 0 {
+		return 0
+	}
+	return r.Events[len(r.Events)-1].T
+}
+
+// Recorder escribe en un archivo JSONL todos los eventos del manager
+type Recorder struct {
+	file        *os.File
+	w           *bufio.Writer
+	events      <-chan Event
+	unsubscribe func()
+	done        chan struct{}
+	count       int
+	err         error
+}
+
+func NewRecorder(path string) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{
+		file: file,
+		w:    bufio.NewWriter(file),
+		done: make(chan struct{}),
+	}, nil
+}
+
+// Start escribe la cabecera y empieza a grabar. Debe llamarse antes de
+// fm.Start para capturar también las luciérnagas iniciales.
+func (r *Recorder) Start(fm *FireflyManager) error {
+	header := ReplayHeader{
+		Version:   replayVersion,
+		Seed:      utils.CurrentSeed(),
+		StartedAt: time.Now(),
+		Settings:  fm.GetSettings(),
+	}
+	if err := json.NewEncoder(r.w).Encode(header); err != nil {
+		return err
+	}
+
+	r.events, r.unsubscribe = fm.Events().Subscribe(recorderBuffer)
+	go r.run()
+
+	return nil
+}
+
+func (r *Recorder) run() {
+	defer close(r.done)
+
+	enc := json.NewEncoder(r.w)
+	for e := range r.events {
+		if r.err != nil {
+			continue
+		}
+		if err := enc.Encode(e); err != nil {
+			r.err = err
+			continue
+		}
+		r.count++
+	}
+}
+
+// Stop deja de grabar, vacía el buffer y cierra el archivo.
+// Retorna cuántos eventos se escribieron.
+func (r *Recorder) Stop() (int, error) {
+	if r.unsubscribe != nil {
+		r.unsubscribe()
+		<-r.done
+	}
+
+	err := errors.Join(r.err, r.w.Flush(), r.file.Close())
+	return r.count, err
+}
+
+// LoadReplay lee un archivo escrito por Recorder
+func LoadReplay(path string) (*Replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(file))
+
+	replay := &Replay{}
+	if err := dec.Decode(&replay.Header); err != nil {
+		return nil, fmt.Errorf("cabecera inválida: %w", err)
+	}
+	if replay.Header.Version != replayVersion {
+		return nil, fmt.Errorf("versión de repetición %d no soportada", replay.Header.Version)
+	}
+
+	for {
+		var e Event
+		err := dec.Decode(&e)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("evento %d: %w", len(replay.Events)+1, err)
+		}
+		replay.Events = append(replay.Events, e)
+	}
+
+	return replay, nil
+}
+
+// Player vuelve a conducir el manager a partir de una repetición. Los eventos
+// se envían por el canal de comandos en el instante en que ocurrieron,
+// escalado por la velocidad de reproducción.
+type Player struct {
+	replay  *Replay
+	fm      *FireflyManager
+	speed   atomic.Int64
+	elapsed atomic.Int64
+	done    chan struct{}
+}
+
+func NewPlayer(replay *Replay) *Player {
+	p := &Player{
+		replay: replay,
+		done:   make(chan struct{}),
+	}
+	p.speed.Store(1)
+	return p
+}
+
+// Start siembra el generador con la semilla grabada y lanza la reproducción.
+// El manager debe tener EnablePlayback activado y haber sido iniciado.
+func (p *Player) Start(fm *FireflyManager) {
+	p.fm = fm
+
+	utils.Seed(p.replay.Header.Seed)
+	fm.applySettings(p.replay.Header.Settings)
+	if speed := p.Speed(); speed > 1 {
+		fm.setTimeScale(float64(speed))
+	}
+
+	fm.wg.Add(1)
+	go p.run()
+}
+
+func (p *Player) run() {
+	defer p.fm.wg.Done()
+	defer p.fm.goroutines.track(SubsystemPlayback)()
+	defer close(p.done)
+
+	ticker := time.NewTicker(playbackResolution)
+	defer ticker.Stop()
+
+	events := p.replay.Events
+	next := 0
+	last := time.Now()
+	var elapsed time.Duration
+
+	for next < len(events) {
+		select {
+		case <-p.fm.ctx.Done():
+			return
+
+		case now := <-ticker.C:
+			elapsed += now.Sub(last) * time.Duration(p.speed.Load())
+			last = now
+			p.elapsed.Store(int64(elapsed))
+
+			for next < len(events) && events[next].T <= elapsed {
+				select {
+				case p.fm.commandCh <- Command{Type: CommandReplayEvent, Data: events[next]}:
+				case <-p.fm.ctx.Done():
+					return
+				}
+				next++
+			}
+		}
+	}
+}
+
+// SetSpeed cambia la velocidad de reproducción (1, 2, 4...). Las luciérnagas
+// se relanzan con el paso de simulación escalado para que el movimiento
+// acompañe a los eventos.
+func (p *Player) SetSpeed(speed int) {
+	if speed < 1 {
+		speed = 1
+	}
+	if int64(speed) == p.speed.Load() {
+		return
+	}
+
+	p.speed.Store(int64(speed))
+	if p.fm != nil {
+		p.fm.setTimeScale(float64(speed))
+	}
+}
+
+func (p *Player) Speed() int {
+	return int(p.speed.Load())
+}
+
+// Progress retorna el tiempo reproducido y la duración total
+func (p *Player) Progress() (time.Duration, time.Duration) {
+	elapsed := time.Duration(p.elapsed.Load())
+	total := p.replay.Duration()
+	if elapsed > total {
+		elapsed = total
+	}
+	return elapsed, total
+}
+
+// Done se cierra al terminar de enviar todos los eventos
+func (p *Player) Done() <-chan struct{} {
+	return p.done
+}
+
+// applyEvent reproduce un evento grabado; se ejecuta en commandLoop
+func (fm *FireflyManager) applyEvent(e Event) {
+	switch e.Type {
+	case EventSpawn:
+		if e.Firefly != nil {
+			fm.replaySpawn(*e.Firefly)
+		}
+
+	case EventLanternAdd:
+		if e.Lantern != nil {
+			fm.replayLantern(*e.Lantern)
+		}
+
+	case EventLanternRemove:
+		fm.replayLanternRemove(e.ID)
+
+	case EventAttraction:
+		if e.Position != nil {
+			point := *e.Position
+			fm.setAttractionPoint(&point)
+		}
+
+	case EventAttractionClear:
+		fm.clearAttractionPoint()
+
+	case EventWind:
+		if e.Wind != nil {
+			fm.wind.SetDirection(*e.Wind)
+			fm.events.Publish(Event{Type: EventWind, Wind: e.Wind})
+		}
+
+	case EventSettings:
+		if e.Settings != nil {
+			fm.applySettings(*e.Settings)
+		}
+
+	case EventRestore:
+		if e.Snapshot != nil {
+			fm.RestoreSnapshot(*e.Snapshot)
+		}
+
+	case EventCapture, EventEaten:
+		fm.takeFirefly(e.ID, e.Type)
+
+	case EventGroupOrder:
+		if e.Group != nil {
+			fm.applyGroupOrder(*e.Group)
+		}
+
+	case EventStorm:
+		// El frente grabado entra ahora, con el reloj de la reproducción
+		if e.Storm != nil {
+			front := *e.Storm
+			front.Start = fm.clock.Now()
+			fm.wind.SetStorm(&front)
+			fm.events.Publish(Event{Type: EventStorm, Storm: &front})
+		}
+
+	case EventStormEnd:
+		fm.endStorm()
+
+		// EventDeath es informativo: cada luciérnaga muere sola al cumplir
+		// la vida que trae su snapshot. Los murciélagos no se reproducen:
+		// de EventBatWave basta con las EventEaten que lo siguen
+	}
+}
+
+// replaySpawn recrea una luciérnaga con el estado exacto con que nació
+func (fm *FireflyManager) replaySpawn(s core.FireflySnapshot) {
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
+
+	// La grabación manda: el spawn se reproduce aunque pase el límite
+	firefly := core.RestoreFirefly(s)
+	fm.world.Add(firefly)
+	fm.admission.live.Add(1)
+	fm.events.Publish(Event{Type: EventSpawn, ID: s.ID, Firefly: &s})
+
+	fm.attachFirefly(firefly)
+	fm.runFirefly(firefly)
+}
+
+// replayLantern coloca un farol con su ID original y sin la ráfaga de AddLantern
+func (fm *FireflyManager) replayLantern(s LanternSnapshot) {
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
+
+	lantern := core.NewLantern(s.ID, s.Position.X, s.Position.Y)
+	lantern.Radius = s.Radius
+	lantern.SetPulsePhase(s.PulsePhase)
+	fm.world.Add(lantern)
+	fm.broadcastLanterns()
+	fm.events.Publish(Event{Type: EventLanternAdd, ID: s.ID, Lantern: &s})
+}
+
+// replayLanternRemove quita el farol con ese ID
+func (fm *FireflyManager) replayLanternRemove(id int) {
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
+
+	fm.world.Remove(id)
+	fm.broadcastLanterns()
+	fm.events.Publish(Event{Type: EventLanternRemove, ID: id})
+}
+
+// setTimeScale relanza las luciérnagas con el paso de simulación escalado
+func (fm *FireflyManager) setTimeScale(scale float64) {
+	fm.lifecycleMux.Lock()
+	defer fm.lifecycleMux.Unlock()
+
+	fm.quiesce()
+	fm.timeScale = scale
+	fm.resume()
+}
@@ -0,0 +1,167 @@
+package manager
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+// controlBuffer es el buffer del canal de control de cada luciérnaga; la
+// goroutine lo atiende entre ticks, así que casi nunca se llena
+const controlBuffer = 8
+
+// route es el canal de control de una luciérnaga en marcha. done se cierra
+// cuando su goroutine termina: un envío nunca queda esperando a una
+// luciérnaga que ya murió.
+type route struct {
+	control chan core.Control
+	done    chan struct{}
+}
+
+// send entrega msg; retorna false si la goroutine ya había terminado
+func (r *route) send(msg core.Control) bool {
+	select {
+	case r.control <- msg:
+		return true
+	case <-r.done:
+		return false
+	}
+}
+
+// request envía msg con un canal de respuesta y espera el snapshot; retorna
+// false si la goroutine terminó sin responder
+func (r *route) request(msg core.Control) (core.FireflySnapshot, bool) {
+	reply := make(chan core.FireflySnapshot, 1)
+	msg.Reply = reply
+	if !r.send(msg) {
+		return core.FireflySnapshot{}, false
+	}
+	select {
+	case snap := <-reply:
+		return snap, true
+	case <-r.done:
+		// La respuesta se envía antes de cerrar done: si llegó, ya está
+		select {
+		case snap := <-reply:
+			return snap, true
+		default:
+			return core.FireflySnapshot{}, false
+		}
+	}
+}
+
+// routingTable es la tabla de rutas del manager: el canal de control de
+// cada luciérnaga cuya goroutine está corriendo, por ID. Los envíos se hacen
+// con lifecycleMux tomado en lectura, así ninguna luciérnaga se detiene
+// (quiesce) con un mensaje a medio entregar.
+type routingTable struct {
+	mux    sync.RWMutex
+	routes map[int]*route
+}
+
+func newRoutingTable() *routingTable {
+	return &routingTable{routes: make(map[int]*route)}
+}
+
+// open crea y registra la ruta de una luciérnaga que está por lanzarse
+func (t *routingTable) open(id int) *route {
+	r := &route{control: make(chan core.Control, controlBuffer), done: make(chan struct{})}
+	t.mux.Lock()
+	t.routes[id] = r
+	t.mux.Unlock()
+	return r
+}
+
+// close da de baja la ruta cuando su goroutine termina; si otra ya la quitó
+// (take) o la reemplazó no hace nada
+func (t *routingTable) close(id int, r *route) {
+	t.mux.Lock()
+	if t.routes[id] == r {
+		delete(t.routes, id)
+	}
+	t.mux.Unlock()
+	close(r.done)
+}
+
+// get retorna la ruta de una luciérnaga en marcha
+func (t *routingTable) get(id int) (*route, bool) {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	r, ok := t.routes[id]
+	return r, ok
+}
+
+// take quita la ruta y la retorna: de dos que quieren sacar a la misma
+// luciérnaga (el frasco y un murciélago) solo una la obtiene
+func (t *routingTable) take(id int) (*route, bool) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	r, ok := t.routes[id]
+	delete(t.routes, id)
+	return r, ok
+}
+
+// len retorna cuántas luciérnagas están en marcha
+func (t *routingTable) len() int {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	return len(t.routes)
+}
+
+// all retorna las rutas actuales para un envío a todas
+func (t *routingTable) all() []*route {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	routes := make([]*route, 0, len(t.routes))
+	for _, r := range t.routes {
+		routes = append(routes, r)
+	}
+	return routes
+}
+
+// sorted retorna las rutas actuales en orden de ID
+func (t *routingTable) sorted() []*route {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	ids := make([]int, 0, len(t.routes))
+	for id := range t.routes {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	routes := make([]*route, len(ids))
+	for i, id := range ids {
+		routes[i] = t.routes[id]
+	}
+	return routes
+}
+
+// broadcast envía msg a todas las luciérnagas en marcha; debe llamarse con
+// lifecycleMux tomado en lectura
+func (fm *FireflyManager) broadcast(msg core.Control) {
+	for _, r := range fm.routes.all() {
+		r.send(msg)
+	}
+}
+
+// broadcastLanterns envía la lista de faroles actual a todas. lanternSync
+// ordena los envíos: cada luciérnaga recibe las listas en el orden en que se
+// tomaron y la última es la vigente.
+func (fm *FireflyManager) broadcastLanterns() {
+	fm.lanternSync.Lock()
+	defer fm.lanternSync.Unlock()
+	fm.broadcast(core.Control{Kind: core.ControlLanterns, Lanterns: fm.getLanternsSnapshot()})
+}
+
+// InspectFirefly pregunta su estado a una luciérnaga en marcha sin
+// detenerla; retorna false si no existe o murió antes de responder
+func (fm *FireflyManager) InspectFirefly(id int) (core.FireflySnapshot, bool) {
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
+
+	r, ok := fm.routes.get(id)
+	if !ok {
+		return core.FireflySnapshot{}, false
+	}
+	return r.request(core.Control{Kind: core.ControlInspect})
+}
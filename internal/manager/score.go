@@ -0,0 +1,228 @@
+package manager
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+)
+
+const (
+	scoreInterval    = time.Second
+	scoreEventBuffer = 1024
+
+	// Cada segundo con la población en el objetivo o por encima se gana
+	// scorePerGlow por cada punto de resplandor (ver Glow), al menos
+	// scoreMinPerSecond; scorePerFreeLantern se suma por cada farol sin
+	// usar (lograrlo con menos faroles vale más)
+	scorePerGlow        = 0.5
+	scoreMinPerSecond   = 1
+	scorePerFreeLantern = 2
+	// scoreFlashWave se gana con cada destello sincronizado
+	scoreFlashWave = 50
+
+	// comboStep son los segundos de racha que suben el multiplicador en uno,
+	// hasta comboMax
+	comboStep = 10
+	comboMax  = 5
+
+	// Un destello sincronizado es cuando al menos flashMinBright luciérnagas
+	// y flashRatio de la población brillan a la vez por encima de
+	// flashBrightness; se vuelve a armar cuando bajan de flashRearm
+	flashSampleInterval = 100 * time.Millisecond
+	flashBrightness     = 0.9
+	flashMinBright      = 8
+	flashRatio          = 0.3
+	flashRearm          = 0.15
+)
+
+// ScoreState es el puntaje de la partida en un momento
+type ScoreState struct {
+	Points     int
+	Multiplier int
+	Streak     int // segundos seguidos en el objetivo o por encima
+	BestStreak int
+	Flashes    int
+
+	// BestGlow es el mayor resplandor de la partida y Achievements los
+	// logros desbloqueados, en orden
+	BestGlow     float64
+	Achievements []string
+
+	// La última ganancia, para mostrarla en el HUD
+	LastGain   int
+	LastReason string
+	LastAt     time.Time
+}
+
+// Score guarda el puntaje que calcula scoreKeeper; se lee desde el render
+type Score struct {
+	mux   sync.RWMutex
+	state ScoreState
+}
+
+// State retorna una copia del puntaje
+func (s *Score) State() ScoreState {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	state := s.state
+	state.Achievements = slices.Clone(state.Achievements)
+	return state
+}
+
+// award suma los puntos base multiplicados por el combo vigente
+func (s *Score) award(base int, reason string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	gain := base * max(s.state.Multiplier, 1)
+	s.state.Points += gain
+	s.state.LastGain, s.state.LastReason, s.state.LastAt = gain, reason, time.Now()
+}
+
+// setStreak actualiza la racha y el multiplicador que de ella resulta
+func (s *Score) setStreak(streak int) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.state.Streak = streak
+	s.state.BestStreak = max(s.state.BestStreak, streak)
+	s.state.Multiplier = min(1+streak/comboStep, comboMax)
+}
+
+// observeGlow registra el resplandor del momento y retorna los logros que
+// desbloquea
+func (s *Score) observeGlow(glow float64) []glowAchievement {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.state.BestGlow = max(s.state.BestGlow, glow)
+
+	var unlocked []glowAchievement
+	for _, a := range glowAchievements[len(s.state.Achievements):] {
+		if glow < a.glow {
+			break
+		}
+		s.state.Achievements = append(s.state.Achievements, a.name)
+		unlocked = append(unlocked, a)
+	}
+	return unlocked
+}
+
+func (s *Score) addFlash() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.state.Flashes++
+}
+
+// Score expone el puntaje de la partida
+func (fm *FireflyManager) Score() *Score {
+	return fm.score
+}
+
+// scoreKeeper lleva la población y los faroles a partir de los eventos del
+// bus (sin consultar el mundo) y cada segundo reparte los puntos según el
+// resplandor del agregador y revisa los logros. La suscripción se hace en
+// Start para no perder las luciérnagas iniciales.
+func (fm *FireflyManager) scoreKeeper(events <-chan Event, unsubscribe func()) {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemScore)()
+	defer unsubscribe()
+
+	ticker := fm.clock.NewTicker(scoreInterval)
+	defer ticker.Stop()
+
+	population, lanterns, streak := 0, 0, 0
+
+	for {
+		select {
+		case <-fm.ctx.Done():
+			return
+
+		case e := <-events:
+			switch e.Type {
+			case EventSpawn:
+				population++
+			case EventDeath, EventCapture, EventEaten:
+				population--
+			case EventLanternAdd:
+				lanterns++
+			case EventLanternRemove:
+				lanterns--
+			case EventRestore:
+				if e.Snapshot != nil {
+					population, lanterns = len(e.Snapshot.Fireflies), len(e.Snapshot.Lanterns)
+				}
+			case EventFlashWave:
+				fm.score.addFlash()
+				fm.score.award(scoreFlashWave, "destello")
+			}
+
+		case <-ticker.C():
+			glow := fm.aggregator.GetGlow()
+			for _, a := range fm.score.observeGlow(glow.Value) {
+				fm.notify(NoticeSuccess, "Logro: %s (resplandor %.0f)", a.name, a.glow)
+				fm.score.award(a.bonus, "logro")
+			}
+
+			if population <= 0 || population < fm.GetObjective() {
+				streak = 0
+				fm.score.setStreak(streak)
+				continue
+			}
+
+			streak++
+			fm.score.setStreak(streak)
+			if objective := fm.GetObjective(); streak == 1 && objective > 0 {
+				fm.notify(NoticeSuccess, "¡Objetivo alcanzado! (%d luciérnagas)", objective)
+			}
+			free := max(config.Get().Lanterns.Max-lanterns, 0)
+			gain := max(int(glow.Value*scorePerGlow), scoreMinPerSecond)
+			fm.score.award(gain+free*scorePerFreeLantern, "resplandor")
+		}
+	}
+}
+
+// flashWatcher busca destellos sincronizados en el snapshot del agregador y
+// los publica en el bus como EventFlashWave
+func (fm *FireflyManager) flashWatcher() {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemScore)()
+
+	ticker := fm.clock.NewTicker(flashSampleInterval)
+	defer ticker.Stop()
+
+	armed := true
+	for {
+		select {
+		case <-fm.ctx.Done():
+			return
+
+		case <-ticker.C():
+			states := fm.aggregator.GetSnapshot()
+			bright := 0
+			for _, state := range states {
+				if state.Brightness >= flashBrightness {
+					bright++
+				}
+			}
+			total := len(states)
+			ReleaseStates(states)
+			if total == 0 {
+				continue
+			}
+
+			ratio := float64(bright) / float64(total)
+			switch {
+			case armed && bright >= flashMinBright && ratio >= flashRatio:
+				armed = false
+				fm.events.Publish(Event{Type: EventFlashWave, Count: bright})
+			case !armed && ratio < flashRearm:
+				armed = true
+			}
+		}
+	}
+}
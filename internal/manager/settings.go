@@ -0,0 +1,71 @@
+package manager
+
+import (
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// Settings son los parámetros de la simulación ajustables en tiempo de ejecución
+type Settings struct {
+	MaxFireflies  int
+	SpawnInterval time.Duration
+	WindStrength  float64
+	LanternRadius float64
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		MaxFireflies:  config.Get().Fireflies.Max,
+		SpawnInterval: config.Get().Fireflies.SpawnInterval.Duration / 2,
+		WindStrength:  config.Get().Wind.Force,
+		LanternRadius: config.Get().Lanterns.Radius,
+	}
+}
+
+// Clamped retorna una copia con todos los valores dentro de rangos válidos
+func (s Settings) Clamped() Settings {
+	if s.MaxFireflies < 1 {
+		s.MaxFireflies = 1
+	}
+	if s.MaxFireflies > config.Get().Fireflies.Max {
+		s.MaxFireflies = config.Get().Fireflies.Max
+	}
+	if s.SpawnInterval < config.MinSpawnInterval {
+		s.SpawnInterval = config.MinSpawnInterval
+	}
+	s.WindStrength = utils.Clamp(s.WindStrength, 0, config.Get().Wind.MaxStrength)
+	s.LanternRadius = utils.Clamp(s.LanternRadius, config.LanternRadiusMin, config.LanternRadiusMax)
+	return s
+}
+
+// UpdateSettings envía los nuevos parámetros por el canal de comandos
+func (fm *FireflyManager) UpdateSettings(s Settings) bool {
+	return fm.Send(Command{Type: CommandUpdateSettings, Data: s})
+}
+
+// applySettings se ejecuta en commandLoop (o antes de Start)
+func (fm *FireflyManager) applySettings(s Settings) {
+	s = s.Clamped()
+
+	fm.settingsMux.Lock()
+	fm.settings = s
+	fm.settingsMux.Unlock()
+
+	fm.wind.SetStrength(s.WindStrength)
+
+	fm.events.Publish(Event{Type: EventSettings, Settings: &s})
+}
+
+// ApplySettings fija los parámetros iniciales; usar solo antes de Start
+func (fm *FireflyManager) ApplySettings(s Settings) {
+	fm.applySettings(s)
+}
+
+func (fm *FireflyManager) GetSettings() Settings {
+	fm.settingsMux.RLock()
+	defer fm.settingsMux.RUnlock()
+
+	return fm.settings
+}
@@ -0,0 +1,203 @@
+package manager
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/wire"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// GardenSnapshot es el estado completo de la simulación en un instante
+type GardenSnapshot struct {
+	SavedAt    time.Time              `json:"saved_at"`
+	Seed       int64                  `json:"seed"`
+	Fireflies  []core.FireflySnapshot `json:"fireflies"`
+	Lanterns   []LanternSnapshot      `json:"lanterns"`
+	Wind       WindSnapshot           `json:"wind"`
+	Attraction *utils.Vector2D        `json:"attraction,omitempty"`
+	Settings   Settings               `json:"settings"`
+	// Field es el campo de fuerzas pintado; nil si no hay
+	Field *core.FieldGrid `json:"field,omitempty"`
+}
+
+type LanternSnapshot struct {
+	ID         int            `json:"id"`
+	Position   utils.Vector2D `json:"position"`
+	Radius     float64        `json:"radius"`
+	PulsePhase float64        `json:"pulse_phase"`
+}
+
+type WindSnapshot struct {
+	Direction core.WindDirection `json:"direction"`
+	Strength  float64            `json:"strength"`
+}
+
+// SaveSnapshot detiene las luciérnagas el tiempo justo para copiar su estado
+// interno y las relanza. Bloquea como máximo un tick de simulación.
+func (fm *FireflyManager) SaveSnapshot() GardenSnapshot {
+	fm.lifecycleMux.Lock()
+	defer fm.lifecycleMux.Unlock()
+
+	fm.quiesce()
+	defer fm.resume()
+
+	wind := fm.wind.Snapshot()
+	snap := GardenSnapshot{
+		SavedAt:  time.Now(),
+		Seed:     utils.CurrentSeed(),
+		Settings: fm.GetSettings(),
+		Field:    fm.field.Grid(),
+		Wind: WindSnapshot{
+			Direction: wind.Direction,
+			Strength:  wind.Strength,
+		},
+	}
+
+	for _, e := range fm.world.Snapshot(core.KindFirefly) {
+		snap.Fireflies = append(snap.Fireflies, e.(*core.Firefly).Snapshot())
+	}
+	for _, l := range fm.getLanternsSnapshot() {
+		snap.Lanterns = append(snap.Lanterns, LanternSnapshot{
+			ID:         l.ID(),
+			Position:   l.Position,
+			Radius:     l.Radius,
+			PulsePhase: l.PulsePhase(),
+		})
+	}
+
+	fm.attractionMux.RLock()
+	if fm.attractionPt != nil {
+		point := *fm.attractionPt
+		snap.Attraction = &point
+	}
+	fm.attractionMux.RUnlock()
+
+	return snap
+}
+
+// RestoreSnapshot reemplaza toda la simulación por la del snapshot y
+// recrea una goroutine por cada luciérnaga guardada
+func (fm *FireflyManager) RestoreSnapshot(snap GardenSnapshot) {
+	fm.lifecycleMux.Lock()
+	defer fm.lifecycleMux.Unlock()
+
+	fm.quiesce()
+	defer fm.resume()
+
+	fm.world.Clear()
+	fm.aggregator.Clear()
+
+	utils.Seed(snap.Seed)
+	fm.applySettings(snap.Settings)
+	fm.wind.SetStrength(snap.Wind.Strength)
+	fm.wind.SetDirection(snap.Wind.Direction)
+
+	fm.attractionMux.Lock()
+	fm.attractionPt = snap.Attraction
+	fm.attractionMux.Unlock()
+	fm.field.Set(snap.Field)
+
+	// Faroles primero: cada luciérnaga copia la lista al lanzarse
+	for _, ls := range snap.Lanterns {
+		lantern := core.NewLantern(ls.ID, ls.Position.X, ls.Position.Y)
+		lantern.Radius = ls.Radius
+		lantern.SetPulsePhase(ls.PulsePhase)
+		fm.world.Add(lantern)
+	}
+
+	for _, fs := range snap.Fireflies {
+		firefly := core.RestoreFirefly(fs)
+		fm.world.Add(firefly)
+		fm.attachFirefly(firefly)
+	}
+	// La población guardada no pasa por el límite: se restaura tal cual
+	fm.admission.live.Store(int64(fm.world.Count(core.KindFirefly)))
+
+	fm.events.Publish(Event{Type: EventRestore, Snapshot: &snap})
+}
+
+// WriteSnapshot guarda el snapshot como JSON indentado o, si la ruta
+// termina en .bin, en el formato binario de MarshalSnapshot
+func WriteSnapshot(path string, snap GardenSnapshot) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	var data []byte
+	var err error
+	if strings.HasSuffix(path, ".bin") {
+		data, err = MarshalSnapshot(snap)
+	} else {
+		data, err = json.MarshalIndent(snap, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ReadSnapshot lee un snapshot guardado con WriteSnapshot; el formato se
+// reconoce por la cabecera, no por la extensión
+func ReadSnapshot(path string) (GardenSnapshot, error) {
+	var snap GardenSnapshot
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snap, err
+	}
+
+	if wire.HasMagic(data, wire.MagicGarden) {
+		snap, err = UnmarshalSnapshot(data)
+	} else {
+		err = json.Unmarshal(data, &snap)
+	}
+	if err == nil && snap.Field != nil {
+		err = snap.Field.Validate()
+	}
+	return snap, err
+}
+
+// MarshalSnapshot codifica el snapshot en binario: la cabecera de
+// wire.MagicGarden, el resto del jardín (faroles, viento, ajustes) como
+// JSON con su largo delante, y las luciérnagas con wire.AppendFireflies,
+// que son lo que pesa con miles de ellas
+func MarshalSnapshot(snap GardenSnapshot) ([]byte, error) {
+	fireflies := snap.Fireflies
+	snap.Fireflies = nil
+	meta, err := json.Marshal(snap)
+	if err != nil {
+		return nil, err
+	}
+
+	data := wire.AppendHeader(make([]byte, 0, wire.HeaderSize+len(meta)+80*len(fireflies)), wire.MagicGarden)
+	data = binary.AppendUvarint(data, uint64(len(meta)))
+	data = append(data, meta...)
+	return wire.AppendFireflies(data, fireflies), nil
+}
+
+// UnmarshalSnapshot lee lo escrito con MarshalSnapshot
+func UnmarshalSnapshot(data []byte) (GardenSnapshot, error) {
+	var snap GardenSnapshot
+
+	data, err := wire.ReadHeader(data, wire.MagicGarden)
+	if err != nil {
+		return snap, err
+	}
+	size, n := binary.Uvarint(data)
+	if n <= 0 || size > uint64(len(data)-n) {
+		return snap, fmt.Errorf("%w: jardín truncado", wire.ErrFormat)
+	}
+	if err := json.Unmarshal(data[n:n+int(size)], &snap); err != nil {
+		return snap, err
+	}
+
+	snap.Fireflies, _, err = wire.DecodeFireflies(data[n+int(size):])
+	return snap, err
+}
@@ -0,0 +1,54 @@
+package manager
+
+import (
+	"math"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// spatialCell es una celda de la grilla de un spatialIndex
+type spatialCell struct{ col, row int }
+
+// spatialIndex es una grilla uniforme sobre un snapshot de estados: una
+// consulta por radio mira solo las celdas que lo cubren en lugar de toda la
+// población. Se arma con cada snapshot y no cambia después; con celdas del
+// lado del radio de consulta cada una mira las 9 de alrededor.
+type spatialIndex struct {
+	cell   float64
+	states []core.FireflyState
+	grid   map[spatialCell][]int
+}
+
+// newSpatialIndex indexa states, que no debe modificarse mientras se use
+func newSpatialIndex(states []core.FireflyState, cell float64) *spatialIndex {
+	idx := &spatialIndex{cell: cell, states: states, grid: make(map[spatialCell][]int)}
+	for i, s := range states {
+		c := idx.cellOf(s.Position)
+		idx.grid[c] = append(idx.grid[c], i)
+	}
+	return idx
+}
+
+func (idx *spatialIndex) cellOf(p utils.Vector2D) spatialCell {
+	return spatialCell{col: int(math.Floor(p.X / idx.cell)), row: int(math.Floor(p.Y / idx.cell))}
+}
+
+// near agrega a dst los índices (en states) de los estados a radius o menos
+// de p, incluido el de p si está indexado
+func (idx *spatialIndex) near(dst []int, p utils.Vector2D, radius float64) []int {
+	c := idx.cellOf(p)
+	reach := int(math.Ceil(radius / idx.cell))
+	radiusSq := radius * radius
+	for dy := -reach; dy <= reach; dy++ {
+		for dx := -reach; dx <= reach; dx++ {
+			for _, j := range idx.grid[spatialCell{col: c.col + dx, row: c.row + dy}] {
+				q := idx.states[j].Position
+				if (q.X-p.X)*(q.X-p.X)+(q.Y-p.Y)*(q.Y-p.Y) <= radiusSq {
+					dst = append(dst, j)
+				}
+			}
+		}
+	}
+	return dst
+}
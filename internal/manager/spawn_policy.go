@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/plugin"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// wavePeriod son los ticks del spawner entre oleadas de la política waves
+const wavePeriod = 10
+
+// newSpawnPolicy crea la política de fábrica con ese nombre (ver
+// config.SpawnPolicies); un nombre desconocido usa objective
+func newSpawnPolicy(name string) plugin.SpawnPolicy {
+	switch name {
+	case config.SpawnSteady:
+		return steadyPolicy{}
+	case config.SpawnWaves:
+		return &wavePolicy{}
+	case config.SpawnOff:
+		return offPolicy{}
+	case ecologyPolicyName:
+		return &ecologyPolicy{}
+	default:
+		return objectivePolicy{}
+	}
+}
+
+// randomPoints retorna n puntos al azar donde pueden nacer luciérnagas
+func randomPoints(ctx plugin.SpawnContext, n int) []utils.Vector2D {
+	points := make([]utils.Vector2D, 0, max(n, 0))
+	for range n {
+		if ctx.RandomPoint != nil {
+			points = append(points, ctx.RandomPoint())
+			continue
+		}
+		points = append(points, utils.NewVector2D(utils.RandomFloat(0, ctx.Width), utils.RandomFloat(0, ctx.Height)))
+	}
+	return points
+}
+
+// objectivePolicy repone hasta burst_count por tick mientras falten
+// luciérnagas para el objetivo, una más si faltan muchas, y alcanzado el
+// objetivo suelta alguna de vez en cuando
+type objectivePolicy struct{}
+
+func (objectivePolicy) Name() string { return config.SpawnObjective }
+
+func (objectivePolicy) Spawn(ctx plugin.SpawnContext) []utils.Vector2D {
+	missing := ctx.Objective - ctx.Population
+	if missing <= 0 {
+		if utils.RandomFloat(0, 1) < 0.05 {
+			return randomPoints(ctx, 1)
+		}
+		return nil
+	}
+
+	n := min(ctx.BurstCount, missing)
+	if missing > ctx.BurstCount*2 {
+		n++
+	}
+	return randomPoints(ctx, n)
+}
+
+// steadyPolicy suelta una por tick mientras falten para el objetivo
+type steadyPolicy struct{}
+
+func (steadyPolicy) Name() string { return config.SpawnSteady }
+
+func (steadyPolicy) Spawn(ctx plugin.SpawnContext) []utils.Vector2D {
+	if ctx.Population >= ctx.Objective {
+		return nil
+	}
+	return randomPoints(ctx, 1)
+}
+
+// wavePolicy no suelta nada durante wavePeriod ticks y después repone de
+// golpe todo lo que falta para el objetivo. Solo la usa la goroutine del
+// spawner.
+type wavePolicy struct {
+	ticks int
+}
+
+func (*wavePolicy) Name() string { return config.SpawnWaves }
+
+func (p *wavePolicy) Spawn(ctx plugin.SpawnContext) []utils.Vector2D {
+	p.ticks++
+	if p.ticks < wavePeriod {
+		return nil
+	}
+	p.ticks = 0
+	return randomPoints(ctx, ctx.Objective-ctx.Population)
+}
+
+// offPolicy no suelta nada: la población solo cambia por el jugador
+type offPolicy struct{}
+
+func (offPolicy) Name() string { return config.SpawnOff }
+
+func (offPolicy) Spawn(plugin.SpawnContext) []utils.Vector2D { return nil }
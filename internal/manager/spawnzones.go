@@ -0,0 +1,151 @@
+package manager
+
+import (
+	"slices"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// spawnTries es cuántas veces se sortea un punto de aparición antes de
+	// aceptar uno sobre un obstáculo o un estanque
+	spawnTries = 8
+	// zoneSpawnTick es cada cuánto las zonas con rate sueltan lo acumulado
+	zoneSpawnTick = 100 * time.Millisecond
+)
+
+// spawnZones retorna las zonas de aparición vigentes: las del mapa o, si
+// no trae, las de spawn.zones, que pueden cambiar con una recarga
+func (fm *FireflyManager) spawnZones() []core.SpawnZone {
+	if fm.gardenMap != nil && len(fm.gardenMap.SpawnZones) > 0 {
+		return fm.gardenMap.SpawnZones
+	}
+
+	cfg := config.Get().Spawn.Zones
+	if len(cfg) == 0 {
+		return nil
+	}
+	zones := make([]core.SpawnZone, len(cfg))
+	for i, z := range cfg {
+		zones[i] = core.SpawnZoneFromConfig(z)
+	}
+	return zones
+}
+
+// spawnPoint sortea dónde nace una luciérnaga: dentro de una zona de
+// aparición, elegida según su peso, o en todo el mundo si no hay; evita
+// los obstáculos y estanques mientras puede
+func (fm *FireflyManager) spawnPoint() utils.Vector2D {
+	zones := fm.spawnZones()
+	var p utils.Vector2D
+	for range spawnTries {
+		p = zonePoint(zones)
+		if !fm.landscape.Blocked(p) {
+			break
+		}
+	}
+	return p
+}
+
+// zonePoint es un punto al azar en una de las zones, elegida por su peso o,
+// si ninguna lo indica, por su superficie. Las zonas con rate y sin peso
+// en un jardín con pesos solo sueltan las suyas.
+func zonePoint(zones []core.SpawnZone) utils.Vector2D {
+	weighted := slices.ContainsFunc(zones, func(z core.SpawnZone) bool { return z.Weight > 0 })
+	weight := func(z core.SpawnZone) float64 {
+		if weighted {
+			return z.Weight
+		}
+		return z.Surface()
+	}
+
+	total := 0.0
+	for _, z := range zones {
+		total += weight(z)
+	}
+	if total == 0 {
+		x, y := randomWorldPoint()
+		return utils.Vector2D{X: x, Y: y}
+	}
+
+	pick := utils.RandomFloat(0, total)
+	for _, z := range zones {
+		if w := weight(z); w > 0 {
+			if pick -= w; pick <= 0 {
+				return clampToWorld(z.RandomPoint())
+			}
+		}
+	}
+	return clampToWorld(zones[len(zones)-1].RandomPoint())
+}
+
+// clampToWorld trae adentro un punto de una zona que se sale del mundo:
+// afuera aparecería del otro lado
+func clampToWorld(p utils.Vector2D) utils.Vector2D {
+	width, height := config.WorldSize()
+	return utils.Vector2D{X: utils.Clamp(p.X, 0, width), Y: utils.Clamp(p.Y, 0, height)}
+}
+
+// spawnHeading retorna hacia dónde arranca una luciérnaga que nace en p:
+// hacia el centro del mundo si p cae en una zona con inward
+func (fm *FireflyManager) spawnHeading(p utils.Vector2D) (utils.Vector2D, bool) {
+	for _, z := range fm.spawnZones() {
+		if z.Inward && z.Contains(p) {
+			width, height := config.WorldSize()
+			return utils.Vector2D{X: width / 2, Y: height / 2}.Sub(p), true
+		}
+	}
+	return utils.Vector2D{}, false
+}
+
+// zoneSpawner suelta las luciérnagas de las zonas con rate, cada una a su
+// ritmo y aparte de la política de spawn; solo las frena el límite de
+// población. Lo que no alcanza para una luciérnaga entera se acumula.
+func (fm *FireflyManager) zoneSpawner() {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemSpawnZones)()
+
+	ticker := fm.clock.NewTicker(zoneSpawnTick)
+	defer ticker.Stop()
+
+	var pending []float64
+	for {
+		select {
+		case <-fm.ctx.Done():
+			return
+
+		case <-ticker.C():
+			zones := fm.spawnZones()
+			if len(pending) != len(zones) {
+				pending = make([]float64, len(zones))
+			}
+			for i, z := range zones {
+				pending[i] += z.Rate * zoneSpawnTick.Seconds()
+				for ; pending[i] >= 1; pending[i]-- {
+					p := fm.zoneSpawnPoint(z)
+					if !fm.spawnFirefly(p.X, p.Y) {
+						// Sin lugar no se acumula: al liberarse no sale todo junto
+						pending[i] = 0
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// zoneSpawnPoint sortea un punto de la zona que no caiga en un obstáculo o
+// estanque, mientras puede
+func (fm *FireflyManager) zoneSpawnPoint(z core.SpawnZone) utils.Vector2D {
+	var p utils.Vector2D
+	for range spawnTries {
+		p = clampToWorld(z.RandomPoint())
+		if !fm.landscape.Blocked(p) {
+			break
+		}
+	}
+	return p
+}
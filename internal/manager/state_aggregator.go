@@ -3,14 +3,49 @@ package manager
 import (
 	"context"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/yourusername/firefly-garden/internal/config"
 	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
 )
 
 type StateAggregator struct {
 	states     map[int]core.FireflyState
+	previous   map[int]core.FireflyState
+	processed  atomic.Uint64
+	served     atomic.Uint64
 	statesMux  sync.RWMutex
 	stateCh    chan core.FireflyState
+	peak       highWater
+	effects    effectWatcher
+	// sequence cuenta los estados aplicados y latest es la hora del más
+	// nuevo; los protege statesMux
+	sequence   uint64
+	latest     time.Time
+	// origin es la hora de Start en el reloj de la simulación y tick lo que
+	// dura un tick; con ellos latest se cuenta en ticks (SetOrigin)
+	origin     time.Time
+	tick       time.Duration
+	// zones cuenta las luciérnagas por zona; también bajo statesMux
+	zones      zoneTracker
+	// glow es el resplandor del jardín; también bajo statesMux
+	glow       glowTracker
+	// inbox junta los lotes de la simulación por lotes (Publish) hasta que
+	// aggregateLoop los aplica: el estado nuevo de una luciérnaga reemplaza
+	// al que todavía esperaba (se coalescen), así un lote nunca se descarta
+	// y lo pendiente no pasa de la población. inboxIdx ubica a cada una en
+	// inbox y spare es el buffer del intercambio; los protege inboxMux.
+	inboxMux   sync.Mutex
+	inbox      []core.FireflyState
+	inboxIdx   map[int]int
+	spare      []core.FireflyState
+	inboxWake  chan struct{}
+	coalesced  atomic.Uint64
+	batches    atomic.Uint64
+	// drained es el buffer de los estados que se sacan juntos de stateCh
+	drained    []core.FireflyState
 	ctx        context.Context
 	cancel     context.CancelFunc
 	wg         sync.WaitGroup
@@ -20,13 +55,27 @@ func NewStateAggregator(bufferSize int) *StateAggregator {
 	ctx, cancel := context.WithCancel(context.Background())
 	
 	return &StateAggregator{
-		states:  make(map[int]core.FireflyState),
+		states:   make(map[int]core.FireflyState),
+		previous: make(map[int]core.FireflyState),
+		zones:    newZoneTracker(),
+		glow:     newGlowTracker(),
+		inboxIdx: make(map[int]int),
+		inboxWake: make(chan struct{}, 1),
 		stateCh: make(chan core.FireflyState, bufferSize),
 		ctx:     ctx,
 		cancel:  cancel,
 	}
 }
 
+// SetOrigin fija la hora en que empieza la simulación y cuánto dura un
+// tick, para numerar los ticks de SnapshotMeta; debe llamarse antes de Start
+func (sa *StateAggregator) SetOrigin(origin time.Time, tick time.Duration) {
+	sa.statesMux.Lock()
+	defer sa.statesMux.Unlock()
+
+	sa.origin, sa.tick = origin, tick
+}
+
 func (sa *StateAggregator) Start() {
 	sa.wg.Add(1)
 	go sa.aggregateLoop()
@@ -40,28 +89,118 @@ func (sa *StateAggregator) aggregateLoop() {
 		case <-sa.ctx.Done():
 			return
 			
+		case <-sa.inboxWake:
+			sa.applyInbox()
+
 		case state := <-sa.stateCh:
-			sa.updateState(state)
+			sa.peak.observe(len(sa.stateCh) + 1)
+			// Lo que llegó antes por lotes va primero: la baja de una
+			// luciérnaga caída (reapFirefly) no puede quedar detrás de su
+			// último estado vivo
+			sa.applyInbox()
+			sa.applyStates(sa.drainStates(state))
 		}
 	}
 }
 
-func (sa *StateAggregator) updateState(state core.FireflyState) {
+// drainStates junta state con lo que ya espera en stateCh, hasta lo que
+// había al empezar: con muchas luciérnagas se aplican de a tandas bajo un
+// solo lock en lugar de uno por estado
+func (sa *StateAggregator) drainStates(state core.FireflyState) []core.FireflyState {
+	states := append(sa.drained[:0], state)
+	for range len(sa.stateCh) {
+		states = append(states, <-sa.stateCh)
+	}
+	sa.drained = states
+	return states
+}
+
+// Publish entrega un lote de estados de la simulación por lotes; no
+// bloquea ni descarta: si la luciérnaga ya tenía un estado esperando, el
+// nuevo lo reemplaza
+func (sa *StateAggregator) Publish(batch []core.FireflyState) {
+	if len(batch) == 0 {
+		return
+	}
+
+	sa.inboxMux.Lock()
+	for _, state := range batch {
+		if i, ok := sa.inboxIdx[state.ID]; ok {
+			sa.inbox[i] = state
+			sa.coalesced.Add(1)
+			continue
+		}
+		sa.inboxIdx[state.ID] = len(sa.inbox)
+		sa.inbox = append(sa.inbox, state)
+	}
+	sa.inboxMux.Unlock()
+	sa.batches.Add(1)
+
+	select {
+	case sa.inboxWake <- struct{}{}:
+	default:
+	}
+}
+
+// applyInbox aplica lo que juntó Publish, intercambiando el buffer para no
+// frenar a quien publica mientras tanto
+func (sa *StateAggregator) applyInbox() {
+	sa.inboxMux.Lock()
+	states := sa.inbox
+	sa.inbox = sa.spare[:0]
+	clear(sa.inboxIdx)
+	sa.inboxMux.Unlock()
+
+	sa.applyStates(states)
+	sa.spare = states
+}
+
+// applyStates aplica una tanda de estados bajo un solo lock
+func (sa *StateAggregator) applyStates(states []core.FireflyState) {
+	if len(states) == 0 {
+		return
+	}
+
 	sa.statesMux.Lock()
-	defer sa.statesMux.Unlock()
+	for _, state := range states {
+		sa.updateState(state)
+	}
+	sa.statesMux.Unlock()
+
+	for _, state := range states {
+		sa.effects.observe(state)
+	}
+	sa.processed.Add(uint64(len(states)))
+}
+
+// updateState aplica un estado; se llama con statesMux tomado
+func (sa *StateAggregator) updateState(state core.FireflyState) {
+	sa.sequence++
+	if state.Timestamp.After(sa.latest) {
+		sa.latest = state.Timestamp
+	}
 	
 	if state.IsAlive {
+		if last, ok := sa.states[state.ID]; ok {
+			sa.previous[state.ID] = last
+		}
 		sa.states[state.ID] = state
+		sa.zones.update(state)
+		sa.glow.update(state)
 	} else {
 		delete(sa.states, state.ID)
+		delete(sa.previous, state.ID)
+		sa.zones.remove(state.ID)
+		sa.glow.remove(state.ID)
 	}
 }
 
 func (sa *StateAggregator) GetSnapshot() []core.FireflyState {
 	sa.statesMux.RLock()
 	defer sa.statesMux.RUnlock()
+	sa.served.Add(1)
 	
-	snapshot := make([]core.FireflyState, 0, len(sa.states))
+	snapshot := acquireStates(len(sa.states))
 	
 	for _, state := range sa.states {
 		snapshot = append(snapshot, state)
@@ -70,6 +209,106 @@ func (sa *StateAggregator) GetSnapshot() []core.FireflyState {
 	return snapshot
 }
 
+// SnapshotMeta ubica un snapshot en la simulación
+type SnapshotMeta struct {
+	// Sequence es un contador de cambios, no un tick de la simulación (ver Tick):
+	// crece con cada estado que aplica el agregador (con mil luciérnagas,
+	// unas mil veces por tick), así que solo sirve para ordenar snapshots y
+	// dos con la misma Sequence tienen los mismos estados. Para ubicarlo en
+	// el tiempo está Timestamp.
+	Sequence uint64
+	// Tick es el número de tick de la simulación del estado más nuevo: el
+	// tiempo simulado desde Start en ticks de simulation_tps, así que nunca
+	// retrocede y dos fuentes con el mismo reloj lo numeran igual. En modo
+	// sincrónico es la cantidad de Step; cero si todavía no llegó ninguno.
+	Tick uint64
+	// Timestamp es la hora del estado más nuevo aplicado, en el reloj de
+	// la simulación; cero si todavía no llegó ninguno
+	Timestamp time.Time
+}
+
+// GetSnapshotWithMeta retorna los estados, igual que GetSnapshot, junto con
+// la secuencia y la hora que les corresponden, tomadas bajo el mismo lock
+func (sa *StateAggregator) GetSnapshotWithMeta() ([]core.FireflyState, SnapshotMeta) {
+	sa.statesMux.RLock()
+	defer sa.statesMux.RUnlock()
+	sa.served.Add(1)
+
+	snapshot := acquireStates(len(sa.states))
+	for _, state := range sa.states {
+		snapshot = append(snapshot, state)
+	}
+
+	return snapshot, SnapshotMeta{Sequence: sa.sequence, Tick: sa.latestTick(), Timestamp: sa.latest}
+}
+
+// latestTick cuenta en ticks el tiempo simulado hasta el estado más nuevo,
+// redondeando para que el temblor de un ticker real no lo corra de tick; se
+// llama con statesMux tomado
+func (sa *StateAggregator) latestTick() uint64 {
+	if sa.tick <= 0 || !sa.latest.After(sa.origin) {
+		return 0
+	}
+	return uint64((sa.latest.Sub(sa.origin) + sa.tick/2) / sa.tick)
+}
+
+// GetInterpolatedSnapshot estima la posición de cada luciérnaga en renderTime
+// interpolando entre sus dos últimos estados publicados
+func (sa *StateAggregator) GetInterpolatedSnapshot(renderTime time.Time) []core.FireflyState {
+	sa.statesMux.RLock()
+	defer sa.statesMux.RUnlock()
+	sa.served.Add(1)
+
+	snapshot := acquireStates(len(sa.states))
+
+	for id, curr := range sa.states {
+		prev, ok := sa.previous[id]
+		if ok {
+			curr = interpolateState(prev, curr, renderTime)
+		}
+		snapshot = append(snapshot, curr)
+	}
+
+	return snapshot
+}
+
+func interpolateState(prev, curr core.FireflyState, renderTime time.Time) core.FireflyState {
+	span := curr.Timestamp.Sub(prev.Timestamp).Seconds()
+	if span <= 0 {
+		return curr
+	}
+
+	// Un salto de borde a borde (wrap-around) no se interpola
+	if width, _ := config.WorldSize(); utils.Distance(prev.Position, curr.Position) > width/2 {
+		return curr
+	}
+
+	t := utils.Clamp(renderTime.Sub(prev.Timestamp).Seconds()/span, 0, 1)
+
+	result := curr
+	result.Position = utils.LerpVector(prev.Position, curr.Position, t)
+	result.Brightness = utils.Lerp(prev.Brightness, curr.Brightness, t)
+	result.Timestamp = renderTime
+	return result
+}
+
+// GetZoneStats retorna la cantidad de luciérnagas y su brillo promedio en
+// cada zona del jardín (ver config.ZoneAt)
+func (sa *StateAggregator) GetZoneStats() ZoneStats {
+	sa.statesMux.RLock()
+	defer sa.statesMux.RUnlock()
+
+	return sa.zones.stats()
+}
+
+// GetGlow retorna el resplandor del jardín con el último estado aplicado
+func (sa *StateAggregator) GetGlow() Glow {
+	sa.statesMux.RLock()
+	defer sa.statesMux.RUnlock()
+
+	return sa.glow.value()
+}
+
 func (sa *StateAggregator) GetCount() int {
 	sa.statesMux.RLock()
 	defer sa.statesMux.RUnlock()
@@ -77,19 +316,73 @@ func (sa *StateAggregator) GetCount() int {
 	return len(sa.states)
 }
 
+// GetProcessedCount retorna cuántos estados ha consumido el agregador desde su inicio
+func (sa *StateAggregator) GetProcessedCount() uint64 {
+	return sa.processed.Load()
+}
+
+// GetServedCount retorna cuántos snapshots se han entregado a los lectores
+func (sa *StateAggregator) GetServedCount() uint64 {
+	return sa.served.Load()
+}
+
+// GetMapSizes retorna el tamaño de los mapas de estados actual y anterior
+// (el agregador usa un único mapa protegido por statesMux, sin shards)
+func (sa *StateAggregator) GetMapSizes() (int, int) {
+	sa.statesMux.RLock()
+	defer sa.statesMux.RUnlock()
+
+	return len(sa.states), len(sa.previous)
+}
+
+// GetGoroutineCount retorna las goroutines propias del agregador (aggregateLoop)
+func (sa *StateAggregator) GetGoroutineCount() int {
+	if sa.ctx.Err() != nil {
+		return 0
+	}
+	return 1
+}
+
+// GetInboxStats retorna cuántos estados esperan en los lotes, cuántos lotes
+// llegaron y cuántos estados se reemplazaron por uno más nuevo antes de
+// aplicarse
+func (sa *StateAggregator) GetInboxStats() (pending int, batches, coalesced uint64) {
+	sa.inboxMux.Lock()
+	pending = len(sa.inbox)
+	sa.inboxMux.Unlock()
+	return pending, sa.batches.Load(), sa.coalesced.Load()
+}
+
+// GetStatePeak retorna lo más lleno que estuvo el canal de estados
+func (sa *StateAggregator) GetStatePeak() int {
+	return sa.peak.get()
+}
+
 func (sa *StateAggregator) GetStateChannel() chan<- core.FireflyState {
 	return sa.stateCh
 }
 
+// Clear vacía los estados; la secuencia y la hora siguen, así los snapshots
+// posteriores nunca parecen más viejos que los anteriores. Vaciar es un
+// cambio: la secuencia avanza y separa el snapshot vacío del anterior.
 func (sa *StateAggregator) Clear() {
 	sa.statesMux.Lock()
 	defer sa.statesMux.Unlock()
 	
+	sa.sequence++
 	sa.states = make(map[int]core.FireflyState)
+	sa.previous = make(map[int]core.FireflyState)
+	sa.inboxMux.Lock()
+	sa.inbox = sa.inbox[:0]
+	clear(sa.inboxIdx)
+	sa.inboxMux.Unlock()
+	sa.zones.reset()
+	sa.glow.reset()
 }
 
 func (sa *StateAggregator) Stop() {
 	sa.cancel()
 	sa.wg.Wait()
+	sa.effects.flush()
 	close(sa.stateCh)
 }
\ No newline at end of file
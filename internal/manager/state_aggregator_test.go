@@ -0,0 +1,111 @@
+package manager
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// BenchmarkStateAggregator mide cuántos estados por segundo consume el
+// agregador desde stateCh, con 1000 luciérnagas que se pisan entre sí
+func BenchmarkStateAggregator(b *testing.B) {
+	sa := NewStateAggregator(config.Get().Channels.StateBuffer)
+	sa.Start()
+	b.Cleanup(sa.Stop)
+
+	stateCh := sa.GetStateChannel()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		stateCh <- core.FireflyState{ID: i % 1000, IsAlive: true}
+	}
+	for sa.GetProcessedCount() < uint64(b.N) {
+		runtime.Gosched()
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "estados/s")
+}
+
+// BenchmarkTick mide un tick de simulación de punta a punta: cada
+// luciérnaga avanza y publica su estado (como el modo por lotes de
+// cmd/loadtest) y el tick termina cuando el agregador aplicó todos
+func BenchmarkTick(b *testing.B) {
+	// Sin muertes de viejas cada tick mueve a la población entera
+	prev := config.Get()
+	cfg := prev.Clone()
+	cfg.Fireflies.LifespanMin, cfg.Fireflies.LifespanMax = math.MaxFloat32, math.MaxFloat32
+	config.Set(cfg)
+	b.Cleanup(func() { config.Set(prev) })
+
+	dt := 1.0 / float64(cfg.SimulationTPS)
+	lanterns := []*core.Lantern{core.NewLantern(1, config.ScreenWidth/2, config.ScreenHeight/2)}
+
+	for _, n := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			sa := NewStateAggregator(n)
+			sa.Start()
+			defer sa.Stop()
+
+			fireflies := make([]*core.Firefly, n)
+			for i := range fireflies {
+				pos := utils.RandomVector2D(0, config.ScreenWidth, 0, config.ScreenHeight)
+				fireflies[i] = core.NewFirefly(i+1, pos.X, pos.Y)
+				fireflies[i].SetLanterns(lanterns)
+			}
+
+			stateCh := sa.GetStateChannel()
+			want := sa.GetProcessedCount()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				for _, f := range fireflies {
+					f.Step(stateCh, dt)
+				}
+				want += uint64(n)
+				for sa.GetProcessedCount() < want {
+					runtime.Gosched()
+				}
+			}
+		})
+	}
+}
+
+// TestSnapshotMeta comprueba que Tick numera el tiempo simulado del estado
+// más nuevo y que Clear avanza la secuencia sin volver atrás el tick
+func TestSnapshotMeta(t *testing.T) {
+	origin := time.Unix(0, 0)
+	tick := time.Second / 30
+	sa := NewStateAggregator(16)
+	sa.SetOrigin(origin, tick)
+	sa.Start()
+	t.Cleanup(sa.Stop)
+
+	stateCh := sa.GetStateChannel()
+	for n := 1; n <= 5; n++ {
+		stateCh <- core.FireflyState{ID: 1, IsAlive: true, Timestamp: origin.Add(time.Duration(n) * tick)}
+		for sa.GetProcessedCount() < uint64(n) {
+			runtime.Gosched()
+		}
+		states, meta := sa.GetSnapshotWithMeta()
+		ReleaseStates(states)
+		if meta.Tick != uint64(n) {
+			t.Fatalf("tras el estado del tick %d la meta dice Tick %d", n, meta.Tick)
+		}
+	}
+
+	_, before := sa.GetSnapshotWithMeta()
+	sa.Clear()
+	states, after := sa.GetSnapshotWithMeta()
+	ReleaseStates(states)
+	if after.Sequence <= before.Sequence {
+		t.Fatalf("Clear dejó la secuencia en %d, antes era %d", after.Sequence, before.Sequence)
+	}
+	if after.Tick != before.Tick {
+		t.Fatalf("Clear cambió el tick de %d a %d", before.Tick, after.Tick)
+	}
+}
@@ -0,0 +1,209 @@
+package manager
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+)
+
+const (
+	statsInterval    = time.Second
+	statsEventBuffer = 1024
+	// dropSpikeThreshold son los estados descartados en un segundo a partir
+	// de los cuales se publica EventDropSpike
+	dropSpikeThreshold = 50
+)
+
+// StatsSample son las métricas de un segundo de simulación
+type StatsSample struct {
+	Seconds       float64 `json:"t_seconds"`
+	Population    int     `json:"population"`
+	Births        int     `json:"births"`
+	Deaths        int     `json:"deaths"`
+	DroppedStates uint64  `json:"dropped_states"`
+	Goroutines    int     `json:"goroutines"`
+	FPS           float64 `json:"fps"`
+	StateQueue    int     `json:"state_queue"`
+	StateCap      int     `json:"state_cap"`
+	CommandQueue  int     `json:"command_queue"`
+	CommandCap    int     `json:"command_cap"`
+	// Capacity es la capacidad del jardín en modo ecológico (0 si está
+	// apagado) y Bats los murciélagos cazando
+	Capacity int `json:"capacity"`
+	Bats     int `json:"bats"`
+}
+
+var statsHeader = []string{
+	"t_seconds", "population", "births", "deaths", "dropped_states", "goroutines",
+	"fps", "state_queue", "state_cap", "command_queue", "command_cap",
+	"capacity", "bats",
+}
+
+func (s StatsSample) record() []string {
+	return []string{
+		strconv.FormatFloat(s.Seconds, 'f', 0, 64),
+		strconv.Itoa(s.Population),
+		strconv.Itoa(s.Births),
+		strconv.Itoa(s.Deaths),
+		strconv.FormatUint(s.DroppedStates, 10),
+		strconv.Itoa(s.Goroutines),
+		strconv.FormatFloat(s.FPS, 'f', 1, 64),
+		strconv.Itoa(s.StateQueue),
+		strconv.Itoa(s.StateCap),
+		strconv.Itoa(s.CommandQueue),
+		strconv.Itoa(s.CommandCap),
+		strconv.Itoa(s.Capacity),
+		strconv.Itoa(s.Bats),
+	}
+}
+
+// SessionStats acumula una muestra por segundo durante toda la sesión
+type SessionStats struct {
+	mux     sync.RWMutex
+	samples []StatsSample
+	fps     atomic.Uint64
+}
+
+// SetFPS informa los FPS del front-end; el manager no los conoce
+func (s *SessionStats) SetFPS(fps float64) {
+	s.fps.Store(math.Float64bits(fps))
+}
+
+func (s *SessionStats) getFPS() float64 {
+	return math.Float64frombits(s.fps.Load())
+}
+
+func (s *SessionStats) add(sample StatsSample) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.samples = append(s.samples, sample)
+}
+
+// Samples retorna una copia de la serie completa
+func (s *SessionStats) Samples() []StatsSample {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	samples := make([]StatsSample, len(s.samples))
+	copy(samples, s.samples)
+	return samples
+}
+
+// Recent retorna como máximo las últimas n muestras
+func (s *SessionStats) Recent(n int) []StatsSample {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	if n > len(s.samples) {
+		n = len(s.samples)
+	}
+	samples := make([]StatsSample, n)
+	copy(samples, s.samples[len(s.samples)-n:])
+	return samples
+}
+
+// statsSampler cuenta nacimientos y muertes desde el bus de eventos y cada
+// segundo guarda una muestra del estado del manager. La suscripción se hace
+// en Start para no perder las luciérnagas iniciales.
+func (fm *FireflyManager) statsSampler(events <-chan Event, unsubscribe func()) {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemStats)()
+	defer unsubscribe()
+
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+
+	start := time.Now()
+	lastDropped := fm.GetDroppedStates()
+	births, deaths := 0, 0
+
+	for {
+		select {
+		case <-fm.ctx.Done():
+			return
+
+		case e := <-events:
+			switch e.Type {
+			case EventSpawn:
+				births++
+			case EventDeath:
+				deaths++
+			}
+
+		case now := <-ticker.C:
+			dropped := fm.GetDroppedStates()
+			stateCh := fm.aggregator.GetStateChannel()
+
+			fm.stats.add(StatsSample{
+				Seconds:       now.Sub(start).Round(time.Second).Seconds(),
+				Population:    fm.GetFireflyCount(),
+				Births:        births,
+				Deaths:        deaths,
+				DroppedStates: dropped - lastDropped,
+				Goroutines:    runtime.NumGoroutine(),
+				FPS:           fm.stats.getFPS(),
+				StateQueue:    len(stateCh),
+				StateCap:      cap(stateCh),
+				CommandQueue:  len(fm.commandCh),
+				CommandCap:    cap(fm.commandCh),
+				Capacity:      fm.GetCarryingCapacity(),
+				Bats:          fm.GetBatCount(),
+			})
+
+			if spike := dropped - lastDropped; spike >= dropSpikeThreshold {
+				fm.events.Publish(Event{Type: EventDropSpike, Count: int(spike)})
+			}
+			lastDropped = dropped
+			births, deaths = 0, 0
+		}
+	}
+}
+
+// WriteStats exporta la serie como JSONL si la ruta termina en .jsonl,
+// o como CSV en cualquier otro caso
+func WriteStats(path string, samples []StatsSample) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".jsonl") {
+		enc := json.NewEncoder(file)
+		for _, s := range samples {
+			if err := enc.Encode(s); err != nil {
+				return err
+			}
+		}
+		return file.Close()
+	}
+
+	w := csv.NewWriter(file)
+	if err := w.Write(statsHeader); err != nil {
+		return err
+	}
+	for _, s := range samples {
+		if err := w.Write(s.record()); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
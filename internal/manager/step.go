@@ -0,0 +1,133 @@
+package manager
+
+import (
+	"math"
+	"runtime"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+const (
+	// stepTimeout acota cuánto espera Step al agregador; en modo sincrónico
+	// los estados llegan siempre, solo se agota si algo se trabó
+	stepTimeout = time.Second
+	// settleTimeout acota cuánto espera Advance a las luciérnagas: una que
+	// nace o muere justo en ese tick no llega a publicarlo
+	settleTimeout = 50 * time.Millisecond
+)
+
+// EnableSync deja el manager en modo sincrónico: las luciérnagas siguen en
+// sus goroutines pero sin ticker, y solo avanzan con Step, que también gira
+// el viento; no corren el spawner, los murciélagos ni la goroutine de
+// comandos. Sirve para pruebas que necesitan un número exacto de ticks y,
+// con utils.Seed, para repetir una simulación idéntica. Debe llamarse antes
+// de Start.
+func (fm *FireflyManager) EnableSync() {
+	fm.synchronous = true
+}
+
+// IsSync indica si el manager está en modo sincrónico
+func (fm *FireflyManager) IsSync() bool {
+	return fm.synchronous
+}
+
+// Step aplica los comandos encolados y avanza exactamente un tick de dt a
+// cada luciérnaga, el pulso de los faroles, el giro del viento y, si hay
+// uno, el FakeClock.
+// Retorna cuando el agregador ya tiene los estados nuevos (o pasó
+// stepTimeout): al volver, GetFireflyStates refleja el paso y las
+// que murieron de viejas ya salieron del mundo. Las luciérnagas avanzan de a
+// una en orden de ID, así el generador compartido se consume siempre en el
+// mismo orden. Retorna cuántas avanzaron; fuera del modo sincrónico no hace
+// nada.
+func (fm *FireflyManager) Step(dt float64) int {
+	if !fm.synchronous {
+		return 0
+	}
+
+	for drained := false; !drained; {
+		select {
+		case cmd := <-fm.commandCh:
+			fm.runCommand(cmd)
+		default:
+			drained = true
+		}
+	}
+
+	// El reloj simulado avanza antes del paso: los estados del Step n llevan
+	// la hora de n ticks después de Start
+	d := time.Duration(math.Round(dt * float64(time.Second)))
+	if fake, ok := fm.clock.(*core.FakeClock); ok {
+		fake.Advance(d)
+	}
+
+	fm.lifecycleMux.RLock()
+	defer fm.lifecycleMux.RUnlock()
+
+	dropped := fm.GetDroppedStates()
+	processed := fm.aggregator.GetProcessedCount()
+
+	stepped := 0
+	for _, r := range fm.routes.sorted() {
+		snap, ok := r.request(core.Control{Kind: core.ControlStep, Dt: dt})
+		if !ok {
+			continue
+		}
+		stepped++
+		if snap.Age > snap.Lifespan {
+			// Murió de vieja: done se cierra cuando ya salió del mundo
+			<-r.done
+		}
+	}
+
+	fm.world.UpdateAll(dt)
+
+	// Cada una publicó un estado; los descartados no van a llegar
+	expected := processed + uint64(stepped) - (fm.GetDroppedStates() - dropped)
+	fm.settle(stepTimeout, func() bool { return fm.aggregator.GetProcessedCount() >= expected })
+
+	// El viento gira cada wind.change_interval de tiempo simulado, como lo
+	// haría Run
+	if !fm.playback && !fm.fixedWind {
+		interval := config.Get().Wind.ChangeInterval.Duration
+		for fm.windElapsed += d; interval > 0 && fm.windElapsed >= interval; fm.windElapsed -= interval {
+			fm.wind.Turn()
+		}
+	}
+	return stepped
+}
+
+// Advance mueve el reloj simulado d y espera a que el agregador tenga los
+// estados de las luciérnagas a las que les tocaba avanzar, a lo sumo
+// settleTimeout. Así el modo con reloj simulado corre un
+// tick por llamada sin depender del planificador. Retorna false si dejó de
+// esperar antes; sin FakeClock no hace nada.
+func (fm *FireflyManager) Advance(d time.Duration) bool {
+	fake, ok := fm.clock.(*core.FakeClock)
+	if !ok {
+		return false
+	}
+
+	tick := time.Second / time.Duration(fm.tickRate())
+	expected := fm.aggregator.GetProcessedCount() + fm.GetDroppedStates() + uint64(fm.routes.len())*uint64(d/tick)
+	fake.Advance(d)
+
+	return fm.settle(settleTimeout, func() bool {
+		return fm.aggregator.GetProcessedCount()+fm.GetDroppedStates() >= expected
+	})
+}
+
+// settle cede el procesador hasta que ready se cumpla; retorna false si
+// pasó timeout o el manager se detuvo antes
+func (fm *FireflyManager) settle(timeout time.Duration, ready func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for !ready() {
+		if fm.ctx.Err() != nil || time.Now().After(deadline) {
+			return false
+		}
+		runtime.Gosched()
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// newSyncManager arranca un manager sincrónico, sembrado y con reloj
+// simulado, con fireflies luciérnagas que no mueren de viejas ni se reponen;
+// setup, si no es nil, ajusta la configuración antes de crearlo
+func newSyncManager(t *testing.T, seed int64, fireflies int, setup func(*config.Config)) *FireflyManager {
+	t.Helper()
+	prev := config.Get()
+	cfg := config.Default()
+	cfg.Fireflies.Initial = fireflies
+	cfg.Fireflies.LifespanMin, cfg.Fireflies.LifespanMax = 1000, 1000
+	cfg.Spawn.AutoSpawn = false
+	if setup != nil {
+		setup(cfg)
+	}
+	config.Set(cfg)
+	t.Cleanup(func() { config.Set(prev) })
+
+	utils.Seed(seed)
+	fm := NewFireflyManager()
+	fm.SetClock(core.NewFakeClock(time.Unix(0, 0)))
+	fm.EnableSync()
+	fm.Start()
+	t.Cleanup(fm.Stop)
+	return fm
+}
+
+func TestStepAdvancesEveryFireflyOnce(t *testing.T) {
+	const fireflies = 40
+	fm := newSyncManager(t, 1, fireflies, nil)
+	dt := 1 / float64(config.Get().SimulationTPS)
+
+	for tick := 1; tick <= 10; tick++ {
+		processed := fm.aggregator.GetProcessedCount()
+		if stepped := fm.Step(dt); stepped != fireflies {
+			t.Fatalf("tick %d: Step avanzó %d luciérnagas, se esperaban %d", tick, stepped, fireflies)
+		}
+		if got := fm.aggregator.GetProcessedCount() - processed; got != fireflies {
+			t.Fatalf("tick %d: el agregador aplicó %d estados, se esperaban %d", tick, got, fireflies)
+		}
+		states, meta := fm.GetSnapshotWithMeta()
+		ReleaseStates(states)
+		if meta.Tick != uint64(tick) {
+			t.Fatalf("tick %d: el snapshot dice tick %d", tick, meta.Tick)
+		}
+	}
+	if dropped := fm.GetDroppedStates(); dropped != 0 {
+		t.Fatalf("se descartaron %d estados", dropped)
+	}
+}
+
+func TestEmergentBehavior(t *testing.T) {
+	center := utils.Vector2D{X: config.ScreenWidth / 2, Y: config.ScreenHeight / 2}
+	radius := config.Default().Lanterns.Radius
+	// A lo sumo avanzan 2 × speed px por segundo: 100 ticks no alcanzan para
+	// que se dispersen ni para que lleguen, por eso corren 40 s simulados
+	ticks := 40 * config.Default().SimulationTPS
+
+	tests := []struct {
+		name    string
+		command Command
+		// spawn es el radio alrededor del centro donde nacen las luciérnagas
+		spawn  float64
+		ticks  int
+		radius float64
+		// share es la fracción mínima de luciérnagas a radius del centro
+		share float64
+	}{
+		{"farol en el centro", Command{Type: CommandAddLantern, Data: center}, radius, ticks, radius, 0.8},
+		// El viento gira cada pocos segundos y la atracción, a diferencia del
+		// farol, no frena a las que llegan: se juntan en un radio más amplio
+		{"atracción al centro", Command{Type: CommandSetAttraction, Data: center}, 2 * radius, ticks, 1.5 * radius, 0.8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm := newSyncManager(t, 7, 0, nil)
+			for range 50 {
+				p := center.Add(utils.RandomUnitVector().Mul(utils.RandomFloat(0, tt.spawn)))
+				fm.spawnFirefly(p.X, p.Y)
+			}
+			dt := 1 / float64(config.Get().SimulationTPS)
+
+			if !fm.Send(tt.command) {
+				t.Fatal("cola de comandos llena")
+			}
+			for range tt.ticks {
+				fm.Step(dt)
+			}
+
+			states := fm.GetFireflyStates()
+			defer ReleaseStates(states)
+			near := 0
+			for _, s := range states {
+				if utils.Distance(s.Position, center) <= tt.radius {
+					near++
+				}
+			}
+			if got := float64(near) / float64(len(states)); got < tt.share {
+				t.Errorf("tras %d ticks hay %d de %d luciérnagas a %.0f px del centro (%.0f%%), se esperaba al menos %.0f%%",
+					tt.ticks, near, len(states), tt.radius, got*100, tt.share*100)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package manager
+
+import (
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// stormPhase es la etapa del ciclo de tormentas de stormLoop
+type stormPhase int
+
+const (
+	stormCalm stormPhase = iota
+	stormWarning
+	stormActive
+)
+
+// stormLoop programa las tormentas con un solo ticker que se reajusta en
+// cada etapa: calma hasta el aviso, aviso hasta la llegada del frente y
+// frente hasta que termina de cruzar el jardín. El frente viaja dentro del
+// WindSnapshot, así las luciérnagas y el render lo leen con el viento.
+func (fm *FireflyManager) stormLoop() {
+	defer fm.wg.Done()
+	defer fm.goroutines.track(SubsystemStorm)()
+
+	storm := config.Get().Storm
+	ticker := fm.clock.NewTicker(storm.Interval.Duration - storm.Warning.Duration)
+	defer ticker.Stop()
+	defer fm.wind.SetStorm(nil)
+
+	phase := stormCalm
+	for {
+		select {
+		case <-fm.ctx.Done():
+			return
+
+		case <-ticker.C():
+			storm = config.Get().Storm
+			switch phase {
+			case stormCalm:
+				fm.notify(NoticeWarning, "⛈ Se acerca una tormenta")
+				fm.events.Publish(Event{Type: EventStormWarning})
+				phase = stormWarning
+				if storm.Warning.Duration > 0 {
+					ticker.Reset(storm.Warning.Duration)
+					continue
+				}
+				fallthrough
+
+			case stormWarning:
+				front := fm.startStorm(fm.stormDirection(), storm)
+				ticker.Reset(front.Duration())
+				phase = stormActive
+
+			case stormActive:
+				fm.endStorm()
+				ticker.Reset(storm.Interval.Duration - storm.Warning.Duration)
+				phase = stormCalm
+			}
+		}
+	}
+}
+
+// stormDirection es la dirección del viento o, en calma, una al azar
+func (fm *FireflyManager) stormDirection() utils.Vector2D {
+	if force := fm.wind.Snapshot().Force; force.Magnitude() > 0 {
+		return force
+	}
+	return utils.RandomUnitVector()
+}
+
+// startStorm publica un frente que entra ahora por el borde contrario a dir
+func (fm *FireflyManager) startStorm(dir utils.Vector2D, storm config.StormConfig) *core.StormFront {
+	width, height := config.WorldSize()
+	front := core.NewStormFront(fm.clock.Now(), dir, width, height, storm)
+	fm.wind.SetStorm(front)
+	fm.events.Publish(Event{Type: EventStorm, Storm: front})
+	fm.log.Info("tormenta", "direction", front.Direction, "duration", front.Duration())
+	return front
+}
+
+// endStorm quita el frente cuando terminó de cruzar
+func (fm *FireflyManager) endStorm() {
+	fm.wind.SetStorm(nil)
+	fm.events.Publish(Event{Type: EventStormEnd})
+}
+
+// GetStorm retorna el frente que está cruzando el jardín, o nil
+func (fm *FireflyManager) GetStorm() *core.StormFront {
+	return fm.wind.Snapshot().Storm
+}
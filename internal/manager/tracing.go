@@ -0,0 +1,182 @@
+package manager
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+// effectTimeout es lo que se espera el primer estado que muestre el efecto
+// de un comando; un spawn rechazado por el límite nunca lo tiene
+const effectTimeout = 2 * time.Second
+
+// tracer traza el recorrido de los comandos. Sin tracing.Setup el
+// proveedor global no graba y traceCommand no hace nada.
+var tracer = otel.Tracer("github.com/yourusername/firefly-garden/internal/manager")
+
+// commandNames nombran los comandos en los spans
+var commandNames = map[CommandType]string{
+	CommandSpawnFirefly:    "spawn_firefly",
+	CommandSetAttraction:   "set_attraction",
+	CommandClearAttraction: "clear_attraction",
+	CommandUpdateWind:      "cycle_wind",
+	CommandSpawnBurst:      "spawn_burst",
+	CommandAddLantern:      "add_lantern",
+	CommandRemoveLantern:   "remove_lantern",
+	CommandUpdateSettings:  "update_settings",
+	CommandReloadConfig:    "reload_config",
+	CommandReplayEvent:     "replay_event",
+	CommandSetWind:         "set_wind",
+	CommandMoveLantern:     "move_lantern",
+	CommandGroupOrder:      "group_order",
+	CommandBatWave:         "bat_wave",
+	CommandClearLanterns:   "clear_lanterns",
+}
+
+// commandTrace sigue un comando de punta a punta: el span raíz va desde
+// que se encola hasta que el agregador recibe el primer estado con su
+// efecto, con un hijo por etapa (cola, proceso y efecto). Los métodos no
+// hacen nada sobre un commandTrace nil, el de los comandos sin trazar.
+type commandTrace struct {
+	ctx   context.Context
+	root  trace.Span
+	stage trace.Span
+}
+
+// traceCommand abre el span raíz y el de la cola; retorna nil si el
+// comando no se muestrea
+func traceCommand(cmd Command) *commandTrace {
+	name, ok := commandNames[cmd.Type]
+	if !ok {
+		name = "unknown"
+	}
+	ctx, root := tracer.Start(context.Background(), "command "+name,
+		trace.WithAttributes(attribute.String("command.type", name)))
+	if !root.IsRecording() {
+		return nil
+	}
+	t := &commandTrace{ctx: ctx, root: root}
+	_, t.stage = tracer.Start(ctx, "queue")
+	return t
+}
+
+// next cierra la etapa actual y abre la siguiente
+func (t *commandTrace) next(stage string) {
+	if t == nil {
+		return
+	}
+	t.stage.End()
+	_, t.stage = tracer.Start(t.ctx, stage)
+}
+
+// end cierra la etapa y el span raíz; un motivo no vacío marca el error
+func (t *commandTrace) end(reason string, attrs ...attribute.KeyValue) {
+	if t == nil {
+		return
+	}
+	if reason != "" {
+		t.stage.SetStatus(codes.Error, reason)
+		t.root.SetStatus(codes.Error, reason)
+	}
+	t.stage.End()
+	t.root.SetAttributes(attrs...)
+	t.root.End()
+}
+
+// pendingEffect es un comando ya procesado que espera ver su efecto: el
+// primer estado producido desde after por una luciérnaga con ID >= minID
+type pendingEffect struct {
+	trace    *commandTrace
+	minID    int
+	after    time.Time
+	deadline time.Time
+}
+
+// effectWatcher cierra los comandos trazados cuando su efecto llega al
+// agregador. El contador evita tomar el lock por cada estado cuando no hay
+// nada esperando.
+type effectWatcher struct {
+	mux     sync.Mutex
+	pending []pendingEffect
+	count   atomic.Int32
+}
+
+func (w *effectWatcher) await(p pendingEffect) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	w.pending = append(w.pending, p)
+	w.count.Store(int32(len(w.pending)))
+}
+
+// observe revisa un estado recibido contra los comandos pendientes
+func (w *effectWatcher) observe(state core.FireflyState) {
+	if w.count.Load() == 0 {
+		return
+	}
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	kept := w.pending[:0]
+	for _, p := range w.pending {
+		switch {
+		case state.ID >= p.minID && !state.Timestamp.Before(p.after):
+			p.trace.end("", attribute.Int("firefly.id", state.ID))
+		case state.Timestamp.After(p.deadline):
+			p.trace.end("sin efecto visible")
+		default:
+			kept = append(kept, p)
+		}
+	}
+	clear(w.pending[len(kept):])
+	w.pending = kept
+	w.count.Store(int32(len(w.pending)))
+}
+
+// flush cierra lo que quedó esperando al detener el manager
+func (w *effectWatcher) flush() {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	for _, p := range w.pending {
+		p.trace.end("manager detenido")
+	}
+	w.pending = nil
+	w.count.Store(0)
+}
+
+// runCommand aplica un comando sacado de la cola y, si está trazado, deja
+// al agregador esperando su efecto. Los spawns esperan a una luciérnaga
+// nueva; el resto, a cualquier estado posterior, que ya refleja el cambio.
+func (fm *FireflyManager) runCommand(cmd Command) {
+	fm.commandPeak.observe(len(fm.commandCh) + 1)
+	cmd.trace.next("process")
+
+	minID := 0
+	if cmd.Type == CommandSpawnFirefly || cmd.Type == CommandSpawnBurst {
+		minID = fm.world.PeekID()
+	}
+	fm.processCommand(cmd)
+	fm.commandsDone.Add(1)
+
+	if cmd.trace == nil {
+		return
+	}
+	cmd.trace.next("effect")
+	now := fm.clock.Now()
+	fm.aggregator.effects.await(pendingEffect{
+		trace:    cmd.trace,
+		minID:    minID,
+		after:    now,
+		deadline: now.Add(effectTimeout),
+	})
+}
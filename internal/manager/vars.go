@@ -0,0 +1,81 @@
+package manager
+
+import (
+	"expvar"
+	"sync"
+	"sync/atomic"
+)
+
+// Vars son los contadores que se publican en /debug/vars (expvar) bajo la
+// clave "garden". Sirven para leer la simulación con curl o un script sin
+// abrir la ventana; el listener es el de -pprof.
+type Vars struct {
+	Population    int    `json:"population"`
+	SpawnCap      int    `json:"spawn_cap"`
+	Admitted      uint64 `json:"spawns_admitted"`
+	Rejected      uint64 `json:"spawns_rejected"`
+	DroppedStates uint64 `json:"dropped_states"`
+	DropRate      uint64 `json:"dropped_states_per_second"`
+	DroppedEvents uint64 `json:"dropped_events"`
+	Processed     uint64 `json:"states_processed"`
+	Goroutines    int    `json:"goroutines"`
+
+	Queues map[string]QueueVars `json:"queues"`
+}
+
+// QueueVars es la ocupación de un canal: en cola, máximo histórico y
+// capacidad
+type QueueVars struct {
+	Len  int `json:"len"`
+	Peak int `json:"peak"`
+	Cap  int `json:"cap"`
+}
+
+var (
+	varsOnce sync.Once
+	// varsManager es el manager que se publica: el último que arrancó
+	varsManager atomic.Pointer[FireflyManager]
+)
+
+// publishVars deja a fm detrás de /debug/vars. expvar no permite registrar
+// dos veces el mismo nombre, así que la variable se registra una sola vez y
+// lee del manager del momento.
+func publishVars(fm *FireflyManager) {
+	varsManager.Store(fm)
+	varsOnce.Do(func() {
+		expvar.Publish("garden", expvar.Func(func() any {
+			if fm := varsManager.Load(); fm != nil {
+				return fm.Vars()
+			}
+			return nil
+		}))
+	})
+}
+
+// unpublishVars retira a fm si todavía es el publicado
+func unpublishVars(fm *FireflyManager) {
+	varsManager.CompareAndSwap(fm, nil)
+}
+
+// Vars lee los contadores sin bloquear la simulación
+func (fm *FireflyManager) Vars() Vars {
+	in := fm.Internals()
+	bp := in.Backpressure
+	return Vars{
+		Population:    in.Spawns.Live,
+		SpawnCap:      in.Spawns.Cap,
+		Admitted:      in.Spawns.Admitted,
+		Rejected:      in.Spawns.Rejected,
+		DroppedStates: in.DroppedStates,
+		DropRate:      bp.DropRate,
+		DroppedEvents: in.DroppedEvents,
+		Processed:     in.AggregatorProcessed,
+		Goroutines:    in.TotalGoroutines,
+		Queues: map[string]QueueVars{
+			"states":   {Len: in.StateQueue, Peak: bp.StatePeak, Cap: in.StateCap},
+			"commands": {Len: in.CommandQueue, Peak: bp.CommandPeak, Cap: in.CommandCap},
+			"jobs":     {Len: in.JobQueue, Peak: bp.JobPeak, Cap: in.JobCap},
+			"results":  {Len: in.ResultQueue, Peak: bp.ResultPeak, Cap: in.ResultCap},
+		},
+	}
+}
@@ -3,6 +3,7 @@ package manager
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 )
 
 type Job struct {
@@ -23,6 +24,10 @@ type WorkerPool struct {
 	ctx         context.Context
 	cancel      context.CancelFunc
 	wg          sync.WaitGroup
+	submitted   atomic.Uint64
+	completed   atomic.Uint64
+	jobPeak     highWater
+	resultPeak  highWater
 }
 
 func NewWorkerPool(workerCount, jobBufferSize, resultBufferSize int) *WorkerPool {
@@ -56,11 +61,14 @@ func (wp *WorkerPool) worker(workerID int) {
 			if !ok {
 				return
 			}
+			wp.jobPeak.observe(len(wp.jobsCh) + 1)
 			
 			result := wp.processJob(job)
+			wp.completed.Add(1)
 			
 			select {
 			case wp.resultsCh <- result:
+				wp.resultPeak.observe(len(wp.resultsCh))
 			case <-wp.ctx.Done():
 				return
 			default:
@@ -92,6 +100,7 @@ func (wp *WorkerPool) Submit(job Job) bool {
 	case <-wp.ctx.Done():
 		return false
 	case wp.jobsCh <- job:
+		wp.submitted.Add(1)
 		return true
 	default:
 		// Canal lleno, descartar trabajo
@@ -107,6 +116,32 @@ func (wp *WorkerPool) GetResultsChannel() <-chan Result {
 	return wp.resultsCh
 }
 
+// GetWorkerCount retorna la cantidad de goroutines trabajadoras
+func (wp *WorkerPool) GetWorkerCount() int {
+	return wp.workerCount
+}
+
+// GetJobCounts retorna los trabajos aceptados y los terminados desde el inicio
+func (wp *WorkerPool) GetJobCounts() (uint64, uint64) {
+	return wp.submitted.Load(), wp.completed.Load()
+}
+
+// JobQueue retorna los trabajos en espera y la capacidad de la cola
+func (wp *WorkerPool) JobQueue() (int, int) {
+	return len(wp.jobsCh), cap(wp.jobsCh)
+}
+
+// ResultQueue retorna los resultados sin leer y la capacidad del canal
+func (wp *WorkerPool) ResultQueue() (int, int) {
+	return len(wp.resultsCh), cap(wp.resultsCh)
+}
+
+// QueuePeaks retorna lo más llenas que estuvieron las colas de trabajos y
+// de resultados
+func (wp *WorkerPool) QueuePeaks() (int, int) {
+	return wp.jobPeak.get(), wp.resultPeak.get()
+}
+
 func (wp *WorkerPool) Stop() {
 	wp.cancel()
 	close(wp.jobsCh)
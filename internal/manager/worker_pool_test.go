@@ -0,0 +1,28 @@
+package manager
+
+import (
+	"runtime"
+	"testing"
+)
+
+// BenchmarkWorkerPool mide la latencia de un trabajo: desde Submit hasta
+// que un worker lo termina
+func BenchmarkWorkerPool(b *testing.B) {
+	pool := NewWorkerPool(4, 100, 100)
+	pool.Start()
+	b.Cleanup(pool.Stop)
+
+	done := make(chan struct{})
+	job := Job{Task: func() interface{} {
+		done <- struct{}{}
+		return nil
+	}}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		for !pool.Submit(job) {
+			runtime.Gosched()
+		}
+		<-done
+	}
+}
@@ -0,0 +1,95 @@
+package manager
+
+import (
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+// ZoneStat es lo que hay en una zona del jardín
+type ZoneStat struct {
+	Count int
+	// Brightness es el brillo promedio de las luciérnagas de la zona; 0 si
+	// está vacía
+	Brightness float64
+}
+
+// ZoneStats son las zonas en el orden de config.ZoneName
+type ZoneStats [config.ZoneCount]ZoneStat
+
+// Densest retorna la zona con más luciérnagas (la primera si empatan)
+func (z ZoneStats) Densest() int {
+	best := 0
+	for i, s := range z {
+		if s.Count > z[best].Count {
+			best = i
+		}
+	}
+	return best
+}
+
+// zoneTracker lleva la cuenta por zona a medida que llegan los estados, sin
+// recorrer el mapa completo en cada snapshot. Recuerda en qué zona contó a
+// cada luciérnaga, así un cambio de tamaño del mundo no descuadra las
+// cuentas: la luciérnaga se mueve de zona con su próximo estado. Lo
+// protege el statesMux del agregador.
+type zoneTracker struct {
+	of         map[int]zoneEntry
+	count      [config.ZoneCount]int
+	brightness [config.ZoneCount]float64
+}
+
+// zoneEntry es dónde y con cuánto brillo se contó una luciérnaga
+type zoneEntry struct {
+	zone       int
+	brightness float64
+}
+
+func newZoneTracker() zoneTracker {
+	return zoneTracker{of: make(map[int]zoneEntry)}
+}
+
+// update cuenta el estado en su zona, sacando lo que aportaba el anterior
+func (z *zoneTracker) update(state core.FireflyState) {
+	z.remove(state.ID)
+
+	entry := zoneEntry{
+		zone:       config.ZoneAt(state.Position.X, state.Position.Y),
+		brightness: state.Brightness,
+	}
+	z.of[state.ID] = entry
+	z.count[entry.zone]++
+	z.brightness[entry.zone] += entry.brightness
+}
+
+// remove descuenta a la luciérnaga de su zona
+func (z *zoneTracker) remove(id int) {
+	entry, ok := z.of[id]
+	if !ok {
+		return
+	}
+	delete(z.of, id)
+	z.count[entry.zone]--
+	z.brightness[entry.zone] -= entry.brightness
+	if z.count[entry.zone] == 0 {
+		// Sin luciérnagas la suma vuelve a cero exacto y no arrastra el
+		// error de redondeo de las restas
+		z.brightness[entry.zone] = 0
+	}
+}
+
+// reset vacía todas las zonas
+func (z *zoneTracker) reset() {
+	*z = newZoneTracker()
+}
+
+// stats retorna la cuenta y el brillo promedio de cada zona
+func (z *zoneTracker) stats() ZoneStats {
+	var stats ZoneStats
+	for i, n := range z.count {
+		stats[i].Count = n
+		if n > 0 {
+			stats[i].Brightness = z.brightness[i] / float64(n)
+		}
+	}
+	return stats
+}
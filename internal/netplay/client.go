@@ -0,0 +1,367 @@
+// Package netplay permite jugar en el jardín de otro equipo de la red. El
+// anfitrión corre la simulación autoritativa y la expone con la sesión gRPC
+// de la API de control (-host); cada cliente (-join) envía por ella sus
+// órdenes de atracción, faroles y viento y recibe el estado del jardín, que
+// interpola para que el movimiento se vea continuo. Un espectador (-spectate)
+// recibe lo mismo sin poder dar órdenes.
+package netplay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/yourusername/firefly-garden/internal/api"
+	"github.com/yourusername/firefly-garden/internal/api/gardenpb"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// DefaultPort es el puerto del anfitrión cuando -join no indica otro
+	DefaultPort = "7777"
+	// interpolationDelay retrasa la vista un intervalo de estado del
+	// anfitrión, así casi siempre hay dos estados reales entre los que interpolar
+	interpolationDelay = 100 * time.Millisecond
+	// sendBuffer es cuántas órdenes pueden esperar a salir por la red
+	sendBuffer = 64
+	// dialTimeout es cuánto espera Dial el primer estado del anfitrión
+	dialTimeout = 5 * time.Second
+)
+
+var (
+	// ErrSendQueueFull indica que la red no da abasto y la orden se descartó
+	ErrSendQueueFull = errors.New("cola de envío llena")
+	// ErrReadOnly indica que el cliente es un espectador
+	ErrReadOnly = errors.New("modo espectador: no se pueden dar órdenes")
+)
+
+// frame es un estado recibido con la hora local de llegada
+type frame struct {
+	received time.Time
+	state    *gardenpb.State
+}
+
+// Lantern es un farol del anfitrión
+type Lantern struct {
+	ID       int
+	Position utils.Vector2D
+	Radius   float64
+}
+
+// View es el jardín remoto tal como se dibuja en un instante
+type View struct {
+	Fireflies  []core.FireflyState
+	Lanterns   []Lantern
+	Wind       core.WindDirection
+	Population int
+	SpawnCap   int
+	Dropped    uint64
+}
+
+// Client es la conexión de un jugador con el anfitrión. Recibe en su propia
+// goroutine; View y las órdenes pueden llamarse desde el hilo de Ebiten.
+type Client struct {
+	addr     string
+	readOnly bool
+	conn     *grpc.ClientConn
+	cancel   context.CancelFunc
+	out      chan *gardenpb.SessionRequest
+	seq      atomic.Uint64
+	wg       sync.WaitGroup
+	log      *slog.Logger
+
+	mux      sync.Mutex
+	prev     frame
+	curr     frame
+	rejected string
+	err      error
+	done     chan struct{}
+}
+
+// Dial se conecta al anfitrión como jugador y espera su primer estado. Si
+// addr no trae puerto se usa DefaultPort.
+func Dial(addr string) (*Client, error) {
+	return dial(addr, false)
+}
+
+// Spectate se conecta al anfitrión en modo solo lectura, para proyectar el
+// jardín en otra pantalla: recibe el estado pero no puede dar órdenes
+func Spectate(addr string) (*Client, error) {
+	return dial(addr, true)
+}
+
+func dial(addr string, readOnly bool) (*Client, error) {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, DefaultPort)
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("dirección de anfitrión inválida %q: %w", addr, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if readOnly {
+		ctx = metadata.AppendToOutgoingContext(ctx, api.RoleHeader, api.RoleSpectator)
+	}
+	stream, err := gardenpb.NewGardenServiceClient(conn).Session(ctx)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return nil, fmt.Errorf("no se pudo abrir la sesión con %s: %w", addr, err)
+	}
+
+	c := &Client{
+		addr:     addr,
+		readOnly: readOnly,
+		conn:     conn,
+		cancel:   cancel,
+		out:      make(chan *gardenpb.SessionRequest, sendBuffer),
+		log:      logging.For("netplay"),
+		done:     make(chan struct{}),
+	}
+
+	first := make(chan struct{})
+	c.wg.Add(2)
+	go c.receive(ctx, stream, first)
+	go c.send(ctx, stream)
+
+	select {
+	case <-first:
+	case <-c.done:
+		err := c.Err()
+		c.Close()
+		return nil, fmt.Errorf("el anfitrión %s cortó la sesión: %w", addr, err)
+	case <-time.After(dialTimeout):
+		c.Close()
+		return nil, fmt.Errorf("el anfitrión %s no respondió en %s", addr, dialTimeout)
+	}
+
+	c.log.Info("conectado al anfitrión", "addr", addr, "spectator", readOnly)
+	return c, nil
+}
+
+// Addr retorna la dirección del anfitrión
+func (c *Client) Addr() string {
+	return c.addr
+}
+
+// ReadOnly indica si el cliente es un espectador
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// receive guarda los dos últimos estados; al cortarse la sesión deja el
+// error en Err y cierra Done
+func (c *Client) receive(ctx context.Context, stream grpc.BidiStreamingClient[gardenpb.SessionRequest, gardenpb.SessionUpdate], first chan struct{}) {
+	defer c.wg.Done()
+	defer close(c.done)
+
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			c.mux.Lock()
+			c.err = err
+			c.mux.Unlock()
+			if ctx.Err() == nil {
+				c.log.Warn("sesión con el anfitrión terminada", "addr", c.addr, "err", err)
+			}
+			return
+		}
+
+		switch u := update.GetUpdate().(type) {
+		case *gardenpb.SessionUpdate_State:
+			c.mux.Lock()
+			c.prev = c.curr
+			c.curr = frame{received: time.Now(), state: u.State}
+			c.mux.Unlock()
+			if first != nil {
+				close(first)
+				first = nil
+			}
+
+		case *gardenpb.SessionUpdate_Result:
+			if !u.Result.GetAccepted() {
+				c.mux.Lock()
+				c.rejected = u.Result.GetError()
+				c.mux.Unlock()
+			}
+		}
+	}
+}
+
+// send es la única goroutine que escribe en el stream
+func (c *Client) send(ctx context.Context, stream grpc.BidiStreamingClient[gardenpb.SessionRequest, gardenpb.SessionUpdate]) {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case req := <-c.out:
+			if err := stream.Send(req); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Done se cierra cuando la sesión con el anfitrión termina
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err retorna por qué terminó la sesión
+func (c *Client) Err() error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.err
+}
+
+// TakeRejected retorna el último motivo de rechazo del anfitrión, una sola vez
+func (c *Client) TakeRejected() string {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	reason := c.rejected
+	c.rejected = ""
+	return reason
+}
+
+// Close corta la sesión y espera a sus goroutines
+func (c *Client) Close() {
+	c.cancel()
+	c.conn.Close()
+	c.wg.Wait()
+}
+
+// enqueue no bloquea: si la red va atrasada la orden se descarta
+func (c *Client) enqueue(req *gardenpb.SessionRequest) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+	req.Seq = c.seq.Add(1)
+	select {
+	case c.out <- req:
+		return nil
+	default:
+		return ErrSendQueueFull
+	}
+}
+
+func point(v utils.Vector2D) *gardenpb.Point {
+	return &gardenpb.Point{X: v.X, Y: v.Y}
+}
+
+// SetAttraction mueve el punto de atracción del jardín
+func (c *Client) SetAttraction(pos utils.Vector2D) error {
+	return c.enqueue(&gardenpb.SessionRequest{Command: &gardenpb.SessionRequest_SetAttraction{
+		SetAttraction: &gardenpb.SetAttractionRequest{Position: point(pos)},
+	}})
+}
+
+// ClearAttraction quita el punto de atracción
+func (c *Client) ClearAttraction() error {
+	return c.enqueue(&gardenpb.SessionRequest{Command: &gardenpb.SessionRequest_ClearAttraction{
+		ClearAttraction: &gardenpb.ClearAttractionRequest{},
+	}})
+}
+
+// AddLantern coloca un farol
+func (c *Client) AddLantern(pos utils.Vector2D) error {
+	return c.enqueue(&gardenpb.SessionRequest{Command: &gardenpb.SessionRequest_AddLantern{
+		AddLantern: &gardenpb.AddLanternRequest{Position: point(pos)},
+	}})
+}
+
+// CycleWind rota el viento a la siguiente dirección
+func (c *Client) CycleWind() error {
+	return c.enqueue(&gardenpb.SessionRequest{Command: &gardenpb.SessionRequest_SetWind{
+		SetWind: &gardenpb.SetWindRequest{Direction: "cycle"},
+	}})
+}
+
+// SpawnBurst hace nacer la ráfaga configurada en pos
+func (c *Client) SpawnBurst(pos utils.Vector2D) error {
+	return c.enqueue(&gardenpb.SessionRequest{Command: &gardenpb.SessionRequest_Spawn{
+		Spawn: &gardenpb.SpawnRequest{Position: point(pos)},
+	}})
+}
+
+// View retorna el jardín en now, interpolando las luciérnagas entre los dos
+// últimos estados recibidos con interpolationDelay de retraso
+func (c *Client) View(now time.Time) View {
+	c.mux.Lock()
+	prev, curr := c.prev, c.curr
+	c.mux.Unlock()
+
+	var view View
+	if curr.state == nil {
+		return view
+	}
+
+	state := curr.state
+	view.Population = int(state.GetPopulation())
+	view.SpawnCap = int(state.GetSpawnCap())
+	view.Dropped = state.GetDropped()
+	if dir, ok := core.ParseWindDirection(state.GetWind().GetDirection()); ok {
+		view.Wind = dir
+	}
+
+	view.Lanterns = make([]Lantern, 0, len(state.GetLanterns()))
+	for _, l := range state.GetLanterns() {
+		view.Lanterns = append(view.Lanterns, Lantern{
+			ID:       int(l.GetId()),
+			Position: utils.Vector2D{X: l.GetPosition().GetX(), Y: l.GetPosition().GetY()},
+			Radius:   l.GetRadius(),
+		})
+	}
+
+	previous := make(map[int32]*gardenpb.Firefly)
+	for _, f := range prev.state.GetFireflies() {
+		previous[f.GetId()] = f
+	}
+
+	renderTime := now.Add(-interpolationDelay)
+	t := 1.0
+	if span := curr.received.Sub(prev.received).Seconds(); prev.state != nil && span > 0 {
+		t = utils.Clamp(renderTime.Sub(prev.received).Seconds()/span, 0, 1)
+	}
+
+	width, _ := config.WorldSize()
+	view.Fireflies = make([]core.FireflyState, 0, len(state.GetFireflies()))
+	for _, f := range state.GetFireflies() {
+		fs := core.FireflyState{
+			ID:         int(f.GetId()),
+			Position:   utils.Vector2D{X: f.GetPosition().GetX(), Y: f.GetPosition().GetY()},
+			Brightness: f.GetBrightness(),
+			IsAlive:    true,
+			Timestamp:  now,
+		}
+
+		// Igual que el agregador: un salto de borde a borde no se interpola
+		if p, ok := previous[f.GetId()]; ok {
+			from := utils.Vector2D{X: p.GetPosition().GetX(), Y: p.GetPosition().GetY()}
+			if utils.Distance(from, fs.Position) <= width/2 {
+				fs.Position = utils.LerpVector(from, fs.Position, t)
+				fs.Brightness = utils.Lerp(p.GetBrightness(), fs.Brightness, t)
+			}
+		}
+		view.Fireflies = append(view.Fireflies, fs)
+	}
+
+	return view
+}
@@ -0,0 +1,116 @@
+// Package plugin define los puntos de extensión de la simulación. Un fork
+// agrega fuerzas o políticas de spawn registrándolas al iniciar (antes de
+// arrancar el manager), sin modificar core ni manager:
+//
+//	func init() {
+//		plugin.RegisterBehavior(cohesion{})
+//	}
+//
+// Los plugins de dibujo viven en render (render.RegisterPlugin) porque
+// dependen de Ebiten.
+package plugin
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// NeighborRadius es la distancia hasta la que una luciérnaga ve vecinas
+const NeighborRadius = 80.0
+
+// Force es el cambio de velocidad que un plugin aplica en un tick; se suma
+// igual que las fuerzas internas (viento, faroles, atracción)
+type Force = utils.Vector2D
+
+// State es el estado de la luciérnaga que se está actualizando
+type State struct {
+	ID         int
+	Position   utils.Vector2D
+	Velocity   utils.Vector2D
+	Brightness float64
+	Age        float64
+	Lifespan   float64
+}
+
+// Neighbor es una luciérnaga cercana según el último estado publicado; puede
+// tener hasta un par de ticks de antigüedad
+type Neighbor struct {
+	ID         int
+	Position   utils.Vector2D
+	Brightness float64
+}
+
+// BehaviorPlugin agrega una fuerza al movimiento de cada luciérnaga. Apply se
+// llama desde la goroutine de cada luciérnaga, concurrentemente: no debe
+// modificar estado compartido sin sincronizarlo.
+type BehaviorPlugin interface {
+	Name() string
+	Apply(state State, neighbors []Neighbor, dt float64) Force
+}
+
+// SpawnContext es lo que ve una política de spawn en cada tick del spawner
+type SpawnContext struct {
+	Population int
+	Objective  int
+	Cap        int
+	BurstCount int
+	Width      float64
+	Height     float64
+	// Interval es el tiempo entre ticks del spawner
+	Interval time.Duration
+	// Lanterns son las posiciones de los faroles
+	Lanterns []utils.Vector2D
+	// RandomPoint sortea un lugar donde puede nacer una luciérnaga: en las
+	// zonas de aparición del mapa o, sin mapa, en todo el mundo
+	RandomPoint func() utils.Vector2D
+}
+
+// SpawnPolicy reemplaza la lógica del spawner automático: en cada tick
+// retorna las posiciones donde nacen luciérnagas (nil para ninguna). El
+// manager igualmente respeta el límite de población.
+type SpawnPolicy interface {
+	Name() string
+	Spawn(ctx SpawnContext) []utils.Vector2D
+}
+
+var (
+	mux         sync.RWMutex
+	behaviors   []BehaviorPlugin
+	spawnPolicy SpawnPolicy
+)
+
+// RegisterBehavior agrega una fuerza. Las luciérnagas toman la lista al
+// nacer, por eso debe registrarse antes de arrancar el manager.
+func RegisterBehavior(p BehaviorPlugin) {
+	mux.Lock()
+	defer mux.Unlock()
+
+	behaviors = append(behaviors, p)
+}
+
+// Behaviors retorna las fuerzas registradas
+func Behaviors() []BehaviorPlugin {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	return append([]BehaviorPlugin(nil), behaviors...)
+}
+
+// RegisterSpawnPolicy reemplaza el spawner automático; si se registran varias
+// gana la última
+func RegisterSpawnPolicy(p SpawnPolicy) {
+	mux.Lock()
+	defer mux.Unlock()
+
+	spawnPolicy = p
+}
+
+// ActiveSpawnPolicy retorna la política registrada o nil si se usa la de fábrica
+func ActiveSpawnPolicy() SpawnPolicy {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	return spawnPolicy
+}
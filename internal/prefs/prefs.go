@@ -0,0 +1,105 @@
+package prefs
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Prefs son las preferencias del usuario que sobreviven entre sesiones.
+// A diferencia de config.Config, las escribe el propio juego al salir.
+type Prefs struct {
+	Window      Window            `json:"window"`
+	Quality     int               `json:"quality"`
+	Language    string            `json:"language"`
+	KeyBindings map[string]string `json:"key_bindings,omitempty"`
+	// Panels es dónde dejó el usuario cada panel del HUD, por nombre
+	Panels map[string]Panel `json:"panels,omitempty"`
+	// UIScale es la escala de los paneles del HUD; 0 la elige sola según
+	// el tamaño de la ventana
+	UIScale float64 `json:"ui_scale,omitempty"`
+	// Skin es el tema visual y Palette la paleta de colores (vacíos son el
+	// clásico y la del tema)
+	Skin    string `json:"skin,omitempty"`
+	Palette string `json:"palette,omitempty"`
+}
+
+// Panel es el desplazamiento de un panel del HUD desde su lugar por defecto
+// y si quedó plegado
+type Panel struct {
+	X         int  `json:"x"`
+	Y         int  `json:"y"`
+	Collapsed bool `json:"collapsed,omitempty"`
+}
+
+// Window guarda la geometría de la ventana; Width 0 significa "sin guardar"
+type Window struct {
+	Width      int  `json:"width"`
+	Height     int  `json:"height"`
+	X          int  `json:"x"`
+	Y          int  `json:"y"`
+	Fullscreen bool `json:"fullscreen"`
+}
+
+func Default(quality int) *Prefs {
+	return &Prefs{
+		Quality:  quality,
+		Language: "es",
+	}
+}
+
+// Path retorna $XDG_CONFIG_HOME/firefly-garden/settings.json
+// (o el equivalente del sistema operativo)
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "firefly-garden", "settings.json"), nil
+}
+
+// Load lee las preferencias guardadas; si no existen retorna Default(quality)
+func Load(quality int) (*Prefs, error) {
+	p := Default(quality)
+
+	path, err := Path()
+	if err != nil {
+		return p, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return p, err
+	}
+
+	if err := json.Unmarshal(data, p); err != nil {
+		return Default(quality), err
+	}
+	return p, nil
+}
+
+// Save escribe las preferencias de forma atómica (archivo temporal + rename)
+func (p *Prefs) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,211 @@
+// Package preset define los escenarios listos para jugar: cada uno ajusta
+// la configuración (fuerzas, población, política de spawn, tormentas,
+// ecología), trae su propio escenario de eventos (ver internal/script) y
+// puede encender la elección de líder. Se eligen desde el menú, la consola
+// ("preset storm") o con -preset, sin reiniciar el programa.
+package preset
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/script"
+)
+
+// Preset es un escenario listo: la configuración que cambia sobre la base,
+// los eventos que conducen la partida y si arranca con elección de líder
+type Preset struct {
+	// Name es el nombre corto de -preset y de la consola
+	Name string
+	// Title y Description son los textos del menú (se traducen al dibujar)
+	Title       string
+	Description string
+	// Events es el escenario, una orden por línea como los de un mapa
+	Events []string
+	// Election enciende la elección de líder al empezar
+	Election bool
+
+	configure func(cfg *config.Config)
+}
+
+// Config retorna base con los cambios del preset, validada; base no se
+// modifica
+func (p *Preset) Config(base *config.Config) (*config.Config, error) {
+	cfg := base.Clone()
+	p.configure(cfg)
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("preset %s: %w", p.Name, err)
+	}
+	return cfg, nil
+}
+
+// Script arma el escenario de los eventos del preset; nil si no trae
+func (p *Preset) Script() (*script.Script, error) {
+	if len(p.Events) == 0 {
+		return nil, nil
+	}
+	return script.Parse("preset "+p.Name, strings.NewReader(strings.Join(p.Events, "\n")))
+}
+
+var presets = []*Preset{
+	{
+		Name:        "calm",
+		Title:       "Noche tranquila",
+		Description: "Brisa suave, pocas luciérnagas que nacen de a una y destellan despacio",
+		Events: []string{
+			"wind none",
+			"log Una noche tranquila en el jardín",
+			"lantern 320 380",
+			"wait 20s",
+			"lantern 700 380",
+		},
+		configure: func(cfg *config.Config) {
+			cfg.Wind.Force = 0.2
+			cfg.Wind.ChangeInterval = config.Duration{Duration: time.Minute}
+			cfg.Spawn.Policy = config.SpawnSteady
+			cfg.Spawn.Objective = min(35, cfg.Fireflies.Max)
+			cfg.Fireflies.Speed *= 0.7
+			cfg.Fireflies.BlinkCycleMin = 2.0
+			cfg.Fireflies.BlinkCycleMax = 4.5
+			cfg.Storm.Enabled = false
+			cfg.Ecology.Enabled = false
+		},
+	},
+	{
+		Name:        "storm",
+		Title:       "Tormenta",
+		Description: "Viento fuerte que rota seguido y frentes de tormenta cada 30 s",
+		Events: []string{
+			"log Se acerca la tormenta",
+			"repeat 20",
+			"    wait 6s",
+			"    wind cycle",
+			"end",
+		},
+		configure: func(cfg *config.Config) {
+			cfg.Storm.Enabled = true
+			cfg.Storm.Interval = config.Duration{Duration: 30 * time.Second}
+			cfg.Storm.Force = 4
+			cfg.Wind.MaxStrength = max(cfg.Wind.MaxStrength, 2.5)
+			cfg.Wind.Force = cfg.Wind.MaxStrength
+			cfg.Wind.ChangeInterval = config.Duration{Duration: 4 * time.Second}
+			cfg.Spawn.Policy = config.SpawnWaves
+		},
+	},
+	{
+		Name:        "swarm",
+		Title:       "Enjambre (estrés)",
+		Description: "Miles de luciérnagas para exigir la simulación: F3 muestra los estados descartados",
+		Events: []string{
+			"log Enjambre: F3 muestra los estados descartados",
+			"repeat 10",
+			"    spawn 100 at random",
+			"    wait 2s",
+			"end",
+			"await population >= 1000 within 30s",
+			"log Enjambre completo",
+		},
+		configure: func(cfg *config.Config) {
+			cfg.Fireflies.Max = 2000
+			cfg.Fireflies.Initial = 500
+			cfg.Spawn.Policy = config.SpawnObjective
+			cfg.Spawn.Objective = 1500
+			cfg.Spawn.BurstCount = 50
+			cfg.Channels.StateBuffer = max(cfg.Channels.StateBuffer, 2000)
+			cfg.Render.AutoQuality = true
+			cfg.Ecology.Enabled = false
+		},
+	},
+	{
+		Name:        "crowd",
+		Title:       "Multitud (10 000)",
+		Description: "Diez mil luciérnagas simuladas por lotes: F3 verifica que no se descarten estados",
+		Events: []string{
+			"log Multitud: F3 muestra los lotes y los descartes",
+			"await population >= 9500 within 30s",
+			"log Multitud completa",
+		},
+		configure: func(cfg *config.Config) {
+			cfg.Fireflies.Max = 10000
+			cfg.Fireflies.Initial = 10000
+			cfg.Fireflies.Size = min(cfg.Fireflies.Size, 4)
+			cfg.Fireflies.LifespanMin = max(cfg.Fireflies.LifespanMin, 60)
+			cfg.Fireflies.LifespanMax = max(cfg.Fireflies.LifespanMax, 120)
+			cfg.Spawn.Policy = config.SpawnObjective
+			cfg.Spawn.Objective = 10000
+			cfg.Spawn.BurstCount = 500
+			cfg.Batch.Enabled = true
+			cfg.Render.Quality = config.QualitySprites
+			cfg.Render.AutoQuality = false
+			cfg.Storm.Enabled = false
+			cfg.Ecology.Enabled = false
+		},
+	},
+	{
+		Name:        "gauntlet",
+		Title:       "Acoso de murciélagos",
+		Description: "Ecología con murciélagos cada 6 s: los faroles agrandan el hábitat",
+		Events: []string{
+			"log Los murciélagos llegan cada 6 s: los faroles agrandan el hábitat",
+			"lantern 260 384",
+			"lantern 760 384",
+		},
+		configure: func(cfg *config.Config) {
+			cfg.Ecology.Enabled = true
+			cfg.Ecology.Predators = true
+			cfg.Ecology.PredatorInterval = config.Duration{Duration: 6 * time.Second}
+			cfg.Ecology.PredationRate = 0.12
+			cfg.Ecology.GrowthRate = 0.5
+			cfg.Ecology.Capacity = 30
+			cfg.Ecology.LanternCapacity = 15
+		},
+	},
+	{
+		Name:        "sync",
+		Title:       "Sincronía",
+		Description: "Cada grupo elige una líder y destella con ella; sin viento que los separe",
+		Election:    true,
+		Events: []string{
+			"wind none",
+			"log Cada grupo elige una líder y destella con ella",
+			"lantern 340 384",
+			"lantern 680 384",
+		},
+		configure: func(cfg *config.Config) {
+			cfg.Wind.Force = 0.1
+			cfg.Wind.ChangeInterval = config.Duration{Duration: 2 * time.Minute}
+			cfg.Fireflies.Speed *= 0.6
+			cfg.Fireflies.BlinkCycleMin = 1.8
+			cfg.Fireflies.BlinkCycleMax = 2.2
+			cfg.Spawn.Objective = min(80, cfg.Fireflies.Max)
+			cfg.Storm.Enabled = false
+			cfg.Ecology.Enabled = false
+		},
+	},
+}
+
+// All retorna los presets en el orden del menú
+func All() []*Preset {
+	return presets
+}
+
+// Find busca un preset por nombre
+func Find(name string) (*Preset, bool) {
+	for _, p := range presets {
+		if p.Name == strings.ToLower(name) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Names retorna los nombres de todos los presets, para los mensajes de uso
+func Names() []string {
+	names := make([]string, len(presets))
+	for i, p := range presets {
+		names[i] = p.Name
+	}
+	return names
+}
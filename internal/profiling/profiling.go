@@ -0,0 +1,108 @@
+package profiling
+
+import (
+	"errors"
+	_ "expvar" // registra /debug/vars en http.DefaultServeMux
+	"fmt"
+	"net/http"
+	_ "net/http/pprof" // registra /debug/pprof en http.DefaultServeMux
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"runtime/trace"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/logging"
+)
+
+// CaptureDuration es lo que dura una captura iniciada con la tecla
+const CaptureDuration = 5 * time.Second
+
+var ErrCaptureInProgress = errors.New("ya hay una captura en curso")
+
+var capturing atomic.Bool
+
+// StartServer expone net/http/pprof y los contadores de expvar
+// (/debug/vars) en addr (por ejemplo ":6060") en su propia goroutine. Un
+// error al escuchar se reporta en el log y no detiene el juego.
+func StartServer(addr string) {
+	log := logging.For("pprof")
+	go func() {
+		log.Info("pprof escuchando", "url", fmt.Sprintf("http://%s/debug/pprof/", displayAddr(addr)),
+			"vars", fmt.Sprintf("http://%s/debug/vars", displayAddr(addr)))
+		if err := http.ListenAndServe(addr, nil); err != nil {
+			log.Error("servidor pprof detenido", "addr", addr, "err", err)
+		}
+	}()
+}
+
+func displayAddr(addr string) string {
+	if len(addr) > 0 && addr[0] == ':' {
+		return "localhost" + addr
+	}
+	return addr
+}
+
+// Result son los archivos escritos por una captura
+type Result struct {
+	TracePath string
+	CPUPath   string
+}
+
+// Capture graba un runtime/trace y un perfil de CPU durante d en dir.
+// Bloquea durante d; solo puede haber una captura a la vez.
+func Capture(dir string, d time.Duration) (Result, error) {
+	if !capturing.CompareAndSwap(false, true) {
+		return Result{}, ErrCaptureInProgress
+	}
+	defer capturing.Store(false)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return Result{}, err
+	}
+
+	stamp := time.Now().Format("20060102-150405")
+	res := Result{
+		TracePath: filepath.Join(dir, fmt.Sprintf("trace-%s.out", stamp)),
+		CPUPath:   filepath.Join(dir, fmt.Sprintf("cpu-%s.pprof", stamp)),
+	}
+
+	traceFile, err := os.Create(res.TracePath)
+	if err != nil {
+		return Result{}, err
+	}
+	defer traceFile.Close()
+
+	cpuFile, err := os.Create(res.CPUPath)
+	if err != nil {
+		return Result{}, err
+	}
+	defer cpuFile.Close()
+
+	if err := trace.Start(traceFile); err != nil {
+		return Result{}, fmt.Errorf("trace: %w", err)
+	}
+	// El perfil de CPU falla si otro cliente (p. ej. /debug/pprof/profile)
+	// ya está perfilando; el trace sigue siendo útil
+	if err := pprof.StartCPUProfile(cpuFile); err != nil {
+		cpuFile.Close()
+		os.Remove(res.CPUPath)
+
+		time.Sleep(d)
+		trace.Stop()
+		return Result{TracePath: res.TracePath}, fmt.Errorf("perfil de CPU: %w", err)
+	}
+
+	time.Sleep(d)
+
+	pprof.StopCPUProfile()
+	trace.Stop()
+
+	return res, nil
+}
+
+// IsCapturing indica si hay una captura en curso
+func IsCapturing() bool {
+	return capturing.Load()
+}
@@ -0,0 +1,87 @@
+package render
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// youngTint y oldTint son los tonos de una luciérnaga recién nacida y de
+// una a punto de apagarse
+var (
+	youngTint = [4]uint8{150, 255, 110, 255}
+	oldTint   = [4]uint8{255, 165, 50, 255}
+)
+
+const (
+	// ageTintAmount es cuánto tiñe la edad el color de la paleta
+	ageTintAmount = 0.45
+	// deathFade es lo que tarda en apagarse una luciérnaga que desaparece
+	deathFade = 600 * time.Millisecond
+	// maxFading acota las que se apagan a la vez: al vaciar o restaurar el
+	// jardín no se animan miles
+	maxFading = 256
+)
+
+// ageTint es el tono de una luciérnaga con esa fracción de vida: verde de
+// joven, ámbar de vieja
+func ageTint(age float64) [4]uint8 {
+	clr := utils.LerpColor(youngTint, oldTint, age)
+	return [4]uint8{clr.R, clr.G, clr.B, clr.A}
+}
+
+// fadingFirefly es el último estado visto de una luciérnaga que ya no está
+type fadingFirefly struct {
+	state core.FireflyState
+	gone  time.Time
+}
+
+// DeathFades apaga de a poco a las luciérnagas que desaparecen del
+// snapshot en lugar de borrarlas de golpe. Compara cada frame con el
+// anterior; solo la usa el hilo de Ebiten.
+type DeathFades struct {
+	last   map[int]core.FireflyState
+	seen   map[int]core.FireflyState
+	fading []fadingFirefly
+}
+
+// NewDeathFades crea el registro vacío
+func NewDeathFades() *DeathFades {
+	return &DeathFades{
+		last: make(map[int]core.FireflyState),
+		seen: make(map[int]core.FireflyState),
+	}
+}
+
+// Update recibe el snapshot del frame: las que estaban en el anterior y ya
+// no están empiezan a apagarse, y las que terminaron de apagarse se quitan
+func (d *DeathFades) Update(states []core.FireflyState, now time.Time) {
+	clear(d.seen)
+	for _, s := range states {
+		d.seen[s.ID] = s
+	}
+	for id, s := range d.last {
+		if _, alive := d.seen[id]; !alive && len(d.fading) < maxFading {
+			d.fading = append(d.fading, fadingFirefly{state: s, gone: now})
+		}
+	}
+	d.last, d.seen = d.seen, d.last
+
+	kept := d.fading[:0]
+	for _, f := range d.fading {
+		if now.Sub(f.gone) < deathFade {
+			kept = append(kept, f)
+		}
+	}
+	d.fading = kept
+}
+
+// Draw dibuja las que se están apagando
+func (d *DeathFades) Draw(world *ebiten.Image, r *Renderer, now time.Time) {
+	for _, f := range d.fading {
+		alpha := 1 - now.Sub(f.gone).Seconds()/deathFade.Seconds()
+		r.DrawFadingFirefly(world, f.state, utils.Clamp(alpha, 0, 1))
+	}
+}
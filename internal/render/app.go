@@ -0,0 +1,405 @@
+package render
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/input"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/internal/prefs"
+	"github.com/yourusername/firefly-garden/internal/preset"
+	"github.com/yourusername/firefly-garden/internal/theme"
+)
+
+// Scene es una pantalla del juego con su propio Update/Draw
+type Scene interface {
+	Update() error
+	Draw(screen *ebiten.Image)
+}
+
+// App implementa ebiten.Game y delega en la escena activa.
+// Es la única responsable de crear y detener el manager de cada partida.
+type App struct {
+	scene        Scene
+	game         *Game
+	uiRenderer   *UIRenderer
+	inputHandler *input.Handler
+	touchBar     *TouchBar
+	gamepad      *GamepadIndicator
+	quit         bool
+
+	// compact se activa cuando la ventana o el canvas del navegador es
+	// chico o vertical: la partida oculta los paneles grandes
+	compact bool
+
+	// Parámetros elegidos en la pantalla de configuración
+	settings manager.Settings
+	quality  int
+
+	prefs   *prefs.Prefs
+	session SessionOptions
+	layout  *HUDLayout
+	// autoScale es la escala de la interfaz que corresponde a la ventana
+	// actual; se usa si el usuario no eligió otra
+	autoScale float64
+
+	// lowPower indica que la ventana no tiene el foco (ver updatePower);
+	// updated, que hubo un Update desde el último Draw
+	lowPower bool
+	updated  bool
+
+	// editor es el editor de mapas al que vuelve la partida de prueba;
+	// editorMap, el mapa de la sesión que la prueba reemplazó
+	editor    *EditorScene
+	editorMap *manager.GardenMap
+
+	// activePreset es el preset cuya configuración está puesta como capa
+	// (config.SetOverlay) y baseSettings, los ajustes que había antes, para
+	// volver al salir de él
+	activePreset *preset.Preset
+	baseSettings manager.Settings
+}
+
+// NewApp crea la aplicación comenzando en el menú principal,
+// con la calidad y las teclas guardadas en las preferencias.
+// session indica si las partidas se graban o se reproduce una grabación.
+func NewApp(p *prefs.Prefs, session SessionOptions) *App {
+	app := &App{
+		uiRenderer:   NewUIRenderer(),
+		inputHandler: input.NewHandler(),
+		touchBar:     NewTouchBar(),
+		gamepad:      NewGamepadIndicator(),
+		settings:     manager.DefaultSettings(),
+		quality:      config.Get().Render.Quality,
+		prefs:        p,
+		session:      session,
+		layout:       NewHUDLayout(p.Panels),
+		autoScale:    1,
+	}
+	app.session.Layout = app.layout
+
+	if p.Quality >= config.QualityCircles && p.Quality <= config.QualityBloom {
+		app.quality = p.Quality
+	}
+
+	if p.Language != "" {
+		if err := i18n.Set(p.Language); err != nil {
+			logging.For("prefs").Warn("idioma guardado inválido, usando español", "err", err)
+		}
+	}
+
+	if p.Skin != "" {
+		if err := theme.SetSkin(p.Skin); err != nil {
+			logging.For("prefs").Warn("tema guardado inválido, usando el clásico", "err", err)
+		}
+	}
+	if p.Palette != "" {
+		if err := theme.Set(p.Palette); err != nil {
+			logging.For("prefs").Warn("paleta guardada inválida, usando la normal", "err", err)
+		}
+	}
+
+	bindings, err := input.ParseBindings(p.KeyBindings)
+	if err != nil {
+		logging.For("prefs").Warn("teclas guardadas inválidas, usando las predeterminadas", "err", err)
+		bindings = input.DefaultBindings()
+	}
+	app.inputHandler.SetBindings(bindings)
+
+	if session.Join != nil {
+		app.scene = NewRemoteScene(app, session.Join)
+	} else if session.Demo {
+		app.StartMode(ModeGarden)
+		app.session.Demo = false
+	} else if session.Preset != nil {
+		app.StartPreset(session.Preset)
+	} else {
+		app.ShowMenu()
+	}
+	return app
+}
+
+// Update implementa ebiten.Game.Update
+func (a *App) Update() error {
+	if ebiten.IsWindowBeingClosed() {
+		a.quit = true
+	}
+	if a.quit {
+		a.capturePrefs()
+		return ebiten.Termination
+	}
+
+	a.updated = true
+	a.updatePower()
+	a.inputHandler.Update()
+	a.gamepad.Update(a.inputHandler)
+	if a.inGarden() {
+		a.touchBar.Update(a.inputHandler)
+	}
+	if a.game != nil {
+		a.game.SetCompact(a.compact)
+	}
+
+	// F11: pantalla completa en cualquier escena
+	if a.inputHandler.IsKeyJustPressed(ebiten.KeyF11) {
+		ebiten.SetFullscreen(!ebiten.IsFullscreen())
+	}
+
+	// Tecla O durante la partida: abrir la configuración sin terminarla
+	if a.game != nil && a.scene == a.game && a.inputHandler.IsActionJustPressed(input.ActionSettings) {
+		a.quality = a.game.Quality()
+		a.settings = a.game.Settings()
+		a.scene = NewSettingsScene(a, a.game)
+		return nil
+	}
+
+	// En el menú, tras un rato sin input arranca un jardín en demostración
+	if _, menu := a.scene.(*MenuScene); menu {
+		if idle := config.Get().Demo.IdleAfter.Duration; idle > 0 && a.inputHandler.IdleFor() >= idle {
+			a.session.Demo = true
+			a.StartMode(ModeGarden)
+			a.session.Demo = false
+			return nil
+		}
+	}
+
+	if err := a.scene.Update(); err != nil {
+		return err
+	}
+
+	// La consola pidió otro preset: la partida se reinicia con él
+	if a.game != nil && a.scene == a.game {
+		if p, ok := a.game.PresetRequest(); ok {
+			a.StartPreset(p)
+			return nil
+		}
+	}
+
+	// La partida terminó: detener sus goroutines y mostrar el resumen, o
+	// volver al editor si era la prueba de un mapa
+	if a.game != nil && a.scene == a.game && a.game.IsFinished() && a.editor != nil {
+		a.returnToEditor()
+		return nil
+	}
+	if a.game != nil && a.scene == a.game && a.game.IsFinished() {
+		summary := a.game.Summary()
+		a.stopGame()
+		recordResult(summary)
+		a.scene = NewSummaryScene(a, summary)
+	}
+
+	return nil
+}
+
+// Draw implementa ebiten.Game.Draw
+func (a *App) Draw(screen *ebiten.Image) {
+	// En bajo consumo la pantalla no se borra: sin Update nuevo queda el
+	// último frame
+	if a.lowPower && !a.updated {
+		return
+	}
+	a.updated = false
+
+	a.scene.Draw(screen)
+	if a.inGarden() {
+		a.touchBar.Draw(screen, a.uiRenderer, a.inputHandler)
+	}
+	a.gamepad.Draw(screen, a.uiRenderer, a.inputHandler, a.inGarden())
+}
+
+// inGarden indica si la escena activa es un jardín (local o remoto)
+func (a *App) inGarden() bool {
+	if _, remote := a.scene.(*RemoteScene); remote {
+		return true
+	}
+	return a.game != nil && a.scene == a.game
+}
+
+// Layout implementa ebiten.Game.Layout. El tamaño lógico sigue a la
+// ventana (ver screenLayout) y con él los límites del mundo; con una
+// ventana (o canvas del navegador) chica o vertical se pasa al modo compacto.
+func (a *App) Layout(outsideWidth, outsideHeight int) (int, int) {
+	a.compact = outsideWidth*4 < config.ScreenWidth*3 || outsideHeight*4 < config.ScreenHeight*3 || outsideHeight > outsideWidth
+	a.autoScale = autoUIScale(outsideWidth, outsideHeight)
+	a.layout.SetScale(UIScale(a.prefs.UIScale, outsideWidth, outsideHeight))
+	width, height := screenLayout(outsideWidth, outsideHeight)
+	config.SetScreenSize(width, height)
+	return width, height
+}
+
+// ShowMenu cambia al menú principal; fuera de un escenario listo vuelve
+// la configuración de siempre
+func (a *App) ShowMenu() {
+	a.session.Preset = nil
+	a.applyPreset()
+	a.scene = NewMenuScene(a)
+}
+
+// ShowPresets abre la lista de escenarios listos
+func (a *App) ShowPresets() {
+	a.scene = NewPresetScene(a)
+}
+
+// ShowSettings abre la configuración desde el menú principal
+func (a *App) ShowSettings() {
+	a.scene = NewSettingsScene(a, a.scene)
+}
+
+// closeSettings vuelve a la escena anterior a la configuración
+func (a *App) closeSettings(back Scene) {
+	if back == a.game && a.game != nil {
+		a.game.Resume()
+	}
+	a.scene = back
+}
+
+// applySettings envía los parámetros a la partida en curso
+func (a *App) applySettings() {
+	if a.game != nil {
+		a.game.ApplySettings(a.settings, a.quality)
+	}
+}
+
+// StartGame crea una partida nueva (arranca el manager) y cambia a ella
+func (a *App) StartGame() {
+	a.stopGame()
+	a.applyPreset()
+	a.game = NewGame(a.inputHandler, a.settings, a.quality, a.session)
+	a.scene = a.game
+	if a.lowPower {
+		a.game.SetLowPower(true)
+	}
+}
+
+// StartMode empieza una partida del modo elegido; "Jugar de nuevo" repite
+// el último
+func (a *App) StartMode(mode GameMode) {
+	a.StartSeeded(mode, 0)
+}
+
+// StartSeeded empieza una partida del modo con la semilla indicada (0 elige
+// una nueva)
+func (a *App) StartSeeded(mode GameMode, seed int64) {
+	a.session.Mode = mode
+	a.session.Seed = seed
+	a.StartGame()
+}
+
+// StartPreset empieza un jardín libre con el preset; nil vuelve al jardín
+// con la configuración de siempre
+func (a *App) StartPreset(p *preset.Preset) {
+	a.session.Preset = p
+	a.StartMode(ModeGarden)
+}
+
+// applyPreset pone la configuración del preset de la sesión como capa
+// sobre la de debajo de cualquier preset, o la quita si ya no hay. La capa
+// sigue puesta cuando se recarga el archivo. Debe llamarse sin partida en
+// curso: el manager lee la configuración al arrancar.
+func (a *App) applyPreset() {
+	p := a.session.Preset
+	if p == a.activePreset {
+		return
+	}
+	if a.activePreset == nil {
+		a.baseSettings = a.settings
+	}
+
+	if p != nil {
+		err := config.SetOverlay(p.Config)
+		if err == nil {
+			a.settings = manager.DefaultSettings()
+			a.activePreset = p
+			return
+		}
+		logging.For("preset").Warn("preset inválido con esta configuración", "preset", p.Name, "err", err)
+		a.session.Preset = nil
+	}
+
+	config.ClearOverlay()
+	a.settings = a.baseSettings
+	a.activePreset = nil
+}
+
+// ShowLeaderboard abre la tabla de récords
+func (a *App) ShowLeaderboard() {
+	a.scene = NewLeaderboardScene(a)
+}
+
+// ShowEditor abre el editor de mapas sobre el mapa de la sesión (-map)
+func (a *App) ShowEditor() {
+	a.scene = NewEditorScene(a, a.session.Map, a.session.MapPath)
+}
+
+// PlayTest prueba el mapa del editor en un jardín libre; Esc termina la
+// prueba y vuelve al editor sin pasar por el resumen
+func (a *App) PlayTest(editor *EditorScene, m *manager.GardenMap) {
+	a.editor = editor
+	a.editorMap = a.session.Map
+	a.session.Map = m
+	a.StartMode(ModeGarden)
+	a.game.toasts.Push(i18n.T("Probando el mapa: Esc vuelve al editor"))
+}
+
+// returnToEditor detiene la partida de prueba y devuelve la sesión a su
+// mapa
+func (a *App) returnToEditor() {
+	a.stopGame()
+	a.session.Map = a.editorMap
+	a.scene = a.editor
+	a.editor.Resume()
+	a.editor, a.editorMap = nil, nil
+}
+
+// Quit termina la aplicación en el próximo Update
+func (a *App) Quit() {
+	a.quit = true
+}
+
+// stopGame detiene la partida activa y todas sus goroutines
+func (a *App) stopGame() {
+	if a.game != nil {
+		a.game.Shutdown()
+		a.game = nil
+	}
+}
+
+// capturePrefs copia el estado de la ventana y las elecciones del usuario;
+// debe llamarse desde Update mientras la ventana sigue abierta
+func (a *App) capturePrefs() {
+	if a.game != nil {
+		a.quality = a.game.Quality()
+	}
+	a.prefs.Quality = a.quality
+	a.prefs.KeyBindings = a.inputHandler.GetBindings().Names()
+	a.prefs.Panels = a.layout.Prefs()
+
+	a.prefs.Window.Fullscreen = ebiten.IsFullscreen()
+	if !a.prefs.Window.Fullscreen {
+		a.prefs.Window.Width, a.prefs.Window.Height = ebiten.WindowSize()
+		a.prefs.Window.X, a.prefs.Window.Y = ebiten.WindowPosition()
+	}
+}
+
+// Prefs retorna las preferencias capturadas al salir
+func (a *App) Prefs() *prefs.Prefs {
+	return a.prefs
+}
+
+// leaveRemote corta la conexión con el anfitrión y vuelve al menú
+func (a *App) leaveRemote() {
+	if a.session.Join != nil {
+		a.session.Join.Close()
+		a.session.Join = nil
+	}
+	a.ShowMenu()
+}
+
+// Shutdown detiene la partida activa si la hay
+func (a *App) Shutdown() {
+	a.stopGame()
+	if a.session.Join != nil {
+		a.session.Join.Close()
+	}
+}
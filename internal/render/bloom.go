@@ -0,0 +1,103 @@
+package render
+
+import (
+	_ "embed"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/theme"
+)
+
+//go:embed shaders/brightpass.kage
+var brightPassKage []byte
+
+//go:embed shaders/blur.kage
+var blurKage []byte
+
+// Bloom implementa el post-procesado de brillo con shaders Kage:
+// bright-pass → blur gaussiano separable → composición aditiva
+// Trabaja a media resolución para abaratar el blur
+type Bloom struct {
+	brightShader *ebiten.Shader
+	blurShader   *ebiten.Shader
+	half         *ebiten.Image
+	pingA        *ebiten.Image
+	pingB        *ebiten.Image
+}
+
+// NewBloom compila los shaders y crea las capas intermedias
+func NewBloom(width, height int) (*Bloom, error) {
+	brightShader, err := ebiten.NewShader(brightPassKage)
+	if err != nil {
+		return nil, err
+	}
+
+	blurShader, err := ebiten.NewShader(blurKage)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bloom{brightShader: brightShader, blurShader: blurShader}
+	b.Resize(width, height)
+	return b, nil
+}
+
+// Resize ajusta las capas de media resolución a una pantalla de
+// width×height
+func (b *Bloom) Resize(width, height int) {
+	hw, hh := width/2, height/2
+	b.half = fitImage(b.half, hw, hh)
+	b.pingA = fitImage(b.pingA, hw, hh)
+	b.pingB = fitImage(b.pingB, hw, hh)
+}
+
+// Apply suma a dst el halo difuminado de las zonas brillantes de src; el
+// umbral y la intensidad son los del tema activo
+func (b *Bloom) Apply(dst, src *ebiten.Image) {
+	skin := theme.CurrentSkin()
+
+	// Reducir a media resolución
+	b.half.Clear()
+	downOp := &ebiten.DrawImageOptions{}
+	downOp.GeoM.Scale(0.5, 0.5)
+	downOp.Filter = ebiten.FilterLinear
+	b.half.DrawImage(src, downOp)
+
+	// Bright-pass
+	bounds := b.half.Bounds()
+	b.pingA.Clear()
+	brightOp := &ebiten.DrawRectShaderOptions{}
+	brightOp.Images[0] = b.half
+	brightOp.Uniforms = map[string]any{
+		"Threshold": float32(skin.BloomThreshold()),
+	}
+	b.pingA.DrawRectShader(bounds.Dx(), bounds.Dy(), b.brightShader, brightOp)
+
+	// Blur separable (horizontal + vertical) varias pasadas
+	for i := 0; i < config.Get().Render.BloomPasses; i++ {
+		b.blur(b.pingB, b.pingA, 1, 0)
+		b.blur(b.pingA, b.pingB, 0, 1)
+	}
+
+	// Composición aditiva a resolución completa
+	upOp := &ebiten.DrawImageOptions{}
+	upOp.GeoM.Scale(2, 2)
+	upOp.Filter = ebiten.FilterLinear
+	upOp.Blend = ebiten.BlendLighter
+	intensity := float32(skin.BloomIntensity())
+	upOp.ColorScale.Scale(intensity, intensity, intensity, 1)
+	dst.DrawImage(b.pingA, upOp)
+}
+
+// blur ejecuta una pasada del blur gaussiano en la dirección indicada
+func (b *Bloom) blur(dst, src *ebiten.Image, dx, dy float32) {
+	bounds := src.Bounds()
+	dst.Clear()
+
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = src
+	op.Uniforms = map[string]any{
+		"Direction": []float32{dx, dy},
+	}
+	dst.DrawRectShader(bounds.Dx(), bounds.Dy(), b.blurShader, op)
+}
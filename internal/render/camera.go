@@ -0,0 +1,146 @@
+package render
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/input"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// Camera define qué parte del jardín se ve en pantalla
+// Position es el centro de la vista en coordenadas del mundo
+type Camera struct {
+	Position utils.Vector2D
+	Zoom     float64
+}
+
+// NewCamera crea una cámara centrada que muestra el mundo completo
+func NewCamera() *Camera {
+	c := &Camera{}
+	c.Reset()
+	return c
+}
+
+// Update mueve la cámara con las flechas y ajusta el zoom con +/- o
+// pellizcando con dos dedos
+func (c *Camera) Update(h *input.Handler, dt float64) {
+	pan := config.Get().Camera.PanSpeed * dt / c.Zoom
+
+	if h.IsKeyPressed(ebiten.KeyArrowLeft) {
+		c.Position.X -= pan
+	}
+	if h.IsKeyPressed(ebiten.KeyArrowRight) {
+		c.Position.X += pan
+	}
+	if h.IsKeyPressed(ebiten.KeyArrowUp) {
+		c.Position.Y -= pan
+	}
+	if h.IsKeyPressed(ebiten.KeyArrowDown) {
+		c.Position.Y += pan
+	}
+
+	if h.IsKeyPressed(ebiten.KeyEqual) || h.IsKeyPressed(ebiten.KeyNumpadAdd) {
+		c.Zoom *= 1 + config.Get().Camera.ZoomSpeed*dt
+	}
+	if h.IsKeyPressed(ebiten.KeyMinus) || h.IsKeyPressed(ebiten.KeyNumpadSubtract) {
+		c.Zoom /= 1 + config.Get().Camera.ZoomSpeed*dt
+	}
+
+	// Pellizco: el punto del mundo entre los dedos queda fijo en pantalla
+	if scale, x, y, ok := h.JustPinched(); ok {
+		c.ZoomAt(scale, x, y)
+	}
+
+	// Tecla 0: restablecer vista completa
+	if h.IsKeyJustPressed(ebiten.Key0) {
+		c.Reset()
+	}
+
+	c.clamp()
+}
+
+// Reset devuelve la cámara a la vista completa del mundo: sin mapa es el
+// zoom 1; un mapa más grande que la ventana se ve entero alejándose
+func (c *Camera) Reset() {
+	width, height := config.WorldSize()
+	c.Position = utils.Vector2D{X: width / 2, Y: height / 2}
+	c.Zoom = min(1, fitZoom())
+}
+
+// fitZoom es el zoom con el que el mundo entero entra en la pantalla
+func fitZoom() float64 {
+	sw, sh := config.ScreenSize()
+	width, height := config.WorldSize()
+	return min(float64(sw)/width, float64(sh)/height)
+}
+
+// zoomRange retorna los límites del zoom: los de la configuración, bajando
+// el mínimo lo necesario para ver entero un mapa grande
+func zoomRange() (lo, hi float64) {
+	cfg := config.Get().Camera
+	return min(cfg.ZoomMin, fitZoom()), cfg.ZoomMax
+}
+
+// ZoomAt multiplica el zoom por factor manteniendo quieto el punto del
+// mundo que está en (sx, sy) de la pantalla
+func (c *Camera) ZoomAt(factor, sx, sy float64) {
+	before := c.ScreenToWorld(sx, sy)
+	lo, hi := zoomRange()
+	c.Zoom = utils.Clamp(c.Zoom*factor, lo, hi)
+	after := c.ScreenToWorld(sx, sy)
+	c.Position = c.Position.Add(before.Sub(after))
+	c.clamp()
+}
+
+// Viewport retorna el rectángulo visible en coordenadas del mundo
+func (c *Camera) Viewport() (x, y, width, height float64) {
+	sw, sh := config.ScreenSize()
+	width = float64(sw) / c.Zoom
+	height = float64(sh) / c.Zoom
+	x = c.Position.X - width/2
+	y = c.Position.Y - height/2
+	return x, y, width, height
+}
+
+// ScreenToWorld convierte coordenadas de pantalla a coordenadas del mundo
+func (c *Camera) ScreenToWorld(sx, sy float64) utils.Vector2D {
+	vx, vy, _, _ := c.Viewport()
+	return utils.Vector2D{X: vx + sx/c.Zoom, Y: vy + sy/c.Zoom}
+}
+
+// WorldToScreen convierte coordenadas del mundo a coordenadas de pantalla
+func (c *Camera) WorldToScreen(p utils.Vector2D) utils.Vector2D {
+	vx, vy, _, _ := c.Viewport()
+	return utils.Vector2D{X: (p.X - vx) * c.Zoom, Y: (p.Y - vy) * c.Zoom}
+}
+
+// GeoM retorna la transformación mundo → pantalla para DrawImage
+func (c *Camera) GeoM() ebiten.GeoM {
+	vx, vy, _, _ := c.Viewport()
+	var m ebiten.GeoM
+	m.Translate(-vx, -vy)
+	m.Scale(c.Zoom, c.Zoom)
+	return m
+}
+
+// clamp mantiene zoom y posición dentro de los límites del mundo, que
+// cambian con el tamaño de la ventana. Si la vista es más grande que el
+// mundo en un eje, el mundo queda centrado en ese eje.
+func (c *Camera) clamp() {
+	lo, hi := zoomRange()
+	c.Zoom = utils.Clamp(c.Zoom, lo, hi)
+
+	worldWidth, worldHeight := config.WorldSize()
+	_, _, width, height := c.Viewport()
+	c.Position.X = clampAxis(c.Position.X, width, worldWidth)
+	c.Position.Y = clampAxis(c.Position.Y, height, worldHeight)
+}
+
+// clampAxis acota el centro de una vista de tamaño view a un mundo de
+// tamaño world
+func clampAxis(center, view, world float64) float64 {
+	if view >= world {
+		return world / 2
+	}
+	return utils.Clamp(center, view/2, world-view/2)
+}
@@ -0,0 +1,61 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/logging"
+)
+
+// captureImage copia los píxeles de una imagen de Ebiten a memoria
+// Debe llamarse desde el hilo del juego (Update o Draw)
+func captureImage(src *ebiten.Image) *image.RGBA {
+	bounds := src.Bounds()
+	img := image.NewRGBA(bounds)
+	src.ReadPixels(img.Pix)
+	return img
+}
+
+// capturePath genera una ruta con marca de tiempo dentro del directorio de capturas
+func capturePath(prefix string) string {
+	name := fmt.Sprintf("%s-%s.png", prefix, time.Now().Format("20060102-150405"))
+	return filepath.Join(config.Get().Capture.Dir, name)
+}
+
+// savePNGAsync codifica y escribe la imagen en una goroutine para no frenar el frame
+// done (opcional) recibe la ruta final o el error
+func savePNGAsync(img image.Image, path string, done func(path string, err error)) {
+	go func() {
+		err := writePNG(img, path)
+		if err != nil {
+			logging.For("capture").Error("no se pudo guardar la imagen", "path", path, "err", err)
+		} else {
+			logging.For("capture").Info("imagen guardada", "path", path)
+		}
+
+		if done != nil {
+			done(path, err)
+		}
+	}()
+}
+
+// writePNG escribe la imagen creando el directorio si no existe
+func writePNG(img image.Image, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return png.Encode(file, img)
+}
@@ -0,0 +1,101 @@
+package render
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// clusterMargin es cuánto se agranda la envolvente para que el halo
+	// rodee a las luciérnagas del borde en lugar de pasar por encima
+	clusterMargin = 14.0
+	// clusterGlowPasses son los trazos del halo, del más ancho y tenue al
+	// más fino
+	clusterGlowPasses = 3
+)
+
+// ClusterOverlay rodea cada cúmulo detectado por el manager con un halo
+// suave sobre su envolvente convexa (tecla C). El color sale del brillo
+// promedio del cúmulo.
+type ClusterOverlay struct {
+	visible bool
+	path    vector.Path
+}
+
+func NewClusterOverlay() *ClusterOverlay {
+	return &ClusterOverlay{}
+}
+
+// Toggle muestra u oculta el overlay y retorna si quedó visible
+func (o *ClusterOverlay) Toggle() bool {
+	o.visible = !o.visible
+	return o.visible
+}
+
+// Draw dibuja los halos debajo de las luciérnagas
+func (o *ClusterOverlay) Draw(world *ebiten.Image, set *manager.ClusterSet) {
+	if !o.visible || set == nil {
+		return
+	}
+
+	for _, c := range set.Clusters {
+		if len(c.Hull) < 3 {
+			// Todas en línea: un círculo alrededor del centro
+			r := float32(clusterMargin)
+			for _, p := range c.Hull {
+				r = max(r, float32(utils.Distance(p, c.Center))+clusterMargin)
+			}
+			vector.FillCircle(world, float32(c.Center.X), float32(c.Center.Y), r, clusterColor(c.Brightness, 0.15), true)
+			continue
+		}
+
+		o.path.Reset()
+		for i, p := range c.Hull {
+			// Cada vértice se aleja del centro clusterMargin píxeles
+			d := utils.Distance(p, c.Center)
+			if d > 0 {
+				p = utils.Vector2D{
+					X: p.X + (p.X-c.Center.X)/d*clusterMargin,
+					Y: p.Y + (p.Y-c.Center.Y)/d*clusterMargin,
+				}
+			}
+			if i == 0 {
+				o.path.MoveTo(float32(p.X), float32(p.Y))
+			} else {
+				o.path.LineTo(float32(p.X), float32(p.Y))
+			}
+		}
+		o.path.Close()
+
+		fill := &vector.DrawPathOptions{AntiAlias: true}
+		fill.ColorScale.ScaleWithColor(clusterColor(c.Brightness, 0.12))
+		vector.FillPath(world, &o.path, nil, fill)
+
+		for pass := 0; pass < clusterGlowPasses; pass++ {
+			stroke := &vector.StrokeOptions{
+				Width:    float32(2 + 6*(clusterGlowPasses-1-pass)),
+				LineJoin: vector.LineJoinRound,
+			}
+			draw := &vector.DrawPathOptions{AntiAlias: true}
+			draw.ColorScale.ScaleWithColor(clusterColor(c.Brightness, 0.1+0.15*float64(pass)))
+			vector.StrokePath(world, &o.path, stroke, draw)
+		}
+	}
+}
+
+// clusterColor va de amarillo verdoso apagado a dorado según el brillo
+// promedio, con alpha premultiplicado
+func clusterColor(brightness, alpha float64) color.RGBA {
+	brightness = utils.Clamp(brightness, 0, 1)
+	a := alpha * 255
+	return color.RGBA{
+		R: uint8((0.6 + 0.4*brightness) * a),
+		G: uint8((0.8 + 0.1*brightness) * a),
+		B: uint8((0.3 - 0.2*brightness) * a),
+		A: uint8(a),
+	}
+}
@@ -0,0 +1,122 @@
+package render
+
+import (
+	"errors"
+	"image/color"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/input"
+)
+
+// consoleMaxLine limita lo que se puede tipear en la consola
+const consoleMaxLine = 120
+
+// consoleCommand ejecuta una orden de la consola con el resto de la línea
+// como argumentos y retorna la respuesta
+type consoleCommand func(args string) (string, error)
+
+// Console es la consola de depuración (tecla `): una línea de texto con
+// órdenes como "label 12,44". Abierta se queda con todo el teclado, así lo
+// que se tipea no dispara las teclas del juego. Solo la usa el hilo de
+// Ebiten.
+type Console struct {
+	open     bool
+	line     []rune
+	chars    []rune
+	reply    string
+	failed   bool
+	commands map[string]consoleCommand
+}
+
+// NewConsole crea la consola cerrada con sus órdenes
+func NewConsole(commands map[string]consoleCommand) *Console {
+	return &Console{commands: commands}
+}
+
+// Toggle abre o cierra la consola
+func (c *Console) Toggle() {
+	c.open = !c.open
+	c.line = c.line[:0]
+}
+
+// IsOpen indica si la consola está abierta
+func (c *Console) IsOpen() bool {
+	return c.open
+}
+
+// Update toma lo tipeado en el frame: Enter ejecuta la línea, Retroceso
+// borra y Esc (o la tecla de la consola) la cierra
+func (c *Console) Update(h *input.Handler) {
+	if h.IsKeyJustPressed(ebiten.KeyEscape) || h.IsActionJustPressed(input.ActionConsole) {
+		c.Toggle()
+		return
+	}
+
+	c.chars = h.AppendInputChars(c.chars[:0])
+	for _, r := range c.chars {
+		if len(c.line) < consoleMaxLine && r != '`' {
+			c.line = append(c.line, r)
+		}
+	}
+	if h.IsKeyJustPressed(ebiten.KeyBackspace) && len(c.line) > 0 {
+		c.line = c.line[:len(c.line)-1]
+	}
+	if h.IsKeyJustPressed(ebiten.KeyEnter) || h.IsKeyJustPressed(ebiten.KeyNumpadEnter) {
+		c.Run(string(c.line))
+		c.line = c.line[:0]
+	}
+}
+
+// Run ejecuta una línea y guarda la respuesta para mostrarla
+func (c *Console) Run(line string) {
+	name, args, _ := strings.Cut(strings.TrimSpace(line), " ")
+	if name == "" {
+		return
+	}
+
+	reply, err := c.run(strings.ToLower(name), strings.TrimSpace(args))
+	c.reply, c.failed = reply, err != nil
+	if err != nil {
+		c.reply = err.Error()
+	}
+}
+
+func (c *Console) run(name, args string) (string, error) {
+	if name == "help" {
+		names := make([]string, 0, len(c.commands))
+		for n := range c.commands {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+		return i18n.T("Órdenes: %s", strings.Join(names, ", ")), nil
+	}
+
+	cmd, ok := c.commands[name]
+	if !ok {
+		return "", errors.New(i18n.T("orden desconocida %q (help las lista)", name))
+	}
+	return cmd(args)
+}
+
+// Draw dibuja la línea de la consola y la última respuesta abajo de todo
+func (c *Console) Draw(screen *ebiten.Image, ui *UIRenderer) {
+	if !c.open {
+		return
+	}
+	sw, sh := config.ScreenSize()
+	height := float32(52)
+	y := float32(sh) - height
+	vector.DrawFilledRect(screen, 0, y, float32(sw), height, color.RGBA{R: 0, G: 0, B: 0, A: 210}, false)
+
+	replyColor := color.RGBA{R: 170, G: 220, B: 170, A: 255}
+	if c.failed {
+		replyColor = color.RGBA{R: 255, G: 140, B: 110, A: 255}
+	}
+	ui.drawText(screen, c.reply, 10, float64(y)+4, replyColor)
+	ui.drawText(screen, "> "+string(c.line)+"_", 10, float64(y)+26, color.RGBA{R: 230, G: 230, B: 230, A: 255})
+}
@@ -0,0 +1,31 @@
+package render
+
+import (
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+// CullStats resume cuántas luciérnagas se dibujaron, descartaron o simplificaron en el frame
+type CullStats struct {
+	Visible int
+	Culled  int
+	NoHalo  int
+}
+
+// fireflyLOD decide si una luciérnaga se dibuja y si lleva halos
+// Se descartan las que quedan fuera de la vista de la cámara y se omiten
+// los halos de las muy tenues o demasiado pequeñas en pantalla
+func fireflyLOD(state core.FireflyState, camera *Camera) (visible, withHalo bool) {
+	vx, vy, vw, vh := camera.Viewport()
+	margin := config.Get().Render.CullMargin
+
+	p := state.Position
+	if p.X < vx-margin || p.X > vx+vw+margin || p.Y < vy-margin || p.Y > vy+vh+margin {
+		return false, false
+	}
+
+	haloPixels := config.Get().Fireflies.Size * 2.8 * state.Brightness * camera.Zoom
+	withHalo = state.Brightness >= config.Get().Render.LODBrightnessThreshold && haloPixels >= config.Get().Render.LODMinHaloPixels
+
+	return true, withHalo
+}
@@ -0,0 +1,156 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"math/rand"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/internal/scores"
+)
+
+// ModeDaily es el desafío del día: la misma semilla, el mismo viento y las
+// mismas oleadas para todos los que jueguen esa fecha
+const ModeDaily GameMode = "diario"
+
+const (
+	// dailyDuration es cuánto dura el desafío
+	dailyDuration = 3 * time.Minute
+	// dailyWindEvery es cada cuánto cambia el viento según el calendario
+	dailyWindEvery = 20 * time.Second
+	// dailyFirstWave y dailyWaveEvery ubican las oleadas de murciélagos
+	dailyFirstWave = 40 * time.Second
+	dailyWaveEvery = 45 * time.Second
+)
+
+var dailyDirections = []core.WindDirection{
+	core.WindNorth, core.WindSouth, core.WindEast, core.WindWest,
+	core.WindNorthEast, core.WindNorthWest, core.WindSouthEast, core.WindSouthWest,
+}
+
+type dailyWind struct {
+	at  time.Duration
+	dir core.WindDirection
+}
+
+type dailyWave struct {
+	at   time.Duration
+	bats int
+}
+
+// DailyChallenge es el escenario de un día. Se arma con su propio generador
+// sembrado con la fecha, no con el compartido, así no depende del orden en
+// que las goroutines lo consumen.
+type DailyChallenge struct {
+	Date      string
+	Seed      int64
+	Objective int
+
+	start core.WindDirection
+	winds []dailyWind
+	waves []dailyWave
+}
+
+// DailySeed retorna la semilla del día: la fecha como AAAAMMDD
+func DailySeed(day time.Time) int64 {
+	y, m, d := day.Date()
+	return int64(y*10000 + int(m)*100 + d)
+}
+
+// NewDailyChallenge arma el desafío de la fecha codificada en seed (ver
+// DailySeed); la misma semilla siempre da el mismo desafío
+func NewDailyChallenge(seed int64) *DailyChallenge {
+	rng := rand.New(rand.NewSource(seed))
+	c := &DailyChallenge{
+		Date:      fmt.Sprintf("%04d-%02d-%02d", seed/10000, seed/100%100, seed%100),
+		Seed:      seed,
+		Objective: min(20+rng.Intn(21), config.Get().Fireflies.Max),
+		start:     dailyDirections[rng.Intn(len(dailyDirections))],
+	}
+
+	for at := dailyWindEvery; at < dailyDuration; at += dailyWindEvery {
+		c.winds = append(c.winds, dailyWind{at: at, dir: dailyDirections[rng.Intn(len(dailyDirections))]})
+	}
+	for at := dailyFirstWave; at < dailyDuration; at += dailyWaveEvery {
+		c.waves = append(c.waves, dailyWave{at: at, bats: 2 + rng.Intn(4)})
+	}
+	return c
+}
+
+// dailyBest lee del historial el mejor puntaje del desafío de la fecha; si
+// no se puede leer se juega igual, contra 0
+func dailyBest(date string) int {
+	entries, err := scores.Load()
+	if err != nil {
+		logging.For("scores").Warn("no se pudo leer el historial", "err", err)
+	}
+	return scores.Best(entries, date)
+}
+
+// dailyStep es lo que toca hacer en un frame del desafío
+type dailyStep struct {
+	wind    core.WindDirection
+	setWind bool
+	bats    int
+	over    bool
+}
+
+// Daily es el avance de una partida del desafío
+type Daily struct {
+	challenge *DailyChallenge
+	elapsed   time.Duration
+	nextWind  int
+	nextWave  int
+	best      int
+}
+
+// NewDaily empieza el desafío; best es el mejor puntaje de esa fecha hasta ahora
+func NewDaily(challenge *DailyChallenge, best int) *Daily {
+	return &Daily{challenge: challenge, best: best}
+}
+
+// Tick avanza el reloj (sin contar la pausa) y retorna el cambio de viento,
+// la oleada o el final que correspondan
+func (d *Daily) Tick(dt float64) dailyStep {
+	d.elapsed += time.Duration(dt * float64(time.Second))
+	c := d.challenge
+
+	var step dailyStep
+	if d.nextWind < len(c.winds) && d.elapsed >= c.winds[d.nextWind].at {
+		step.wind, step.setWind = c.winds[d.nextWind].dir, true
+		d.nextWind++
+	}
+	if d.nextWave < len(c.waves) && d.elapsed >= c.waves[d.nextWave].at {
+		step.bats = c.waves[d.nextWave].bats
+		d.nextWave++
+	}
+	step.over = d.elapsed >= dailyDuration
+	return step
+}
+
+// DrawPanel dibuja la fecha, el tiempo que queda, la próxima oleada y el
+// mejor puntaje del día arriba al centro
+func (d *Daily) DrawPanel(screen *ebiten.Image, ui *UIRenderer, points int) {
+	sw, _ := config.ScreenSize()
+	label := fmt.Sprintf("📅 %s   ⏱ %s", d.challenge.Date, formatClock(max(dailyDuration-d.elapsed, 0)))
+	if d.nextWave < len(d.challenge.waves) {
+		label += fmt.Sprintf("   🦇 en %s", formatClock(d.challenge.waves[d.nextWave].at-d.elapsed))
+	}
+	label += fmt.Sprintf("   Mejor: %d", max(d.best, points))
+
+	width := float32(500)
+	x := (float32(sw) - width) / 2
+	vector.DrawFilledRect(screen, x, 44, width, 28, color.RGBA{R: 20, G: 35, B: 40, A: 200}, false)
+	vector.StrokeRect(screen, x, 44, width, 28, 1, color.RGBA{R: 130, G: 210, B: 200, A: 255}, false)
+
+	textColor := color.RGBA{R: 230, G: 250, B: 245, A: 255}
+	if points > d.best {
+		textColor = color.RGBA{R: 255, G: 230, B: 130, A: 255}
+	}
+	ui.drawText(screen, label, float64(x)+12, 49, textColor)
+}
@@ -0,0 +1,189 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/manager"
+)
+
+const (
+	// memStatsInterval limita ReadMemStats, que detiene brevemente el mundo
+	memStatsInterval = 500 * time.Millisecond
+	// dropAlertShare es desde qué fracción de los estados esperados por
+	// segundo los descartes dejan de estar "cerca de cero"
+	dropAlertShare = 0.001
+)
+
+// DebugOverlay muestra información interna para desarrollo (tecla F3):
+// culling del frame y la maquinaria concurrente del manager
+type DebugOverlay struct {
+	visible   bool
+	memStats  runtime.MemStats
+	lastMemAt time.Time
+}
+
+// NewDebugOverlay crea el overlay de depuración oculto
+func NewDebugOverlay() *DebugOverlay {
+	return &DebugOverlay{}
+}
+
+// Toggle muestra u oculta el overlay
+func (d *DebugOverlay) Toggle() {
+	d.visible = !d.visible
+}
+
+// IsVisible indica si el overlay está activo
+func (d *DebugOverlay) IsVisible() bool {
+	return d.visible
+}
+
+// Draw dibuja el panel de depuración con las estadísticas del frame
+func (d *DebugOverlay) Draw(screen *ebiten.Image, ui *UIRenderer, cull CullStats, camera *Camera, in manager.Internals) {
+	if !d.visible {
+		return
+	}
+
+	if time.Since(d.lastMemAt) >= memStatsInterval {
+		runtime.ReadMemStats(&d.memStats)
+		d.lastMemAt = time.Now()
+	}
+
+	lines := d.lines(cull, camera, in)
+
+	lineHeight := 20.0
+	width := 340.0
+	height := lineHeight * float64(len(lines)+1)
+	// A la izquierda del panel de controles, entre éste y el HUD
+	sw, _ := config.ScreenSize()
+	x := float64(sw) - 330 - width
+	y := 10.0
+
+	panelColor := color.RGBA{R: 0, G: 0, B: 0, A: 200}
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), float32(height), panelColor, false)
+
+	titleColor := color.RGBA{R: 255, G: 150, B: 150, A: 255}
+	sectionColor := color.RGBA{R: 150, G: 200, B: 255, A: 255}
+	textColor := color.RGBA{R: 200, G: 255, B: 200, A: 255}
+	alertColor := color.RGBA{R: 255, G: 110, B: 110, A: 255}
+
+	ui.drawText(screen, "🛠 DEBUG (F3)", x+10, y+4, titleColor)
+	y += lineHeight
+
+	for _, line := range lines {
+		clr := textColor
+		switch {
+		case line.section:
+			clr = sectionColor
+		case line.alert:
+			clr = alertColor
+		}
+		ui.drawText(screen, line.text, x+10, y, clr)
+		y += lineHeight
+	}
+}
+
+// debugLine es una línea del panel: un título de sección, una alerta (en
+// rojo) o un dato
+type debugLine struct {
+	text    string
+	section bool
+	alert   bool
+}
+
+func (d *DebugOverlay) lines(cull CullStats, camera *Camera, in manager.Internals) []debugLine {
+	var lines []debugLine
+	section := func(s string) { lines = append(lines, debugLine{text: s, section: true}) }
+	line := func(format string, args ...interface{}) {
+		lines = append(lines, debugLine{text: fmt.Sprintf(format, args...)})
+	}
+	alert := func(format string, args ...interface{}) {
+		lines = append(lines, debugLine{text: fmt.Sprintf(format, args...), alert: true})
+	}
+
+	section("Render")
+	line("Dibujadas: %d  Descartadas: %d  Sin halo: %d", cull.Visible, cull.Culled, cull.NoHalo)
+	line("Zoom: %.2fx", camera.Zoom)
+
+	section(fmt.Sprintf("Goroutines (%d en total)", in.TotalGoroutines))
+	tracked := 0
+	for _, g := range in.Goroutines {
+		line("  %-14s %d", g.Subsystem, g.Count)
+		tracked += g.Count
+	}
+	line("  %-14s %d", "runtime/ebiten", in.TotalGoroutines-tracked)
+
+	section("Canales (en cola / capacidad)")
+	line("Estados: %d / %d   Comandos: %d / %d", in.StateQueue, in.StateCap, in.CommandQueue, in.CommandCap)
+	line("Trabajos: %d / %d   Resultados: %d / %d", in.JobQueue, in.JobCap, in.ResultQueue, in.ResultCap)
+	bp := in.Backpressure
+	line("Máx. estados: %d   comandos: %d", bp.StatePeak, bp.CommandPeak)
+	line("Máx. trabajos: %d   resultados: %d", bp.JobPeak, bp.ResultPeak)
+
+	section("Simulación")
+	if b := in.Batch; b.Enabled {
+		line("Por lotes: %d luciérnagas en %d shards", b.Fireflies, b.Shards)
+		line("Buzón: %d pendientes  Lotes: %d  Coalescidos: %d", b.Pending, b.Batches, b.Coalesced)
+	} else {
+		line("Una goroutine por luciérnaga")
+	}
+
+	section("Agregador")
+	line("Mapa actual: %d  anterior: %d", in.AggregatorStates, in.AggregatorPrevious)
+	line("Procesados: %d  Descartados: %d", in.AggregatorProcessed, in.DroppedStates)
+	line("Eventos descartados: %d", in.DroppedEvents)
+
+	section("Descartes de estados")
+	line("Último segundo: %d/s con %d vivas", bp.DropRate, bp.Population)
+	line("Peor segundo: %d/s con %d vivas", bp.PeakRate, bp.PeakPopulation)
+	// Cada viva publica simulation_tps estados por segundo; los descartes
+	// se comparan con eso
+	share := 0.0
+	if expected := bp.Population * config.Get().SimulationTPS; expected > 0 {
+		share = float64(bp.DropRate) / float64(expected)
+	}
+	if share <= dropAlertShare {
+		line("Cerca de cero: %.2f%% de los estados", share*100)
+	} else {
+		alert("Descartando el %.2f%% de los estados", share*100)
+	}
+	if len(bp.TopDroppers) > 0 {
+		top := make([]string, len(bp.TopDroppers))
+		for i, d := range bp.TopDroppers {
+			top[i] = fmt.Sprintf("#%d: %d", d.ID, d.Dropped)
+		}
+		line("Más descartadas: %s", strings.Join(top, "  "))
+	}
+
+	section("Admisión")
+	line("Vivas: %d / %d", in.Spawns.Live, in.Spawns.Cap)
+	line("Spawns admitidos: %d  rechazados: %d", in.Spawns.Admitted, in.Spawns.Rejected)
+
+	if in.Chaos.Enabled {
+		section("Caos")
+		line("Estados demorados: %d  descartados: %d", in.Chaos.Delayed, in.Chaos.Dropped)
+		line("Goroutines tiradas: %d  limpiadas: %d", in.Chaos.Crashed, in.Chaos.Reaped)
+		line("Workers trabados: %d", in.Chaos.Stalled)
+	}
+
+	section("Memoria")
+	line("Heap en uso: %.1f MB  Objetos: %d", float64(d.memStats.HeapInuse)/(1<<20), d.memStats.HeapObjects)
+	line("GC: %d ciclos  Última pausa: %v", d.memStats.NumGC, lastGCPause(&d.memStats))
+	line("Próximo GC: %.1f MB  CPU en GC: %.2f%%", float64(d.memStats.NextGC)/(1<<20), d.memStats.GCCPUFraction*100)
+
+	return lines
+}
+
+// lastGCPause retorna la pausa del ciclo de GC más reciente
+func lastGCPause(m *runtime.MemStats) time.Duration {
+	if m.NumGC == 0 {
+		return 0
+	}
+	return time.Duration(m.PauseNs[(m.NumGC+255)%256])
+}
@@ -0,0 +1,170 @@
+package render
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// demoAction es lo que hace el cursor virtual al llegar a su destino
+type demoAction int
+
+const (
+	demoAttract demoAction = iota
+	demoLantern
+	demoWind
+	demoBurst
+)
+
+// demoScript es el guion que repite la demostración
+var demoScript = []demoAction{
+	demoAttract, demoLantern, demoAttract, demoWind,
+	demoBurst, demoAttract, demoLantern, demoWind,
+}
+
+const (
+	// demoLanterns es cuántos faroles deja la demostración; con más,
+	// mueve el último
+	demoLanterns = 4
+	// demoCursorSpeed es qué fracción del camino recorre el cursor por
+	// segundo (se frena al llegar)
+	demoCursorSpeed = 1.5
+	// demoCameraSpeed es lo mismo para la cámara, más lenta para que
+	// los movimientos se vean de cine
+	demoCameraSpeed = 0.25
+	// demoZoomMax es el acercamiento máximo de las tomas
+	demoZoomMax = 1.8
+	// demoShotSteps es cada cuántas acciones cambia la toma
+	demoShotSteps = 3
+)
+
+// Demo es el modo demostración: un cursor virtual recorre el jardín
+// siguiendo un guion (atraer, colocar faroles, cambiar el viento, ráfagas)
+// mientras la cámara pasea entre tomas. Empieza con -demo o tras un rato sin
+// input; cualquier input real le devuelve el control al jugador.
+type Demo struct {
+	cursor, target utils.Vector2D
+	step           int
+	wait           float64
+	placed         int
+
+	// La toma actual: hacia dónde y con qué zoom va la cámara
+	shot     utils.Vector2D
+	shotZoom float64
+	clock    float64
+}
+
+// NewDemo crea la demostración con el cursor en el centro de la vista
+func NewDemo(camera *Camera) *Demo {
+	d := &Demo{cursor: camera.Position, shot: camera.Position, shotZoom: 1}
+	d.target = d.randomTarget()
+	return d
+}
+
+// randomTarget elige un punto del jardín lejos de los bordes
+func (d *Demo) randomTarget() utils.Vector2D {
+	width, height := config.WorldSize()
+	return utils.RandomVector2D(width*0.15, width*0.85, height*0.15, height*0.85)
+}
+
+// Update mueve el cursor y la cámara y, al llegar a destino y pasado el
+// paso de la configuración, ejecuta la próxima acción del guion
+func (d *Demo) Update(g *Game, dt float64) {
+	d.clock += dt
+	d.cursor = utils.LerpVector(d.cursor, d.target, min(dt*demoCursorSpeed, 1))
+
+	d.wait -= dt
+	if d.wait <= 0 && utils.Distance(d.cursor, d.target) < 8 {
+		d.act(g, demoScript[d.step%len(demoScript)])
+		d.step++
+		d.wait = config.Get().Demo.Step.Seconds()
+		if d.step%demoShotSteps == 0 {
+			d.shot = d.target
+			d.shotZoom = utils.RandomFloat(1, demoZoomMax)
+		}
+		d.target = d.randomTarget()
+	}
+
+	// La cámara sigue a la toma con un leve vaivén
+	c := g.camera
+	sway := utils.Vector2D{X: math.Sin(d.clock*0.3) * 30, Y: math.Cos(d.clock*0.2) * 20}
+	c.Position = utils.LerpVector(c.Position, d.shot.Add(sway), min(dt*demoCameraSpeed, 1))
+	c.Zoom = utils.Lerp(c.Zoom, d.shotZoom, min(dt*demoCameraSpeed, 1))
+	c.clamp()
+}
+
+// act ejecuta una acción en la posición del cursor
+func (d *Demo) act(g *Game, action demoAction) {
+	pos := d.cursor
+	switch action {
+	case demoAttract:
+		g.setAttractionPoint(pos.X, pos.Y)
+	case demoLantern:
+		// Los faroles de la demostración no cuentan como del jugador; al
+		// llegar al límite se quita el último que puso
+		if d.placed >= demoLanterns {
+			g.manager.RemoveLantern()
+			d.placed--
+		}
+		if g.manager.AddLantern(pos.X, pos.Y) {
+			d.placed++
+		}
+	case demoWind:
+		g.changeWind()
+	case demoBurst:
+		go g.manager.SpawnBurst(pos.X, pos.Y, config.Get().Spawn.BurstCount)
+	}
+}
+
+// Draw dibuja el cursor virtual y el cartel de arriba
+func (d *Demo) Draw(screen *ebiten.Image, ui *UIRenderer, camera *Camera) {
+	p := camera.WorldToScreen(d.cursor)
+	x, y := float32(p.X), float32(p.Y)
+	pulse := float32(1 + 0.15*math.Sin(d.clock*4))
+	vector.StrokeCircle(screen, x, y, 14*pulse, 2, color.RGBA{R: 255, G: 240, B: 180, A: 220}, true)
+	vector.DrawFilledCircle(screen, x, y, 3, color.RGBA{R: 255, G: 240, B: 180, A: 255}, true)
+
+	sw, _ := config.ScreenSize()
+	vector.DrawFilledRect(screen, 0, 0, float32(sw), 24, color.RGBA{R: 20, G: 30, B: 60, A: 200}, false)
+	ui.drawTextCentered(screen, i18n.T("● DEMOSTRACIÓN  (cualquier tecla para jugar)"), 4, color.RGBA{R: 200, G: 220, B: 255, A: 255})
+}
+
+// canDemo indica si la partida admite la demostración: solo el jardín libre
+// corriendo, sin repetición ni foto en curso
+func (g *Game) canDemo() bool {
+	return g.mode == ModeGarden && g.gameState == config.GameStateRunning &&
+		g.player == nil && !g.photoMode.IsActive()
+}
+
+// updateDemo arranca la demostración tras el tiempo sin input de la
+// configuración y la termina con cualquier input real; retorna true si la
+// demostración sigue, y entonces el input del jugador no se procesa
+func (g *Game) updateDemo(dt float64) bool {
+	if g.demo != nil && (g.inputHandler.Active() || !g.canDemo()) {
+		g.stopDemo()
+		return false
+	}
+	idle := config.Get().Demo.IdleAfter.Duration
+	if g.demo == nil && idle > 0 && g.inputHandler.IdleFor() >= idle && g.canDemo() {
+		g.demo = NewDemo(g.camera)
+	}
+	if g.demo == nil {
+		return false
+	}
+	g.demo.Update(g, dt)
+	return true
+}
+
+// stopDemo le devuelve el control al jugador
+func (g *Game) stopDemo() {
+	g.demo = nil
+	if g.showAttraction {
+		g.clearAttractionPoint()
+	}
+	g.toasts.Push("Control devuelto al jugador")
+}
@@ -0,0 +1,454 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/input"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// editorDefaultPath es dónde se guarda un mapa nuevo (sin -map)
+	editorDefaultPath = "maps/mi-mapa.json"
+	// editorMinSize es el lado (o radio) mínimo de un área arrastrada; más
+	// chica se toma como un click sin querer
+	editorMinSize = 8.0
+	// editorPickRadius es la distancia a la que el click derecho alcanza un
+	// farol
+	editorPickRadius = 16.0
+	// editorUndo es cuántos pasos guarda Ctrl+Z
+	editorUndo = 50
+	// editorRateStep es cuánto cambian [ y ] el ritmo de una zona de
+	// aparición, en luciérnagas por segundo
+	editorRateStep = 0.5
+)
+
+// editorTool es lo que pone el click izquierdo en el editor
+type editorTool int
+
+const (
+	toolObstacle editorTool = iota
+	toolPond
+	toolLantern
+	toolSpawnZone
+	toolWindZone
+)
+
+// editorTools son los nombres de las herramientas, en el orden de sus
+// teclas (1 a 5)
+var editorTools = []string{"Obstáculo", "Estanque", "Farol", "Zona de aparición", "Zona de viento"}
+
+// editorWindDirections es el orden en que W rota la dirección de las
+// zonas de viento, en sentido horario
+var editorWindDirections = []core.WindDirection{
+	core.WindNorth, core.WindNorthEast, core.WindEast, core.WindSouthEast,
+	core.WindSouth, core.WindSouthWest, core.WindWest, core.WindNorthWest,
+}
+
+var (
+	spawnZoneFill   = color.RGBA{R: 30, G: 80, B: 40, A: 50}
+	spawnZoneStroke = color.RGBA{R: 110, G: 220, B: 120, A: 150}
+	editorDragFill  = color.RGBA{R: 255, G: 255, B: 255, A: 25}
+	editorDragLine  = color.RGBA{R: 255, G: 255, B: 255, A: 180}
+	editorSelected  = color.RGBA{R: 255, G: 255, B: 100, A: 255}
+	editorHelp      = color.RGBA{R: 200, G: 200, B: 220, A: 255}
+)
+
+// EditorScene es el editor de mapas: obstáculos, estanques, zonas de
+// aparición y de viento se arrastran con el mouse (Shift para un círculo),
+// los faroles se ponen con un click y el click derecho borra lo que está
+// bajo el cursor. Las zonas de aparición nuevas sueltan hacia el centro;
+// su ritmo propio se ajusta con [ y ]. Ctrl+S guarda en el formato de -map y Enter prueba el
+// mapa en un jardín libre; al terminar la prueba se vuelve al editor.
+type EditorScene struct {
+	app       *App
+	gardenMap *manager.GardenMap
+	path      string
+	history   []*manager.GardenMap
+
+	camera   *Camera
+	renderer *Renderer
+	world    *ebiten.Image
+	toasts   *Toasts
+
+	tool     editorTool
+	wind     int
+	dragging bool
+	dragFrom utils.Vector2D
+	last     time.Time
+}
+
+// NewEditorScene abre el editor sobre una copia de m; sin mapa empieza uno
+// vacío del tamaño de la ventana. path es dónde guarda Ctrl+S.
+func NewEditorScene(app *App, m *manager.GardenMap, path string) *EditorScene {
+	if m == nil {
+		m = &manager.GardenMap{Name: "Mapa nuevo"}
+	}
+	m = m.Clone()
+	// El editor trabaja sobre un mundo fijo: un mapa que sigue a la ventana
+	// toma su tamaño actual
+	if m.Width == 0 || m.Height == 0 {
+		m.Width, m.Height = config.ScreenSize()
+	}
+	if path == "" {
+		path = editorDefaultPath
+	}
+
+	e := &EditorScene{
+		app:       app,
+		gardenMap: m,
+		path:      path,
+		renderer:  NewRenderer(),
+		toasts:    NewToasts(),
+		wind:      2,
+		last:      time.Now(),
+	}
+	e.Resume()
+	return e
+}
+
+// Resume vuelve a fijar el mundo del mapa editado, que la prueba o el menú
+// pudieron cambiar, y encuadra el mapa entero
+func (e *EditorScene) Resume() {
+	config.SetWorldSize(e.gardenMap.Width, e.gardenMap.Height)
+	if e.camera == nil {
+		e.camera = NewCamera()
+	}
+	e.camera.Reset()
+	e.dragging = false
+	e.last = time.Now()
+}
+
+// Update procesa las herramientas, la cámara y los atajos del editor
+func (e *EditorScene) Update() error {
+	h := e.app.inputHandler
+	now := time.Now()
+	dt := min(now.Sub(e.last).Seconds(), 0.1)
+	e.last = now
+
+	if h.IsKeyJustPressed(ebiten.KeyEscape) {
+		if e.dragging {
+			e.dragging = false
+			return nil
+		}
+		config.SetWorldSize(0, 0)
+		e.app.ShowMenu()
+		return nil
+	}
+
+	switch {
+	case h.IsShortcutJustPressed(ebiten.KeyS):
+		e.save()
+		return nil
+	case h.IsShortcutJustPressed(ebiten.KeyZ):
+		e.undo()
+		return nil
+	case h.IsKeyJustPressed(ebiten.KeyEnter):
+		e.playTest()
+		return nil
+	}
+
+	for i := range editorTools {
+		if h.IsKeyJustPressed(ebiten.Key1 + ebiten.Key(i)) {
+			e.tool = editorTool(i)
+		}
+	}
+	if h.IsKeyJustPressed(ebiten.KeyTab) {
+		e.tool = (e.tool + 1) % editorTool(len(editorTools))
+	}
+	if h.IsKeyJustPressed(ebiten.KeyW) {
+		e.wind = (e.wind + 1) % len(editorWindDirections)
+	}
+	e.updateSpawnZone(h)
+
+	e.camera.Update(h, dt)
+	p := h.Pointer()
+	if _, wy := h.GetMouseWheel(); wy != 0 {
+		e.camera.ZoomAt(1+wy*0.1, float64(p.X), float64(p.Y))
+	}
+
+	pos := e.cursor()
+	switch {
+	case h.IsMouseButtonJustPressed(ebiten.MouseButtonRight):
+		e.dragging = false
+		e.removeAt(pos)
+	case p.JustPressed && e.tool == toolLantern:
+		e.addLantern(pos)
+	case p.JustPressed:
+		e.dragging = true
+		e.dragFrom = pos
+	case p.JustReleased && e.dragging:
+		e.dragging = false
+		if a, ok := e.dragArea(pos, h.IsKeyPressed(ebiten.KeyShift)); ok {
+			e.addArea(a)
+		}
+	}
+	return nil
+}
+
+// updateSpawnZone ajusta la zona de aparición bajo el cursor: [ y ]
+// cambian su ritmo propio e I si sus luciérnagas salen hacia el centro
+func (e *EditorScene) updateSpawnZone(h *input.Handler) {
+	i := e.spawnZoneAt(e.cursor())
+	if i < 0 {
+		return
+	}
+	z := e.gardenMap.SpawnZones[i]
+	switch {
+	case h.IsKeyJustPressed(ebiten.KeyBracketRight):
+		z.Rate = min(z.Rate+editorRateStep, config.MaxSpawnZoneRate)
+	case h.IsKeyJustPressed(ebiten.KeyBracketLeft):
+		z.Rate = max(z.Rate-editorRateStep, 0)
+	case h.IsKeyJustPressed(ebiten.KeyI):
+		z.Inward = !z.Inward
+	default:
+		return
+	}
+	e.checkpoint()
+	e.gardenMap.SpawnZones[i] = z
+}
+
+// spawnZoneAt retorna la zona de aparición de más arriba que contiene pos,
+// o -1
+func (e *EditorScene) spawnZoneAt(pos utils.Vector2D) int {
+	zones := e.gardenMap.SpawnZones
+	for i := len(zones) - 1; i >= 0; i-- {
+		if zones[i].Contains(pos) {
+			return i
+		}
+	}
+	return -1
+}
+
+// cursor retorna el puntero en el mundo, dentro de sus bordes
+func (e *EditorScene) cursor() utils.Vector2D {
+	p := e.app.inputHandler.Pointer()
+	pos := e.camera.ScreenToWorld(float64(p.X), float64(p.Y))
+	return utils.Vector2D{
+		X: utils.Clamp(pos.X, 0, float64(e.gardenMap.Width)),
+		Y: utils.Clamp(pos.Y, 0, float64(e.gardenMap.Height)),
+	}
+}
+
+// dragArea arma el área arrastrada desde dragFrom hasta to: el rectángulo
+// entre los dos puntos o, con circle, el círculo con centro en el primero
+func (e *EditorScene) dragArea(to utils.Vector2D, circle bool) (core.Area, bool) {
+	from := e.dragFrom
+	if circle {
+		r := utils.Distance(from, to)
+		return core.Area{X: from.X, Y: from.Y, R: r}, r >= editorMinSize
+	}
+	a := core.Area{
+		X: min(from.X, to.X),
+		Y: min(from.Y, to.Y),
+		W: max(from.X, to.X) - min(from.X, to.X),
+		H: max(from.Y, to.Y) - min(from.Y, to.Y),
+	}
+	return a, a.W >= editorMinSize && a.H >= editorMinSize
+}
+
+// checkpoint guarda el mapa antes de un cambio para Ctrl+Z
+func (e *EditorScene) checkpoint() {
+	if len(e.history) == editorUndo {
+		e.history = e.history[1:]
+	}
+	e.history = append(e.history, e.gardenMap.Clone())
+}
+
+func (e *EditorScene) undo() {
+	if len(e.history) == 0 {
+		return
+	}
+	e.gardenMap = e.history[len(e.history)-1]
+	e.history = e.history[:len(e.history)-1]
+}
+
+// addArea agrega el área con la herramienta activa
+func (e *EditorScene) addArea(a core.Area) {
+	m := e.gardenMap
+	e.checkpoint()
+	switch e.tool {
+	case toolObstacle:
+		m.Obstacles = append(m.Obstacles, a)
+	case toolPond:
+		m.Ponds = append(m.Ponds, a)
+	case toolSpawnZone:
+		m.SpawnZones = append(m.SpawnZones, core.SpawnZone{Area: a, Inward: true})
+	case toolWindZone:
+		m.WindZones = append(m.WindZones, core.WindZone{Area: a, Direction: e.windDirection()})
+	}
+}
+
+// addLantern pone un farol con el radio de la configuración
+func (e *EditorScene) addLantern(pos utils.Vector2D) {
+	m := e.gardenMap
+	if len(m.Lanterns) >= config.Get().Lanterns.Max {
+		e.toasts.Warn(i18n.T("Máximo de faroles alcanzado (%d)", config.Get().Lanterns.Max))
+		return
+	}
+	if m.Terrain().Blocked(pos) {
+		e.toasts.Warn(i18n.T("No se puede poner un farol sobre un obstáculo ni un estanque"))
+		return
+	}
+	e.checkpoint()
+	m.Lanterns = append(m.Lanterns, manager.MapLantern{X: pos.X, Y: pos.Y})
+}
+
+// removeAt borra lo que está bajo pos, empezando por lo que se dibuja
+// encima: faroles, zonas de viento, de aparición, obstáculos y estanques
+func (e *EditorScene) removeAt(pos utils.Vector2D) {
+	m := e.gardenMap
+	for i := len(m.Lanterns) - 1; i >= 0; i-- {
+		if utils.Distance(pos, utils.Vector2D{X: m.Lanterns[i].X, Y: m.Lanterns[i].Y}) <= editorPickRadius {
+			e.checkpoint()
+			m.Lanterns = append(m.Lanterns[:i], m.Lanterns[i+1:]...)
+			return
+		}
+	}
+	for i := len(m.WindZones) - 1; i >= 0; i-- {
+		if m.WindZones[i].Contains(pos) {
+			e.checkpoint()
+			m.WindZones = append(m.WindZones[:i], m.WindZones[i+1:]...)
+			return
+		}
+	}
+	if i := e.spawnZoneAt(pos); i >= 0 {
+		e.checkpoint()
+		m.SpawnZones = append(m.SpawnZones[:i], m.SpawnZones[i+1:]...)
+		return
+	}
+	for _, list := range []*[]core.Area{&m.Obstacles, &m.Ponds} {
+		for i := len(*list) - 1; i >= 0; i-- {
+			if (*list)[i].Contains(pos) {
+				e.checkpoint()
+				*list = append((*list)[:i], (*list)[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// windDirection es la dirección de las zonas de viento nuevas, como la
+// escribe el formato de mapas
+func (e *EditorScene) windDirection() string {
+	return strings.ToLower(editorWindDirections[e.wind].String())
+}
+
+// save valida el mapa y lo escribe en path
+func (e *EditorScene) save() {
+	if err := e.gardenMap.Validate(); err != nil {
+		e.toasts.Warn(i18n.T("Mapa inválido: %s", firstLine(err)))
+		return
+	}
+	if err := manager.WriteMap(e.path, e.gardenMap); err != nil {
+		logging.For("editor").Warn("no se pudo guardar el mapa", "path", e.path, "err", err)
+		e.toasts.Warn(i18n.T("No se pudo guardar el mapa: %s", err.Error()))
+		return
+	}
+	logging.For("editor").Info("mapa guardado", "path", e.path)
+	e.toasts.Push(i18n.T("Mapa guardado en %s", e.path))
+}
+
+// playTest valida el mapa y lo abre en un jardín libre
+func (e *EditorScene) playTest() {
+	if err := e.gardenMap.Validate(); err != nil {
+		e.toasts.Warn(i18n.T("Mapa inválido: %s", firstLine(err)))
+		return
+	}
+	e.app.PlayTest(e, e.gardenMap.Clone())
+}
+
+// firstLine es el primer error de un errors.Join, que cabe en un aviso
+func firstLine(err error) string {
+	msg, _, _ := strings.Cut(err.Error(), "\n")
+	return msg
+}
+
+// Draw dibuja el mapa con la cámara del editor y las instrucciones encima
+func (e *EditorScene) Draw(screen *ebiten.Image) {
+	e.renderer.DrawBackground(screen)
+
+	width, height := config.WorldPixels()
+	if e.world == nil || e.world.Bounds().Dx() != width || e.world.Bounds().Dy() != height {
+		e.world = ebiten.NewImage(width, height)
+	}
+	world := e.world
+	world.Clear()
+
+	m := e.gardenMap
+	e.renderer.DrawTerrain(world, m.Terrain(), time.Now())
+	for _, z := range m.SpawnZones {
+		drawArea(world, z.Area, spawnZoneFill, spawnZoneStroke)
+	}
+	radius := config.Get().Lanterns.Radius
+	for _, l := range m.Lanterns {
+		pos := utils.Vector2D{X: l.X, Y: l.Y}
+		r := radius
+		if l.Radius > 0 {
+			r = l.Radius
+		}
+		e.renderer.DrawLanternPreview(world, pos, r, 1)
+		vector.DrawFilledCircle(world, float32(l.X), float32(l.Y), 6, color.RGBA{R: 255, G: 210, B: 120, A: 255}, true)
+	}
+
+	cursor := e.cursor()
+	if e.dragging {
+		if a, ok := e.dragArea(cursor, e.app.inputHandler.IsKeyPressed(ebiten.KeyShift)); ok {
+			drawArea(world, a, editorDragFill, editorDragLine)
+		}
+	} else if e.tool == toolLantern {
+		e.renderer.DrawLanternPreview(world, cursor, radius, 0.6)
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM = e.camera.GeoM()
+	op.Filter = ebiten.FilterLinear
+	screen.DrawImage(world, op)
+
+	e.drawPanel(screen)
+	e.toasts.Draw(screen, e.app.uiRenderer)
+}
+
+// drawPanel escribe las herramientas, lo que tiene el mapa y los atajos
+func (e *EditorScene) drawPanel(screen *ebiten.Image) {
+	ui := e.app.uiRenderer
+	_, sh := config.ScreenSize()
+	m := e.gardenMap
+
+	ui.drawText(screen, i18n.T("Editor de mapas: %s (%d×%d)", m.Name, m.Width, m.Height), 16, 20, color.RGBA{R: 255, G: 255, B: 200, A: 255})
+	for i, name := range editorTools {
+		label := fmt.Sprintf("%d  %s", i+1, i18n.T(name))
+		if editorTool(i) == toolWindZone {
+			label += " (W: " + editorWindDirections[e.wind].String() + ")"
+		}
+		clr := editorHelp
+		if editorTool(i) == e.tool {
+			clr = editorSelected
+			label = "▶ " + label
+		}
+		ui.drawText(screen, label, 16, 52+float64(i)*22, clr)
+	}
+	ui.drawText(screen, i18n.T("%d obstáculos, %d estanques, %d faroles, %d zonas de aparición, %d zonas de viento",
+		len(m.Obstacles), len(m.Ponds), len(m.Lanterns), len(m.SpawnZones), len(m.WindZones)), 16, 52+float64(len(editorTools))*22+8, editorHelp)
+
+	if i := e.spawnZoneAt(e.cursor()); i >= 0 {
+		z := m.SpawnZones[i]
+		inward := i18n.T("no")
+		if z.Inward {
+			inward = i18n.T("sí")
+		}
+		ui.drawTextCentered(screen, i18n.T("Zona de aparición: %s por segundo ([ ]), hacia el centro: %s (I)", fmt.Sprintf("%.1f", z.Rate), inward), float64(sh)-76, editorSelected)
+	}
+	ui.drawTextCentered(screen, i18n.T("Arrastrar: rectángulo · Shift: círculo · Click derecho: borrar · Ctrl+Z: deshacer · Flechas y rueda: cámara"), float64(sh)-52, editorHelp)
+	ui.drawTextCentered(screen, i18n.T("Ctrl+S: guardar en %s · Enter: probar · Esc: menú", e.path), float64(sh)-28, editorHelp)
+}
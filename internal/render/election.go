@@ -0,0 +1,42 @@
+package render
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// leaderTint es el color de las líderes de grupo
+var leaderTint = [4]uint8{90, 230, 255, 255}
+
+// toggleElection empieza o termina el modo elección de líder
+func (g *Game) toggleElection() {
+	on := !g.manager.GetElectionStatus().Active
+	go g.manager.SetElection(on)
+	if on {
+		g.toasts.Push("Elección de líder activada")
+	} else {
+		g.toasts.Push("Elección de líder desactivada")
+	}
+}
+
+// drawElectionPanel muestra cuántos grupos tienen líder y lo que costaron
+// las elecciones
+func drawElectionPanel(screen *ebiten.Image, ui *UIRenderer, status manager.ElectionStatus, y float32) {
+	sw, _ := config.ScreenSize()
+	label := i18n.T("Líderes: %d grupos, %d elecciones, %d msj", status.Clusters, status.Elections, status.Messages)
+	if status.Reelections > 0 {
+		label += "   " + i18n.T("reelecciones: %d (cayó #%d)", status.Reelections, status.LastFallen)
+	}
+
+	width := float32(560)
+	x := (float32(sw) - width) / 2
+	vector.DrawFilledRect(screen, x, y, width, 28, color.RGBA{R: 15, G: 35, B: 45, A: 200}, false)
+	vector.StrokeRect(screen, x, y, width, 28, 1, utils.ArrayToRGBA(leaderTint), false)
+	ui.drawText(screen, label, float64(x)+12, float64(y)+5, color.RGBA{R: 200, G: 245, B: 255, A: 255})
+}
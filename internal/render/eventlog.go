@@ -0,0 +1,209 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/input"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/internal/theme"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// eventLogCapacity es cuántos eventos guarda el anillo; los más viejos
+	// se pisan
+	eventLogCapacity = 200
+	// eventLogBuffer es el buffer de la suscripción al bus
+	eventLogBuffer = 256
+	// eventLogRows son las líneas visibles a la vez
+	eventLogRows = 16
+	// eventLogWidth y eventLogRowHeight fijan el tamaño del panel
+	eventLogWidth     = 360
+	eventLogRowHeight = 18
+)
+
+// logEntry es una línea del registro ya formateada
+type logEntry struct {
+	t     time.Duration
+	text  string
+	color color.RGBA
+}
+
+// EventLog es el registro de eventos de la simulación (tecla Tab). Se
+// suscribe al bus del manager y guarda las últimas entradas en un anillo de
+// tamaño fijo; se llena aunque esté oculto para que al abrirlo ya tenga
+// historia.
+type EventLog struct {
+	events      <-chan manager.Event
+	unsubscribe func()
+	entries     [eventLogCapacity]logEntry
+	next        int
+	count       int
+	scroll      int
+	visible     bool
+}
+
+// NewEventLog se suscribe a los eventos de fm; debe crearse antes de Start
+// para registrar las luciérnagas iniciales
+func NewEventLog(fm *manager.FireflyManager) *EventLog {
+	events, unsubscribe := fm.Events().Subscribe(eventLogBuffer)
+	return &EventLog{events: events, unsubscribe: unsubscribe}
+}
+
+// Toggle muestra u oculta el panel
+func (l *EventLog) Toggle() {
+	l.visible = !l.visible
+	l.scroll = 0
+}
+
+// IsVisible indica si el panel está abierto
+func (l *EventLog) IsVisible() bool {
+	return l.visible
+}
+
+// Close cancela la suscripción
+func (l *EventLog) Close() {
+	l.unsubscribe()
+}
+
+// Update consume los eventos pendientes sin bloquear y, con el panel
+// abierto, lo desplaza con RePág/AvPág
+func (l *EventLog) Update(h *input.Handler) {
+	for drained := false; !drained; {
+		select {
+		case e := <-l.events:
+			l.add(e)
+		default:
+			drained = true
+		}
+	}
+
+	if !l.visible {
+		return
+	}
+	if h.IsKeyJustPressed(ebiten.KeyPageUp) {
+		l.Scroll(eventLogRows / 2)
+	}
+	if h.IsKeyJustPressed(ebiten.KeyPageDown) {
+		l.Scroll(-eventLogRows / 2)
+	}
+}
+
+// add guarda el evento en el anillo. Si se está mirando el historial, la
+// vista se corre con la entrada nueva para no moverse bajo el lector.
+func (l *EventLog) add(e manager.Event) {
+	text, clr := describeEvent(e)
+	l.entries[l.next] = logEntry{t: e.T, text: text, color: clr}
+	l.next = (l.next + 1) % eventLogCapacity
+	l.count = min(l.count+1, eventLogCapacity)
+	if l.scroll > 0 {
+		l.Scroll(1)
+	}
+}
+
+// Scroll sube (positivo) o baja el registro la cantidad de líneas indicada
+func (l *EventLog) Scroll(lines int) {
+	l.scroll = max(min(l.scroll+lines, l.count-eventLogRows), 0)
+}
+
+// Bounds retorna el rectángulo del panel en su lugar por defecto
+func (l *EventLog) Bounds() Rect {
+	sw, _ := config.ScreenSize()
+	return Rect{
+		X: float32(sw - eventLogWidth - 10),
+		Y: 10,
+		W: eventLogWidth,
+		H: eventLogRows*eventLogRowHeight + 34,
+	}
+}
+
+// entry retorna la i-ésima entrada contando desde la más nueva (0)
+func (l *EventLog) entry(i int) logEntry {
+	return l.entries[(l.next-1-i+eventLogCapacity)%eventLogCapacity]
+}
+
+// Draw dibuja las últimas entradas, la más nueva abajo
+func (l *EventLog) Draw(screen *ebiten.Image, ui *UIRenderer) {
+	if !l.visible {
+		return
+	}
+
+	b := l.Bounds()
+	x, y, w, h := b.X, b.Y, b.W, b.H
+	vector.DrawFilledRect(screen, x, y, w, h, color.RGBA{R: 0, G: 0, B: 0, A: 180}, false)
+	vector.StrokeRect(screen, x, y, w, h, 1, color.RGBA{R: 100, G: 120, B: 150, A: 200}, false)
+
+	title := fmt.Sprintf("📜 EVENTOS (Tab) — %d", l.count)
+	if l.scroll > 0 {
+		title += fmt.Sprintf("  ↑%d", l.scroll)
+	}
+	ui.drawText(screen, title, float64(x)+10, float64(y)+6, utils.ArrayToRGBA(theme.Current().Accent))
+
+	rows := min(eventLogRows, l.count)
+	top := float64(y) + 30
+	for row := 0; row < rows; row++ {
+		e := l.entry(l.scroll + rows - 1 - row)
+		rowY := top + float64(row*eventLogRowHeight)
+		ui.drawText(screen, formatClock(e.t), float64(x)+10, rowY, color.RGBA{R: 140, G: 140, B: 160, A: 255})
+		ui.drawText(screen, e.text, float64(x)+70, rowY, e.color)
+	}
+}
+
+// describeEvent arma el texto y el color de un evento para el registro
+func describeEvent(e manager.Event) (string, color.RGBA) {
+	plain := color.RGBA{R: 210, G: 210, B: 220, A: 255}
+	warm := color.RGBA{R: 255, G: 220, B: 130, A: 255}
+	cold := color.RGBA{R: 160, G: 170, B: 190, A: 255}
+	alert := color.RGBA{R: 255, G: 140, B: 110, A: 255}
+
+	switch e.Type {
+	case manager.EventSpawn:
+		return fmt.Sprintf("Nace la luciérnaga #%d", e.ID), warm
+	case manager.EventDeath:
+		return fmt.Sprintf("Se apaga la luciérnaga #%d", e.ID), cold
+	case manager.EventCapture:
+		return fmt.Sprintf("#%d atrapada en el frasco", e.ID), plain
+	case manager.EventEaten:
+		return fmt.Sprintf("#%d comida por un murciélago", e.ID), alert
+	case manager.EventLanternAdd:
+		return fmt.Sprintf("Farol #%d colocado", e.ID), warm
+	case manager.EventLanternRemove:
+		return fmt.Sprintf("Farol #%d quitado", e.ID), plain
+	case manager.EventAttraction:
+		if e.Position != nil {
+			return fmt.Sprintf("Atracción en (%.0f, %.0f)", e.Position.X, e.Position.Y), plain
+		}
+	case manager.EventAttractionClear:
+		return "Atracción liberada", plain
+	case manager.EventWind:
+		if e.Wind != nil {
+			return "Viento: " + e.Wind.String(), color.RGBA{R: 150, G: 210, B: 255, A: 255}
+		}
+	case manager.EventSettings:
+		return "Ajustes cambiados", plain
+	case manager.EventRestore:
+		return "Jardín restaurado", plain
+	case manager.EventGroupOrder:
+		if e.Group != nil {
+			return fmt.Sprintf("Orden %s a %d luciérnagas", e.Group.Kind, len(e.Group.IDs)), plain
+		}
+	case manager.EventBatWave:
+		return fmt.Sprintf("Oleada de %d murciélagos", e.Count), alert
+	case manager.EventFlashWave:
+		return fmt.Sprintf("Destello sincronizado (%d)", e.Count), warm
+	case manager.EventDropSpike:
+		return fmt.Sprintf("⚠ %d estados descartados en 1 s", e.Count), alert
+	case manager.EventStormWarning:
+		return "Se acerca una tormenta", alert
+	case manager.EventStorm:
+		return "⛈ Tormenta", alert
+	case manager.EventStormEnd:
+		return "Pasó la tormenta", plain
+	}
+	return string(e.Type), plain
+}
@@ -0,0 +1,104 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/input"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// fieldPaintRate es cuánta intensidad suma el pincel por segundo en su
+	// centro
+	fieldPaintRate = 1.5
+	// Radio del pincel en píxeles del mundo
+	fieldBrushMin     = 24.0
+	fieldBrushMax     = 240.0
+	fieldBrushDefault = 80.0
+	fieldBrushStep    = 12.0
+)
+
+// FieldEditor es el modo de edición del campo de fuerzas (tecla F2): con
+// el botón izquierdo se pinta atracción y con el derecho repulsión, la
+// rueda cambia el tamaño del pincel y Supr borra todo. Mientras está
+// activo el click no mueve el punto de atracción y el campo se ve como una
+// grilla verde (atrae) y roja (repele).
+type FieldEditor struct {
+	active bool
+	radius float64
+}
+
+func NewFieldEditor() *FieldEditor {
+	return &FieldEditor{radius: fieldBrushDefault}
+}
+
+// Toggle entra o sale del modo y retorna si quedó activo
+func (e *FieldEditor) Toggle() bool {
+	e.active = !e.active
+	return e.active
+}
+
+func (e *FieldEditor) Active() bool {
+	return e.active
+}
+
+// Update pinta bajo el cursor con el tiempo de juego dt; pos es el cursor
+// en el mundo
+func (e *FieldEditor) Update(h *input.Handler, field *core.ForceField, pos utils.Vector2D, dt float64) {
+	if _, wy := h.GetMouseWheel(); wy != 0 {
+		e.radius = utils.Clamp(e.radius+wy*fieldBrushStep, fieldBrushMin, fieldBrushMax)
+	}
+	if h.IsKeyJustPressed(ebiten.KeyDelete) {
+		field.Clear()
+		return
+	}
+
+	switch {
+	case h.Pointer().Pressed:
+		field.Paint(pos, e.radius, fieldPaintRate*dt)
+	case h.IsMouseButtonPressed(ebiten.MouseButtonRight):
+		field.Paint(pos, e.radius, -fieldPaintRate*dt)
+	}
+}
+
+// Draw dibuja el campo y el pincel en el mundo
+func (e *FieldEditor) Draw(world *ebiten.Image, field *core.ForceField, cursor utils.Vector2D) {
+	if !e.active {
+		return
+	}
+
+	if grid := field.Grid(); grid != nil {
+		for row := 0; row < grid.Rows; row++ {
+			for col := 0; col < grid.Cols; col++ {
+				v := grid.At(col, row)
+				if v == 0 {
+					continue
+				}
+				a := uint8(140 * min(math.Abs(v), 1))
+				clr := color.RGBA{G: a, B: a / 3, A: a}
+				if v < 0 {
+					clr = color.RGBA{R: a, B: a / 4, A: a}
+				}
+				vector.DrawFilledRect(world, float32(col*core.FieldCellSize)+1, float32(row*core.FieldCellSize)+1,
+					core.FieldCellSize-2, core.FieldCellSize-2, clr, false)
+			}
+		}
+	}
+
+	vector.StrokeCircle(world, float32(cursor.X), float32(cursor.Y), float32(e.radius), 1.5, color.RGBA{R: 230, G: 230, B: 230, A: 200}, true)
+}
+
+// DrawStatus escribe las instrucciones del modo arriba de la pantalla
+func (e *FieldEditor) DrawStatus(screen *ebiten.Image, ui *UIRenderer) {
+	if !e.active {
+		return
+	}
+	label := i18n.T("Campo de fuerzas: click atrae, derecho repele, rueda %s px, Supr borra, F2 sale", fmt.Sprint(int(e.radius)))
+	ui.drawTextCentered(screen, label, 64, color.RGBA{R: 180, G: 255, B: 190, A: 255})
+}
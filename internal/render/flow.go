@@ -0,0 +1,237 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/manager"
+)
+
+const (
+	flowPanelWidth  = 620.0
+	flowPanelHeight = 360.0
+	flowBoxWidth    = 140.0
+	flowBoxHeight   = 46.0
+
+	// flowPulseSpeed es la fracción del tramo que recorre un pulso por segundo
+	flowPulseSpeed = 1.2
+	// flowMaxPulses limita los pulsos por tramo para que el dibujo no crezca
+	// con la población
+	flowMaxPulses = 12
+)
+
+type flowBox struct {
+	label string
+	x, y  float64
+	color color.RGBA
+}
+
+// flowEdge es un tramo del diagrama; count lee su contador acumulado
+type flowEdge struct {
+	from, to int
+	label    string
+	count    func(f manager.Flow) uint64
+	color    color.RGBA
+}
+
+const (
+	boxFireflies = iota
+	boxAggregator
+	boxUI
+	boxManager
+	boxWorkers
+	boxEvents
+)
+
+var flowBoxes = []flowBox{
+	boxFireflies:  {label: "Luciérnagas", x: 20, y: 40, color: color.RGBA{R: 255, G: 220, B: 100, A: 255}},
+	boxAggregator: {label: "Agregador", x: 240, y: 40, color: color.RGBA{R: 120, G: 220, B: 255, A: 255}},
+	boxUI:         {label: "UI (Ebiten)", x: 460, y: 40, color: color.RGBA{R: 200, G: 160, B: 255, A: 255}},
+	boxManager:    {label: "Manager", x: 240, y: 170, color: color.RGBA{R: 150, G: 255, B: 150, A: 255}},
+	boxWorkers:    {label: "Worker pool", x: 20, y: 290, color: color.RGBA{R: 255, G: 160, B: 120, A: 255}},
+	boxEvents:     {label: "Bus de eventos", x: 460, y: 290, color: color.RGBA{R: 255, G: 140, B: 200, A: 255}},
+}
+
+var flowEdges = []flowEdge{
+	{from: boxFireflies, to: boxAggregator, label: "stateCh", count: func(f manager.Flow) uint64 { return f.States }},
+	{from: boxAggregator, to: boxUI, label: "snapshot", count: func(f manager.Flow) uint64 { return f.Snapshots }},
+	{from: boxUI, to: boxManager, label: "commandCh", count: func(f manager.Flow) uint64 { return f.Commands }},
+	{from: boxManager, to: boxFireflies, label: "go run()", count: func(f manager.Flow) uint64 { return f.Spawns }},
+	{from: boxManager, to: boxWorkers, label: "jobsCh", count: func(f manager.Flow) uint64 { return f.Jobs }},
+	{from: boxWorkers, to: boxManager, label: "resultsCh", count: func(f manager.Flow) uint64 { return f.JobsDone }},
+	{from: boxManager, to: boxEvents, label: "Publish", count: func(f manager.Flow) uint64 { return f.Events }},
+}
+
+// flowPulse es un mensaje viajando por un tramo; t va de 0 a 1
+type flowPulse struct {
+	edge int
+	t    float64
+}
+
+// FlowOverlay dibuja la arquitectura en vivo (tecla F6): una caja por
+// componente y pulsos que recorren cada canal cuando pasan mensajes por él
+type FlowOverlay struct {
+	visible bool
+	last    manager.Flow
+	pulses  []flowPulse
+
+	// Tasas por tramo (mensajes/s), recalculadas una vez por segundo
+	rates     []float64
+	rateBase  manager.Flow
+	rateSince time.Time
+}
+
+// NewFlowOverlay crea el diagrama oculto
+func NewFlowOverlay() *FlowOverlay {
+	return &FlowOverlay{rates: make([]float64, len(flowEdges))}
+}
+
+// Toggle muestra u oculta el diagrama
+func (f *FlowOverlay) Toggle() {
+	f.visible = !f.visible
+}
+
+// IsVisible indica si el diagrama está activo
+func (f *FlowOverlay) IsVisible() bool {
+	return f.visible
+}
+
+// Update compara los contadores con los del frame anterior, lanza un pulso
+// por cada tramo con tráfico y avanza los pulsos en vuelo
+func (f *FlowOverlay) Update(flow manager.Flow, dt float64) {
+	if f.rateSince.IsZero() {
+		f.last, f.rateBase, f.rateSince = flow, flow, time.Now()
+	}
+
+	inFlight := make([]int, len(flowEdges))
+	alive := f.pulses[:0]
+	for _, p := range f.pulses {
+		p.t += dt * flowPulseSpeed
+		if p.t < 1 {
+			alive = append(alive, p)
+			inFlight[p.edge]++
+		}
+	}
+	f.pulses = alive
+
+	for i, edge := range flowEdges {
+		if edge.count(flow) > edge.count(f.last) && inFlight[i] < flowMaxPulses {
+			f.pulses = append(f.pulses, flowPulse{edge: i})
+		}
+	}
+	f.last = flow
+
+	if elapsed := time.Since(f.rateSince).Seconds(); elapsed >= 1 {
+		for i, edge := range flowEdges {
+			f.rates[i] = float64(edge.count(flow)-edge.count(f.rateBase)) / elapsed
+		}
+		f.rateBase, f.rateSince = flow, time.Now()
+	}
+}
+
+// Draw dibuja el panel centrado con cajas, tramos, tasas y pulsos
+func (f *FlowOverlay) Draw(screen *ebiten.Image, ui *UIRenderer, flow manager.Flow) {
+	if !f.visible {
+		return
+	}
+
+	sw, sh := config.ScreenSize()
+	ox := (float64(sw) - flowPanelWidth) / 2
+	oy := (float64(sh) - flowPanelHeight) / 2
+
+	vector.DrawFilledRect(screen, float32(ox), float32(oy), flowPanelWidth, flowPanelHeight, color.RGBA{R: 5, G: 5, B: 20, A: 220}, false)
+	vector.StrokeRect(screen, float32(ox), float32(oy), flowPanelWidth, flowPanelHeight, 1, color.RGBA{R: 100, G: 150, B: 200, A: 255}, false)
+	ui.drawText(screen, "🔀 FLUJO DE CANALES (F6)", ox+10, oy+6, color.RGBA{R: 150, G: 200, B: 255, A: 255})
+
+	edgeColor := color.RGBA{R: 90, G: 90, B: 120, A: 255}
+	labelColor := color.RGBA{R: 200, G: 200, B: 200, A: 255}
+
+	for i, edge := range flowEdges {
+		x0, y0, x1, y1 := edgeEndpoints(edge, ox, oy)
+		vector.StrokeLine(screen, float32(x0), float32(y0), float32(x1), float32(y1), 2, edgeColor, true)
+		drawArrowHead(screen, x0, y0, x1, y1, edgeColor)
+
+		mx, my := (x0+x1)/2, (y0+y1)/2
+		ui.drawText(screen, fmt.Sprintf("%s %.0f/s", edge.label, f.rates[i]), mx+6, my-18, labelColor)
+	}
+
+	for _, p := range f.pulses {
+		edge := flowEdges[p.edge]
+		x0, y0, x1, y1 := edgeEndpoints(edge, ox, oy)
+		px := x0 + (x1-x0)*p.t
+		py := y0 + (y1-y0)*p.t
+		clr := flowBoxes[edge.from].color
+		vector.DrawFilledCircle(screen, float32(px), float32(py), 4, clr, true)
+	}
+
+	for i, box := range flowBoxes {
+		bx, by := ox+box.x, oy+box.y
+		vector.DrawFilledRect(screen, float32(bx), float32(by), flowBoxWidth, flowBoxHeight, color.RGBA{R: 20, G: 20, B: 40, A: 255}, false)
+		vector.StrokeRect(screen, float32(bx), float32(by), flowBoxWidth, flowBoxHeight, 2, box.color, false)
+		ui.drawText(screen, box.label, bx+8, by+4, box.color)
+		ui.drawText(screen, boxDetail(i, flow), bx+8, by+24, labelColor)
+	}
+}
+
+// boxDetail es la segunda línea de cada caja
+func boxDetail(box int, flow manager.Flow) string {
+	switch box {
+	case boxFireflies:
+		return fmt.Sprintf("descart.: %d", flow.Dropped)
+	case boxAggregator:
+		return fmt.Sprintf("estados: %d", flow.States)
+	case boxManager:
+		return fmt.Sprintf("comandos: %d", flow.Commands)
+	case boxWorkers:
+		return fmt.Sprintf("trabajos: %d", flow.JobsDone)
+	case boxEvents:
+		return fmt.Sprintf("eventos: %d", flow.Events)
+	default:
+		return fmt.Sprintf("snapshots: %d", flow.Snapshots)
+	}
+}
+
+// edgeEndpoints une los centros de las dos cajas recortando en sus bordes.
+// Los tramos de ida y vuelta entre las mismas cajas se separan unos píxeles.
+func edgeEndpoints(edge flowEdge, ox, oy float64) (float64, float64, float64, float64) {
+	from, to := flowBoxes[edge.from], flowBoxes[edge.to]
+	cx0, cy0 := ox+from.x+flowBoxWidth/2, oy+from.y+flowBoxHeight/2
+	cx1, cy1 := ox+to.x+flowBoxWidth/2, oy+to.y+flowBoxHeight/2
+
+	dx, dy := cx1-cx0, cy1-cy0
+	length := math.Hypot(dx, dy)
+	nx, ny := -dy/length*6, dx/length*6
+	cx0, cy0, cx1, cy1 = cx0+nx, cy0+ny, cx1+nx, cy1+ny
+
+	t0 := boxExit(dx, dy)
+	t1 := boxExit(-dx, -dy)
+	return cx0 + dx*t0, cy0 + dy*t0, cx1 - dx*t1, cy1 - dy*t1
+}
+
+// boxExit retorna la fracción del vector (dx, dy) en la que éste sale de una
+// caja centrada en su origen
+func boxExit(dx, dy float64) float64 {
+	tx, ty := math.Inf(1), math.Inf(1)
+	if dx != 0 {
+		tx = flowBoxWidth / 2 / math.Abs(dx)
+	}
+	if dy != 0 {
+		ty = flowBoxHeight / 2 / math.Abs(dy)
+	}
+	return math.Min(tx, ty)
+}
+
+// drawArrowHead dibuja la punta de flecha en el extremo (x1, y1)
+func drawArrowHead(screen *ebiten.Image, x0, y0, x1, y1 float64, clr color.RGBA) {
+	angle := math.Atan2(y1-y0, x1-x0)
+	for _, side := range []float64{-0.5, 0.5} {
+		ax := x1 - 10*math.Cos(angle+side)
+		ay := y1 - 10*math.Sin(angle+side)
+		vector.StrokeLine(screen, float32(x1), float32(y1), float32(ax), float32(ay), 2, clr, true)
+	}
+}
@@ -0,0 +1,85 @@
+package render
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// velocityScale y forceScale alargan la velocidad y los empujes de un
+	// tick para que se lean en pantalla
+	velocityScale = 10.0
+	forceScale    = 30.0
+	// forceArrowHead es el largo de las puntas
+	forceArrowHead = 4.0
+)
+
+// Colores de cada flecha: blanca la velocidad, azul el viento, ámbar los
+// faroles, rosa la atracción y verde el campo pintado
+var (
+	velocityColor   = color.RGBA{R: 240, G: 240, B: 240, A: 220}
+	windForceColor  = color.RGBA{R: 110, G: 170, B: 255, A: 230}
+	lanternColor    = color.RGBA{R: 255, G: 180, B: 60, A: 230}
+	attractionColor = color.RGBA{R: 255, G: 90, B: 200, A: 230}
+	fieldColor      = color.RGBA{R: 110, G: 230, B: 120, A: 230}
+)
+
+// ForcesOverlay dibuja sobre cada luciérnaga visible su velocidad y lo que
+// le sumaron en el último tick el viento, los faroles y la atracción (tecla
+// F7). Mientras está visible prende el rastreo de fuerzas de core, así los
+// estados las traen.
+type ForcesOverlay struct {
+	visible bool
+}
+
+// NewForcesOverlay crea el overlay oculto
+func NewForcesOverlay() *ForcesOverlay {
+	return &ForcesOverlay{}
+}
+
+// Toggle muestra u oculta el overlay y retorna si quedó visible
+func (o *ForcesOverlay) Toggle() bool {
+	o.visible = !o.visible
+	core.SetTraceForces(o.visible)
+	return o.visible
+}
+
+// Draw dibuja las flechas de las luciérnagas que ve la cámara
+func (o *ForcesOverlay) Draw(world *ebiten.Image, states []core.FireflyState, camera *Camera) {
+	if !o.visible {
+		return
+	}
+	for _, s := range states {
+		if visible, _ := fireflyLOD(s, camera); !visible {
+			continue
+		}
+		f := s.Forces
+		drawForceArrow(world, s.Position, f.Velocity.Mul(velocityScale), velocityColor)
+		drawForceArrow(world, s.Position, f.Wind.Mul(forceScale), windForceColor)
+		drawForceArrow(world, s.Position, f.Lantern.Mul(forceScale), lanternColor)
+		drawForceArrow(world, s.Position, f.Attraction.Mul(forceScale), attractionColor)
+		drawForceArrow(world, s.Position, f.Field.Mul(forceScale), fieldColor)
+	}
+}
+
+// drawForceArrow dibuja v como una flecha desde from; las muy cortas no se
+// dibujan
+func drawForceArrow(world *ebiten.Image, from, v utils.Vector2D, clr color.RGBA) {
+	if v.Magnitude() < 1 {
+		return
+	}
+	to := from.Add(v)
+	vector.StrokeLine(world, float32(from.X), float32(from.Y), float32(to.X), float32(to.Y), 1, clr, true)
+
+	angle := math.Atan2(v.Y, v.X)
+	for _, side := range [2]float64{0.75, -0.75} {
+		a := angle + math.Pi*side
+		tip := to.Add(utils.NewVector2D(math.Cos(a), math.Sin(a)).Mul(forceArrowHead))
+		vector.StrokeLine(world, float32(to.X), float32(to.Y), float32(tip.X), float32(tip.Y), 1, clr, true)
+	}
+}
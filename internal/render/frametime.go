@@ -0,0 +1,217 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+)
+
+// drawPhase es una etapa de Game.Draw que mide el perfilador
+type drawPhase int
+
+const (
+	phaseBackground drawPhase = iota
+	phaseWind
+	phaseLanterns
+	phaseFireflies
+	phaseProjection
+	phaseHUD
+	drawPhaseCount
+)
+
+// drawPhaseNames y drawPhaseColors rotulan cada etapa en la barra y la leyenda
+var drawPhaseNames = [drawPhaseCount]string{
+	phaseBackground: "Fondo",
+	phaseWind:       "Viento",
+	phaseLanterns:   "Faroles",
+	phaseFireflies:  "Luciérnagas",
+	phaseProjection: "Proyección",
+	phaseHUD:        "HUD",
+}
+
+var drawPhaseColors = [drawPhaseCount]color.RGBA{
+	phaseBackground: {R: 90, G: 110, B: 170, A: 255},
+	phaseWind:       {R: 120, G: 220, B: 255, A: 255},
+	phaseLanterns:   {R: 255, G: 180, B: 80, A: 255},
+	phaseFireflies:  {R: 255, G: 230, B: 120, A: 255},
+	phaseProjection: {R: 190, G: 140, B: 255, A: 255},
+	phaseHUD:        {R: 140, G: 230, B: 150, A: 255},
+}
+
+const (
+	// frameHistory es la cantidad de frames que muestra el perfilador
+	frameHistory = 120
+	// frameBudget es el tiempo de un frame a 60 FPS, la línea de referencia
+	frameBudget = time.Second / 60
+)
+
+// frameTimes es lo que tardó cada etapa en un frame
+type frameTimes [drawPhaseCount]time.Duration
+
+func (t frameTimes) total() time.Duration {
+	var sum time.Duration
+	for _, d := range t {
+		sum += d
+	}
+	return sum
+}
+
+// FrameProfiler mide cuánto tarda cada etapa de Draw (tecla F8) y la
+// muestra como una barra apilada por frame. Mide el tiempo de CPU en que
+// Draw arma los comandos de dibujo; la GPU los ejecuta después, fuera del
+// frame. Apagado, Begin, Mark y End no hacen nada.
+type FrameProfiler struct {
+	visible bool
+
+	// phaseStart es cuándo empezó la etapa en curso; current acumula el
+	// frame que se está dibujando
+	phaseStart time.Time
+	current    frameTimes
+	measuring  bool
+
+	// history es un anillo con los últimos frames completos
+	history [frameHistory]frameTimes
+	next    int
+	filled  int
+}
+
+// NewFrameProfiler crea el perfilador oculto
+func NewFrameProfiler() *FrameProfiler {
+	return &FrameProfiler{}
+}
+
+// Toggle muestra u oculta el perfilador; al prenderlo empieza de cero
+func (p *FrameProfiler) Toggle() bool {
+	p.visible = !p.visible
+	p.next, p.filled = 0, 0
+	return p.visible
+}
+
+// IsVisible indica si el perfilador está activo
+func (p *FrameProfiler) IsVisible() bool {
+	return p.visible
+}
+
+// Begin empieza a medir un frame
+func (p *FrameProfiler) Begin() {
+	if !p.visible {
+		return
+	}
+	p.current = frameTimes{}
+	p.measuring = true
+	p.phaseStart = time.Now()
+}
+
+// Mark cierra la etapa en curso: lo transcurrido desde la marca anterior
+// se suma a phase
+func (p *FrameProfiler) Mark(phase drawPhase) {
+	if !p.measuring {
+		return
+	}
+	now := time.Now()
+	p.current[phase] += now.Sub(p.phaseStart)
+	p.phaseStart = now
+}
+
+// End guarda el frame medido en el historial. Un frame que no llega a End
+// (por ejemplo en modo foto) se descarta.
+func (p *FrameProfiler) End() {
+	if !p.measuring {
+		return
+	}
+	p.measuring = false
+	p.history[p.next] = p.current
+	p.next = (p.next + 1) % frameHistory
+	p.filled = min(p.filled+1, frameHistory)
+}
+
+// average retorna el promedio por etapa de los frames del historial
+func (p *FrameProfiler) average() frameTimes {
+	var avg frameTimes
+	if p.filled == 0 {
+		return avg
+	}
+	for i := 0; i < p.filled; i++ {
+		for phase, d := range p.history[i] {
+			avg[phase] += d
+		}
+	}
+	for phase := range avg {
+		avg[phase] /= time.Duration(p.filled)
+	}
+	return avg
+}
+
+// Draw dibuja el panel abajo al centro: una barra apilada por frame, del
+// más viejo al más nuevo, y la leyenda con el promedio de cada etapa
+func (p *FrameProfiler) Draw(screen *ebiten.Image, ui *UIRenderer) {
+	if !p.visible {
+		return
+	}
+
+	const (
+		width       = 420.0
+		graphHeight = 80.0
+		lineHeight  = 20.0
+		legendRows  = (drawPhaseCount + 1) / 2
+	)
+	height := lineHeight + graphHeight + 8 + lineHeight*float64(legendRows+1)
+	sw, sh := config.ScreenSize()
+	x := float64(sw)/2 - width/2
+	y := float64(sh) - height - 90
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), float32(height), color.RGBA{A: 200}, false)
+
+	avg := p.average()
+	ui.drawText(screen, fmt.Sprintf("⏱ TIEMPO DE DIBUJO (F8)  %s/frame", formatMillis(avg.total())), x+10, y+3, color.RGBA{R: 255, G: 150, B: 150, A: 255})
+	y += lineHeight
+
+	p.drawBars(screen, x+10, y, width-20, graphHeight)
+	y += graphHeight + 8
+
+	// Leyenda en dos columnas
+	for phase := drawPhase(0); phase < drawPhaseCount; phase++ {
+		col, row := float64(phase%2), float64(phase/2)
+		lx, ly := x+10+col*(width-20)/2, y+row*lineHeight
+		vector.DrawFilledRect(screen, float32(lx), float32(ly+5), 10, 10, drawPhaseColors[phase], false)
+		ui.drawText(screen, fmt.Sprintf("%s: %s", drawPhaseNames[phase], formatMillis(avg[phase])), lx+16, ly, drawPhaseColors[phase])
+	}
+	y += lineHeight * float64(legendRows)
+	ui.drawText(screen, fmt.Sprintf("Promedio de %d frames (CPU; la línea es %s)", p.filled, formatMillis(frameBudget)), x+10, y, color.RGBA{R: 180, G: 180, B: 180, A: 255})
+}
+
+// drawBars dibuja el historial alineado a la derecha. La escala es el
+// presupuesto de 60 FPS o el frame más lento, lo que sea mayor.
+func (p *FrameProfiler) drawBars(screen *ebiten.Image, x, y, width, height float64) {
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), float32(height), color.RGBA{R: 20, G: 20, B: 40, A: 180}, false)
+
+	scale := frameBudget
+	for i := 0; i < p.filled; i++ {
+		scale = max(scale, p.history[i].total())
+	}
+
+	barWidth := width / frameHistory
+	offset := frameHistory - p.filled
+	for i := 0; i < p.filled; i++ {
+		frame := p.history[(p.next-p.filled+i+frameHistory)%frameHistory]
+		bx := x + float64(offset+i)*barWidth
+		by := y + height
+		for phase, d := range frame {
+			h := float64(d) / float64(scale) * height
+			by -= h
+			vector.DrawFilledRect(screen, float32(bx), float32(by), float32(barWidth), float32(h), drawPhaseColors[phase], false)
+		}
+	}
+
+	budgetY := y + height - float64(frameBudget)/float64(scale)*height
+	vector.StrokeLine(screen, float32(x), float32(budgetY), float32(x+width), float32(budgetY), 1, color.RGBA{R: 255, G: 100, B: 100, A: 200}, false)
+}
+
+// formatMillis escribe una duración en milisegundos con dos decimales
+func formatMillis(d time.Duration) string {
+	return fmt.Sprintf("%.2f ms", float64(d)/float64(time.Millisecond))
+}
@@ -1,13 +1,28 @@
 package render
 
 import (
+	"context"
+	"fmt"
+	"image/color"
+	"log/slog"
 	"math"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/api"
+	"github.com/yourusername/firefly-garden/internal/chat"
 	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
 	"github.com/yourusername/firefly-garden/internal/input"
+	"github.com/yourusername/firefly-garden/internal/level"
+	"github.com/yourusername/firefly-garden/internal/logging"
 	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/internal/netplay"
+	"github.com/yourusername/firefly-garden/internal/preset"
+	"github.com/yourusername/firefly-garden/internal/profiling"
+	"github.com/yourusername/firefly-garden/internal/script"
+	"github.com/yourusername/firefly-garden/internal/sound"
+	"github.com/yourusername/firefly-garden/pkg/garden"
 	"github.com/yourusername/firefly-garden/pkg/utils"
 )
 
@@ -24,10 +39,120 @@ type Game struct {
 	showAttraction    bool
 	attractionPoint   utils.Vector2D
 	fpsCounter        *FPSCounter
+	camera            *Camera
+	minimap           *Minimap
+	heatmap           *HeatmapOverlay
+	photoMode         *PhotoMode
+	bloom             *Bloom
+	fireflyBatch      *FireflyBatch
+	fireflyLayer      *ebiten.Image
+	deathFades        *DeathFades
+	windOverlay       *WindOverlay
+	forcesOverlay     *ForcesOverlay
+	frameProfiler     *FrameProfiler
+	zonePanel         *ZonePanel
+	clusterOverlay    *ClusterOverlay
+	ghost             *GhostTrail
+	fieldEditor       *FieldEditor
+	quality           int
+	cullStats         CullStats
+	debugOverlay      *DebugOverlay
+	graphPanel        *GraphPanel
+	flowOverlay       *FlowOverlay
+	toasts            *Toasts
+	log               *slog.Logger
+	governor          *QualityGovernor
+	worldLayer        *ebiten.Image
+	sessionStart      time.Time
+	peakFireflies     int
+	lanternsPlaced    int
+	recorder          *manager.Recorder
+	player            *manager.Player
+	scriptRunner      *script.Runner
+	scriptCancel      context.CancelFunc
+	plugins           []RenderPlugin
+	garden            *garden.Garden
+	api               *api.Server
+	chat              *chat.Bridge
+	players           int
+	spectators        int
+	compact           bool
+
+	// lanternRadius es el radio del próximo farol (0 usa el de la
+	// configuración); la rueda lo ajusta y muestra su vista previa
+	lanternRadius float64
+	previewUntil  time.Time
+
+	selection *Selection
+
+	mode     GameMode
+	jar      *Jar
+	levels   *LevelRun
+	survival *Survival
+	daily    *Daily
+	tutorial *Tutorial
+	eventLog *EventLog
+	tools    *ToolsPanel
+	extinct  bool
+
+	// particles son los destellos al nacer y la disolución al morir
+	particles *Particles
+
+	// console es la consola de depuración (`); idLabels, sus etiquetas
+	// de IDs
+	console  *Console
+	idLabels *IDLabels
+
+	// mixer reproduce los efectos de sonido que effects saca del bus; music
+	// toca una nota por destello cuando el modo musical está prendido
+	mixer   *sound.Mixer
+	effects *sound.Effects
+	music   *sound.Music
+
+	// layout mueve, pliega u oculta los paneles del HUD; hudFrame es lo que
+	// ve el frame en curso, para que los paneles lo dibujen
+	layout   *HUDLayout
+	panels   []hudPanel
+	hudFrame Frame
+	seed     int64
+
+	// screenshot es la captura pedida con F12; shotLayer, la capa donde se
+	// copia la pantalla para leerla
+	screenshot screenshotMode
+	shotLayer  *ebiten.Image
+
+	// demo es la demostración en curso (nil mientras juega el jugador)
+	demo *Demo
+	// lowPower es el modo de bajo consumo con la ventana sin foco
+	lowPower bool
 
 	// Nuevos campos para spawn del jugador
 	lastPlayerSpawn     time.Time
 	playerSpawnCooldown time.Duration
+	// burstPattern es la forma de las ráfagas de K (se cambia con B); vacía
+	// es la nube
+	burstPattern manager.BurstPattern
+
+	// preset es el escenario listo con que arrancó la partida (nil sin
+	// preset); presetSwitch, el cambio pedido por la consola que hace App
+	preset       *preset.Preset
+	presetSwitch *presetSwitch
+}
+
+const (
+	// lanternRadiusStep es cuánto cambia el radio por cada paso de la rueda
+	lanternRadiusStep = 10.0
+	// lanternPreviewDuration es cuánto se ve la vista previa después de
+	// mover la rueda; lanternPreviewFade es el tramo final en que se desvanece
+	lanternPreviewDuration = 2 * time.Second
+	lanternPreviewFade     = 500 * time.Millisecond
+)
+
+// replaySpeedKeys son las velocidades de reproducción disponibles
+var replaySpeedKeys = map[ebiten.Key]int{
+	ebiten.KeyDigit1: 1,
+	ebiten.KeyDigit2: 2,
+	ebiten.KeyDigit4: 4,
 }
 
 // FPSCounter calcula los FPS del juego
@@ -62,10 +187,58 @@ func (f *FPSCounter) Update() float64 {
 	return f.currentFPS
 }
 
-// NewGame crea una nueva instancia del juego
-func NewGame() *Game {
+// SessionOptions indica si la partida se graba o reproduce una grabación
+// y, opcionalmente, el escenario que la conduce y la API que la controla
+type SessionOptions struct {
+	RecordPath  string
+	Replay      *manager.Replay
+	ReplaySpeed int
+	Script      *script.Script
+	API         *api.Server
+	Chat        *chat.Bridge
+	// Join es la conexión con un anfitrión (jugador o espectador); si está,
+	// la app abre su jardín en lugar del menú
+	Join *netplay.Client
+	// Mode es el tipo de partida elegido en el menú (vacío es el jardín libre)
+	Mode GameMode
+	// Levels son los niveles del modo niveles (nil usa los del juego)
+	Levels *level.Campaign
+	// Seed es la semilla del generador compartido (0 elige una nueva); una
+	// semilla de la tabla de récords repite esa partida
+	Seed int64
+	// Layout es la disposición de los paneles del HUD, compartida entre
+	// partidas (nil usa la de por defecto)
+	Layout *HUDLayout
+	// Demo arranca la partida en modo demostración (-demo)
+	Demo bool
+	// Map es el mapa con que arranca cada partida (-map); nil es el jardín
+	// del tamaño de la ventana
+	Map *manager.GardenMap
+	// MapPath es el archivo de Map, donde guarda el editor de mapas
+	MapPath string
+	// Preset es el escenario listo del jardín libre (-preset, menú o
+	// consola); App aplica su configuración antes de crear la partida
+	Preset *preset.Preset
+}
+
+// NewGame crea una nueva instancia del juego con los parámetros elegidos
+func NewGame(inputHandler *input.Handler, settings manager.Settings, quality int, session SessionOptions) *Game {
+	// La repetición siembra el generador con la semilla de su archivo
+	seed := session.Seed
+	if seed == 0 && session.Mode == ModeDaily {
+		seed = DailySeed(time.Now())
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	if session.Replay == nil {
+		utils.Seed(seed)
+	}
+
 	manager := manager.NewFireflyManager()
-	inputHandler := input.NewHandler()
+	manager.ApplySettings(settings)
+	// El mapa fija el tamaño del mundo antes de crear la cámara y las capas
+	manager.ApplyMap(session.Map)
 
 	game := &Game{
 		manager:             manager,
@@ -74,13 +247,168 @@ func NewGame() *Game {
 		uiRenderer:          NewUIRenderer(),
 		gameState:           config.GameStateRunning,
 		lastUpdateTime:      time.Now(),
+		sessionStart:        time.Now(),
 		fpsCounter:          NewFPSCounter(),
+		camera:              NewCamera(),
+		minimap:             NewMinimap(),
+		selection:           NewSelection(),
+		heatmap:             NewHeatmapOverlay(manager.GetHeatmap()),
+		photoMode:           NewPhotoMode(),
+		fireflyBatch:        NewFireflyBatch(),
+		deathFades:          NewDeathFades(),
+		windOverlay:         NewWindOverlay(),
+		forcesOverlay:       NewForcesOverlay(),
+		frameProfiler:       NewFrameProfiler(),
+		zonePanel:           NewZonePanel(),
+		clusterOverlay:      NewClusterOverlay(),
+		ghost:               NewGhostTrail(),
+		fieldEditor:         NewFieldEditor(),
+		debugOverlay:        NewDebugOverlay(),
+		graphPanel:          NewGraphPanel(),
+		flowOverlay:         NewFlowOverlay(),
+		toasts:              NewToasts(),
+		log:                 logging.For("game"),
+		plugins:             registeredPlugins(),
+		garden:              garden.Wrap(manager),
+		api:                 session.API,
+		chat:                session.Chat,
+		governor:            NewQualityGovernor(),
+		quality:             quality,
 		lastPlayerSpawn:     time.Now().Add(-time.Hour),
-		playerSpawnCooldown: time.Duration(config.PlayerSpawnCooldownSecs) * time.Second,
+		playerSpawnCooldown: config.Get().Spawn.PlayerCooldown.Duration,
+		mode:                ModeGarden,
+		seed:                seed,
+		preset:              session.Preset,
+	}
+
+	// Los avisos del manager (límites, órdenes descartadas, metas) salen
+	// como toasts
+	game.toasts.Follow(manager.Notices())
+	game.eventLog = NewEventLog(manager)
+	game.particles = NewParticles(manager)
+	game.idLabels = NewIDLabels()
+	game.console = NewConsole(map[string]consoleCommand{
+		"label": game.idLabels.Command,
+		"ghost":  game.ghost.Command,
+		"preset": game.presetCommand,
+	})
+	game.mixer = sound.NewMixer()
+	game.effects = sound.NewEffects(manager, game.mixer)
+	game.music = sound.NewMusic()
+	game.tools = NewToolsPanel(game)
+	game.layout = session.Layout
+	if game.layout == nil {
+		game.layout = NewHUDLayout(nil)
+	}
+	game.panels = game.hudPanels()
+	game.fitScreen()
+
+	// En el frasco el cursor es el frasco: se oculta el del sistema
+	if session.Mode == ModeJar {
+		game.mode = ModeJar
+		game.jar = NewJar()
+		ebiten.SetCursorMode(ebiten.CursorModeHidden)
+	}
+
+	// En el modo niveles cada nivel fija la dificultad del jardín
+	if session.Mode == ModeLevels {
+		campaign := session.Levels
+		if campaign == nil {
+			campaign = level.Default()
+		}
+		game.mode = ModeLevels
+		game.levels = NewLevelRun(campaign, manager)
+	}
+
+	// En supervivencia el spawner no repone: solo nacen las que trae el
+	// jugador y alguna suelta
+	if session.Mode == ModeSurvival {
+		game.mode = ModeSurvival
+		game.survival = NewSurvival()
+		manager.SetObjective(0)
+	}
+
+	// En el desafío diario el viento y las oleadas siguen el calendario del
+	// día: el viento automático no debe moverlo
+	if session.Mode == ModeDaily {
+		challenge := NewDailyChallenge(seed)
+		game.mode = ModeDaily
+		game.daily = NewDaily(challenge, dailyBest(challenge.Date))
+		manager.DisableAutoWind()
+		manager.SetObjective(challenge.Objective)
+	}
+
+	// El tutorial avanza con los eventos del bus; el viento automático se
+	// apaga para que el paso del viento lo cumpla el jugador
+	if session.Mode == ModeTutorial {
+		game.mode = ModeTutorial
+		game.tutorial = NewTutorial(manager)
+		manager.DisableAutoWind()
+	}
+
+	// Si los shaders no compilan se usa el render con círculos
+	bloom, err := NewBloom(config.WorldPixels())
+	if err != nil {
+		game.log.Warn("bloom no disponible, usando sprites", "err", err)
+		if game.quality == config.QualityBloom {
+			game.quality = config.QualitySprites
+		}
+	} else {
+		game.bloom = bloom
+	}
+
+	// En reproducción el manager no genera nada propio: todo llega del archivo
+	if session.Replay != nil {
+		manager.EnablePlayback()
+	}
+
+	// La grabación empieza antes de Start para capturar las luciérnagas iniciales
+	if session.RecordPath != "" && session.Replay == nil {
+		recorder, err := newRecorder(session.RecordPath, manager)
+		if err != nil {
+			game.log.Error("no se pudo grabar la partida", "path", session.RecordPath, "err", err)
+		} else {
+			game.recorder = recorder
+			game.log.Info("grabando partida", "path", session.RecordPath)
+		}
 	}
 
 	// Iniciar manager (arranca todas las goroutines)
 	manager.Start()
+	if game.daily != nil {
+		game.setWind(game.daily.challenge.start)
+	}
+
+	// Sin escenario propio, el preset conduce la partida con sus eventos
+	scenario := session.Script
+	if scenario == nil && session.Preset != nil {
+		scenario = game.presetScript()
+	}
+	if session.Preset != nil && session.Preset.Election && session.Replay == nil {
+		go manager.SetElection(true)
+	}
+
+	if session.Replay != nil {
+		game.player = newPlayer(session.Replay, session.ReplaySpeed)
+		game.player.Start(manager)
+	} else if scenario != nil {
+		// El escenario usa la misma cola de comandos que el jugador
+		ctx, cancel := context.WithCancel(context.Background())
+		game.scriptCancel = cancel
+		game.scriptRunner = script.NewRunner(scenario)
+		game.scriptRunner.SetPrint(game.toasts.Push)
+		game.scriptRunner.Start(ctx, game.garden)
+	}
+
+	if game.api != nil {
+		game.api.Attach(game.garden)
+	}
+	if game.chat != nil {
+		game.chat.Attach(game.garden, func(msg string) { game.toasts.Push("💬 " + msg) })
+	}
+	if session.Demo && game.canDemo() {
+		game.demo = NewDemo(game.camera)
+	}
 
 	return game
 }
@@ -93,29 +421,211 @@ func (g *Game) Update() error {
 	dt := now.Sub(g.lastUpdateTime).Seconds()
 	g.lastUpdateTime = now
 
-	// Procesar input
-	g.processInput(dt)
+	// Procesar input: primero las barras de los paneles y los widgets, que
+	// se quedan con sus clicks
+	g.layout.Update(g.inputHandler, g.panels)
+	if tools := g.panels[panelTools]; g.layout.shown(tools) && !g.layout.Collapsed(tools.id) {
+		g.tools.Update(g.inputHandler, g.layout.view(tools).Local)
+	}
+	// Con la consola abierta el teclado es suyo
+	demo := g.updateDemo(dt)
+	if g.console.IsOpen() {
+		g.console.Update(g.inputHandler)
+	} else if !demo {
+		g.processInput(dt)
+	}
+	g.eventLog.Update(g.inputHandler)
+	// En bajo consumo los faroles no sueltan brasas
+	var embers []*core.Lantern
+	if !g.lowPower {
+		embers = g.manager.GetLanterns()
+	}
+	wind, simNow := g.manager.GetWindSnapshot(), g.manager.Clock().Now()
+	g.particles.Update(dt, wind, simNow, embers)
+	g.windOverlay.Update(dt, wind, simNow)
+	g.effects.Update(g.screenPan)
+	if g.gameState == config.GameStateGameOver {
+		return nil
+	}
 
 	// Actualizar lógica según estado del juego
 	if g.gameState == config.GameStateRunning {
 		g.updateGameLogic(dt)
 	}
 
-	// Actualizar contador de FPS
-	g.fpsCounter.Update()
+	// Los pulsos del diagrama avanzan aunque el juego esté en pausa: los
+	// canales siguen activos
+	if g.flowOverlay.IsVisible() {
+		g.flowOverlay.Update(g.manager.Flow(), dt)
+	}
+
+	if count := g.manager.GetFireflyCount(); count > g.peakFireflies {
+		g.peakFireflies = count
+	}
+
+	// Actualizar contador de FPS y ajustar calidad automáticamente; en bajo
+	// consumo los FPS bajan a propósito y no cuentan para el gobernador
+	fps := g.fpsCounter.Update()
+	g.manager.Stats().SetFPS(fps)
+	if !g.lowPower && g.governor.Update(fps) {
+		g.manager.SetSpawnCap(g.governor.SpawnCap())
+	}
+
+	// El estado para GET /state se copia aquí, en el mismo hilo que anima los faroles
+	if g.api != nil {
+		g.api.Refresh(g.garden)
+		g.announcePlayers()
+	}
 
 	return nil
 }
 
+// SetCompact oculta los paneles grandes cuando la ventana es chica
+func (g *Game) SetCompact(compact bool) {
+	g.compact = compact
+}
+
+// announcePlayers avisa cuando un jugador o espectador remoto entra o sale
+// del jardín
+func (g *Game) announcePlayers() {
+	players := g.api.Players()
+	switch {
+	case players > g.players:
+		g.toasts.Push(fmt.Sprintf("Se unió un jugador (%d conectados)", players))
+	case players < g.players:
+		g.toasts.Push(fmt.Sprintf("Un jugador salió (%d conectados)", players))
+	}
+	g.players = players
+
+	spectators := g.api.Spectators()
+	switch {
+	case spectators > g.spectators:
+		g.toasts.Push(fmt.Sprintf("Se conectó un espectador (%d mirando)", spectators))
+	case spectators < g.spectators:
+		g.toasts.Push(fmt.Sprintf("Un espectador se fue (%d mirando)", spectators))
+	}
+	g.spectators = spectators
+}
+
 // processInput procesa todos los inputs del usuario
 func (g *Game) processInput(dt float64) {
-	// Detectar tecla ESC para salir
-	if g.inputHandler.IsKeyJustPressed(ebiten.KeyEscape) {
-		return // El juego se cerrará
+	// Tecla ESC: terminar la partida y pasar al resumen
+	if g.inputHandler.IsActionJustPressed(input.ActionEndGame) {
+		g.gameState = config.GameStateGameOver
+		return
+	}
+
+	// Tecla `: consola de depuración
+	if g.inputHandler.IsActionJustPressed(input.ActionConsole) {
+		g.console.Toggle()
+		return
+	}
+
+	// Ctrl+S / Ctrl+O: guardar y cargar el jardín completo
+	if g.inputHandler.IsShortcutJustPressed(ebiten.KeyS) {
+		g.saveSnapshot()
+	}
+	if g.inputHandler.IsShortcutJustPressed(ebiten.KeyO) && g.player == nil {
+		g.loadSnapshot()
+	}
+
+	// Teclas 1/2/4: velocidad de la repetición
+	if g.player != nil {
+		for key, speed := range replaySpeedKeys {
+			if g.inputHandler.IsKeyJustPressed(key) {
+				g.setReplaySpeed(speed)
+			}
+		}
+	}
+
+	// Tecla F1: ocultar toda la interfaz (Ctrl+F1 devuelve los paneles a su
+	// lugar)
+	if g.inputHandler.IsActionJustPressed(input.ActionHideHUD) {
+		if g.inputHandler.IsCtrlPressed() {
+			g.layout.Reset()
+		} else {
+			g.layout.ToggleHidden()
+		}
+	}
+
+	// Tecla F12: captura de pantalla (Ctrl+F12, solo el jardín)
+	if g.inputHandler.IsActionJustPressed(input.ActionScreenshot) {
+		g.requestScreenshot(false)
+	} else if g.inputHandler.IsShortcutJustPressed(g.inputHandler.GetBindings()[input.ActionScreenshot]) {
+		g.requestScreenshot(true)
+	}
+
+	// Tecla M: modo musical
+	if g.inputHandler.IsActionJustPressed(input.ActionMusic) {
+		if g.music.Toggle() {
+			g.toasts.Push("Modo musical activado")
+		} else {
+			g.toasts.Push("Modo musical desactivado")
+		}
+	}
+
+	// Tecla U: modo rumor
+	if g.inputHandler.IsActionJustPressed(input.ActionGossip) {
+		g.toggleGossip()
+	}
+
+	// Tecla E: elección de líder
+	if g.inputHandler.IsActionJustPressed(input.ActionElection) {
+		g.toggleElection()
+	}
+
+	// Tecla F4: plegar/desplegar las gráficas
+	if g.inputHandler.IsActionJustPressed(input.ActionGraphs) {
+		g.graphPanel.Toggle()
+	}
+
+	// Tecla F5: capturar trace y perfil de CPU
+	if g.inputHandler.IsActionJustPressed(input.ActionProfile) {
+		g.captureProfile()
+	}
+
+	// Tecla Tab: registro de eventos
+	if g.inputHandler.IsActionJustPressed(input.ActionEventLog) {
+		g.eventLog.Toggle()
+	}
+
+	// Tecla F6: diagrama de flujo de canales
+	if g.inputHandler.IsActionJustPressed(input.ActionFlow) {
+		g.flowOverlay.Toggle()
+	}
+
+	// Tecla F9: exportar la serie de estadísticas de la sesión
+	if g.inputHandler.IsActionJustPressed(input.ActionStats) {
+		g.exportStats()
+	}
+
+	// Tecla F3: overlay de depuración
+	if g.inputHandler.IsActionJustPressed(input.ActionDebug) {
+		g.debugOverlay.Toggle()
+	}
+
+	// Tecla F8: tiempo de cada etapa de Draw
+	if g.inputHandler.IsActionJustPressed(input.ActionFrameTime) {
+		g.frameProfiler.Toggle()
+	}
+
+	// Tecla V: grabar la luciérnaga bajo el cursor (o borrar el fantasma)
+	if g.inputHandler.IsActionJustPressed(input.ActionGhost) {
+		g.toggleGhost()
+	}
+
+	// Tecla C: halos de los cúmulos
+	if g.inputHandler.IsActionJustPressed(input.ActionClusters) {
+		g.clusterOverlay.Toggle()
+	}
+
+	// Tecla Z: luciérnagas por zona
+	if g.inputHandler.IsActionJustPressed(input.ActionZones) {
+		g.zonePanel.Toggle()
 	}
 
 	// Detectar tecla P para pausar
-	if g.inputHandler.IsKeyJustPressed(ebiten.KeyP) {
+	if g.inputHandler.IsActionJustPressed(input.ActionPause) {
 		g.togglePause()
 	}
 
@@ -124,36 +634,133 @@ func (g *Game) processInput(dt float64) {
 		return
 	}
 
+	// Tecla F10: iniciar/cancelar foto de larga exposición
+	if g.inputHandler.IsActionJustPressed(input.ActionPhoto) {
+		if g.photoMode.IsActive() {
+			g.photoMode.Cancel()
+		} else {
+			g.photoMode.Start()
+		}
+	}
+
+	// Flechas y +/-: mover cámara y zoom
+	g.camera.Update(g.inputHandler, dt)
+
+	// En una repetición el jardín solo se observa: faroles, viento, atracción
+	// y ráfagas vienen del archivo
+	if g.player != nil {
+		return
+	}
+
+	// Tecla T: panel de herramientas
+	if g.inputHandler.IsActionJustPressed(input.ActionTools) {
+		g.tools.Toggle()
+	}
+
+	// Tecla F2: pintar el campo de fuerzas
+	if g.inputHandler.IsActionJustPressed(input.ActionFieldEditor) {
+		if g.fieldEditor.Toggle() {
+			g.toasts.Push("Campo de fuerzas: click atrae, click derecho repele")
+		} else {
+			g.toasts.Push("Campo de fuerzas guardado con el jardín")
+		}
+	}
+	if g.fieldEditor.Active() {
+		g.fieldEditor.Update(g.inputHandler, g.manager.ForceField(), g.cursorWorldPosition(), dt)
+	}
+
+	// Rueda del mouse: radio del próximo farol (o desplazar el registro si
+	// el cursor está encima). Pintando el campo cambia el pincel.
+	if _, wy := g.inputHandler.GetMouseWheel(); wy != 0 && !g.fieldEditor.Active() {
+		if p := g.inputHandler.Pointer(); g.overEventLog(p.X, p.Y) {
+			g.eventLog.Scroll(int(math.Round(wy)))
+		} else {
+			g.adjustLanternRadius(wy)
+		}
+	}
+
 	// Detectar tecla L para crear farol
-	if g.inputHandler.IsKeyJustPressed(ebiten.KeyL) {
-		mx, my := ebiten.CursorPosition()
-		g.createLantern(float64(mx), float64(my))
+	if g.inputHandler.IsActionJustPressed(input.ActionLantern) {
+		pos := g.cursorWorldPosition()
+		g.createLantern(pos.X, pos.Y)
+	}
+
+	// Tecla H: mostrar/ocultar mapa de calor
+	if g.inputHandler.IsActionJustPressed(input.ActionHeatmap) {
+		g.heatmap.Toggle()
 	}
 
-	// Detectar tecla W para cambiar viento
-	if g.inputHandler.IsKeyJustPressed(ebiten.KeyW) {
-		g.changeWind()
+	// Tecla G: alternar calidad del brillo (bloom / círculos)
+	if g.inputHandler.IsActionJustPressed(input.ActionQuality) {
+		g.cycleQuality()
+	}
+
+	// Detectar tecla W para cambiar viento (Shift+W: cómo se muestra)
+	if g.inputHandler.IsActionJustPressed(input.ActionWind) {
+		if g.inputHandler.IsKeyPressed(ebiten.KeyShift) {
+			g.toasts.Push("Vista del viento: " + windViewNames[g.windOverlay.Cycle()])
+		} else {
+			g.changeWind()
+		}
+	}
+
+	// Deslizar con dos dedos: el viento sopla hacia donde se deslizó
+	if dx, dy, ok := g.inputHandler.JustSwiped(); ok {
+		g.setWind(core.WindFromVector(dx, dy))
+	}
+
+	// En el frasco el click atrapa y R suelta lo atrapado
+	if g.jar != nil {
+		g.updateJar()
+	}
+
+	// Shift + arrastrar: elegir un grupo en lugar de atraer
+	if !g.fieldEditor.Active() {
+		g.updateSelection()
 	}
 
 	// Detectar click izquierdo para atraer luciérnagas
-	if g.inputHandler.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		mx, my := ebiten.CursorPosition()
-		g.setAttractionPoint(float64(mx), float64(my))
+	if g.inputHandler.Pointer().JustPressed && !g.selection.Dragging() && g.jar == nil && !g.fieldEditor.Active() {
+		pos := g.cursorWorldPosition()
+		g.setAttractionPoint(pos.X, pos.Y)
+	}
+
+	// Con el control el punto de atracción sigue al cursor virtual
+	if g.inputHandler.GamepadAttracting() && g.jar == nil {
+		pos := g.cursorWorldPosition()
+		if pos.Sub(g.attractionPoint).Magnitude() > 4 {
+			g.setAttractionPoint(pos.X, pos.Y)
+		}
 	}
 
 	// Tecla K: Spawn burst cerca del cursor (feedback inmediato)
-	if g.inputHandler.IsKeyJustPressed(ebiten.KeyK) {
+	if g.inputHandler.IsActionJustPressed(input.ActionBurst) {
 		// cooldown para evitar spam
 		if time.Since(g.lastPlayerSpawn) >= g.playerSpawnCooldown {
-			mx, my := ebiten.CursorPosition()
+			pos := g.cursorWorldPosition()
 			// spawn burst via manager (no bloqueante)
-			go g.manager.SpawnBurst(float64(mx), float64(my), config.SpawnBurstCount)
+			go g.manager.SpawnBurstPattern(pos.X, pos.Y, config.Get().Spawn.BurstCount, g.burstPattern)
 			g.lastPlayerSpawn = time.Now()
 		}
 	}
 
+	// Tecla F7: fuerzas de cada luciérnaga
+	if g.inputHandler.IsActionJustPressed(input.ActionForces) {
+		if g.forcesOverlay.Toggle() {
+			g.toasts.Push("Fuerzas: velocidad (blanca), viento (azul), faroles (ámbar), atracción (rosa), campo (verde)")
+		} else {
+			g.toasts.Push("Fuerzas ocultas")
+		}
+	}
+
+	// Tecla B: forma de la próxima ráfaga
+	if g.inputHandler.IsActionJustPressed(input.ActionBurstPattern) {
+		g.burstPattern = g.burstPattern.Next()
+		g.toasts.Push("Ráfaga: " + burstPatternNames[g.burstPattern])
+	}
+
 	// Si se suelta el botón, quitar atracción después de un tiempo
-	if !g.inputHandler.IsMouseButtonPressed(ebiten.MouseButtonLeft) && g.showAttraction {
+	if !g.inputHandler.Pointer().Pressed && g.showAttraction {
 		g.attractionPulse += dt * 3
 		if g.attractionPulse > 1.0 {
 			g.clearAttractionPoint()
@@ -173,60 +780,605 @@ func (g *Game) updateGameLogic(dt float64) {
 			g.attractionPulse = 0.0
 		}
 	}
+
+	g.ghost.Advance(dt)
+
+	// Se acabó el tiempo del frasco: pasar a los resultados
+	if g.jar != nil && g.jar.Tick(dt) {
+		g.gameState = config.GameStateGameOver
+	}
+
+	// Se superó el último nivel
+	if g.levels != nil && g.levels.Update(dt) {
+		g.gameState = config.GameStateGameOver
+	}
+
+	if g.survival != nil {
+		g.updateSurvival(dt)
+	}
+
+	if g.daily != nil {
+		g.updateDaily(dt)
+	}
+
+	// Se cumplió el último paso del tutorial
+	if g.tutorial != nil && g.tutorial.Update(g.inputHandler) {
+		g.gameState = config.GameStateGameOver
+	}
 }
 
 // Draw implementa ebiten.Game.Draw
 // Dibuja todos los elementos en pantalla
 func (g *Game) Draw(screen *ebiten.Image) {
+	g.fitScreen()
+
+	// F12: la captura se toma al terminar el frame; sin interfaz, apenas se
+	// proyecta el mundo
+	if g.screenshot != screenshotNone {
+		defer g.takeScreenshot(screen)
+	}
+
+	// Modo foto: solo la exposición acumulada, sin UI
+	if g.photoMode.IsActive() {
+		states := g.manager.GetFireflyStates()
+		g.photoMode.Expose(g.renderer, states)
+		g.manager.ReleaseStates(states)
+		g.photoMode.Draw(screen, g.renderer, g.camera)
+		return
+	}
+
+	// F8: cada etapa se cierra con Mark y el frame con End
+	g.frameProfiler.Begin()
+
 	// 1. Dibujar fondo
 	g.renderer.DrawBackground(screen)
 
-	// 2. Dibujar indicadores de viento
-	g.renderer.DrawWind(screen, g.manager.GetWind())
+	// El mundo se dibuja en una capa propia que luego se proyecta con la cámara
+	world := g.worldLayer
+	world.Clear()
+
+	// 1b. Terreno del mapa y mapa de calor debajo de todos los elementos
+	terrain := g.manager.Landscape().Terrain()
+	g.renderer.DrawTerrain(world, terrain, time.Now())
+	g.heatmap.Draw(world, g.manager.GetHeatmap())
+	g.frameProfiler.Mark(phaseBackground)
 
-	// 3. Dibujar faroles
+	// 2. Dibujar indicadores de viento; una sola foto para todo el frame
+	wind := g.manager.GetWindSnapshot()
+	if g.governor.WindEnabled() {
+		g.windOverlay.Draw(world, g.renderer, wind)
+	}
+
+	// 2b. Frente de tormenta, si está cruzando
+	now := g.manager.Clock().Now()
+	g.renderer.DrawStorm(world, wind.Storm, now)
+	g.frameProfiler.Mark(phaseWind)
+
+	// 3. Dibujar faroles; una tormenta los atenúa mientras cruza
 	lanterns := g.manager.GetLanterns()
+	dim := wind.LanternDim(now)
 	for _, lantern := range lanterns {
-		g.renderer.DrawLantern(screen, lantern)
+		g.renderer.DrawLantern(world, lantern, dim)
 	}
+	g.clusterOverlay.Draw(world, g.manager.GetClusters())
+	g.fieldEditor.Draw(world, g.manager.ForceField(), g.cursorWorldPosition())
+	g.frameProfiler.Mark(phaseLanterns)
+
+	// 4. Dibujar luciérnagas (snapshot thread-safe interpolado entre ticks)
+	fireflyStates := g.manager.GetInterpolatedStates(time.Now())
+	g.renderer.DrawPondReflections(world, terrain, fireflyStates)
+	g.drawFireflies(world, fireflyStates)
+	g.deathFades.Update(fireflyStates, time.Now())
+	g.deathFades.Draw(world, g.renderer, time.Now())
+	g.particles.Draw(world)
+	g.ghost.Observe(fireflyStates)
+	g.ghost.Draw(world)
+	g.forcesOverlay.Draw(world, fireflyStates, g.camera)
+	g.selection.Draw(world, fireflyStates)
 
-	// 4. Dibujar luciérnagas (obtener snapshot thread-safe)
-	fireflyStates := g.manager.GetFireflyStates()
-	for _, state := range fireflyStates {
-		g.renderer.DrawFirefly(screen, state)
+	// 4b. Murciélagos de las oleadas
+	for _, bat := range g.manager.GetBats() {
+		g.renderer.DrawBat(world, bat)
 	}
+	g.frameProfiler.Mark(phaseFireflies)
 
 	// 5. Dibujar punto de atracción si está activo
 	if g.showAttraction {
 		pulse := math.Abs(math.Sin(g.attractionPulse * math.Pi))
-		g.renderer.DrawAttractionPoint(screen, g.attractionPoint, pulse)
+		g.renderer.DrawAttractionPoint(world, g.attractionPoint, pulse)
+	}
+
+	// 5a. Vista previa del próximo farol mientras se ajusta su radio
+	if remaining := time.Until(g.previewUntil); remaining > 0 {
+		alpha := min(remaining.Seconds()/lanternPreviewFade.Seconds(), 1)
+		g.renderer.DrawLanternPreview(world, g.cursorWorldPosition(), g.nextLanternRadius(), alpha)
 	}
 
-	// 6. Dibujar HUD
+	// 5b. Plugins de dibujo sobre el mundo
+	frame := Frame{
+		Time:      time.Now(),
+		Fireflies: fireflyStates,
+		Lanterns:  lanterns,
+		Wind:      wind,
+		Camera:    g.camera,
+	}
+	for _, p := range g.plugins {
+		p.DrawWorld(world, frame)
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM = g.camera.GeoM()
+	op.Filter = ebiten.FilterLinear
+	screen.DrawImage(world, op)
+	g.frameProfiler.Mark(phaseProjection)
+	if g.screenshot == screenshotClean {
+		g.takeScreenshot(screen)
+	}
+
+	// 5c. IDs de la consola (label), ya en coordenadas de pantalla
+	g.idLabels.Draw(screen, g.uiRenderer, fireflyStates, g.camera)
+	g.ghost.DrawStatus(screen, g.uiRenderer)
+	g.fieldEditor.DrawStatus(screen, g.uiRenderer)
+
+	// 6. Paneles del HUD (HUD, gráficas, controles, registro, herramientas,
+	// puntaje y minimapa), cada uno donde lo dejó el usuario
 	fireflyCount := g.manager.GetFireflyCount()
-	lanternCount := len(lanterns)
-	wind := g.manager.GetWind()
-	fps := g.fpsCounter.currentFPS
-	isPaused := g.gameState == config.GameStatePaused
+	g.minimap.Update(fireflyStates)
+	g.music.Update(fireflyStates, g.screenPan)
+	g.hudFrame = frame
+	g.layout.Draw(screen, g.uiRenderer, g.panels)
+	g.hudFrame = Frame{}
+
+	// Con la interfaz oculta solo queda el jardín (y la pausa)
+	if g.layout.Hidden() {
+		g.manager.ReleaseStates(fireflyStates)
+		if g.jar != nil {
+			p := g.inputHandler.Pointer()
+			g.jar.DrawCursor(screen, float32(p.X), float32(p.Y))
+		}
+		if g.gameState == config.GameStatePaused {
+			g.uiRenderer.DrawPauseOverlay(screen)
+		}
+		g.frameProfiler.Mark(phaseHUD)
+		g.frameProfiler.End()
+		return
+	}
 
-	g.uiRenderer.DrawHUD(screen, fireflyCount, lanternCount, wind, fps, isPaused)
+	// 7b. Ayuda de las órdenes de grupo
+	if g.selection.Len() > 0 {
+		_, sh := config.ScreenSize()
+		g.uiRenderer.drawText(screen, g.selection.Status(), 20, float64(sh-config.MinimapHeight-40), color.RGBA{R: 120, G: 220, B: 255, A: 255})
+	}
+
+	// 7b'. Tutorial, desafío diario o supervivencia: el panel de arriba al centro
+	if g.tutorial != nil {
+		g.tutorial.Draw(screen, g.uiRenderer, g.layout.view(g.panels[panelHUD]).at)
+	}
+	if g.daily != nil {
+		g.daily.DrawPanel(screen, g.uiRenderer, g.manager.Score().State().Points)
+	}
+	if g.survival != nil {
+		g.survival.DrawPanel(screen, g.uiRenderer, fireflyCount)
+	}
+
+	// Rumor y elección de líder: sus carteles se apilan debajo
+	bannerY := float32(80)
+	if status := g.manager.GetGossipStatus(); status.Active {
+		drawGossipPanel(screen, g.uiRenderer, status, bannerY)
+		bannerY += 34
+	}
+	if status := g.manager.GetElectionStatus(); status.Active {
+		drawElectionPanel(screen, g.uiRenderer, status, bannerY)
+	}
+
+	// 7c. Minijuego del frasco: tiempo, puntaje y el frasco como cursor
+	if g.jar != nil {
+		g.jar.DrawPanel(screen, g.uiRenderer)
+		p := g.inputHandler.Pointer()
+		g.jar.DrawCursor(screen, float32(p.X), float32(p.Y))
+	}
+
+	// 8. Dibujar panel de objetivos (o el del nivel en curso)
+	if g.levels != nil {
+		g.levels.DrawPanel(screen, g.uiRenderer, fireflyCount)
+	} else {
+		g.uiRenderer.DrawObjectivePanel(screen, fireflyCount, g.manager.GetObjective())
+	}
 
-	// 7. Dibujar controles
-	g.uiRenderer.DrawControls(screen)
+	// 8b. Plugins de dibujo sobre el HUD
+	for _, p := range g.plugins {
+		p.DrawOverlay(screen, frame)
+	}
+
+	g.manager.ReleaseStates(fireflyStates)
+
+	// 10. Overlay de depuración
+	if g.debugOverlay.IsVisible() {
+		g.debugOverlay.Draw(screen, g.uiRenderer, g.cullStats, g.camera, g.manager.Internals())
+	}
+
+	// 10b. Diagrama de flujo de canales
+	if g.flowOverlay.IsVisible() {
+		g.flowOverlay.Draw(screen, g.uiRenderer, g.manager.Flow())
+	}
+
+	// 10c. Avisos y consola
+	g.toasts.Draw(screen, g.uiRenderer)
+	g.console.Draw(screen, g.uiRenderer)
+
+	// 10d. Cursor y cartel de la demostración
+	if g.demo != nil {
+		g.demo.Draw(screen, g.uiRenderer, g.camera)
+	}
+
+	// 11. Estado de la repetición
+	if g.player != nil {
+		elapsed, total := g.player.Progress()
+		g.uiRenderer.DrawReplayBanner(screen, g.player.Speed(), elapsed, total, g.replayFinished())
+	}
 
-	// 8. Dibujar panel de objetivos
-	g.uiRenderer.DrawObjectivePanel(screen, fireflyCount)
+	// 11b. Cartel entre niveles
+	if g.levels != nil {
+		g.levels.DrawTransition(screen, g.uiRenderer)
+	}
 
-	// 9. Dibujar overlay de pausa si está pausado
+	// 12. Dibujar overlay de pausa si está pausado
 	if g.gameState == config.GameStatePaused {
 		g.uiRenderer.DrawPauseOverlay(screen)
 	}
+	g.frameProfiler.Mark(phaseHUD)
+	g.frameProfiler.End()
+
+	// 13. Perfilador de Draw, fuera de lo que mide
+	g.frameProfiler.Draw(screen, g.uiRenderer)
+}
+
+// Índices de los paneles en g.panels, en el orden en que se dibujan
+const (
+	panelHUD = iota
+	panelGraphs
+	panelControls
+	panelEvents
+	panelTools
+	panelScore
+	panelMinimap
+	panelZones
+)
+
+// hudPanels arma los paneles movibles del HUD. Leen el estado del juego al
+// dibujarse; lo del frame en curso (faroles, viento) sale de g.hudFrame.
+func (g *Game) hudPanels() []hudPanel {
+	return []hudPanel{
+		panelHUD: {
+			id:     "hud",
+			title:  "JARDÍN",
+			bounds: func() Rect { return Rect{X: 10, Y: 10, W: 300, H: 198} },
+			draw: func(screen *ebiten.Image) {
+				isPaused := g.gameState == config.GameStatePaused
+				g.uiRenderer.DrawHUD(screen, g.manager.GetFireflyCount(), len(g.hudFrame.Lanterns), g.manager.GetObjective(), g.hudFrame.Wind, g.fpsCounter.currentFPS, g.governor.TierName(), g.manager.GetClusters(), isPaused)
+			},
+		},
+		panelGraphs: {
+			id:     "graphs",
+			title:  "GRÁFICAS",
+			bounds: g.graphPanel.Bounds,
+			toggle: g.graphPanel.Toggle,
+			draw: func(screen *ebiten.Image) {
+				g.graphPanel.Draw(screen, g.uiRenderer, g.manager.Stats().Recent(GraphWindow))
+			},
+		},
+		// Los controles no se muestran en modo compacto ni al jugar con la
+		// pantalla táctil, que usa la barra de botones; el registro de
+		// eventos ocupa su lugar mientras está abierto
+		panelControls: {
+			id:    "controls",
+			title: "CONTROLES",
+			bounds: func() Rect {
+				sw, _ := config.ScreenSize()
+				return Rect{X: float32(sw - 320), Y: 10, W: 300, H: 22 * 25}
+			},
+			visible: func() bool {
+				return !g.eventLog.IsVisible() && !g.compact && !g.inputHandler.TouchUsed()
+			},
+			draw: g.uiRenderer.DrawControls,
+		},
+		panelEvents: {
+			id:      "events",
+			title:   "EVENTOS",
+			bounds:  g.eventLog.Bounds,
+			visible: g.eventLog.IsVisible,
+			draw: func(screen *ebiten.Image) {
+				g.eventLog.Draw(screen, g.uiRenderer)
+			},
+		},
+		panelTools: {
+			id:      "tools",
+			title:   "HERRAMIENTAS",
+			bounds:  g.tools.Bounds,
+			visible: g.tools.IsVisible,
+			draw: func(screen *ebiten.Image) {
+				g.tools.Draw(screen, g.uiRenderer)
+			},
+		},
+		panelScore: {
+			id:    "score",
+			title: "PUNTAJE",
+			bounds: func() Rect {
+				sw, sh := config.ScreenSize()
+				return Rect{X: float32(sw - 240), Y: float32(sh - scorePanelHeight - 10), W: 230, H: scorePanelHeight}
+			},
+			draw: func(screen *ebiten.Image) {
+				g.uiRenderer.DrawScore(screen, g.manager.Score().State(), g.manager.GetGlow())
+			},
+		},
+		panelMinimap: {
+			id:    "minimap",
+			title: "MINIMAPA",
+			bounds: func() Rect {
+				_, sh := config.ScreenSize()
+				return Rect{X: 10, Y: float32(sh - config.MinimapHeight - 10), W: config.MinimapWidth, H: config.MinimapHeight}
+			},
+			draw: func(screen *ebiten.Image) {
+				g.minimap.Draw(screen, g.hudFrame.Lanterns, g.camera)
+			},
+		},
+		// Las zonas se abren con Z y solas mientras la meta del nivel es
+		// una zona
+		panelZones: {
+			id:     "zones",
+			title:  "ZONAS",
+			bounds: g.zonePanel.Bounds,
+			visible: func() bool {
+				_, levelZone := g.levelZone()
+				return g.zonePanel.IsVisible() || levelZone
+			},
+			draw: func(screen *ebiten.Image) {
+				highlight, ok := g.levelZone()
+				if !ok {
+					highlight = -1
+				}
+				g.zonePanel.Draw(screen, g.uiRenderer, g.manager.GetZoneStats(), highlight)
+			},
+		},
+	}
+}
+
+// levelZone retorna la zona de la meta del nivel en curso, si la hay
+func (g *Game) levelZone() (int, bool) {
+	if g.levels == nil {
+		return 0, false
+	}
+	return g.levels.TargetZone()
+}
+
+// screenPan ubica un punto del mundo en el ancho de la pantalla, de -1
+// (borde izquierdo) a 1 (derecho), para el paneo de los sonidos
+func (g *Game) screenPan(p utils.Vector2D) float64 {
+	sw, _ := config.ScreenSize()
+	return utils.Clamp(g.camera.WorldToScreen(p).X/float64(sw)*2-1, -1, 1)
+}
+
+// overEventLog indica si el punto de pantalla cae sobre el registro de
+// eventos abierto, donde sea que esté
+func (g *Game) overEventLog(x, y int) bool {
+	panel := g.panels[panelEvents]
+	if !g.layout.shown(panel) || g.layout.Collapsed(panel.id) {
+		return false
+	}
+	return g.layout.view(panel).at.Contains(x, y)
 }
 
 // Layout implementa ebiten.Game.Layout
-// Define el tamaño lógico de la pantalla
+// Define el tamaño lógico de la pantalla, que sigue a la ventana
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return config.ScreenWidth, config.ScreenHeight
+	width, height := screenLayout(outsideWidth, outsideHeight)
+	config.SetScreenSize(width, height)
+	return width, height
+}
+
+// crowdBatchThreshold es desde cuántas luciérnagas los círculos también se
+// dibujan en lote: miles de trazos vectoriales por frame no llegan a 60 FPS
+const crowdBatchThreshold = 1500
+
+// drawFireflies dibuja las luciérnagas según la calidad de render activa
+// Sprites y bloom agrupan todas las luciérnagas en un único DrawTriangles32,
+// y los círculos también cuando son una multitud (crowdBatchThreshold).
+// Las luciérnagas fuera de la vista se descartan y las tenues pierden sus halos.
+func (g *Game) drawFireflies(world *ebiten.Image, states []core.FireflyState) {
+	g.cullStats = CullStats{}
+	quality := g.quality
+	if quality > g.governor.MaxQuality() {
+		quality = g.governor.MaxQuality()
+	}
+	if g.lowPower {
+		quality = config.QualityCircles
+	}
+	bloom := quality == config.QualityBloom && g.bloom != nil
+	batched := quality != config.QualityCircles || len(states) > crowdBatchThreshold
+
+	if batched {
+		g.fireflyBatch.Begin()
+	}
+
+	for _, state := range states {
+		visible, withHalo := fireflyLOD(state, g.camera)
+		withHalo = withHalo && g.governor.HalosEnabled() && !g.lowPower
+		if !visible {
+			g.cullStats.Culled++
+			continue
+		}
+		g.cullStats.Visible++
+		if !withHalo {
+			g.cullStats.NoHalo++
+		}
+
+		switch {
+		case !batched && withHalo:
+			g.renderer.DrawFirefly(world, state)
+		case !batched:
+			g.renderer.DrawFireflyCore(world, state)
+		default:
+			g.fireflyBatch.AddFirefly(state, withHalo && !bloom)
+		}
+	}
+
+	switch {
+	case bloom:
+		g.fireflyLayer.Clear()
+		g.fireflyBatch.Flush(g.fireflyLayer)
+		world.DrawImage(g.fireflyLayer, nil)
+		g.bloom.Apply(world, g.fireflyLayer)
+	case batched:
+		g.fireflyBatch.Flush(world)
+	}
+}
+
+// cycleQuality alterna entre círculos, sprites y bloom
+func (g *Game) cycleQuality() {
+	g.quality = (g.quality + 1) % (config.QualityBloom + 1)
+	if g.quality == config.QualityBloom && g.bloom == nil {
+		g.quality = config.QualityCircles
+	}
+}
+
+// newRecorder abre el archivo de grabación y se suscribe a los eventos del manager
+func newRecorder(path string, fm *manager.FireflyManager) (*manager.Recorder, error) {
+	recorder, err := manager.NewRecorder(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := recorder.Start(fm); err != nil {
+		recorder.Stop()
+		return nil, err
+	}
+	return recorder, nil
+}
+
+// newPlayer prepara la reproducción a la velocidad inicial pedida
+func newPlayer(replay *manager.Replay, speed int) *manager.Player {
+	player := manager.NewPlayer(replay)
+	player.SetSpeed(speed)
+	return player
+}
+
+// setReplaySpeed cambia la velocidad fuera del hilo de render: relanzar las
+// luciérnagas con el nuevo paso puede tardar hasta un tick
+func (g *Game) setReplaySpeed(speed int) {
+	go g.player.SetSpeed(speed)
+}
+
+// replayFinished indica si ya se enviaron todos los eventos de la repetición
+func (g *Game) replayFinished() bool {
+	select {
+	case <-g.player.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// captureProfile graba runtime/trace y un perfil de CPU durante unos segundos
+// en su propia goroutine y avisa en pantalla dónde quedaron
+func (g *Game) captureProfile() {
+	if profiling.IsCapturing() {
+		g.toasts.Warn("Ya hay una captura de perfil en curso")
+		return
+	}
+
+	dir := config.Get().Capture.ProfileDir
+	g.toasts.Push(fmt.Sprintf("Capturando trace y CPU durante %v...", profiling.CaptureDuration))
+	go func() {
+		res, err := profiling.Capture(dir, profiling.CaptureDuration)
+		if err != nil {
+			g.log.Error("captura de perfil incompleta", "trace", res.TracePath, "err", err)
+			if res.TracePath != "" {
+				g.toasts.Push("Trace guardado en " + res.TracePath + " (sin perfil de CPU)")
+			} else {
+				g.toasts.Warn("No se pudo capturar el perfil: " + err.Error())
+			}
+			return
+		}
+		g.log.Info("perfil guardado", "trace", res.TracePath, "cpu", res.CPUPath)
+		g.toasts.Push("Trace: " + res.TracePath + "  CPU: " + res.CPUPath)
+	}()
+}
+
+// exportStats escribe las métricas por segundo en capture.stats_file
+// (CSV, o JSONL si la extensión es .jsonl)
+func (g *Game) exportStats() {
+	path := config.Get().Capture.StatsFile
+	samples := g.manager.Stats().Samples()
+	go func() {
+		if err := manager.WriteStats(path, samples); err != nil {
+			g.log.Error("no se pudieron exportar las estadísticas", "path", path, "err", err)
+			g.toasts.Warn("No se pudieron exportar las estadísticas: " + err.Error())
+			return
+		}
+		g.log.Info("estadísticas exportadas", "path", path, "samples", len(samples))
+		g.toasts.Push("Estadísticas exportadas en " + path)
+	}()
+}
+
+// saveSnapshot guarda el jardín fuera del hilo de render: detener las
+// luciérnagas para copiar su estado puede tardar hasta un tick
+func (g *Game) saveSnapshot() {
+	path := config.Get().Capture.SnapshotFile
+	go func() {
+		if err := manager.WriteSnapshot(path, g.manager.SaveSnapshot()); err != nil {
+			g.log.Error("no se pudo guardar el jardín", "path", path, "err", err)
+			g.toasts.Warn("No se pudo guardar el jardín: " + err.Error())
+			return
+		}
+		g.log.Info("jardín guardado", "path", path)
+		g.toasts.Push("Jardín guardado en " + path)
+	}()
+}
+
+// loadSnapshot restaura el último jardín guardado con Ctrl+S
+func (g *Game) loadSnapshot() {
+	path := config.Get().Capture.SnapshotFile
+	go func() {
+		snap, err := manager.ReadSnapshot(path)
+		if err != nil {
+			g.log.Error("no se pudo cargar el jardín", "path", path, "err", err)
+			g.toasts.Warn("No se pudo cargar el jardín: " + err.Error())
+			return
+		}
+		g.manager.RestoreSnapshot(snap)
+		g.log.Info("jardín restaurado", "path", path, "fireflies", len(snap.Fireflies), "lanterns", len(snap.Lanterns))
+		g.toasts.Push("Jardín restaurado desde " + path)
+	}()
+}
+
+// Quality retorna la calidad de render activa
+func (g *Game) Quality() int {
+	return g.quality
+}
+
+// Settings retorna los parámetros vigentes del manager (pueden venir de una recarga)
+func (g *Game) Settings() manager.Settings {
+	return g.manager.GetSettings()
+}
+
+// ApplySettings aplica en caliente los parámetros de la pantalla de configuración
+func (g *Game) ApplySettings(settings manager.Settings, quality int) {
+	g.manager.UpdateSettings(settings)
+
+	g.quality = quality
+	if g.quality == config.QualityBloom && g.bloom == nil {
+		g.quality = config.QualitySprites
+	}
+}
+
+// Resume reinicia el reloj al volver de otra escena para evitar un dt enorme
+func (g *Game) Resume() {
+	g.lastUpdateTime = time.Now()
+}
+
+// cursorWorldPosition retorna la posición del cursor en coordenadas del mundo
+func (g *Game) cursorWorldPosition() utils.Vector2D {
+	p := g.inputHandler.Pointer()
+	return g.camera.ScreenToWorld(float64(p.X), float64(p.Y))
 }
 
 // togglePause alterna entre pausado y corriendo
@@ -239,12 +1391,147 @@ func (g *Game) togglePause() {
 	}
 }
 
+// updateJar atrapa la luciérnaga más cercana al click y suelta el frasco
+// con R como una ráfaga bajo el cursor
+func (g *Game) updateJar() {
+	h := g.inputHandler
+	if g.gameState != config.GameStateRunning {
+		return
+	}
+
+	if h.Pointer().JustPressed {
+		pos := g.cursorWorldPosition()
+		states := g.manager.GetFireflyStates()
+		id, ok := g.jar.nearest(states, pos)
+		g.manager.ReleaseStates(states)
+		if ok && g.manager.CaptureFirefly(id) {
+			g.jar.add()
+		}
+	}
+
+	if h.IsActionJustPressed(input.ActionJarRelease) {
+		if n := g.jar.empty(); n > 0 {
+			pos := g.cursorWorldPosition()
+			go g.manager.SpawnBurst(pos.X, pos.Y, n)
+		}
+	}
+}
+
+// toggleGhost borra el fantasma o la grabación en curso; si no hay, empieza
+// a grabar la luciérnaga más cercana al cursor
+func (g *Game) toggleGhost() {
+	if g.ghost.Active() {
+		g.ghost.Clear()
+		g.toasts.Push("Fantasma borrado")
+		return
+	}
+
+	states := g.manager.GetFireflyStates()
+	id, ok := g.ghost.nearest(states, g.cursorWorldPosition())
+	g.manager.ReleaseStates(states)
+	if !ok {
+		g.toasts.Push("No hay ninguna luciérnaga bajo el cursor")
+		return
+	}
+	g.ghost.Mark(id)
+	g.toasts.Push(fmt.Sprintf("Grabando la luciérnaga %d durante 30 s", id))
+}
+
+// updateSurvival suelta las oleadas a tiempo y termina la partida cuando
+// no queda ninguna luciérnaga. Se cuenta en el mundo y no en el agregador,
+// que recién se entera un tick después.
+func (g *Game) updateSurvival(dt float64) {
+	if bats := g.survival.Tick(dt); bats > 0 {
+		g.manager.LaunchBatWave(bats)
+	}
+
+	if g.manager.GetWorld().Count(core.KindFirefly) == 0 {
+		g.extinct = true
+		g.gameState = config.GameStateGameOver
+	}
+}
+
+// updateDaily aplica el calendario del desafío: cambios de viento, oleadas
+// y el final cuando se acaba el tiempo
+func (g *Game) updateDaily(dt float64) {
+	step := g.daily.Tick(dt)
+	if step.setWind {
+		g.setWind(step.wind)
+	}
+	if step.bats > 0 {
+		g.manager.LaunchBatWave(step.bats)
+	}
+	if step.over {
+		g.gameState = config.GameStateGameOver
+	}
+}
+
+// updateSelection maneja el rectángulo de selección y las órdenes al grupo
+func (g *Game) updateSelection() {
+	h := g.inputHandler
+	if g.jar != nil {
+		return
+	}
+	pointer := h.Pointer()
+
+	switch {
+	case g.selection.Dragging():
+		g.selection.Drag(g.cursorWorldPosition())
+		if !pointer.Pressed {
+			states := g.manager.GetFireflyStates()
+			g.selection.End(states)
+			g.manager.ReleaseStates(states)
+		}
+	case pointer.JustPressed && h.IsKeyPressed(ebiten.KeyShift):
+		g.selection.Begin(g.cursorWorldPosition())
+	}
+
+	if g.selection.Len() == 0 {
+		return
+	}
+	if h.IsActionJustPressed(input.ActionGroupAttract) || h.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		g.sendGroupOrder(manager.GroupAttract)
+	}
+	if h.IsActionJustPressed(input.ActionGroupFreeze) {
+		g.sendGroupOrder(manager.GroupFreeze)
+	}
+	if h.IsActionJustPressed(input.ActionGroupRelease) {
+		g.sendGroupOrder(manager.GroupRelease)
+	}
+}
+
+// sendGroupOrder envía la orden a las seleccionadas, atrayéndolas al cursor
+func (g *Game) sendGroupOrder(kind manager.GroupOrderKind) {
+	cmd := manager.Command{
+		Type: manager.CommandGroupOrder,
+		Data: g.selection.Order(kind, g.cursorWorldPosition()),
+	}
+
+	g.manager.Send(cmd)
+}
+
+// nextLanternRadius retorna el radio con el que se colocará el próximo farol
+func (g *Game) nextLanternRadius() float64 {
+	if g.lanternRadius > 0 {
+		return g.lanternRadius
+	}
+	return g.manager.GetSettings().LanternRadius
+}
+
+// adjustLanternRadius cambia el radio del próximo farol con la rueda
+func (g *Game) adjustLanternRadius(wheel float64) {
+	radius := g.nextLanternRadius() + wheel*lanternRadiusStep
+	g.lanternRadius = utils.Clamp(radius, config.LanternRadiusMin, config.LanternRadiusMax)
+	g.previewUntil = time.Now().Add(lanternPreviewDuration)
+}
+
 // createLantern crea un nuevo farol en la posición especificada
 func (g *Game) createLantern(x, y float64) {
-	success := g.manager.AddLantern(x, y)
-	if !success {
-		// Podríamos mostrar un mensaje de que se alcanzó el límite
+	// Si se alcanzó el límite el manager lo avisa
+	if !g.manager.AddLanternWithRadius(x, y, g.nextLanternRadius()) {
+		return
 	}
+	g.lanternsPlaced++
 }
 
 // changeWind cambia la dirección del viento
@@ -253,12 +1540,17 @@ func (g *Game) changeWind() {
 		Type: manager.CommandUpdateWind,
 	}
 
-	// Envío non-blocking
-	select {
-	case g.manager.GetCommandChannel() <- cmd:
-	default:
-		// Canal lleno, ignorar
+	g.manager.Send(cmd)
+}
+
+// setWind fija la dirección del viento
+func (g *Game) setWind(dir core.WindDirection) {
+	cmd := manager.Command{
+		Type: manager.CommandSetWind,
+		Data: dir,
 	}
+
+	g.manager.Send(cmd)
 }
 
 // setAttractionPoint establece un punto de atracción para las luciérnagas
@@ -272,12 +1564,7 @@ func (g *Game) setAttractionPoint(x, y float64) {
 		Data: g.attractionPoint,
 	}
 
-	// Envío non-blocking
-	select {
-	case g.manager.GetCommandChannel() <- cmd:
-	default:
-		// Canal lleno, ignorar
-	}
+	g.manager.Send(cmd)
 }
 
 // clearAttractionPoint elimina el punto de atracción
@@ -288,15 +1575,101 @@ func (g *Game) clearAttractionPoint() {
 		Type: manager.CommandClearAttraction,
 	}
 
-	// Envío non-blocking
-	select {
-	case g.manager.GetCommandChannel() <- cmd:
-	default:
-		// Canal lleno, ignorar
+	g.manager.Send(cmd)
+}
+
+// IsFinished indica si la partida terminó
+func (g *Game) IsFinished() bool {
+	return g.gameState == config.GameStateGameOver
+}
+
+// Summary retorna las estadísticas de la partida
+func (g *Game) Summary() SessionSummary {
+	summary := SessionSummary{
+		Duration:       time.Since(g.sessionStart),
+		PeakFireflies:  g.peakFireflies,
+		FinalFireflies: g.manager.GetFireflyCount(),
+		LanternsPlaced: g.lanternsPlaced,
+		DroppedStates:  g.manager.GetDroppedStates(),
+		Mode:           g.mode,
+		Captured:       g.captured(),
+		Score:          g.manager.Score().State(),
+		Seed:           g.seed,
+		Replay:         g.player != nil,
+	}
+	if g.preset != nil {
+		summary.Preset = g.preset.Name
+	}
+	if g.survival != nil {
+		summary.Duration = g.survival.Elapsed()
+		summary.Waves = g.survival.Waves()
+		summary.Extinct = g.extinct
+	}
+	if g.daily != nil {
+		summary.Duration = min(g.daily.elapsed, dailyDuration)
+		summary.Challenge = g.daily.challenge.Date
+		summary.DailyBest = g.daily.best
+	}
+	if g.tutorial != nil {
+		summary.TutorialDone = g.tutorial.step == len(tutorialSteps)
 	}
+	if g.levels != nil {
+		summary.Levels = g.levels.Completed()
+		summary.LevelsTotal = g.levels.Total()
+	}
+	return summary
+}
+
+// captured retorna el puntaje del frasco (0 en otros modos)
+func (g *Game) captured() int {
+	if g.jar == nil {
+		return 0
+	}
+	return g.jar.Captured()
 }
 
 // Shutdown detiene el juego y todas sus goroutines de forma limpia
 func (g *Game) Shutdown() {
+	if g.scriptRunner != nil {
+		g.scriptCancel()
+		<-g.scriptRunner.Done()
+		g.scriptRunner = nil
+	}
+	if g.api != nil {
+		g.api.Attach(nil)
+	}
+	if g.chat != nil {
+		g.chat.Attach(nil, nil)
+	}
+	if g.jar != nil {
+		ebiten.SetCursorMode(ebiten.CursorModeVisible)
+	}
+	if g.tutorial != nil {
+		g.tutorial.Close()
+	}
+	g.eventLog.Close()
+	g.particles.Close()
+	g.effects.Close()
+	g.mixer.Close()
+	g.music.Close()
+
 	g.manager.Stop()
+
+	if capture := config.Get().Capture; capture.StatsOnExit {
+		samples := g.manager.Stats().Samples()
+		if err := manager.WriteStats(capture.StatsFile, samples); err != nil {
+			g.log.Error("no se pudieron exportar las estadísticas", "path", capture.StatsFile, "err", err)
+		} else {
+			g.log.Info("estadísticas exportadas", "path", capture.StatsFile, "samples", len(samples))
+		}
+	}
+
+	if g.recorder != nil {
+		count, err := g.recorder.Stop()
+		if err != nil {
+			g.log.Error("error al cerrar la grabación", "err", err)
+		}
+		g.log.Info("grabación cerrada", "events", count)
+		g.recorder = nil
+	}
 }
\ No newline at end of file
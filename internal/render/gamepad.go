@@ -0,0 +1,79 @@
+package render
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/input"
+)
+
+// gamepadNoticeDuration es cuánto se muestra el aviso de conexión
+const gamepadNoticeDuration = 3 * time.Second
+
+// GamepadIndicator muestra arriba al centro qué control está activo y avisa
+// cuando se conecta o desconecta uno. En el jardín también dibuja el cursor
+// virtual que mueve la palanca.
+type GamepadIndicator struct {
+	notice  string
+	expires time.Time
+}
+
+// NewGamepadIndicator crea el indicador sin control
+func NewGamepadIndicator() *GamepadIndicator {
+	return &GamepadIndicator{}
+}
+
+// Update registra las conexiones y desconexiones del frame
+func (g *GamepadIndicator) Update(h *input.Handler) {
+	name, connected, ok := h.JustPluggedGamepad()
+	if !ok {
+		return
+	}
+	if connected {
+		g.notice = "🎮 Control conectado: " + name
+	} else {
+		g.notice = "🎮 Control desconectado: " + name
+	}
+	g.expires = time.Now().Add(gamepadNoticeDuration)
+}
+
+// Draw dibuja el indicador y, si cursor es true y se usa el control, el
+// cursor virtual
+func (g *GamepadIndicator) Draw(screen *ebiten.Image, ui *UIRenderer, h *input.Handler, cursor bool) {
+	sw, _ := config.ScreenSize()
+	label := g.notice
+	highlight := time.Now().Before(g.expires)
+	if !highlight {
+		name, ok := h.GamepadName()
+		if !ok {
+			return
+		}
+		label = "🎮 " + name
+	}
+
+	width := text.Advance(label, ui.fontFace) + 24
+	x := (float64(sw) - width) / 2
+	border := color.RGBA{R: 90, G: 110, B: 140, A: 200}
+	if highlight {
+		border = color.RGBA{R: 150, G: 220, B: 150, A: 255}
+	}
+	vector.DrawFilledRect(screen, float32(x), 10, float32(width), 26, color.RGBA{R: 0, G: 0, B: 0, A: 150}, false)
+	vector.StrokeRect(screen, float32(x), 10, float32(width), 26, 1, border, false)
+	ui.drawText(screen, label, x+12, 14, color.RGBA{R: 220, G: 230, B: 240, A: 255})
+
+	p := h.Pointer()
+	if !cursor || p.Source != input.PointerGamepad {
+		return
+	}
+	cx, cy := float32(p.X), float32(p.Y)
+	cursorColor := color.RGBA{R: 200, G: 240, B: 255, A: 220}
+	vector.StrokeCircle(screen, cx, cy, 10, 1.5, cursorColor, true)
+	vector.StrokeLine(screen, cx-16, cy, cx-6, cy, 1.5, cursorColor, true)
+	vector.StrokeLine(screen, cx+6, cy, cx+16, cy, 1.5, cursorColor, true)
+	vector.StrokeLine(screen, cx, cy-16, cx, cy-6, 1.5, cursorColor, true)
+	vector.StrokeLine(screen, cx, cy+6, cx, cy+16, 1.5, cursorColor, true)
+}
@@ -0,0 +1,230 @@
+package render
+
+import (
+	"errors"
+	"image/color"
+	"strconv"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// ghostDuration es cuánto se graba el recorrido de la luciérnaga marcada
+	ghostDuration = 30 * time.Second
+	// ghostPickRadius es la distancia máxima al cursor para marcar una
+	ghostPickRadius = 30.0
+	// ghostTail es el tramo del recorrido que arrastra el fantasma
+	ghostTail = 2 * time.Second
+)
+
+var (
+	ghostColor     = color.RGBA{R: 150, G: 200, B: 255, A: 255}
+	recordingColor = color.RGBA{R: 255, G: 90, B: 90, A: 220}
+)
+
+// ghostSample es un punto del recorrido grabado
+type ghostSample struct {
+	at         time.Duration
+	pos        utils.Vector2D
+	brightness float64
+}
+
+// GhostTrail graba 30 segundos del recorrido de una luciérnaga marcada
+// (tecla V sobre ella o "ghost 12" en la consola) y después lo repite en
+// bucle como un fantasma translúcido sobre la simulación en vivo, para
+// comparar cómo se mueve antes y después de cambiar un parámetro. El
+// tiempo es el del juego: la pausa detiene la grabación y el fantasma.
+type GhostTrail struct {
+	id        int
+	recording bool
+	// elapsed es el tiempo de juego desde que se marcó la luciérnaga; al
+	// terminar la grabación vuelve a cero y cuenta la repetición
+	elapsed time.Duration
+	samples []ghostSample
+}
+
+func NewGhostTrail() *GhostTrail {
+	return &GhostTrail{}
+}
+
+// Mark empieza a grabar la luciérnaga id, descartando el fantasma anterior
+func (g *GhostTrail) Mark(id int) {
+	g.id = id
+	g.recording = true
+	g.elapsed = 0
+	g.samples = g.samples[:0]
+}
+
+// Clear borra la grabación o el fantasma
+func (g *GhostTrail) Clear() {
+	g.recording = false
+	g.samples = g.samples[:0]
+}
+
+// Active indica si se está grabando o hay un fantasma
+func (g *GhostTrail) Active() bool {
+	return g.recording || len(g.samples) > 1
+}
+
+// Advance avanza el tiempo de juego
+func (g *GhostTrail) Advance(dt float64) {
+	if g.Active() {
+		g.elapsed += time.Duration(dt * float64(time.Second))
+	}
+}
+
+// Observe anota la posición de la luciérnaga marcada mientras se graba.
+// Si la luciérnaga muere antes de los 30 segundos, el fantasma es lo que
+// se llegó a grabar.
+func (g *GhostTrail) Observe(states []core.FireflyState) {
+	if !g.recording {
+		return
+	}
+
+	for _, s := range states {
+		if s.ID != g.id {
+			continue
+		}
+		if n := len(g.samples); n == 0 || g.elapsed > g.samples[n-1].at {
+			g.samples = append(g.samples, ghostSample{at: g.elapsed, pos: s.Position, brightness: s.Brightness})
+		}
+		if g.elapsed >= ghostDuration {
+			g.finish()
+		}
+		return
+	}
+	g.finish()
+}
+
+// finish pasa de grabar a repetir
+func (g *GhostTrail) finish() {
+	g.recording = false
+	g.elapsed = 0
+}
+
+// length es lo que dura la grabación
+func (g *GhostTrail) length() time.Duration {
+	if len(g.samples) == 0 {
+		return 0
+	}
+	return g.samples[len(g.samples)-1].at
+}
+
+// at interpola el recorrido en el instante t de la grabación
+func (g *GhostTrail) at(t time.Duration) ghostSample {
+	i := 1
+	for i < len(g.samples)-1 && g.samples[i].at < t {
+		i++
+	}
+	prev, next := g.samples[i-1], g.samples[i]
+
+	// Un salto de borde a borde no se interpola
+	if utils.Distance(prev.pos, next.pos) > ghostPickRadius*4 {
+		return next
+	}
+	u := utils.Clamp(float64(t-prev.at)/float64(next.at-prev.at), 0, 1)
+	return ghostSample{
+		at:         t,
+		pos:        utils.LerpVector(prev.pos, next.pos, u),
+		brightness: utils.Lerp(prev.brightness, next.brightness, u),
+	}
+}
+
+// Draw dibuja en el mundo lo grabado hasta ahora o el fantasma
+func (g *GhostTrail) Draw(world *ebiten.Image) {
+	if g.recording {
+		g.drawPath(world, 0, g.length(), recordingColor, 0.5)
+		if n := len(g.samples); n > 0 {
+			p := g.samples[n-1].pos
+			vector.StrokeCircle(world, float32(p.X), float32(p.Y), 10, 1.5, recordingColor, true)
+		}
+		return
+	}
+	if len(g.samples) < 2 {
+		return
+	}
+
+	length := g.length()
+	t := g.elapsed % length
+	g.drawPath(world, max(t-ghostTail, 0), t, ghostColor, 0.35)
+
+	s := g.at(t)
+	glow := float32(0.25 + 0.35*s.brightness)
+	vector.FillCircle(world, float32(s.pos.X), float32(s.pos.Y), 9, scaleAlpha(ghostColor, glow*0.4), true)
+	vector.FillCircle(world, float32(s.pos.X), float32(s.pos.Y), 4, scaleAlpha(ghostColor, glow), true)
+}
+
+// drawPath traza el recorrido entre from y to
+func (g *GhostTrail) drawPath(world *ebiten.Image, from, to time.Duration, clr color.RGBA, alpha float32) {
+	line := scaleAlpha(clr, alpha)
+	for i := 1; i < len(g.samples); i++ {
+		a, b := g.samples[i-1], g.samples[i]
+		if b.at < from || a.at > to {
+			continue
+		}
+		if utils.Distance(a.pos, b.pos) > ghostPickRadius*4 {
+			continue
+		}
+		vector.StrokeLine(world, float32(a.pos.X), float32(a.pos.Y), float32(b.pos.X), float32(b.pos.Y), 1.5, line, true)
+	}
+}
+
+// DrawStatus escribe en pantalla qué se está grabando o repitiendo
+func (g *GhostTrail) DrawStatus(screen *ebiten.Image, ui *UIRenderer) {
+	var label string
+	switch {
+	case g.recording:
+		label = i18n.T("● Grabando luciérnaga %d: %ds / %ds", g.id, int(g.elapsed.Seconds()), int(ghostDuration.Seconds()))
+	case len(g.samples) > 1:
+		label = i18n.T("Fantasma de la luciérnaga %d (%ds, V lo borra)", g.id, int(g.length().Seconds()))
+	default:
+		return
+	}
+	ui.drawTextCentered(screen, label, 40, color.RGBA{R: 220, G: 230, B: 255, A: 255})
+}
+
+// nearest busca la luciérnaga más cercana a pos dentro de ghostPickRadius
+func (g *GhostTrail) nearest(states []core.FireflyState, pos utils.Vector2D) (int, bool) {
+	best, bestDist := 0, ghostPickRadius
+	found := false
+	for _, s := range states {
+		if d := utils.Distance(s.Position, pos); d <= bestDist {
+			best, bestDist, found = s.ID, d, true
+		}
+	}
+	return best, found
+}
+
+// Command es la orden "ghost" de la consola: "ghost 12" graba la
+// luciérnaga 12 y "ghost off" borra el fantasma
+func (g *GhostTrail) Command(args string) (string, error) {
+	switch args {
+	case "off":
+		g.Clear()
+		return i18n.T("Fantasma borrado"), nil
+	case "":
+		return "", errors.New(i18n.T("usar ghost 12 o ghost off"))
+	}
+
+	id, err := strconv.Atoi(args)
+	if err != nil {
+		return "", errors.New(i18n.T("ID inválido %q: usar ghost 12", args))
+	}
+	g.Mark(id)
+	return i18n.T("Grabando la luciérnaga %d durante %ds", id, int(ghostDuration.Seconds())), nil
+}
+
+// scaleAlpha multiplica el color (alpha premultiplicado) por alpha
+func scaleAlpha(clr color.RGBA, alpha float32) color.RGBA {
+	return color.RGBA{
+		R: uint8(float32(clr.R) * alpha),
+		G: uint8(float32(clr.G) * alpha),
+		B: uint8(float32(clr.B) * alpha),
+		A: uint8(float32(clr.A) * alpha),
+	}
+}
@@ -0,0 +1,64 @@
+package render
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// gossipTint es el color del rumor; las luciérnagas que lo tienen se tiñen
+// de él a medias para que su brillo se siga viendo
+var gossipTint = [4]uint8{255, 80, 200, 255}
+
+// stateColor es el color de una luciérnaga en el frame: el de su brillo en
+// la paleta teñido según su edad, y además si es líder de su grupo o tiene
+// el rumor
+func stateColor(state core.FireflyState) color.RGBA {
+	clr := tinted(fireflyColor(state.Brightness), ageTint(state.Age), ageTintAmount)
+	switch {
+	case state.Leader:
+		clr = tinted(clr, leaderTint, 0.8)
+	case state.Gossip > 0:
+		clr = tinted(clr, gossipTint, 0.65)
+	}
+	return clr
+}
+
+// tinted mezcla clr con tint conservando su transparencia
+func tinted(clr color.RGBA, tint [4]uint8, amount float64) color.RGBA {
+	tint[3] = clr.A
+	return utils.LerpColor([4]uint8{clr.R, clr.G, clr.B, clr.A}, tint, amount)
+}
+
+// toggleGossip empieza o termina el modo rumor
+func (g *Game) toggleGossip() {
+	on := !g.manager.GetGossipStatus().Active
+	go g.manager.SetGossip(on)
+	if on {
+		g.toasts.Push("Modo rumor: una luciérnaga tiene el mensaje")
+	} else {
+		g.toasts.Push("Modo rumor desactivado")
+	}
+}
+
+// drawGossipPanel muestra cuántas tienen el rumor y en cuántas rondas llegó
+// a todas
+func drawGossipPanel(screen *ebiten.Image, ui *UIRenderer, status manager.GossipStatus, y float32) {
+	sw, _ := config.ScreenSize()
+	label := i18n.T("Rumor: %d/%d luciérnagas, ronda %d", status.Infected, status.Alive, status.Round)
+	if status.Complete {
+		label += "   " + i18n.T("todas en %d rondas (%.1f s)", status.CompleteRound, status.CompleteAfter.Seconds())
+	}
+
+	width := float32(480)
+	x := (float32(sw) - width) / 2
+	vector.DrawFilledRect(screen, x, y, width, 28, color.RGBA{R: 40, G: 20, B: 40, A: 200}, false)
+	vector.StrokeRect(screen, x, y, width, 28, 1, utils.ArrayToRGBA(gossipTint), false)
+	ui.drawText(screen, label, float64(x)+12, float64(y)+5, color.RGBA{R: 255, G: 210, B: 240, A: 255})
+}
@@ -0,0 +1,127 @@
+package render
+
+import (
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+)
+
+// Niveles de calidad del gobernador automático, de mayor a menor costo
+const (
+	TierFull = iota
+	TierReduced
+	TierLow
+	TierMinimal
+)
+
+// QualityGovernor baja la calidad cuando los FPS caen por debajo del objetivo
+// y la restaura cuando vuelve a haber margen. Evalúa una vez por segundo.
+type QualityGovernor struct {
+	enabled    bool
+	tier       int
+	slowWindow int
+	fastWindow int
+	lastCheck  time.Time
+}
+
+// NewQualityGovernor crea el gobernador en calidad completa
+func NewQualityGovernor() *QualityGovernor {
+	return &QualityGovernor{
+		enabled:   config.Get().Render.AutoQuality,
+		tier:      TierFull,
+		lastCheck: time.Now(),
+	}
+}
+
+// Update evalúa los FPS medidos y retorna true si cambió el nivel
+func (q *QualityGovernor) Update(fps float64) bool {
+	if !q.enabled || fps <= 0 || time.Since(q.lastCheck) < time.Second {
+		return false
+	}
+	q.lastCheck = time.Now()
+
+	cfg := config.Get()
+	target := float64(cfg.TargetFPS)
+
+	switch {
+	case fps < target*cfg.Render.AutoQualityDropRatio:
+		q.slowWindow++
+		q.fastWindow = 0
+	case fps >= target*cfg.Render.AutoQualityRaiseRatio:
+		q.fastWindow++
+		q.slowWindow = 0
+	default:
+		q.slowWindow = 0
+		q.fastWindow = 0
+	}
+
+	if q.slowWindow >= config.Get().Render.AutoQualityDropAfter && q.tier < TierMinimal {
+		q.tier++
+		q.slowWindow = 0
+		return true
+	}
+
+	if q.fastWindow >= config.Get().Render.AutoQualityRaiseAfter && q.tier > TierFull {
+		q.tier--
+		q.fastWindow = 0
+		return true
+	}
+
+	return false
+}
+
+// Tier retorna el nivel de calidad actual
+func (q *QualityGovernor) Tier() int {
+	return q.tier
+}
+
+// MaxQuality limita la calidad de render elegida por el jugador
+func (q *QualityGovernor) MaxQuality() int {
+	if q.tier >= TierReduced {
+		return config.QualitySprites
+	}
+	return config.QualityBloom
+}
+
+// HalosEnabled indica si se dibujan halos alrededor de las luciérnagas
+func (q *QualityGovernor) HalosEnabled() bool {
+	return q.tier < TierLow
+}
+
+// WindEnabled indica si se dibujan los indicadores de viento
+func (q *QualityGovernor) WindEnabled() bool {
+	return q.tier < TierMinimal
+}
+
+// SpawnCap retorna la población máxima permitida en el nivel actual
+func (q *QualityGovernor) SpawnCap() int {
+	switch q.tier {
+	case TierLow:
+		cfg := config.Get()
+		return (cfg.Fireflies.Max + cfg.Render.AutoQualityMinSpawnCap) / 2
+	case TierMinimal:
+		return config.Get().Render.AutoQualityMinSpawnCap
+	default:
+		return config.Get().Fireflies.Max
+	}
+}
+
+// TierName retorna el nombre del nivel para el HUD
+func (q *QualityGovernor) TierName() string {
+	name := "Completa"
+	switch q.tier {
+	case TierReduced:
+		name = "Reducida"
+	case TierLow:
+		name = "Baja"
+	case TierMinimal:
+		name = "Mínima"
+	}
+
+	name = i18n.T(name)
+	if !q.enabled {
+		return name + " (manual)"
+	}
+	return name + " (auto)"
+}
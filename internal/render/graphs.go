@@ -0,0 +1,178 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/internal/theme"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// GraphWindow es la cantidad de muestras (segundos) que muestran las gráficas
+const GraphWindow = 60
+
+// sparkline es una serie de la gráfica con su forma de extraer el valor
+type sparkline struct {
+	label  string
+	unit   string
+	color  color.RGBA
+	value  func(s manager.StatsSample) float64
+	format string
+	// reference es una línea tenue sobre la misma escala (por ejemplo, la
+	// capacidad del jardín); se omite mientras valga 0
+	reference func(s manager.StatsSample) float64
+}
+
+var graphSeries = []sparkline{
+	{
+		label:  "Luciérnagas",
+		color:  color.RGBA{R: 255, G: 230, B: 120, A: 255},
+		value:  func(s manager.StatsSample) float64 { return float64(s.Population) },
+		format: "%.0f",
+		// En modo ecológico la población se ve crecer hacia la capacidad
+		reference: func(s manager.StatsSample) float64 { return float64(s.Capacity) },
+	},
+	{
+		label:  "Murciélagos",
+		color:  color.RGBA{R: 190, G: 140, B: 255, A: 255},
+		value:  func(s manager.StatsSample) float64 { return float64(s.Bats) },
+		format: "%.0f",
+	},
+	{
+		label:  "FPS",
+		color:  color.RGBA{R: 120, G: 220, B: 255, A: 255},
+		value:  func(s manager.StatsSample) float64 { return s.FPS },
+		format: "%.1f",
+	},
+	{
+		label:  "Descartados",
+		unit:   "/s",
+		color:  color.RGBA{R: 255, G: 140, B: 120, A: 255},
+		value:  func(s manager.StatsSample) float64 { return float64(s.DroppedStates) },
+		format: "%.0f",
+	},
+}
+
+// GraphPanel dibuja la población y el rendimiento de los últimos 60 segundos
+// (tecla F4). Las muestras las toma la goroutine de estadísticas del manager;
+// el panel solo las lee al dibujar.
+type GraphPanel struct {
+	expanded bool
+}
+
+// NewGraphPanel crea el panel plegado
+func NewGraphPanel() *GraphPanel {
+	return &GraphPanel{}
+}
+
+// Toggle pliega o despliega el panel
+func (p *GraphPanel) Toggle() {
+	p.expanded = !p.expanded
+}
+
+// IsExpanded indica si el panel está desplegado
+func (p *GraphPanel) IsExpanded() bool {
+	return p.expanded
+}
+
+// Bounds retorna el rectángulo del panel en su lugar por defecto
+func (p *GraphPanel) Bounds() Rect {
+	const lineHeight, graphHeight = 22, 36
+	if !p.expanded {
+		return Rect{X: 10, Y: 196, W: 300, H: lineHeight}
+	}
+	return Rect{X: 10, Y: 196, W: 300, H: lineHeight + (lineHeight+graphHeight+6)*float32(len(graphSeries))}
+}
+
+// Draw dibuja el panel bajo el HUD; plegado solo muestra el título
+func (p *GraphPanel) Draw(screen *ebiten.Image, ui *UIRenderer, samples []manager.StatsSample) {
+	x := 10.0
+	y := 196.0
+	width := 300.0
+	lineHeight := 22.0
+	graphHeight := 36.0
+
+	panelColor := utils.ArrayToRGBA(theme.Current().Panel)
+	titleColor := utils.ArrayToRGBA(theme.Current().Accent)
+
+	if !p.expanded {
+		vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), float32(lineHeight), panelColor, false)
+		ui.drawText(screen, "▸ GRÁFICAS (F4)", x+10, y+3, titleColor)
+		return
+	}
+
+	rowHeight := lineHeight + graphHeight + 6
+	panelHeight := lineHeight + rowHeight*float64(len(graphSeries))
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), float32(panelHeight), panelColor, false)
+	ui.drawText(screen, fmt.Sprintf("▾ GRÁFICAS (F4)  últimos %ds", GraphWindow), x+10, y+3, titleColor)
+	y += lineHeight
+
+	for _, series := range graphSeries {
+		current := 0.0
+		if len(samples) > 0 {
+			current = series.value(samples[len(samples)-1])
+		}
+		label := fmt.Sprintf("%s: "+series.format+"%s", series.label, current, series.unit)
+		if series.reference != nil && len(samples) > 0 {
+			if ref := series.reference(samples[len(samples)-1]); ref > 0 {
+				label += fmt.Sprintf(" / "+series.format, ref)
+			}
+		}
+		ui.drawText(screen, label, x+10, y, series.color)
+		y += lineHeight
+
+		drawSparkline(screen, samples, series, x+10, y, width-20, graphHeight)
+		y += graphHeight + 6
+	}
+}
+
+// drawSparkline escala la serie entre 0 y su máximo en la ventana; las
+// muestras se alinean a la derecha para que el presente quede siempre al borde
+func drawSparkline(screen *ebiten.Image, samples []manager.StatsSample, series sparkline, x, y, width, height float64) {
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), float32(height), color.RGBA{R: 20, G: 20, B: 40, A: 180}, false)
+
+	if len(samples) < 2 {
+		return
+	}
+
+	maxValue := 0.0
+	for _, s := range samples {
+		if v := series.value(s); v > maxValue {
+			maxValue = v
+		}
+		if series.reference != nil {
+			maxValue = max(maxValue, series.reference(s))
+		}
+	}
+	if maxValue == 0 {
+		maxValue = 1
+	}
+
+	step := width / float64(GraphWindow-1)
+	offset := float64(GraphWindow - len(samples))
+
+	if series.reference != nil {
+		faint := series.color
+		faint.A = 90
+		for i := 1; i < len(samples); i++ {
+			r0, r1 := series.reference(samples[i-1]), series.reference(samples[i])
+			if r0 == 0 || r1 == 0 {
+				continue
+			}
+			x0 := x + (offset+float64(i-1))*step
+			x1 := x + (offset+float64(i))*step
+			vector.StrokeLine(screen, float32(x0), float32(y+height-r0/maxValue*height), float32(x1), float32(y+height-r1/maxValue*height), 1, faint, true)
+		}
+	}
+
+	for i := 1; i < len(samples); i++ {
+		x0 := x + (offset+float64(i-1))*step
+		x1 := x + (offset+float64(i))*step
+		y0 := y + height - series.value(samples[i-1])/maxValue*height
+		y1 := y + height - series.value(samples[i])/maxValue*height
+		vector.StrokeLine(screen, float32(x0), float32(y0), float32(x1), float32(y1), 1.5, series.color, true)
+	}
+}
@@ -0,0 +1,102 @@
+package render
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// HeatmapOverlay dibuja el mapa de calor acumulado por el manager
+// Solo copia la grilla ya difuminada; el cálculo ocurre en el worker pool
+type HeatmapOverlay struct {
+	visible bool
+	cols    int
+	rows    int
+	values  []float64
+	pixels  []byte
+	image   *ebiten.Image
+}
+
+// NewHeatmapOverlay crea el overlay con el tamaño de la grilla del manager
+func NewHeatmapOverlay(heatmap *manager.Heatmap) *HeatmapOverlay {
+	cols, rows := heatmap.Size()
+
+	return &HeatmapOverlay{
+		cols:   cols,
+		rows:   rows,
+		values: make([]float64, cols*rows),
+		pixels: make([]byte, cols*rows*4),
+		image:  ebiten.NewImage(cols, rows),
+	}
+}
+
+// Toggle muestra u oculta el overlay
+func (h *HeatmapOverlay) Toggle() {
+	h.visible = !h.visible
+}
+
+// IsVisible indica si el overlay está activo
+func (h *HeatmapOverlay) IsVisible() bool {
+	return h.visible
+}
+
+// Draw copia la grilla del manager y la dibuja escalada sobre el mundo
+func (h *HeatmapOverlay) Draw(world *ebiten.Image, heatmap *manager.Heatmap) {
+	if !h.visible {
+		return
+	}
+
+	var maxValue float64
+	h.values, maxValue = heatmap.Snapshot(h.values)
+
+	// La grilla sigue al tamaño de la ventana; si cambió entre Snapshot y
+	// Size se espera al frame siguiente
+	cols, rows := heatmap.Size()
+	if len(h.values) != cols*rows {
+		return
+	}
+	if cols != h.cols || rows != h.rows {
+		h.image.Deallocate()
+		h.cols, h.rows = cols, rows
+		h.pixels = make([]byte, cols*rows*4)
+		h.image = ebiten.NewImage(cols, rows)
+	}
+
+	for i, value := range h.values {
+		t := 0.0
+		if maxValue > 0 {
+			t = value / maxValue
+		}
+		clr := heatColor(t)
+		h.pixels[i*4+0] = clr[0]
+		h.pixels[i*4+1] = clr[1]
+		h.pixels[i*4+2] = clr[2]
+		h.pixels[i*4+3] = clr[3]
+	}
+
+	h.image.WritePixels(h.pixels)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(float64(config.Get().Heatmap.CellSize), float64(config.Get().Heatmap.CellSize))
+	op.Filter = ebiten.FilterLinear
+	world.DrawImage(h.image, op)
+}
+
+// heatColor mapea un valor normalizado a la rampa azul → rojo → amarillo
+// Los colores se premultiplican por alfa como espera WritePixels
+func heatColor(t float64) [4]uint8 {
+	t = utils.Clamp(t, 0, 1)
+
+	var r, g, b float64
+	if t < 0.5 {
+		k := t / 0.5
+		r, g, b = utils.Lerp(40, 255, k), utils.Lerp(40, 60, k), utils.Lerp(200, 40, k)
+	} else {
+		k := (t - 0.5) / 0.5
+		r, g, b = 255, utils.Lerp(60, 240, k), utils.Lerp(40, 120, k)
+	}
+
+	alpha := t * float64(config.Get().Heatmap.MaxAlpha) / 255
+	return [4]uint8{uint8(r * alpha), uint8(g * alpha), uint8(b * alpha), uint8(255 * alpha)}
+}
@@ -0,0 +1,121 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// GameMode es el tipo de partida elegido en el menú
+type GameMode string
+
+const (
+	// ModeGarden es el jardín libre
+	ModeGarden GameMode = "jardin"
+	// ModeJar es el minijuego del frasco: atrapar luciérnagas contra reloj
+	ModeJar GameMode = "frasco"
+)
+
+const (
+	// jarTimeLimit es la duración de una partida del frasco
+	jarTimeLimit = 60 * time.Second
+	// jarCaptureRadius es a qué distancia del click se puede atrapar una
+	// luciérnaga, en coordenadas del mundo
+	jarCaptureRadius = 30.0
+	// jarFlashDuration es cuánto brilla el frasco al atrapar
+	jarFlashDuration = 300 * time.Millisecond
+)
+
+// Jar es el estado del minijuego: el puntaje es cuántas se atraparon en
+// total; inJar son las que todavía no se soltaron con R
+type Jar struct {
+	remaining time.Duration
+	captured  int
+	inJar     int
+	flash     time.Time
+}
+
+// NewJar crea el frasco vacío con el tiempo completo
+func NewJar() *Jar {
+	return &Jar{remaining: jarTimeLimit}
+}
+
+// Tick descuenta el tiempo jugado (no corre en pausa); retorna true al
+// terminarse
+func (j *Jar) Tick(dt float64) bool {
+	j.remaining -= time.Duration(dt * float64(time.Second))
+	return j.remaining <= 0
+}
+
+// Captured retorna el puntaje
+func (j *Jar) Captured() int {
+	return j.captured
+}
+
+// nearest retorna la luciérnaga más cercana a pos dentro del radio de captura
+func (j *Jar) nearest(states []core.FireflyState, pos utils.Vector2D) (int, bool) {
+	best, found := jarCaptureRadius, false
+	id := 0
+	for _, state := range states {
+		if d := utils.Distance(state.Position, pos); d <= best {
+			best, id, found = d, state.ID, true
+		}
+	}
+	return id, found
+}
+
+// add suma una luciérnaga atrapada
+func (j *Jar) add() {
+	j.captured++
+	j.inJar++
+	j.flash = time.Now()
+}
+
+// empty vacía el frasco y retorna cuántas había
+func (j *Jar) empty() int {
+	n := j.inJar
+	j.inJar = 0
+	return n
+}
+
+// DrawCursor dibuja el frasco en la posición del puntero (pantalla); se
+// ilumina con las luciérnagas que lleva dentro
+func (j *Jar) DrawCursor(screen *ebiten.Image, x, y float32) {
+	glass := color.RGBA{R: 190, G: 220, B: 230, A: 200}
+	if time.Since(j.flash) < jarFlashDuration {
+		glass = color.RGBA{R: 255, G: 255, B: 180, A: 255}
+	}
+
+	// Luz de las atrapadas: más llenas, más brillo
+	glow := uint8(min(40+j.inJar*15, 200))
+	vector.DrawFilledRect(screen, x-12, y-10, 24, 26, color.RGBA{R: 255, G: 240, B: 120, A: glow}, false)
+
+	vector.StrokeRect(screen, x-12, y-10, 24, 26, 2, glass, true)
+	vector.DrawFilledRect(screen, x-14, y-16, 28, 6, color.RGBA{R: 150, G: 110, B: 70, A: 255}, false)
+	vector.StrokeCircle(screen, x, y, jarCaptureRadius, 1, color.RGBA{R: 190, G: 220, B: 230, A: 70}, true)
+}
+
+// DrawPanel dibuja el tiempo, el frasco y el puntaje arriba al centro
+func (j *Jar) DrawPanel(screen *ebiten.Image, ui *UIRenderer) {
+	sw, _ := config.ScreenSize()
+	remaining := max(j.remaining, 0).Round(time.Second)
+	label := fmt.Sprintf("🫙 En el frasco: %d   Atrapadas: %d   ⏱ %d:%02d  (R suelta)",
+		j.inJar, j.captured, int(remaining.Minutes()), int(remaining.Seconds())%60)
+
+	width := float32(440)
+	x := (float32(sw) - width) / 2
+	vector.DrawFilledRect(screen, x, 44, width, 28, color.RGBA{R: 20, G: 30, B: 50, A: 200}, false)
+	vector.StrokeRect(screen, x, 44, width, 28, 1, color.RGBA{R: 190, G: 220, B: 230, A: 255}, false)
+
+	textColor := color.RGBA{R: 230, G: 240, B: 255, A: 255}
+	if j.remaining < 10*time.Second {
+		textColor = color.RGBA{R: 255, G: 160, B: 120, A: 255}
+	}
+	ui.drawText(screen, label, float64(x)+12, 49, textColor)
+}
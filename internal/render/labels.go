@@ -0,0 +1,70 @@
+package render
+
+import (
+	"errors"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+)
+
+// IDLabels escribe el ID de cada luciérnaga al lado, para seguir en la
+// pantalla a la goroutine que aparece en los logs. Con un filtro solo
+// muestra esos IDs. Se maneja con la orden "label" de la consola.
+type IDLabels struct {
+	visible bool
+	// only son los IDs a mostrar; vacío muestra todos
+	only map[int]bool
+}
+
+// NewIDLabels crea las etiquetas ocultas
+func NewIDLabels() *IDLabels {
+	return &IDLabels{only: make(map[int]bool)}
+}
+
+// Command es la orden "label" de la consola: sin argumentos muestra todos
+// los IDs, "label 12,44,91" solo esos y "label off" los oculta
+func (l *IDLabels) Command(args string) (string, error) {
+	clear(l.only)
+	switch args {
+	case "off":
+		l.visible = false
+		return i18n.T("Etiquetas ocultas"), nil
+	case "", "all":
+		l.visible = true
+		return i18n.T("Etiquetas: todas las luciérnagas"), nil
+	}
+
+	for _, field := range strings.FieldsFunc(args, func(r rune) bool { return r == ',' || r == ' ' }) {
+		id, err := strconv.Atoi(field)
+		if err != nil {
+			clear(l.only)
+			return "", errors.New(i18n.T("ID inválido %q: usar label 12,44,91", field))
+		}
+		l.only[id] = true
+	}
+	l.visible = true
+	return i18n.T("Etiquetas: %d luciérnagas", len(l.only)), nil
+}
+
+// Draw escribe los IDs de las luciérnagas visibles sobre la pantalla, ya
+// proyectadas con la cámara
+func (l *IDLabels) Draw(screen *ebiten.Image, ui *UIRenderer, states []core.FireflyState, camera *Camera) {
+	if !l.visible {
+		return
+	}
+	labelColor := color.RGBA{R: 230, G: 230, B: 160, A: 230}
+	for _, s := range states {
+		if len(l.only) > 0 && !l.only[s.ID] {
+			continue
+		}
+		if visible, _ := fireflyLOD(s, camera); !visible {
+			continue
+		}
+		p := camera.WorldToScreen(s.Position)
+		ui.drawText(screen, strconv.Itoa(s.ID), p.X+6, p.Y-18, labelColor)
+	}
+}
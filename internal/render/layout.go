@@ -0,0 +1,326 @@
+package render
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/input"
+	"github.com/yourusername/firefly-garden/internal/prefs"
+	"github.com/yourusername/firefly-garden/internal/theme"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// panelTitleHeight es la franja de arriba de cada panel que sirve para
+	// arrastrarlo y plegarlo
+	panelTitleHeight = 22
+	// panelDragThreshold es cuánto hay que mover el puntero para que un
+	// click en la barra cuente como arrastre y no como plegar
+	panelDragThreshold = 4
+)
+
+// hudPanel es un panel del HUD que se puede mover y plegar. Cada panel se
+// dibuja en su lugar por defecto (bounds) y a escala 1; HUDLayout lo
+// traslada y lo escala.
+type hudPanel struct {
+	id    string
+	title string
+	// bounds es el rectángulo en el lugar por defecto
+	bounds func() Rect
+	// visible indica si el panel se muestra en este frame (nil: siempre)
+	visible func() bool
+	// toggle reemplaza al plegado del layout en los paneles que ya se
+	// pliegan solos (las gráficas)
+	toggle func()
+	draw   func(screen *ebiten.Image)
+}
+
+// panelState es el desplazamiento de un panel y si está plegado
+type panelState struct {
+	dx, dy    float32
+	collapsed bool
+}
+
+// panelDrag es el arrastre en curso de una barra de título
+type panelDrag struct {
+	id             string
+	startX, startY int
+	origin         panelState
+	moved          bool
+}
+
+// panelView es dónde se ve un panel: su rectángulo por defecto y el que
+// ocupa en pantalla, ya trasladado y escalado
+type panelView struct {
+	bounds Rect
+	at     Rect
+	scale  float32
+}
+
+// Local pasa un punto de pantalla a las coordenadas por defecto del panel,
+// que son las que usan sus widgets
+func (v panelView) Local(x, y int) (int, int) {
+	lx := v.bounds.X + (float32(x)-v.at.X)/v.scale
+	ly := v.bounds.Y + (float32(y)-v.at.Y)/v.scale
+	return int(lx), int(ly)
+}
+
+// HUDLayout guarda dónde dejó el usuario cada panel y si lo plegó. La crea
+// App con lo guardado en las preferencias y dura entre partidas; además
+// escala los paneles (ver UIScale) y oculta toda la interfaz con una tecla
+// (para capturas).
+type HUDLayout struct {
+	states  map[string]*panelState
+	hidden  bool
+	scale   float32
+	drag    *panelDrag
+	scratch *ebiten.Image
+	// screen es el tamaño de pantalla con que se acomodaron los paneles
+	screen [2]int
+}
+
+// NewHUDLayout parte de la disposición guardada
+func NewHUDLayout(saved map[string]prefs.Panel) *HUDLayout {
+	l := &HUDLayout{states: make(map[string]*panelState), scale: 1}
+	for id, p := range saved {
+		l.states[id] = &panelState{dx: float32(p.X), dy: float32(p.Y), collapsed: p.Collapsed}
+	}
+	return l
+}
+
+// Prefs retorna la disposición para guardarla; los paneles en su lugar y
+// desplegados no se guardan
+func (l *HUDLayout) Prefs() map[string]prefs.Panel {
+	saved := make(map[string]prefs.Panel)
+	for id, s := range l.states {
+		if s.dx != 0 || s.dy != 0 || s.collapsed {
+			saved[id] = prefs.Panel{X: int(s.dx), Y: int(s.dy), Collapsed: s.collapsed}
+		}
+	}
+	return saved
+}
+
+// ToggleHidden oculta o muestra toda la interfaz
+func (l *HUDLayout) ToggleHidden() {
+	l.hidden = !l.hidden
+	l.drag = nil
+}
+
+// Hidden indica si la interfaz está oculta
+func (l *HUDLayout) Hidden() bool {
+	return l.hidden
+}
+
+// SetScale cambia el tamaño de los paneles; cada uno crece desde la esquina
+// de pantalla más cercana
+func (l *HUDLayout) SetScale(scale float64) {
+	l.scale = float32(scale)
+}
+
+// Reset devuelve todos los paneles a su lugar
+func (l *HUDLayout) Reset() {
+	clear(l.states)
+	l.drag = nil
+}
+
+// state retorna el estado del panel, creándolo si hace falta
+func (l *HUDLayout) state(id string) *panelState {
+	s, ok := l.states[id]
+	if !ok {
+		s = &panelState{}
+		l.states[id] = s
+	}
+	return s
+}
+
+// offset retorna cuánto se movió el panel desde su lugar por defecto
+func (l *HUDLayout) offset(id string) (dx, dy float32) {
+	if s, ok := l.states[id]; ok {
+		return s.dx, s.dy
+	}
+	return 0, 0
+}
+
+// Collapsed indica si el panel está plegado
+func (l *HUDLayout) Collapsed(id string) bool {
+	s, ok := l.states[id]
+	return ok && s.collapsed
+}
+
+// anchored retorna dónde queda el rectángulo b escalado sin moverlo: los
+// paneles de la mitad derecha (o de abajo) crecen hacia la izquierda (o
+// hacia arriba) para no salirse de la pantalla
+func (l *HUDLayout) anchored(b Rect) Rect {
+	sw, sh := config.ScreenSize()
+	at := Rect{X: b.X, Y: b.Y, W: b.W * l.scale, H: b.H * l.scale}
+	if b.X+b.W/2 > float32(sw)/2 {
+		at.X = b.X + b.W - at.W
+	}
+	if b.Y+b.H/2 > float32(sh)/2 {
+		at.Y = b.Y + b.H - at.H
+	}
+	return at
+}
+
+// view retorna dónde se ve el panel ahora
+func (l *HUDLayout) view(p hudPanel) panelView {
+	b := p.bounds()
+	at := l.anchored(b)
+	dx, dy := l.offset(p.id)
+	at.X += dx
+	at.Y += dy
+	return panelView{bounds: b, at: at, scale: l.scale}
+}
+
+// titleBar retorna la barra de título del panel donde está ahora
+func (l *HUDLayout) titleBar(p hudPanel) Rect {
+	at := l.view(p).at
+	return Rect{X: at.X, Y: at.Y, W: at.W, H: panelTitleHeight * l.scale}
+}
+
+// shown indica si el panel se dibuja en este frame
+func (l *HUDLayout) shown(p hudPanel) bool {
+	return !l.hidden && (p.visible == nil || p.visible())
+}
+
+// Update mueve el panel cuya barra de título se arrastra; un click sin
+// arrastrar lo pliega o despliega. Se queda con el puntero mientras está
+// sobre una barra, así el click no llega al jardín. Debe llamarse antes que
+// el resto del input del juego.
+func (l *HUDLayout) Update(h *input.Handler, panels []hudPanel) {
+	l.reflow(panels)
+	p := h.Pointer()
+
+	if l.drag != nil {
+		h.CapturePointer()
+		d := l.drag
+		dx, dy := p.X-d.startX, p.Y-d.startY
+		if abs(dx) > panelDragThreshold || abs(dy) > panelDragThreshold {
+			d.moved = true
+		}
+
+		s := l.state(d.id)
+		if d.moved {
+			s.dx, s.dy = d.origin.dx+float32(dx), d.origin.dy+float32(dy)
+			l.clamp(d.id, panels)
+		}
+		if !p.Pressed {
+			if !d.moved {
+				l.toggle(d.id, panels)
+			}
+			l.drag = nil
+		}
+		return
+	}
+
+	// El último dibujado queda arriba: se prueba primero
+	for i := len(panels) - 1; i >= 0; i-- {
+		panel := panels[i]
+		if !l.shown(panel) || !l.titleBar(panel).Contains(p.X, p.Y) {
+			continue
+		}
+		if p.JustPressed {
+			l.drag = &panelDrag{id: panel.id, startX: p.X, startY: p.Y, origin: *l.state(panel.id)}
+		}
+		h.CapturePointer()
+		return
+	}
+}
+
+// toggle pliega o despliega el panel id
+func (l *HUDLayout) toggle(id string, panels []hudPanel) {
+	for _, p := range panels {
+		if p.id != id {
+			continue
+		}
+		if p.toggle != nil {
+			p.toggle()
+			return
+		}
+		s := l.state(id)
+		s.collapsed = !s.collapsed
+	}
+}
+
+// clamp mantiene la barra de título del panel dentro de la pantalla
+func (l *HUDLayout) clamp(id string, panels []hudPanel) {
+	sw, sh := config.ScreenSize()
+	for _, p := range panels {
+		if p.id != id {
+			continue
+		}
+		at := l.anchored(p.bounds())
+		s := l.state(id)
+		s.dx = float32(utils.Clamp(float64(s.dx), float64(-at.X), float64(float32(sw)-at.X-at.W)))
+		s.dy = float32(utils.Clamp(float64(s.dy), float64(-at.Y), float64(float32(sh)-at.Y-panelTitleHeight*l.scale)))
+	}
+}
+
+// reflow vuelve a meter en pantalla los paneles movidos si la ventana
+// cambió de tamaño; los que no se movieron ya siguen a su esquina
+func (l *HUDLayout) reflow(panels []hudPanel) {
+	sw, sh := config.ScreenSize()
+	if l.screen == [2]int{sw, sh} {
+		return
+	}
+	l.screen = [2]int{sw, sh}
+	for id := range l.states {
+		l.clamp(id, panels)
+	}
+}
+
+// Draw dibuja los paneles visibles en su lugar. Los que no se movieron ni
+// cambiaron de escala se dibujan directo; el resto se dibuja en una capa
+// auxiliar y se copia trasladado y escalado, así cada panel sigue
+// dibujándose con sus coordenadas de siempre.
+func (l *HUDLayout) Draw(screen *ebiten.Image, ui *UIRenderer, panels []hudPanel) {
+	if l.hidden {
+		return
+	}
+	sw, sh := config.ScreenSize()
+	l.scratch = fitImage(l.scratch, sw, sh)
+
+	for _, p := range panels {
+		if !l.shown(p) {
+			continue
+		}
+
+		v := l.view(p)
+		if v.at == v.bounds {
+			l.drawPanel(screen, ui, p, v.bounds)
+			continue
+		}
+
+		b := v.bounds
+		l.scratch.Clear()
+		l.drawPanel(l.scratch, ui, p, b)
+		area := image.Rect(int(b.X), int(b.Y), int(b.X+b.W)+1, int(b.Y+b.H)+1)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(float64(v.scale), float64(v.scale))
+		op.GeoM.Translate(float64(v.at.X), float64(v.at.Y))
+		op.Filter = ebiten.FilterLinear
+		screen.DrawImage(l.scratch.SubImage(area).(*ebiten.Image), op)
+	}
+}
+
+// drawPanel dibuja el panel en su lugar por defecto b; plegado es solo la
+// barra de título
+func (l *HUDLayout) drawPanel(target *ebiten.Image, ui *UIRenderer, p hudPanel, b Rect) {
+	if !l.Collapsed(p.id) {
+		p.draw(target)
+		return
+	}
+	vector.DrawFilledRect(target, b.X, b.Y, b.W, panelTitleHeight, color.RGBA{R: 0, G: 0, B: 0, A: 170}, false)
+	ui.drawText(target, "▸ "+i18n.T(p.title), float64(b.X)+10, float64(b.Y)+3, utils.ArrayToRGBA(theme.Current().Accent))
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,255 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/internal/scores"
+)
+
+// modeNames son los nombres de los modos en la tabla de récords
+var modeNames = map[GameMode]string{
+	ModeGarden:   "Jardín libre",
+	ModeLevels:   "Niveles",
+	ModeSurvival: "Supervivencia",
+	ModeDaily:    "Desafío diario",
+	ModeJar:      "Frasco",
+}
+
+// leaderboardColumn es una columna de la tabla; las que tienen sortable
+// se pueden elegir para ordenar
+type leaderboardColumn struct {
+	title    string
+	x        float64
+	sortable bool
+	key      scores.SortKey
+}
+
+var leaderboardColumns = []leaderboardColumn{
+	{title: "#", x: 60},
+	{title: "Modo", x: 100},
+	{title: "Puntaje", x: 260, sortable: true, key: scores.ByScore},
+	{title: "Tiempo", x: 380, sortable: true, key: scores.ByDuration},
+	{title: "Pico", x: 490, sortable: true, key: scores.ByPeak},
+	{title: "Fecha", x: 580, sortable: true, key: scores.ByDate},
+	{title: "Semilla", x: 760},
+}
+
+const (
+	leaderboardTop     = 150.0
+	leaderboardRowH    = 30.0
+	leaderboardVisible = 14
+)
+
+// LeaderboardScene es la tabla de récords: el historial de partidas
+// ordenable por columna; Enter vuelve a jugar una con su semilla
+type LeaderboardScene struct {
+	app      *App
+	entries  []scores.Entry
+	sortBy   scores.SortKey
+	selected int
+	offset   int
+	err      error
+}
+
+// NewLeaderboardScene lee el historial y lo ordena por puntaje
+func NewLeaderboardScene(app *App) *LeaderboardScene {
+	entries, err := scores.Load()
+	if err != nil {
+		logging.For("scores").Warn("no se pudo leer el historial", "err", err)
+	}
+
+	s := &LeaderboardScene{app: app, entries: entries, err: err}
+	s.sort(scores.ByScore)
+	return s
+}
+
+// sort reordena la tabla y vuelve a la primera fila
+func (s *LeaderboardScene) sort(key scores.SortKey) {
+	s.sortBy = key
+	scores.Sort(s.entries, key)
+	s.selected, s.offset = 0, 0
+}
+
+// cycleSort pasa a la columna ordenable siguiente (o anterior con step -1)
+func (s *LeaderboardScene) cycleSort(step int) {
+	var keys []scores.SortKey
+	current := 0
+	for _, col := range leaderboardColumns {
+		if col.sortable {
+			if col.key == s.sortBy {
+				current = len(keys)
+			}
+			keys = append(keys, col.key)
+		}
+	}
+	s.sort(keys[(current+step+len(keys))%len(keys)])
+}
+
+// Update procesa el orden, la fila elegida y la salida
+func (s *LeaderboardScene) Update() error {
+	h := s.app.inputHandler
+
+	if h.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.app.ShowMenu()
+		return nil
+	}
+	if h.IsKeyJustPressed(ebiten.KeyArrowRight) || h.IsKeyJustPressed(ebiten.KeyTab) {
+		s.cycleSort(1)
+	}
+	if h.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+		s.cycleSort(-1)
+	}
+	if len(s.entries) == 0 {
+		return nil
+	}
+
+	if h.IsKeyJustPressed(ebiten.KeyArrowDown) {
+		s.selected = min(s.selected+1, len(s.entries)-1)
+	}
+	if h.IsKeyJustPressed(ebiten.KeyArrowUp) {
+		s.selected = max(s.selected-1, 0)
+	}
+
+	p := h.Pointer()
+	if p.JustPressed {
+		s.click(float64(p.X), float64(p.Y))
+	}
+
+	// La fila elegida siempre a la vista
+	if s.selected < s.offset {
+		s.offset = s.selected
+	}
+	if s.selected >= s.offset+leaderboardVisible {
+		s.offset = s.selected - leaderboardVisible + 1
+	}
+
+	if h.IsKeyJustPressed(ebiten.KeyEnter) {
+		s.replay(s.selected)
+	}
+	return nil
+}
+
+// click ordena al tocar un título o juega la fila tocada (la primera vez
+// la elige)
+func (s *LeaderboardScene) click(x, y float64) {
+	sw, _ := config.ScreenSize()
+	if y >= leaderboardTop-leaderboardRowH && y < leaderboardTop {
+		for i, col := range leaderboardColumns {
+			right := float64(sw)
+			if i+1 < len(leaderboardColumns) {
+				right = leaderboardColumns[i+1].x
+			}
+			if col.sortable && x >= col.x && x < right {
+				s.sort(col.key)
+			}
+		}
+		return
+	}
+
+	row := int((y-leaderboardTop)/leaderboardRowH) + s.offset
+	if y < leaderboardTop || row >= len(s.entries) || row >= s.offset+leaderboardVisible {
+		return
+	}
+	if row == s.selected {
+		s.replay(row)
+		return
+	}
+	s.selected = row
+}
+
+// replay empieza una partida del mismo modo con la misma semilla
+func (s *LeaderboardScene) replay(i int) {
+	e := s.entries[i]
+	s.app.StartSeeded(GameMode(e.Mode), e.Seed)
+}
+
+// Draw dibuja la tabla
+func (s *LeaderboardScene) Draw(screen *ebiten.Image) {
+	sw, sh := config.ScreenSize()
+	drawSceneBackground(screen)
+
+	ui := s.app.uiRenderer
+	ui.drawTitleCentered(screen, "🏆 Récords", 50, color.RGBA{R: 255, G: 220, B: 120, A: 255})
+
+	headerColor := color.RGBA{R: 150, G: 200, B: 255, A: 255}
+	for _, col := range leaderboardColumns {
+		title := col.title
+		if col.sortable && col.key == s.sortBy {
+			title += " ▼"
+		}
+		ui.drawText(screen, title, col.x, leaderboardTop-leaderboardRowH+4, headerColor)
+	}
+	vector.StrokeLine(screen, 50, float32(leaderboardTop)-2, float32(sw)-50, float32(leaderboardTop)-2, 1, color.RGBA{R: 100, G: 100, B: 140, A: 200}, false)
+
+	switch {
+	case s.err != nil:
+		ui.drawTextCentered(screen, "No se pudo leer el historial: "+s.err.Error(), leaderboardTop+20, color.RGBA{R: 255, G: 150, B: 120, A: 255})
+	case len(s.entries) == 0:
+		ui.drawTextCentered(screen, "Todavía no hay partidas terminadas", leaderboardTop+20, color.RGBA{R: 200, G: 200, B: 200, A: 255})
+	}
+
+	end := min(s.offset+leaderboardVisible, len(s.entries))
+	for i := s.offset; i < end; i++ {
+		e := s.entries[i]
+		y := leaderboardTop + float64(i-s.offset)*leaderboardRowH
+
+		rowColor := color.RGBA{R: 220, G: 220, B: 220, A: 255}
+		if i == s.selected {
+			vector.DrawFilledRect(screen, 50, float32(y), float32(sw)-100, leaderboardRowH-2, color.RGBA{R: 60, G: 70, B: 120, A: 180}, false)
+			rowColor = color.RGBA{R: 255, G: 255, B: 220, A: 255}
+		}
+
+		mode := modeNames[GameMode(e.Mode)]
+		if mode == "" {
+			mode = e.Mode
+		}
+		cells := []string{
+			strconv.Itoa(i + 1),
+			mode,
+			strconv.Itoa(e.Score),
+			formatClock(e.Duration()),
+			strconv.Itoa(e.Peak),
+			e.Date.Local().Format("2006-01-02 15:04"),
+			strconv.FormatInt(e.Seed, 10),
+		}
+		for c, cell := range cells {
+			ui.drawText(screen, cell, leaderboardColumns[c].x, y+5, rowColor)
+		}
+	}
+
+	hint := "←/→ ordenar · ↑/↓ elegir · Enter jugar con la misma semilla · Esc volver"
+	if len(s.entries) > leaderboardVisible {
+		hint = fmt.Sprintf("%d partidas · %s", len(s.entries), hint)
+	}
+	ui.drawTextCentered(screen, hint, float64(sh)-50, color.RGBA{R: 170, G: 170, B: 200, A: 255})
+}
+
+// recordResult agrega la partida al historial; las repeticiones y el
+// tutorial no cuentan
+func recordResult(summary SessionSummary) {
+	if summary.Replay || summary.Preset != "" || summary.Mode == ModeTutorial {
+		return
+	}
+
+	entry := scores.Entry{
+		Date:     time.Now(),
+		Mode:     string(summary.Mode),
+		Score:    summary.Score.Points,
+		Seconds:  summary.Duration.Round(time.Second).Seconds(),
+		Peak:     summary.PeakFireflies,
+		Seed:     summary.Seed,
+		Lanterns: summary.LanternsPlaced,
+
+		Challenge: summary.Challenge,
+	}
+	if err := scores.Append(entry); err != nil {
+		logging.For("scores").Warn("no se pudo guardar el resultado", "err", err)
+	}
+}
@@ -0,0 +1,175 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/level"
+	"github.com/yourusername/firefly-garden/internal/manager"
+)
+
+// ModeLevels es la progresión de niveles con metas y dificultad creciente
+const ModeLevels GameMode = "niveles"
+
+// levelTransition es cuánto dura el cartel de nivel completado antes de
+// empezar el siguiente
+const levelTransition = 3 * time.Second
+
+// levelGarden adapta el manager a lo que necesita el progreso de un nivel
+type levelGarden struct {
+	fm *manager.FireflyManager
+}
+
+func (l levelGarden) Population() int {
+	return l.fm.GetFireflyCount()
+}
+
+func (l levelGarden) Bats() int {
+	return l.fm.GetBatCount()
+}
+
+func (l levelGarden) BatWave(count int) {
+	l.fm.LaunchBatWave(count)
+}
+
+func (l levelGarden) ZonePopulation(zone int) int {
+	return l.fm.GetZoneStats()[zone].Count
+}
+
+func (l levelGarden) LargestCluster() int {
+	return l.fm.GetClusters().Largest()
+}
+
+// LevelRun es el avance por los niveles de una partida
+type LevelRun struct {
+	campaign *level.Campaign
+	index    int
+	progress *level.Progress
+	garden   levelGarden
+
+	// transition cuenta el cartel entre niveles; completed es cuántos se
+	// superaron
+	transition time.Duration
+	completed  int
+}
+
+// NewLevelRun empieza la campaña desde el primer nivel
+func NewLevelRun(campaign *level.Campaign, fm *manager.FireflyManager) *LevelRun {
+	run := &LevelRun{campaign: campaign, garden: levelGarden{fm: fm}}
+	run.start(0)
+	return run
+}
+
+// start aplica la dificultad del nivel i al jardín
+func (r *LevelRun) start(i int) {
+	r.index = i
+	l := &r.campaign.Levels[i]
+	r.progress = level.NewProgress(l)
+
+	fm := r.garden.fm
+	fm.SetObjective(l.Objective)
+
+	settings := fm.GetSettings()
+	if l.SpawnInterval.Duration > 0 {
+		settings.SpawnInterval = l.SpawnInterval.Duration
+	}
+	if l.Wind > 0 {
+		settings.WindStrength = l.Wind
+	}
+	fm.UpdateSettings(settings)
+}
+
+// Update avanza la meta actual o el cartel entre niveles; retorna true al
+// superar el último
+func (r *LevelRun) Update(dt float64) bool {
+	step := time.Duration(dt * float64(time.Second))
+
+	if r.transition > 0 {
+		r.transition -= step
+		if r.transition <= 0 {
+			r.start(r.index + 1)
+		}
+		return false
+	}
+
+	if !r.progress.Update(r.garden, step) {
+		return false
+	}
+
+	r.completed++
+	if r.completed == len(r.campaign.Levels) {
+		return true
+	}
+	r.transition = levelTransition
+	return false
+}
+
+// Completed retorna cuántos niveles se superaron
+func (r *LevelRun) Completed() int {
+	return r.completed
+}
+
+// Total retorna cuántos niveles tiene la campaña
+func (r *LevelRun) Total() int {
+	return len(r.campaign.Levels)
+}
+
+// TargetZone retorna la zona de la meta actual si es una meta de zona
+func (r *LevelRun) TargetZone() (int, bool) {
+	target, _, ok := r.progress.Current()
+	if !ok || target.Kind != level.TargetZone {
+		return 0, false
+	}
+	return config.ZoneByName(target.Zone)
+}
+
+// Objective es la población a la que repone el spawner en este nivel
+func (r *LevelRun) Objective() int {
+	return r.garden.fm.GetObjective()
+}
+
+// DrawPanel reemplaza al panel de objetivo: nivel, meta actual y su avance
+func (r *LevelRun) DrawPanel(screen *ebiten.Image, ui *UIRenderer, population int) {
+	sw, sh := config.ScreenSize()
+	x := float32(sw/2 - 190)
+	y := float32(sh - 100)
+	width, height := float32(380), float32(80)
+
+	vector.DrawFilledRect(screen, x, y, width, height, color.RGBA{R: 20, G: 20, B: 40, A: 200}, false)
+	vector.StrokeRect(screen, x, y, width, height, 2, color.RGBA{R: 100, G: 150, B: 200, A: 255}, false)
+
+	l := r.progress.Level()
+	title := fmt.Sprintf("🎯 NIVEL %d/%d — %s", r.index+1, len(r.campaign.Levels), l.Name)
+	if _, n, ok := r.progress.Current(); ok {
+		title += fmt.Sprintf("  (meta %d/%d)", n, len(l.Targets))
+	}
+	ui.drawTextCentered(screen, title, float64(y)+8, color.RGBA{R: 255, G: 255, B: 150, A: 255})
+	ui.drawTextCentered(screen, r.progress.Describe(population), float64(y)+32, color.RGBA{R: 200, G: 200, B: 200, A: 255})
+
+	barX, barY := x+30, y+56
+	barWidth, barHeight := width-60, float32(12)
+	fraction := r.progress.Fraction(population)
+	vector.DrawFilledRect(screen, barX, barY, barWidth, barHeight, color.RGBA{R: 50, G: 50, B: 50, A: 255}, false)
+	vector.DrawFilledRect(screen, barX, barY, barWidth*float32(fraction), barHeight, color.RGBA{R: 100, G: 255, B: 100, A: 255}, false)
+	vector.StrokeRect(screen, barX, barY, barWidth, barHeight, 1, color.RGBA{R: 150, G: 150, B: 150, A: 255}, false)
+}
+
+// DrawTransition dibuja el cartel de nivel completado mientras dura
+func (r *LevelRun) DrawTransition(screen *ebiten.Image, ui *UIRenderer) {
+	if r.transition <= 0 {
+		return
+	}
+
+	sw, sh := config.ScreenSize()
+	alpha := uint8(160 * min(r.transition.Seconds(), 1))
+	vector.DrawFilledRect(screen, 0, 0, float32(sw), float32(sh), color.RGBA{A: alpha}, false)
+
+	centerY := float64(sh) / 2
+	ui.drawTitleCentered(screen, fmt.Sprintf("¡Nivel %d completado!", r.index+1), centerY-40, color.RGBA{R: 255, G: 230, B: 140, A: 255})
+	next := r.campaign.Levels[r.index+1]
+	ui.drawTextCentered(screen, fmt.Sprintf("Siguiente: %s", next.Name), centerY+30, color.RGBA{R: 200, G: 200, B: 220, A: 255})
+}
@@ -0,0 +1,118 @@
+package render
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/theme"
+)
+
+// Minimap dibuja una vista reducida del jardín en una esquina
+// La densidad se calcula a partir del mismo snapshot que usa Draw,
+// así no se vuelve a bloquear el agregador en el camino crítico
+type Minimap struct {
+	cols    int
+	rows    int
+	density []int
+	pixels  []byte
+	image   *ebiten.Image
+}
+
+// NewMinimap crea un minimapa con una celda por cada MinimapCellSize píxeles del mundo
+func NewMinimap() *Minimap {
+	m := &Minimap{}
+	m.resize()
+	return m
+}
+
+// resize ajusta la grilla si el mundo cambió de tamaño con la ventana
+func (m *Minimap) resize() {
+	width, height := config.WorldPixels()
+	cols := width / config.MinimapCellSize
+	rows := height / config.MinimapCellSize
+	if cols == m.cols && rows == m.rows {
+		return
+	}
+
+	m.cols, m.rows = cols, rows
+	m.density = make([]int, cols*rows)
+	m.pixels = make([]byte, cols*rows*4)
+	m.image = fitImage(m.image, cols, rows)
+}
+
+// Update recalcula el mapa de densidad a partir del snapshot del agregador
+func (m *Minimap) Update(states []core.FireflyState) {
+	m.resize()
+	for i := range m.density {
+		m.density[i] = 0
+	}
+
+	maxCount := 1
+	for _, state := range states {
+		col := int(state.Position.X) / config.MinimapCellSize
+		row := int(state.Position.Y) / config.MinimapCellSize
+		if col < 0 || col >= m.cols || row < 0 || row >= m.rows {
+			continue
+		}
+
+		idx := row*m.cols + col
+		m.density[idx]++
+		if m.density[idx] > maxCount {
+			maxCount = m.density[idx]
+		}
+	}
+
+	full := theme.Current().FireflyFull
+	for i, count := range m.density {
+		t := float64(count) / float64(maxCount)
+		m.pixels[i*4+0] = uint8(float64(full[0]) * t)
+		m.pixels[i*4+1] = uint8(float64(full[1]) * t)
+		m.pixels[i*4+2] = uint8(float64(full[2]) * t * 0.6)
+		m.pixels[i*4+3] = uint8(255 * t)
+	}
+
+	m.image.WritePixels(m.pixels)
+}
+
+// Draw dibuja el minimapa con faroles y el rectángulo visible de la cámara
+func (m *Minimap) Draw(screen *ebiten.Image, lanterns []*core.Lantern, camera *Camera) {
+	_, sh := config.ScreenSize()
+	ww, wh := config.WorldSize()
+	x := float32(10)
+	y := float32(sh - config.MinimapHeight - 10)
+	width := float32(config.MinimapWidth)
+	height := float32(config.MinimapHeight)
+
+	scaleX := width / float32(ww)
+	scaleY := height / float32(wh)
+
+	// Panel de fondo
+	vector.DrawFilledRect(screen, x, y, width, height, color.RGBA{R: 0, G: 0, B: 0, A: 170}, false)
+
+	// Densidad escalada con filtro lineal (suaviza las celdas)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(float64(width)/float64(m.cols), float64(height)/float64(m.rows))
+	op.GeoM.Translate(float64(x), float64(y))
+	op.Filter = ebiten.FilterLinear
+	screen.DrawImage(m.image, op)
+
+	// Faroles
+	lc := theme.Current().Lantern
+	lanternColor := color.RGBA{R: lc[0], G: lc[1], B: lc[2], A: 255}
+	for _, lantern := range lanterns {
+		lx := x + float32(lantern.Position.X)*scaleX
+		ly := y + float32(lantern.Position.Y)*scaleY
+		vector.DrawFilledCircle(screen, lx, ly, 3, lanternColor, false)
+	}
+
+	// Rectángulo visible de la cámara
+	vx, vy, vw, vh := camera.Viewport()
+	viewColor := color.RGBA{R: 150, G: 200, B: 255, A: 220}
+	vector.StrokeRect(screen, x+float32(vx)*scaleX, y+float32(vy)*scaleY, float32(vw)*scaleX, float32(vh)*scaleY, 1, viewColor, false)
+
+	// Borde
+	vector.StrokeRect(screen, x, y, width, height, 1, color.RGBA{R: 100, G: 150, B: 200, A: 255}, false)
+}
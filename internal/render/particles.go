@@ -0,0 +1,206 @@
+package render
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/internal/render/particles"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// particleCapacity es el tamaño del pool compartido por todos los
+	// efectos
+	particleCapacity = 4096
+	// particleBuffer es el buffer de la suscripción al bus
+	particleBuffer = 256
+
+	sparkleCount  = 10
+	dissolveCount = 14
+	// stormRain son las gotas por segundo dentro del frente de tormenta
+	stormRain = 320
+	// lanternEmbers son las brasas por segundo que suelta cada farol
+	lanternEmbers = 5
+)
+
+// Los efectos del jardín, como recetas del sistema de partículas
+var (
+	// sparkleEmitter es el destello corto y rápido de un nacimiento
+	sparkleEmitter = particles.Emitter{
+		Speed: particles.Range{Min: 60, Max: 140},
+		Life:  particles.Range{Min: 0.35, Max: 0.6},
+		Size:  particles.Range{Min: 1.5, Max: 3},
+		Color: color.RGBA{R: 255, G: 250, B: 200, A: 255},
+		Drag:  0.9,
+		Alpha: particles.FadeOut,
+		Scale: particles.Shrink,
+	}
+	// dissolveEmitter es la nube lenta que sube cuando una se apaga
+	dissolveEmitter = particles.Emitter{
+		Speed:   particles.Range{Min: 10, Max: 25},
+		Offset:  particles.Range{Min: 0, Max: 4},
+		Life:    particles.Range{Min: 0.8, Max: 1.4},
+		Size:    particles.Range{Min: 2, Max: 4},
+		Color:   color.RGBA{R: 255, G: 180, B: 90, A: 160},
+		Gravity: utils.NewVector2D(0, -25),
+		Drag:    0.9,
+		Alpha:   particles.FadeOut,
+		Scale:   particles.Shrink,
+	}
+	// rainEmitter son las gotas que arrastra el frente de tormenta
+	rainEmitter = particles.Emitter{
+		Speed:   particles.Range{Min: 260, Max: 360},
+		Angle:   particles.Range{Min: -0.08, Max: 0.08},
+		Life:    particles.Range{Min: 0.25, Max: 0.45},
+		Size:    particles.Range{Min: 0.8, Max: 1.2},
+		Color:   color.RGBA{R: 170, G: 190, B: 230, A: 140},
+		Stretch: 0.04,
+		Alpha:   particles.Pulse,
+	}
+	// emberEmitter son las brasas de los faroles: suben apenas y el viento
+	// se las lleva, así se ve hacia dónde sopla sin las flechas
+	emberEmitter = particles.Emitter{
+		Speed:   particles.Range{Min: 4, Max: 12},
+		Offset:  particles.Range{Min: 2, Max: 10},
+		Life:    particles.Range{Min: 1.5, Max: 2.6},
+		Size:    particles.Range{Min: 0.8, Max: 1.6},
+		Color:   color.RGBA{R: 255, G: 150, B: 60, A: 220},
+		Gravity: utils.NewVector2D(0, -8),
+		Drag:    0.5,
+		Drift:   40,
+		Alpha:   particles.Pulse,
+	}
+)
+
+// Particles son los efectos de partículas del jardín: destellos al nacer y
+// disolución al morir, que salen de los eventos del bus del manager, la
+// lluvia de las tormentas y las brasas de los faroles. Todos comparten un solo particles.System. Solo
+// la usa el hilo de Ebiten.
+type Particles struct {
+	events      <-chan manager.Event
+	unsubscribe func()
+	system      *particles.System
+	rain        particles.Stream
+	embers      particles.Stream
+
+	// field es la corriente del viento en wind y now; se arma una sola vez
+	// para no reservar memoria por frame
+	field particles.Field
+	wind  core.WindSnapshot
+	now   time.Time
+}
+
+// NewParticles se suscribe a los eventos de fm
+func NewParticles(fm *manager.FireflyManager) *Particles {
+	events, unsubscribe := fm.Events().Subscribe(particleBuffer)
+	p := &Particles{
+		events:      events,
+		unsubscribe: unsubscribe,
+		system:      particles.NewSystem(particleCapacity),
+		rain:        particles.Stream{Rate: stormRain},
+	}
+	p.field = p.windAt
+	return p
+}
+
+// Close cancela la suscripción
+func (p *Particles) Close() {
+	p.unsubscribe()
+}
+
+// Update consume los eventos pendientes sin bloquear, emite la lluvia de la
+// tormenta que esté cruzando y las brasas de lanterns, y avanza las
+// partículas dt segundos con el viento de wind en now
+func (p *Particles) Update(dt float64, wind core.WindSnapshot, now time.Time, lanterns []*core.Lantern) {
+	for drained := false; !drained; {
+		select {
+		case e := <-p.events:
+			p.handle(e)
+		default:
+			drained = true
+		}
+	}
+
+	p.wind, p.now = wind, now
+	if wind.Storm != nil {
+		p.stormRain(wind.Storm, now, dt)
+	}
+	p.lanternEmbers(lanterns, wind.LanternDim(now), dt)
+	p.system.Update(dt, p.field)
+}
+
+// Draw dibuja todas las partículas
+func (p *Particles) Draw(dst *ebiten.Image) {
+	p.system.Draw(dst)
+}
+
+// handle emite el efecto de un evento de nacimiento o muerte
+func (p *Particles) handle(e manager.Event) {
+	switch e.Type {
+	case manager.EventSpawn:
+		if e.Firefly != nil {
+			p.system.Emit(&sparkleEmitter, e.Firefly.Position, sparkleCount)
+		}
+	case manager.EventDeath:
+		if e.Position != nil {
+			p.system.Emit(&dissolveEmitter, *e.Position, dissolveCount)
+		}
+	}
+}
+
+// stormRain suelta las gotas del frame en puntos al azar de la franja del
+// frente, cayendo en diagonal hacia donde avanza
+func (p *Particles) stormRain(storm *core.StormFront, now time.Time, dt float64) {
+	width, height := config.WorldSize()
+	reach := math.Hypot(width, height) / 2
+	edge := storm.Edge(now)
+	fall := storm.Direction.Add(utils.NewVector2D(0, 1.5))
+
+	for range p.rain.Due(dt) {
+		at := stormPoint(storm.Direction, edge-rand.Float64()*storm.Width, (rand.Float64()*2-1)*reach)
+		p.system.EmitToward(&rainEmitter, at, fall, 1)
+	}
+}
+
+// lanternEmbers suelta las brasas del frame desde faroles al azar; un
+// farol atenuado por la tormenta suelta menos
+func (p *Particles) lanternEmbers(lanterns []*core.Lantern, dim float64, dt float64) {
+	if len(lanterns) == 0 {
+		return
+	}
+	p.embers.Rate = lanternEmbers * float64(len(lanterns)) * dim
+	for range p.embers.Due(dt) {
+		lantern := lanterns[rand.Intn(len(lanterns))]
+		p.system.Emit(&emberEmitter, lantern.Position, 1)
+	}
+}
+
+// stormPoint retorna el punto del mundo con proyección t sobre dir y s
+// sobre su perpendicular, medidas desde el centro del mundo
+func stormPoint(dir utils.Vector2D, t, s float64) utils.Vector2D {
+	width, height := config.WorldSize()
+	center := utils.NewVector2D(width/2, height/2)
+	along := t - (center.X*dir.X + center.Y*dir.Y)
+	return center.Add(dir.Mul(along)).Add(utils.NewVector2D(-dir.Y, dir.X).Mul(s))
+}
+
+// windAt es el viento del frame en pos
+func (p *Particles) windAt(pos utils.Vector2D) utils.Vector2D {
+	return windForce(p.wind, p.now, pos)
+}
+
+// windForce es el viento de wind en pos y now, con el empuje del frente de
+// tormenta donde esté pasando
+func windForce(wind core.WindSnapshot, now time.Time, pos utils.Vector2D) utils.Vector2D {
+	force := wind.Force
+	if wind.Storm != nil {
+		force = force.Add(wind.Storm.ForceAt(pos, now))
+	}
+	return force
+}
@@ -0,0 +1,268 @@
+// Package particles es el sistema de partículas de los efectos del render.
+// Un Emitter describe cómo nacen y cómo envejecen las partículas de un
+// efecto (velocidad, vida, tamaño, curvas de transparencia y escala); un
+// System las guarda en un pool de tamaño fijo, las mueve y las dibuja en
+// una sola llamada sin reservar memoria por frame. Solo lo usa el hilo de
+// Ebiten.
+package particles
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// spriteSize es el lado del disco suave que se estira en cada partícula
+const spriteSize = 16
+
+// Range es un intervalo del que se sortea un valor por partícula. Usa el
+// generador global de math/rand y no el de utils, para no correr la
+// semilla de la simulación.
+type Range struct {
+	Min, Max float64
+}
+
+func (r Range) pick() float64 {
+	return r.Min + rand.Float64()*(r.Max-r.Min)
+}
+
+// Curve da un factor según la fracción de vida t: 0 al nacer, 1 al morir
+type Curve func(t float64) float64
+
+var (
+	// Constant no cambia con la edad
+	Constant Curve = func(float64) float64 { return 1 }
+	// FadeOut baja de 1 a 0 en línea recta
+	FadeOut Curve = func(t float64) float64 { return 1 - t }
+	// EaseOut baja rápido al principio y despacio al final
+	EaseOut Curve = func(t float64) float64 { return (1 - t) * (1 - t) }
+	// Pulse sube y vuelve a bajar: aparece y se va sin cortes
+	Pulse Curve = func(t float64) float64 { return math.Sin(math.Pi * t) }
+	// Shrink achica hasta la mitad
+	Shrink Curve = func(t float64) float64 { return 1 - 0.5*t }
+)
+
+// Field es una corriente que arrastra a las partículas, por ejemplo el
+// viento; da la aceleración en cada posición
+type Field func(pos utils.Vector2D) utils.Vector2D
+
+// Emitter es la receta de un efecto. Las partículas guardan un puntero a su
+// emisor, así que no debe cambiarse mientras haya partículas vivas.
+type Emitter struct {
+	// Speed es la rapidez inicial en px/s
+	Speed Range
+	// Angle es el desvío en radianes respecto de la dirección de emisión
+	Angle Range
+	// Offset es la distancia al punto de emisión, hacia un lado al azar
+	Offset Range
+	// Life es la vida en segundos y Size el radio en px
+	Life  Range
+	Size  Range
+	Color color.RGBA
+	// Gravity es una aceleración constante en px/s²
+	Gravity utils.Vector2D
+	// Drag es la fracción de la velocidad que se pierde por segundo
+	Drag float64
+	// Drift es cuánto las empuja el Field de Update; 0 las deja fuera
+	Drift float64
+	// Stretch alarga el sprite en la dirección en que se mueve: el largo
+	// es lo que recorre en Stretch segundos. Sirve para vetas y lluvia.
+	Stretch float64
+	// Alpha y Scale modulan transparencia y tamaño con la edad; nil es
+	// Constant
+	Alpha, Scale Curve
+}
+
+// particle es una partícula viva del pool
+type particle struct {
+	emitter   *Emitter
+	pos, vel  utils.Vector2D
+	age, life float64
+	size      float64
+}
+
+// System es el pool de partículas de uno o varios efectos
+type System struct {
+	pool  []particle
+	count int
+
+	atlas    *ebiten.Image
+	vertices []ebiten.Vertex
+	indices  []uint32
+}
+
+// NewSystem crea un pool para capacity partículas; con el pool lleno las
+// nuevas se descartan
+func NewSystem(capacity int) *System {
+	img := image.NewRGBA(image.Rect(0, 0, spriteSize, spriteSize))
+	center := float64(spriteSize) / 2
+	for y := 0; y < spriteSize; y++ {
+		for x := 0; x < spriteSize; x++ {
+			d := math.Hypot(float64(x)+0.5-center, float64(y)+0.5-center) / center
+			a := uint8(255 * utils.Clamp(1-d*d, 0, 1))
+			img.SetRGBA(x, y, color.RGBA{R: a, G: a, B: a, A: a})
+		}
+	}
+
+	return &System{
+		pool:     make([]particle, capacity),
+		atlas:    ebiten.NewImageFromImage(img),
+		vertices: make([]ebiten.Vertex, 0, capacity*4),
+		indices:  make([]uint32, 0, capacity*6),
+	}
+}
+
+// Emit suelta n partículas de e en at, cada una hacia un lado al azar
+func (s *System) Emit(e *Emitter, at utils.Vector2D, n int) {
+	for range n {
+		s.emit(e, at, rand.Float64()*2*math.Pi)
+	}
+}
+
+// EmitToward suelta n partículas de e en at hacia dir, desviadas según
+// e.Angle
+func (s *System) EmitToward(e *Emitter, at, dir utils.Vector2D, n int) {
+	base := math.Atan2(dir.Y, dir.X)
+	for range n {
+		s.emit(e, at, base)
+	}
+}
+
+func (s *System) emit(e *Emitter, at utils.Vector2D, angle float64) {
+	if s.count == len(s.pool) {
+		return
+	}
+	angle += e.Angle.pick()
+	dir := utils.NewVector2D(math.Cos(angle), math.Sin(angle))
+
+	pos := at
+	if e.Offset.Max > 0 {
+		side := rand.Float64() * 2 * math.Pi
+		pos = pos.Add(utils.NewVector2D(math.Cos(side), math.Sin(side)).Mul(e.Offset.pick()))
+	}
+
+	s.pool[s.count] = particle{
+		emitter: e,
+		pos:     pos,
+		vel:     dir.Mul(e.Speed.pick()),
+		life:    math.Max(e.Life.pick(), 1e-3),
+		size:    e.Size.pick(),
+	}
+	s.count++
+}
+
+// Update avanza las partículas dt segundos; field, si no es nil, empuja a
+// las de emisores con Drift. Las que cumplieron su vida se quitan pisándolas
+// con la última.
+func (s *System) Update(dt float64, field Field) {
+	for i := 0; i < s.count; {
+		p := &s.pool[i]
+		p.age += dt
+		if p.age >= p.life {
+			s.count--
+			s.pool[i] = s.pool[s.count]
+			continue
+		}
+
+		e := p.emitter
+		p.vel = p.vel.Add(e.Gravity.Mul(dt))
+		if field != nil && e.Drift != 0 {
+			p.vel = p.vel.Add(field(p.pos).Mul(e.Drift * dt))
+		}
+		if e.Drag > 0 {
+			p.vel = p.vel.Mul(math.Pow(1-utils.Clamp(e.Drag, 0, 1), dt))
+		}
+		p.pos = p.pos.Add(p.vel.Mul(dt))
+		i++
+	}
+}
+
+// Len retorna las partículas vivas
+func (s *System) Len() int {
+	return s.count
+}
+
+// Clear quita todas las partículas
+func (s *System) Clear() {
+	s.count = 0
+}
+
+// Draw dibuja todas las partículas en una sola llamada
+func (s *System) Draw(dst *ebiten.Image) {
+	if s.count == 0 {
+		return
+	}
+
+	s.vertices = s.vertices[:0]
+	s.indices = s.indices[:0]
+	for i := 0; i < s.count; i++ {
+		s.addQuad(&s.pool[i])
+	}
+
+	op := &ebiten.DrawTrianglesOptions{}
+	op.Filter = ebiten.FilterLinear
+	dst.DrawTriangles32(s.vertices, s.indices, s.atlas, op)
+}
+
+// addQuad agrega el sprite de p: un disco de su radio, estirado a lo largo
+// de su velocidad si el emisor tiene Stretch. El color va premultiplicado
+// por la transparencia.
+func (s *System) addQuad(p *particle) {
+	e := p.emitter
+	t := p.age / p.life
+	alpha, scale := Constant, Constant
+	if e.Alpha != nil {
+		alpha = e.Alpha
+	}
+	if e.Scale != nil {
+		scale = e.Scale
+	}
+
+	radius := p.size * scale(t)
+	along := utils.NewVector2D(1, 0)
+	half := radius
+	if speed := p.vel.Magnitude(); e.Stretch > 0 && speed > 0 {
+		along = p.vel.Mul(1 / speed)
+		half += speed * e.Stretch / 2
+	}
+	u := along.Mul(half)
+	v := utils.NewVector2D(-along.Y, along.X).Mul(radius)
+
+	a := float32(float64(e.Color.A) / 255 * utils.Clamp(alpha(t), 0, 1))
+	r := float32(e.Color.R) / 255 * a
+	g := float32(e.Color.G) / 255 * a
+	b := float32(e.Color.B) / 255 * a
+
+	vertex := func(corner utils.Vector2D, sx, sy float32) ebiten.Vertex {
+		return ebiten.Vertex{DstX: float32(corner.X), DstY: float32(corner.Y), SrcX: sx, SrcY: sy, ColorR: r, ColorG: g, ColorB: b, ColorA: a}
+	}
+
+	base := uint32(len(s.vertices))
+	s.vertices = append(s.vertices,
+		vertex(p.pos.Sub(u).Sub(v), 0, 0),
+		vertex(p.pos.Add(u).Sub(v), spriteSize, 0),
+		vertex(p.pos.Sub(u).Add(v), 0, spriteSize),
+		vertex(p.pos.Add(u).Add(v), spriteSize, spriteSize),
+	)
+	s.indices = append(s.indices, base, base+1, base+2, base+1, base+3, base+2)
+}
+
+// Stream convierte un ritmo continuo en partículas por frame: acumula las
+// fracciones de un frame al siguiente
+type Stream struct {
+	// Rate son las partículas por segundo
+	Rate    float64
+	pending float64
+}
+
+// Due retorna cuántas partículas tocan en un frame de dt segundos
+func (st *Stream) Due(dt float64) int {
+	st.pending += st.Rate * dt
+	n := int(st.pending)
+	st.pending -= float64(n)
+	return n
+}
@@ -0,0 +1,93 @@
+package render
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+// PhotoMode acumula la luz de las luciérnagas durante varios segundos
+// para producir una imagen de "larga exposición" (light painting)
+type PhotoMode struct {
+	active    bool
+	startTime time.Time
+	frame     *ebiten.Image
+	exposure  *ebiten.Image
+	result    *ebiten.Image
+}
+
+// NewPhotoMode crea el modo foto con sus capas offscreen
+func NewPhotoMode() *PhotoMode {
+	p := &PhotoMode{}
+	p.Resize(config.WorldPixels())
+	return p
+}
+
+// Resize ajusta las capas al tamaño del mundo; si cambió, la
+// exposición en curso se descarta porque su luz quedó en las capas viejas
+func (p *PhotoMode) Resize(width, height int) {
+	if p.exposure != nil {
+		if b := p.exposure.Bounds(); b.Dx() == width && b.Dy() == height {
+			return
+		}
+	}
+	p.frame = fitImage(p.frame, width, height)
+	p.exposure = fitImage(p.exposure, width, height)
+	p.result = fitImage(p.result, width, height)
+	p.active = false
+}
+
+// Start inicia una nueva exposición
+func (p *PhotoMode) Start() {
+	p.active = true
+	p.startTime = time.Now()
+	p.exposure.Clear()
+}
+
+// Cancel descarta la exposición en curso
+func (p *PhotoMode) Cancel() {
+	p.active = false
+}
+
+// IsActive indica si hay una exposición en curso
+func (p *PhotoMode) IsActive() bool {
+	return p.active
+}
+
+// Progress retorna el avance de la exposición entre 0 y 1
+func (p *PhotoMode) Progress() float64 {
+	return time.Since(p.startTime).Seconds() / config.Get().Capture.ExposureDuration.Duration.Seconds()
+}
+
+// Expose suma la luz del frame actual a la exposición (mezcla aditiva)
+func (p *PhotoMode) Expose(renderer *Renderer, states []core.FireflyState) {
+	p.frame.Clear()
+	for _, state := range states {
+		renderer.DrawFirefly(p.frame, state)
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.Blend = ebiten.BlendLighter
+	op.ColorScale.ScaleAlpha(float32(config.Get().Capture.ExposureGain))
+	p.exposure.DrawImage(p.frame, op)
+}
+
+// Draw muestra la exposición acumulada sobre el fondo, sin UI
+// Al terminar el tiempo de exposición guarda el PNG de forma asíncrona
+func (p *PhotoMode) Draw(screen *ebiten.Image, renderer *Renderer, camera *Camera) {
+	p.result.Clear()
+	renderer.DrawBackground(p.result)
+	p.result.DrawImage(p.exposure, nil)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM = camera.GeoM()
+	op.Filter = ebiten.FilterLinear
+	screen.DrawImage(p.result, op)
+
+	if p.Progress() >= 1.0 {
+		p.active = false
+		savePNGAsync(captureImage(p.result), capturePath("long-exposure"), nil)
+	}
+}
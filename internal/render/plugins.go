@@ -0,0 +1,50 @@
+package render
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/core"
+)
+
+// Frame es lo que recibe un plugin de dibujo en cada frame. Los slices son
+// del render: se pueden leer pero no guardar ni modificar.
+type Frame struct {
+	Time      time.Time
+	Fireflies []core.FireflyState
+	Lanterns  []*core.Lantern
+	Wind      core.WindSnapshot
+	Camera    *Camera
+}
+
+// RenderPlugin agrega dibujos propios. DrawWorld dibuja en coordenadas del
+// mundo, encima de las luciérnagas y bajo la cámara; DrawOverlay dibuja en
+// coordenadas de pantalla, encima del HUD. Ambos se llaman desde el hilo de
+// Ebiten.
+type RenderPlugin interface {
+	Name() string
+	DrawWorld(world *ebiten.Image, frame Frame)
+	DrawOverlay(screen *ebiten.Image, frame Frame)
+}
+
+var (
+	renderPluginsMux sync.RWMutex
+	renderPlugins    []RenderPlugin
+)
+
+// RegisterPlugin agrega un plugin de dibujo; debe llamarse antes de ebiten.RunGame
+func RegisterPlugin(p RenderPlugin) {
+	renderPluginsMux.Lock()
+	defer renderPluginsMux.Unlock()
+
+	renderPlugins = append(renderPlugins, p)
+}
+
+// registeredPlugins retorna los plugins de dibujo registrados
+func registeredPlugins() []RenderPlugin {
+	renderPluginsMux.RLock()
+	defer renderPluginsMux.RUnlock()
+
+	return append([]RenderPlugin(nil), renderPlugins...)
+}
@@ -0,0 +1,36 @@
+package render
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/config"
+)
+
+// updatePower pasa al modo de bajo consumo cuando la ventana pierde el foco
+// y vuelve al ritmo normal al recuperarlo. En bajo consumo Update corre a
+// background_tps, la pantalla solo se redibuja tras un Update (el resto de
+// los frames queda la imagen anterior) y la partida deja los halos y baja el
+// ritmo de las luciérnagas.
+func (a *App) updatePower() {
+	tps := config.Get().BackgroundTPS
+	low := tps > 0 && !ebiten.IsFocused()
+	if low == a.lowPower {
+		return
+	}
+	a.lowPower = low
+	if !low {
+		tps = config.Get().TargetFPS
+	}
+	ebiten.SetTPS(tps)
+	ebiten.SetScreenClearedEveryFrame(!low)
+	if a.game != nil {
+		a.game.SetLowPower(low)
+	}
+}
+
+// SetLowPower deja de dibujar halos y bloom y pide relanzar las
+// luciérnagas al ritmo de bajo consumo (o al normal); el manager lo aplica
+// en su propia goroutine
+func (g *Game) SetLowPower(on bool) {
+	g.lowPower = on
+	g.manager.SetLowPower(on)
+}
@@ -0,0 +1,123 @@
+package render
+
+import (
+	"errors"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/preset"
+	"github.com/yourusername/firefly-garden/internal/script"
+)
+
+// PresetScene es la lista de escenarios listos del menú principal; cada
+// uno arranca un jardín libre con su configuración y sus eventos
+type PresetScene struct {
+	app     *App
+	presets []*preset.Preset
+	menu    *menuList
+}
+
+// NewPresetScene crea la lista con los presets y "Volver"
+func NewPresetScene(app *App) *PresetScene {
+	presets := preset.All()
+	items := make([]string, 0, len(presets)+1)
+	for _, p := range presets {
+		items = append(items, p.Title)
+	}
+	items = append(items, "Volver")
+
+	return &PresetScene{
+		app:     app,
+		presets: presets,
+		menu: &menuList{
+			items: items,
+			top:   func(height float32) float32 { return height/2 - 120 },
+		},
+	}
+}
+
+// Update procesa la selección; Esc vuelve al menú
+func (s *PresetScene) Update() error {
+	if s.app.inputHandler.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.app.ShowMenu()
+		return nil
+	}
+
+	switch i := s.menu.Update(s.app.inputHandler); {
+	case i < 0:
+	case i < len(s.presets):
+		s.app.StartPreset(s.presets[i])
+	default:
+		s.app.ShowMenu()
+	}
+	return nil
+}
+
+// Draw dibuja el título, la descripción del preset elegido y los botones
+func (s *PresetScene) Draw(screen *ebiten.Image) {
+	_, sh := config.ScreenSize()
+	drawSceneBackground(screen)
+
+	ui := s.app.uiRenderer
+	ui.drawTitleCentered(screen, i18n.T("Escenarios"), float64(sh)/8, color.RGBA{R: 255, G: 255, B: 200, A: 255})
+	if s.menu.selected < len(s.presets) {
+		ui.drawTextCentered(screen, i18n.T(s.presets[s.menu.selected].Description), float64(sh)/8+60, color.RGBA{R: 180, G: 180, B: 220, A: 255})
+	}
+
+	s.menu.Draw(screen, ui)
+}
+
+// presetSwitch es un cambio de preset pedido desde la consola; preset nil
+// vuelve a la configuración sin preset
+type presetSwitch struct {
+	preset *preset.Preset
+}
+
+// presetCommand es la orden "preset" de la consola: sin argumentos lista
+// los presets, "preset storm" reinicia el jardín con ese y "preset off"
+// vuelve a la configuración de siempre
+func (g *Game) presetCommand(args string) (string, error) {
+	switch args {
+	case "":
+		current := "off"
+		if g.preset != nil {
+			current = g.preset.Name
+		}
+		return i18n.T("Presets: %s (ahora: %s)", strings.Join(preset.Names(), ", "), current), nil
+	case "off":
+		g.presetSwitch = &presetSwitch{}
+		return i18n.T("Volviendo al jardín sin preset"), nil
+	}
+
+	p, ok := preset.Find(args)
+	if !ok {
+		return "", errors.New(i18n.T("preset desconocido %q: usar %s u off", args, strings.Join(preset.Names(), ", ")))
+	}
+	g.presetSwitch = &presetSwitch{preset: p}
+	return i18n.T("Cambiando a %s", i18n.T(p.Title)), nil
+}
+
+// PresetRequest retorna el cambio de preset pedido por la consola, una
+// sola vez; App reinicia la partida con él
+func (g *Game) PresetRequest() (*preset.Preset, bool) {
+	req := g.presetSwitch
+	if req == nil {
+		return nil, false
+	}
+	g.presetSwitch = nil
+	return req.preset, true
+}
+
+// presetScript arma el escenario del preset de la partida; un error solo
+// deja la partida sin eventos
+func (g *Game) presetScript() *script.Script {
+	scenario, err := g.preset.Script()
+	if err != nil {
+		g.log.Error("eventos del preset inválidos", "preset", g.preset.Name, "err", err)
+		return nil
+	}
+	return scenario
+}
@@ -0,0 +1,201 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/input"
+	"github.com/yourusername/firefly-garden/internal/netplay"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// RemoteScene muestra el jardín de un anfitrión (-join o -spectate). No corre
+// simulación propia: dibuja el estado interpolado que llega por la red y, si
+// no es espectador, envía las órdenes del jugador al anfitrión.
+type RemoteScene struct {
+	app      *App
+	client   *netplay.Client
+	renderer *Renderer
+	toasts   *Toasts
+	fps      *FPSCounter
+
+	// Los faroles pulsan localmente; la red solo trae su posición
+	lanterns map[int]*core.Lantern
+	wind     *core.Wind
+
+	attracting      bool
+	attractionPoint utils.Vector2D
+	attractionPulse float64
+
+	lastSpawn     time.Time
+	spawnCooldown time.Duration
+	lastUpdate    time.Time
+	view          netplay.View
+}
+
+// NewRemoteScene crea la vista de un jardín remoto ya conectado
+func NewRemoteScene(app *App, client *netplay.Client) *RemoteScene {
+	s := &RemoteScene{
+		app:           app,
+		client:        client,
+		renderer:      NewRenderer(),
+		toasts:        NewToasts(),
+		fps:           NewFPSCounter(),
+		lanterns:      make(map[int]*core.Lantern),
+		wind:          core.NewWind(),
+		spawnCooldown: config.Get().Spawn.PlayerCooldown.Duration,
+		lastUpdate:    time.Now(),
+	}
+	if client.ReadOnly() {
+		s.toasts.Push("Observando el jardín de " + client.Addr())
+	} else {
+		s.toasts.Push("Conectado al jardín de " + client.Addr())
+	}
+	return s
+}
+
+// disconnected indica si la sesión con el anfitrión terminó
+func (s *RemoteScene) disconnected() bool {
+	select {
+	case <-s.client.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// Update procesa la entrada del jugador y avanza la vista remota
+func (s *RemoteScene) Update() error {
+	now := time.Now()
+	dt := now.Sub(s.lastUpdate).Seconds()
+	s.lastUpdate = now
+	s.fps.Update()
+
+	h := s.app.inputHandler
+	if h.IsActionJustPressed(input.ActionEndGame) {
+		s.app.leaveRemote()
+		return nil
+	}
+	if s.disconnected() {
+		return nil
+	}
+
+	s.view = s.client.View(now)
+	s.syncLanterns(dt)
+	s.wind.SetDirection(s.view.Wind)
+
+	// El espectador solo mira: no procesa el mouse ni las teclas del jardín
+	if s.client.ReadOnly() {
+		return nil
+	}
+
+	pointer := h.Pointer()
+	cursor := utils.Vector2D{X: float64(pointer.X), Y: float64(pointer.Y)}
+
+	if pointer.JustPressed {
+		s.attracting = true
+		s.attractionPulse = 0
+		s.attractionPoint = cursor
+		s.report(s.client.SetAttraction(cursor))
+	}
+	if s.attracting && h.GamepadAttracting() && cursor.Sub(s.attractionPoint).Magnitude() > 4 {
+		s.attractionPoint = cursor
+		s.report(s.client.SetAttraction(cursor))
+	}
+	if s.attracting && pointer.JustReleased {
+		s.attracting = false
+		s.report(s.client.ClearAttraction())
+	}
+	if h.IsActionJustPressed(input.ActionLantern) {
+		s.report(s.client.AddLantern(cursor))
+	}
+	if _, _, swiped := h.JustSwiped(); swiped || h.IsActionJustPressed(input.ActionWind) {
+		s.report(s.client.CycleWind())
+	}
+	if h.IsActionJustPressed(input.ActionBurst) && now.Sub(s.lastSpawn) >= s.spawnCooldown {
+		s.report(s.client.SpawnBurst(cursor))
+		s.lastSpawn = now
+	}
+
+	if reason := s.client.TakeRejected(); reason != "" {
+		s.toasts.Warn("El anfitrión rechazó la orden: " + reason)
+	}
+
+	if s.attracting {
+		s.attractionPulse += dt * 2
+		if s.attractionPulse > 1.0 {
+			s.attractionPulse = 0.0
+		}
+	}
+
+	return nil
+}
+
+// report avisa cuando una orden no pudo salir
+func (s *RemoteScene) report(err error) {
+	if err != nil {
+		s.toasts.Warn("No se pudo enviar la orden: " + err.Error())
+	}
+}
+
+// syncLanterns mantiene un core.Lantern por farol remoto para animar su pulso
+func (s *RemoteScene) syncLanterns(dt float64) {
+	seen := make(map[int]bool, len(s.view.Lanterns))
+	for _, l := range s.view.Lanterns {
+		seen[l.ID] = true
+		lantern, ok := s.lanterns[l.ID]
+		if !ok {
+			lantern = core.NewLantern(l.ID, l.Position.X, l.Position.Y)
+			s.lanterns[l.ID] = lantern
+		}
+		lantern.Position = l.Position
+		lantern.Radius = l.Radius
+		lantern.Update(dt)
+	}
+	for id := range s.lanterns {
+		if !seen[id] {
+			delete(s.lanterns, id)
+		}
+	}
+}
+
+// Draw dibuja el jardín remoto con el HUD de la partida
+func (s *RemoteScene) Draw(screen *ebiten.Image) {
+	sw, sh := config.ScreenSize()
+	s.renderer.DrawBackground(screen)
+	s.renderer.DrawWind(screen, s.wind.Snapshot())
+
+	for _, l := range s.view.Lanterns {
+		s.renderer.DrawLantern(screen, s.lanterns[l.ID], 1)
+	}
+	for _, state := range s.view.Fireflies {
+		s.renderer.DrawFirefly(screen, state)
+	}
+	if s.attracting {
+		pulse := math.Abs(math.Sin(s.attractionPulse * math.Pi))
+		s.renderer.DrawAttractionPoint(screen, s.attractionPoint, pulse)
+	}
+
+	ui := s.app.uiRenderer
+	ui.DrawHUD(screen, s.view.Population, len(s.view.Lanterns), config.Get().Spawn.Objective, s.wind.Snapshot(), s.fps.currentFPS, "Remota", nil, false)
+
+	status := fmt.Sprintf("🌐 Jardín de %s — ESC para salir", s.client.Addr())
+	if s.client.ReadOnly() {
+		status = fmt.Sprintf("👁 Espectador de %s — ESC para salir", s.client.Addr())
+	}
+	ui.drawText(screen, status, 20, float64(sh)-30, color.RGBA{R: 160, G: 200, B: 240, A: 255})
+
+	if s.disconnected() {
+		vector.DrawFilledRect(screen, 0, 0, float32(sw), float32(sh), color.RGBA{R: 0, G: 0, B: 0, A: 160}, false)
+		ui.drawTitleCentered(screen, "Conexión perdida", float64(sh)/2-40, color.RGBA{R: 255, G: 140, B: 120, A: 255})
+		ui.drawTextCentered(screen, "ESC para volver al menú", float64(sh)/2+20, color.RGBA{R: 220, G: 220, B: 220, A: 255})
+	}
+
+	s.toasts.Draw(screen, ui)
+}
@@ -3,11 +3,13 @@ package render
 import (
 	"image/color"
 	"math"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/yourusername/firefly-garden/internal/config"
 	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/theme"
 	"github.com/yourusername/firefly-garden/pkg/utils"
 )
 
@@ -20,46 +22,92 @@ func NewRenderer() *Renderer {
 	return &Renderer{}
 }
 
-// DrawBackground dibuja el fondo nocturno con gradiente
+// DrawBackground dibuja el fondo nocturno según el estilo del tema activo:
+// liso, con gradiente o con gradiente y estrellas
 func (r *Renderer) DrawBackground(screen *ebiten.Image) {
-	screen.Fill(utils.ArrayToRGBA(config.BackgroundColor))
-	
+	sw, sh := config.ScreenSize()
+	screen.Fill(utils.ArrayToRGBA(theme.Current().Background))
+
+	skin := theme.CurrentSkin()
+	if skin.Background.Style == theme.BackgroundSolid {
+		return
+	}
+
 	// Efecto de gradiente sutil de arriba hacia abajo
-	width := float32(config.ScreenWidth)
-	height := float32(config.ScreenHeight)
-	
+	width := float32(sw)
+	height := float32(sh)
+	tint := skin.Background.Tint
+
 	for i := 0; i < 3; i++ {
 		y := float32(i) * height / 3
-		alpha := uint8(20 - i*5)
-		clr := color.RGBA{R: 20, G: 25, B: 50, A: alpha}
+		alpha := tint[3] - uint8(i)*tint[3]/4
+		clr := color.RGBA{R: tint[0], G: tint[1], B: tint[2], A: alpha}
 		vector.DrawFilledRect(screen, 0, y, width, height/3, clr, false)
 	}
+
+	if skin.Background.Style == theme.BackgroundStars {
+		r.drawStars(screen, skin.Background.Stars)
+	}
+}
+
+// drawStars dibuja estrellas fijas que titilan despacio; las posiciones
+// salen de un patrón determinístico para no guardar estado
+func (r *Renderer) drawStars(screen *ebiten.Image, count int) {
+	sw, sh := config.ScreenSize()
+	t := float64(time.Now().UnixMilli()) / 1000
+	for i := 0; i < count; i++ {
+		x := float32((i*7919 + 13) % sw)
+		y := float32((i*104729 + 71) % sh)
+		twinkle := 0.5 + 0.5*math.Sin(t*(0.5+float64(i%7)*0.15)+float64(i))
+		alpha := uint8(60 + 120*twinkle)
+		vector.DrawFilledRect(screen, x, y, 1.5, 1.5, color.RGBA{R: alpha, G: alpha, B: alpha, A: alpha}, false)
+	}
+}
+
+// fireflyColor es el color de una luciérnaga según su brillo en la paleta activa
+func fireflyColor(brightness float64) color.RGBA {
+	p := theme.Current()
+	return utils.LerpColor(p.FireflyDim, p.FireflyFull, brightness)
 }
 
 // DrawFirefly dibuja una luciérnaga con efecto de brillo
 func (r *Renderer) DrawFirefly(screen *ebiten.Image, state core.FireflyState) {
+	r.drawFireflyHalos(screen, state)
+	r.DrawFireflyCore(screen, state)
+}
+
+// drawFireflyHalos dibuja los halos con círculos concéntricos (calidad sin bloom)
+func (r *Renderer) drawFireflyHalos(screen *ebiten.Image, state core.FireflyState) {
 	x := float32(state.Position.X)
 	y := float32(state.Position.Y)
-	
-	// Interpolar color según brillo
-	clr := utils.LerpColor(config.FireflyColorDim, config.FireflyColorFull, state.Brightness)
-	
+	clr := stateColor(state)
+
 	// Halo externo (suavizado y con gradiente)
-if state.Brightness > 0.15 {
-	haloRadius := float32(config.FireflySize * 2.8 * state.Brightness)
-	haloColor := utils.WithAlpha(clr, uint8(float64(clr.A)*0.28))
-	vector.DrawFilledCircle(screen, x, y, haloRadius, haloColor, false)
-}
+	if state.Brightness > 0.15 {
+		haloRadius := float32(config.Get().Fireflies.Size * 2.8 * state.Brightness * theme.CurrentSkin().Glow.Halo)
+		haloColor := utils.WithAlpha(clr, uint8(float64(clr.A)*0.28))
+		vector.DrawFilledCircle(screen, x, y, haloRadius, haloColor, false)
+	}
 
-// Halo medio
-if state.Brightness > 0.1 {
-	midRadius := float32(config.FireflySize * 1.6 * (0.7 + 0.6*state.Brightness))
-	midColor := utils.WithAlpha(clr, uint8(float64(clr.A)*0.55))
-	vector.DrawFilledCircle(screen, x, y, midRadius, midColor, false)
+	// Halo medio
+	if state.Brightness > 0.1 {
+		midRadius := float32(config.Get().Fireflies.Size * 1.6 * (0.7 + 0.6*state.Brightness) * theme.CurrentSkin().Glow.Halo)
+		midColor := utils.WithAlpha(clr, uint8(float64(clr.A)*0.55))
+		vector.DrawFilledCircle(screen, x, y, midRadius, midColor, false)
+	}
 }
+
+// DrawFireflyCore dibuja solo el núcleo de la luciérnaga
+// Con bloom activo los halos los genera el shader a partir de este núcleo
+func (r *Renderer) DrawFireflyCore(screen *ebiten.Image, state core.FireflyState) {
+	x := float32(state.Position.X)
+	y := float32(state.Position.Y)
+	
+	// Interpolar color según brillo
+	clr := stateColor(state)
 	
 	// Dibujar núcleo brillante
-	coreRadius := float32(config.FireflySize * state.Brightness)
+	coreRadius := float32(config.Get().Fireflies.Size * state.Brightness)
 	if coreRadius < 2 {
 		coreRadius = 2
 	}
@@ -72,14 +120,28 @@ if state.Brightness > 0.1 {
 	}
 }
 
-// DrawLantern dibuja un farol con efecto de pulso
-func (r *Renderer) DrawLantern(screen *ebiten.Image, lantern *core.Lantern) {
+// DrawFadingFirefly dibuja una luciérnaga que se está apagando: alpha va de
+// 1 a 0 y con él se achica y se vuelve transparente
+func (r *Renderer) DrawFadingFirefly(screen *ebiten.Image, state core.FireflyState, alpha float64) {
+	x := float32(state.Position.X)
+	y := float32(state.Position.Y)
+	clr := stateColor(state)
+
+	radius := float32(config.Get().Fireflies.Size * (0.5 + state.Brightness) * alpha)
+	haloColor := utils.WithAlpha(clr, uint8(float64(clr.A)*0.3*alpha))
+	vector.DrawFilledCircle(screen, x, y, radius*1.8, haloColor, false)
+	vector.DrawFilledCircle(screen, x, y, radius, utils.WithAlpha(clr, uint8(float64(clr.A)*alpha)), false)
+}
+
+// DrawLantern dibuja un farol con efecto de pulso; dim atenúa su brillo
+// (1 es el normal, menos durante una tormenta)
+func (r *Renderer) DrawLantern(screen *ebiten.Image, lantern *core.Lantern, dim float64) {
 	x := float32(lantern.Position.X)
 	y := float32(lantern.Position.Y)
-	intensity := lantern.GetIntensity()
+	intensity := lantern.GetIntensity() * dim
 	
 	// Color base del farol
-	baseColor := utils.ArrayToRGBA(config.LanternColor)
+	baseColor := utils.ArrayToRGBA(theme.Current().Lantern)
 	
 	// Dibujar aura de influencia (círculo grande transparente)
 	auraRadius := float32(lantern.Radius)
@@ -95,7 +157,7 @@ func (r *Renderer) DrawLantern(screen *ebiten.Image, lantern *core.Lantern) {
 	}
 	
 	// Dibujar núcleo del farol
-	coreRadius := float32(config.LanternSize)
+	coreRadius := float32(config.Get().Lanterns.Size)
 	coreColor := utils.Brighten(baseColor, 0.3)
 	vector.DrawFilledCircle(screen, x, y, coreRadius, coreColor, false)
 	
@@ -108,18 +170,43 @@ func (r *Renderer) DrawLantern(screen *ebiten.Image, lantern *core.Lantern) {
 	vector.DrawFilledCircle(screen, x, y, centerRadius*0.4, color.RGBA{R: 255, G: 255, B: 255, A: 255}, false)
 }
 
+// DrawLanternPreview dibuja el contorno del farol que se colocaría en pos,
+// con su radio de influencia; alpha lo desvanece
+func (r *Renderer) DrawLanternPreview(screen *ebiten.Image, pos utils.Vector2D, radius, alpha float64) {
+	x := float32(pos.X)
+	y := float32(pos.Y)
+	baseColor := utils.ArrayToRGBA(theme.Current().Lantern)
+
+	vector.DrawFilledCircle(screen, x, y, float32(radius), utils.WithAlpha(baseColor, uint8(18*alpha)), false)
+
+	// Anillo punteado: segmentos cortos alrededor del radio
+	const segments = 48
+	ringColor := utils.WithAlpha(baseColor, uint8(160*alpha))
+	for i := 0; i < segments; i += 2 {
+		a0 := 2 * math.Pi * float64(i) / segments
+		a1 := 2 * math.Pi * float64(i+1) / segments
+		vector.StrokeLine(screen,
+			x+float32(radius*math.Cos(a0)), y+float32(radius*math.Sin(a0)),
+			x+float32(radius*math.Cos(a1)), y+float32(radius*math.Sin(a1)),
+			2, ringColor, true)
+	}
+
+	vector.StrokeCircle(screen, x, y, float32(config.Get().Lanterns.Size), 1.5, ringColor, true)
+}
+
 // DrawWind dibuja indicadores visuales del viento
-func (r *Renderer) DrawWind(screen *ebiten.Image, wind *core.Wind) {
-	force := wind.GetForce()
+func (r *Renderer) DrawWind(screen *ebiten.Image, wind core.WindSnapshot) {
+	sw, sh := config.ScreenSize()
+	force := wind.Force
 	
 	// Dibujar partículas de viento en varias posiciones
 	particleCount := 12
-	particleColor := utils.ArrayToRGBA(config.WindColor)
+	particleColor := utils.ArrayToRGBA(theme.Current().Wind)
 	
 	for i := 0; i < particleCount; i++ {
 		// Posición inicial aleatoria pero determinística
-		startX := float32(i * config.ScreenWidth / particleCount)
-		startY := float32((i*137) % config.ScreenHeight) // Patrón pseudo-aleatorio
+		startX := float32(i * sw / particleCount)
+		startY := float32((i*137) % sh) // Patrón pseudo-aleatorio
 		
 		// Línea que indica dirección del viento
 		endX := startX + float32(force.X)*30
@@ -133,6 +220,20 @@ func (r *Renderer) DrawWind(screen *ebiten.Image, wind *core.Wind) {
 	}
 }
 
+// DrawStorm dibuja el borde delantero del frente de tormenta en now; la
+// lluvia de la franja la sueltan las partículas
+func (r *Renderer) DrawStorm(screen *ebiten.Image, storm *core.StormFront, now time.Time) {
+	if storm == nil {
+		return
+	}
+	width, height := config.WorldSize()
+	reach := math.Hypot(width, height) / 2
+	edge := storm.Edge(now)
+
+	a, b := stormPoint(storm.Direction, edge, -reach), stormPoint(storm.Direction, edge, reach)
+	vector.StrokeLine(screen, float32(a.X), float32(a.Y), float32(b.X), float32(b.Y), 3, color.RGBA{R: 220, G: 230, B: 255, A: 140}, true)
+}
+
 // drawArrowHead dibuja la punta de una flecha
 func (r *Renderer) drawArrowHead(screen *ebiten.Image, x1, y1, x2, y2 float32, clr color.RGBA) {
 	// Calcular ángulo de la línea
@@ -172,26 +273,54 @@ func (r *Renderer) DrawAttractionPoint(screen *ebiten.Image, point utils.Vector2
 	vector.StrokeLine(screen, x, y-crossSize, x, y+crossSize, 2, clr, false)
 }
 
+// DrawBat dibuja un murciélago de perfil oscuro; las alas suben y bajan con
+// la fase del aleteo y el cuerpo apunta hacia donde vuela
+func (r *Renderer) DrawBat(screen *ebiten.Image, bat core.BatState) {
+	size := config.Get().Bats.Size
+	x, y := bat.Position.X, bat.Position.Y
+	flap := math.Sin(bat.Wing * 2 * math.Pi)
+
+	// Eje del cuerpo y su perpendicular (las alas)
+	ax, ay := math.Cos(bat.Heading), math.Sin(bat.Heading)
+	px, py := -ay, ax
+
+	body := color.RGBA{R: 25, G: 20, B: 35, A: 255}
+	edge := color.RGBA{R: 120, G: 90, B: 150, A: 200}
+	for _, side := range []float64{-1, 1} {
+		tipX := x + px*side*size - ax*size*0.3
+		tipY := y + py*side*size - ay*size*0.3 + flap*size*0.5
+		midX := x + px*side*size*0.5 - ax*size*0.1
+		midY := y + py*side*size*0.5 - ay*size*0.1 + flap*size*0.25
+
+		vector.StrokeLine(screen, float32(x), float32(y), float32(midX), float32(midY), float32(size*0.35), body, true)
+		vector.StrokeLine(screen, float32(midX), float32(midY), float32(tipX), float32(tipY), float32(size*0.2), body, true)
+		vector.StrokeLine(screen, float32(midX), float32(midY), float32(tipX), float32(tipY), 1, edge, true)
+	}
+	vector.DrawFilledCircle(screen, float32(x), float32(y), float32(size*0.3), body, true)
+	vector.DrawFilledCircle(screen, float32(x+ax*size*0.3), float32(y+ay*size*0.3), float32(size*0.18), body, true)
+}
+
 // DrawGrid dibuja una grilla de referencia (útil para debug)
 func (r *Renderer) DrawGrid(screen *ebiten.Image, cellSize int) {
+	sw, sh := config.ScreenSize()
 	gridColor := color.RGBA{R: 50, G: 50, B: 80, A: 50}
 	
 	// Líneas verticales
-	for x := 0; x < config.ScreenWidth; x += cellSize {
+	for x := 0; x < sw; x += cellSize {
 		vector.StrokeLine(
 			screen,
 			float32(x), 0,
-			float32(x), float32(config.ScreenHeight),
+			float32(x), float32(sh),
 			1, gridColor, false,
 		)
 	}
 	
 	// Líneas horizontales
-	for y := 0; y < config.ScreenHeight; y += cellSize {
+	for y := 0; y < sh; y += cellSize {
 		vector.StrokeLine(
 			screen,
 			0, float32(y),
-			float32(config.ScreenWidth), float32(y),
+			float32(sw), float32(y),
 			1, gridColor, false,
 		)
 	}
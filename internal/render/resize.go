@@ -0,0 +1,51 @@
+package render
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// fitImage retorna img si ya mide width×height; si no (o es nil) la libera
+// y crea una nueva de ese tamaño. Las capas de pantalla completa la usan
+// para seguir a la ventana.
+func fitImage(img *ebiten.Image, width, height int) *ebiten.Image {
+	if img != nil {
+		if b := img.Bounds(); b.Dx() == width && b.Dy() == height {
+			return img
+		}
+		img.Deallocate()
+	}
+	return ebiten.NewImage(width, height)
+}
+
+// screenLayout elige el tamaño lógico para una ventana de outsideWidth×
+// outsideHeight: el mismo que la ventana mientras quepa entre el mínimo y
+// el máximo de config; si no, el más cercano con la misma proporción, que
+// Ebiten escala sin bandas negras
+func screenLayout(outsideWidth, outsideHeight int) (int, int) {
+	if outsideWidth <= 0 || outsideHeight <= 0 {
+		return config.ScreenWidth, config.ScreenHeight
+	}
+	w, h := float64(outsideWidth), float64(outsideHeight)
+	grow := max(config.MinScreenWidth/w, config.MinScreenHeight/h, 1)
+	shrink := min(config.MaxScreenWidth/w, config.MaxScreenHeight/h, 1)
+	scale := grow * shrink
+	width := int(utils.Clamp(w*scale, config.MinScreenWidth, config.MaxScreenWidth))
+	height := int(utils.Clamp(h*scale, config.MinScreenHeight, config.MaxScreenHeight))
+	return width, height
+}
+
+// fitScreen ajusta las capas del mundo y el panel de herramientas al
+// tamaño lógico actual; no hace nada si no cambió. Sin mapa las capas
+// miden lo que la pantalla; con un mapa, lo que su mundo.
+func (g *Game) fitScreen() {
+	worldWidth, worldHeight := config.WorldPixels()
+	g.worldLayer = fitImage(g.worldLayer, worldWidth, worldHeight)
+	g.fireflyLayer = fitImage(g.fireflyLayer, worldWidth, worldHeight)
+	g.photoMode.Resize(worldWidth, worldHeight)
+	if g.bloom != nil {
+		g.bloom.Resize(worldWidth, worldHeight)
+	}
+	g.tools.place(g)
+}
@@ -0,0 +1,284 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"runtime"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/input"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/internal/theme"
+)
+
+// SessionSummary resume una partida terminada
+type SessionSummary struct {
+	Duration       time.Duration
+	PeakFireflies  int
+	FinalFireflies int
+	LanternsPlaced int
+	DroppedStates  uint64
+	Mode           GameMode
+	Captured       int
+	Levels         int
+	LevelsTotal    int
+	Waves          int
+	Extinct        bool
+	Score          manager.ScoreState
+	Seed           int64
+	// Challenge es la fecha del desafío diario y DailyBest el mejor puntaje
+	// de esa fecha antes de esta partida
+	Challenge string
+	DailyBest int
+	// TutorialDone indica que se cumplieron todos los pasos del tutorial
+	TutorialDone bool
+	// Replay indica que era una repetición: no va a la tabla de récords
+	Replay bool
+	// Preset es el escenario listo de la partida: tampoco va a los récords,
+	// cambia las reglas del jardín libre
+	Preset string
+}
+
+// menuList es una lista vertical de botones navegable con teclado y mouse;
+// las etiquetas se traducen al dibujar. top ubica el primer botón según el
+// tamaño actual de la pantalla.
+type menuList struct {
+	items    []string
+	selected int
+	top      func(height float32) float32
+}
+
+const (
+	menuButtonWidth  = 260
+	menuButtonHeight = 44
+	menuButtonGap    = 16
+)
+
+// buttonRect retorna el rectángulo del botón i; si la lista no entra en
+// la pantalla los botones se juntan, hasta quedar casi pegados
+func (m *menuList) buttonRect(i int) (x, y, w, h float32) {
+	sw, sh := config.ScreenSize()
+	top := m.top(float32(sh))
+	step := float32(menuButtonHeight + menuButtonGap)
+	if fit := (float32(sh) - menuButtonGap - top) / float32(len(m.items)); fit < step {
+		step = max(fit, menuButtonHeight+4)
+	}
+	x = float32(sw-menuButtonWidth) / 2
+	y = top + float32(i)*step
+	return x, y, menuButtonWidth, menuButtonHeight
+}
+
+// Update procesa flechas, Enter y mouse; retorna el índice activado o -1
+func (m *menuList) Update(h *input.Handler) int {
+	if h.IsKeyJustPressed(ebiten.KeyArrowDown) {
+		m.selected = (m.selected + 1) % len(m.items)
+	}
+	if h.IsKeyJustPressed(ebiten.KeyArrowUp) {
+		m.selected = (m.selected + len(m.items) - 1) % len(m.items)
+	}
+	if h.IsKeyJustPressed(ebiten.KeyEnter) || h.IsKeyJustPressed(ebiten.KeySpace) {
+		return m.selected
+	}
+
+	p := h.Pointer()
+	for i := range m.items {
+		x, y, w, bh := m.buttonRect(i)
+		if float32(p.X) >= x && float32(p.X) <= x+w && float32(p.Y) >= y && float32(p.Y) <= y+bh {
+			m.selected = i
+			if p.JustPressed {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// Draw dibuja los botones resaltando el seleccionado
+func (m *menuList) Draw(screen *ebiten.Image, ui *UIRenderer) {
+	for i, label := range m.items {
+		x, y, w, h := m.buttonRect(i)
+		ui.DrawButton(screen, x, y, w, h, i18n.T(label), i == m.selected)
+	}
+}
+
+// drawSceneBackground dibuja el fondo nocturno con un velo para las pantallas de menú
+func drawSceneBackground(screen *ebiten.Image) {
+	sw, sh := config.ScreenSize()
+	bg := theme.Current().Background
+	screen.Fill(color.RGBA{R: bg[0], G: bg[1], B: bg[2], A: 255})
+	vector.DrawFilledRect(screen, 0, 0, float32(sw), float32(sh), color.RGBA{R: 0, G: 0, B: 0, A: 80}, false)
+}
+
+// canQuit indica si la plataforma tiene una ventana que cerrar: en el
+// navegador salir dejaría el canvas vacío y en el celular la app la cierra
+// el sistema
+func canQuit() bool {
+	switch runtime.GOOS {
+	case "js", "android", "ios":
+		return false
+	}
+	return true
+}
+
+// MenuScene es el menú principal
+type MenuScene struct {
+	app  *App
+	menu *menuList
+}
+
+// NewMenuScene crea el menú principal
+func NewMenuScene(app *App) *MenuScene {
+	items := []string{"Niveles", "Jardín libre", "Escenarios", "Supervivencia", "Desafío diario", "Frasco (minijuego)", "Tutorial", "Editor de mapas", "Récords", "Configuración", "Salir"}
+	if !canQuit() {
+		items = items[:len(items)-1]
+	}
+
+	return &MenuScene{
+		app: app,
+		menu: &menuList{
+			items: items,
+			top:   func(height float32) float32 { return height/2 - 210 },
+		},
+	}
+}
+
+// Update procesa la selección del menú
+func (s *MenuScene) Update() error {
+	if s.app.inputHandler.IsKeyJustPressed(ebiten.KeyEscape) && canQuit() {
+		s.app.Quit()
+		return nil
+	}
+
+	switch s.menu.Update(s.app.inputHandler) {
+	case 0:
+		s.app.StartMode(ModeLevels)
+	case 1:
+		s.app.StartMode(ModeGarden)
+	case 2:
+		s.app.ShowPresets()
+	case 3:
+		s.app.StartMode(ModeSurvival)
+	case 4:
+		s.app.StartMode(ModeDaily)
+	case 5:
+		s.app.StartMode(ModeJar)
+	case 6:
+		s.app.StartMode(ModeTutorial)
+	case 7:
+		s.app.ShowEditor()
+	case 8:
+		s.app.ShowLeaderboard()
+	case 9:
+		s.app.ShowSettings()
+	case 10:
+		s.app.Quit()
+	}
+	return nil
+}
+
+// Draw dibuja el título y los botones
+func (s *MenuScene) Draw(screen *ebiten.Image) {
+	_, sh := config.ScreenSize()
+	drawSceneBackground(screen)
+
+	ui := s.app.uiRenderer
+	ui.drawTitleCentered(screen, i18n.T("🌙 Jardín de Luciérnagas"), float64(sh)/8, color.RGBA{R: 255, G: 255, B: 200, A: 255})
+	ui.drawTextCentered(screen, i18n.T("Proyecto de Programación Concurrente"), float64(sh)/8+60, color.RGBA{R: 180, G: 180, B: 220, A: 255})
+
+	s.menu.Draw(screen, ui)
+}
+
+// SummaryScene muestra el resumen de la partida terminada
+type SummaryScene struct {
+	app     *App
+	summary SessionSummary
+	menu    *menuList
+}
+
+// NewSummaryScene crea la pantalla de fin de partida
+func NewSummaryScene(app *App, summary SessionSummary) *SummaryScene {
+	return &SummaryScene{
+		app:     app,
+		summary: summary,
+		menu: &menuList{
+			items: []string{"Jugar de nuevo", "Récords", "Menú principal"},
+			top:   func(height float32) float32 { return height - 220 },
+		},
+	}
+}
+
+// Update procesa la selección
+func (s *SummaryScene) Update() error {
+	switch s.menu.Update(s.app.inputHandler) {
+	case 0:
+		s.app.StartGame()
+	case 1:
+		s.app.ShowLeaderboard()
+	case 2:
+		s.app.ShowMenu()
+	}
+	return nil
+}
+
+// Draw dibuja las estadísticas de la partida
+func (s *SummaryScene) Draw(screen *ebiten.Image) {
+	drawSceneBackground(screen)
+
+	ui := s.app.uiRenderer
+	title := i18n.T("Fin de la partida")
+	timeLabel := i18n.T("Tiempo jugado")
+	var lines []string
+	switch s.summary.Mode {
+	case ModeJar:
+		title = i18n.T("¡Se acabó el tiempo!")
+		lines = append(lines, i18n.T("Luciérnagas atrapadas: %s", i18n.Number(s.summary.Captured)))
+	case ModeLevels:
+		if s.summary.Levels == s.summary.LevelsTotal {
+			title = i18n.T("¡Superaste todos los niveles!")
+		}
+		lines = append(lines, i18n.T("Niveles superados: %d de %d", s.summary.Levels, s.summary.LevelsTotal))
+	case ModeSurvival:
+		if s.summary.Extinct {
+			title = i18n.T("El jardín se apagó")
+		}
+		timeLabel = i18n.T("Tiempo sobrevivido")
+		lines = append(lines, i18n.T("Oleadas de murciélagos: %s", i18n.Number(s.summary.Waves)))
+	case ModeTutorial:
+		if s.summary.TutorialDone {
+			title = i18n.T("¡Tutorial completado!")
+		}
+	case ModeDaily:
+		title = i18n.T("Desafío del %s", s.summary.Challenge)
+		if s.summary.Score.Points > s.summary.DailyBest && !s.summary.Replay {
+			lines = append(lines, i18n.T("¡Nuevo récord del día!"))
+		} else {
+			lines = append(lines, i18n.T("Mejor del día: %s", i18n.Number(s.summary.DailyBest)))
+		}
+	}
+	ui.drawTitleCentered(screen, title, 120, color.RGBA{R: 255, G: 200, B: 120, A: 255})
+
+	score := s.summary.Score
+	lines = append(lines,
+		i18n.T("Puntaje: %s  (mejor racha %ds, %s)", i18n.Number(score.Points), score.BestStreak, i18n.Plural(score.Flashes, "%s destello", "%s destellos")),
+		i18n.T("Resplandor máximo: %.0f  (%s)", score.BestGlow, i18n.Plural(len(score.Achievements), "%s logro", "%s logros")),
+		fmt.Sprintf("%s: %s", timeLabel, s.summary.Duration.Round(time.Second)),
+		i18n.T("Población máxima: %s", i18n.Number(s.summary.PeakFireflies)),
+		i18n.T("Población final: %s", i18n.Number(s.summary.FinalFireflies)),
+		i18n.T("Faroles colocados: %s", i18n.Number(s.summary.LanternsPlaced)),
+		i18n.T("Estados descartados: %s", i18n.Number(int(s.summary.DroppedStates))),
+	)
+
+	y := 220.0
+	textColor := color.RGBA{R: 220, G: 220, B: 220, A: 255}
+	for _, line := range lines {
+		ui.drawTextCentered(screen, line, y, textColor)
+		y += 32
+	}
+
+	s.menu.Draw(screen, ui)
+}
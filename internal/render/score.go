@@ -0,0 +1,62 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/internal/theme"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// scoreGainDuration es cuánto se ve la última ganancia de puntos
+const scoreGainDuration = 1500 * time.Millisecond
+
+// scorePanelHeight es el alto del panel de puntaje con el medidor
+const scorePanelHeight = 86
+
+// DrawScore dibuja el puntaje abajo a la derecha: puntos, multiplicador del
+// combo, racha, el medidor de resplandor y la última ganancia
+// desvaneciéndose
+func (u *UIRenderer) DrawScore(screen *ebiten.Image, score manager.ScoreState, glow manager.Glow) {
+	sw, sh := config.ScreenSize()
+	width, height := float32(230), float32(scorePanelHeight)
+	x := float32(sw) - width - 10
+	y := float32(sh) - height - 10
+
+	border := color.RGBA{R: 200, G: 170, B: 80, A: 200}
+	if score.Multiplier > 1 {
+		border = color.RGBA{R: 255, G: 210, B: 90, A: 255}
+	}
+	vector.DrawFilledRect(screen, x, y, width, height, utils.ArrayToRGBA(theme.Current().Panel), false)
+	vector.StrokeRect(screen, x, y, width, height, 1, border, false)
+
+	u.drawText(screen, "⭐ "+i18n.Number(score.Points), float64(x)+10, float64(y)+6, color.RGBA{R: 255, G: 240, B: 170, A: 255})
+	if score.Multiplier > 1 {
+		u.drawText(screen, fmt.Sprintf("x%d", score.Multiplier), float64(x+width)-40, float64(y)+6, color.RGBA{R: 255, G: 180, B: 80, A: 255})
+	}
+	u.drawText(screen, i18n.T("Racha: %ds  Destellos: %s", score.Streak, i18n.Number(score.Flashes)), float64(x)+10, float64(y)+32, color.RGBA{R: 200, G: 200, B: 210, A: 255})
+
+	// Medidor de resplandor: el largo es la intensidad (cuánto de lo posible
+	// con esta población) y el número el resplandor, que es lo que puntúa
+	meterX, meterY, meterWidth := x+10, y+62, width-80
+	intensity := float32(glow.Intensity())
+	vector.DrawFilledRect(screen, meterX, meterY, meterWidth, 10, color.RGBA{R: 40, G: 40, B: 50, A: 255}, false)
+	vector.DrawFilledRect(screen, meterX, meterY, meterWidth*intensity, 10, color.RGBA{R: 255, G: uint8(170 + 70*intensity), B: 80, A: 255}, false)
+	vector.StrokeRect(screen, meterX, meterY, meterWidth, 10, 1, color.RGBA{R: 150, G: 150, B: 150, A: 255}, false)
+	u.drawText(screen, fmt.Sprintf("✨ %.0f", glow.Value), float64(meterX+meterWidth)+8, float64(meterY)-6, color.RGBA{R: 255, G: 230, B: 150, A: 255})
+
+	// La última ganancia sube y se desvanece sobre el panel
+	since := time.Since(score.LastAt)
+	if score.LastGain > 0 && since < scoreGainDuration {
+		t := since.Seconds() / scoreGainDuration.Seconds()
+		alpha := uint8(255 * (1 - t))
+		label := fmt.Sprintf("+%d %s", score.LastGain, score.LastReason)
+		u.drawText(screen, label, float64(x)+10, float64(y)-22-t*16, color.RGBA{R: 255, G: 230, B: 120, A: alpha})
+	}
+}
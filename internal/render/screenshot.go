@@ -0,0 +1,46 @@
+package render
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// screenshotMode es la captura pedida con F12 para el próximo frame
+type screenshotMode int
+
+const (
+	screenshotNone screenshotMode = iota
+	// screenshotFull es el frame tal cual se ve, con la interfaz
+	screenshotFull
+	// screenshotClean es solo el jardín: se toma antes de dibujar el HUD
+	screenshotClean
+)
+
+// requestScreenshot pide una captura para el próximo Draw; clean la pide
+// sin interfaz
+func (g *Game) requestScreenshot(clean bool) {
+	g.screenshot = screenshotFull
+	if clean {
+		g.screenshot = screenshotClean
+	}
+}
+
+// takeScreenshot copia la pantalla a una capa propia, lee sus píxeles y
+// guarda el PNG en capture.dir con nombre garden-AAAAMMDD-HHMMSS.png. Solo
+// la lectura ocurre en el frame; codificar y escribir van en una goroutine.
+func (g *Game) takeScreenshot(screen *ebiten.Image) {
+	if g.screenshot == screenshotNone {
+		return
+	}
+	g.screenshot = screenshotNone
+
+	b := screen.Bounds()
+	g.shotLayer = fitImage(g.shotLayer, b.Dx(), b.Dy())
+	g.shotLayer.Clear()
+	g.shotLayer.DrawImage(screen, nil)
+
+	savePNGAsync(captureImage(g.shotLayer), capturePath("garden"), func(path string, err error) {
+		if err != nil {
+			g.toasts.Warn("No se pudo guardar la captura: " + err.Error())
+			return
+		}
+		g.toasts.Push("Captura guardada en " + path)
+	})
+}
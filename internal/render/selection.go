@@ -0,0 +1,139 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// minSelectionDrag es el tamaño mínimo del rectángulo; Shift + click sin
+// arrastrar limpia la selección
+const minSelectionDrag = 4.0
+
+// Selection es el grupo de luciérnagas elegido con Shift + arrastrar. Las
+// coordenadas son del mundo; la selección se prueba contra el último
+// snapshot de estados y se olvida de las que mueren.
+type Selection struct {
+	ids      map[int]bool
+	order    manager.GroupOrderKind
+	dragging bool
+	start    utils.Vector2D
+	end      utils.Vector2D
+}
+
+// NewSelection crea una selección vacía
+func NewSelection() *Selection {
+	return &Selection{ids: make(map[int]bool)}
+}
+
+// Len retorna cuántas luciérnagas están seleccionadas
+func (s *Selection) Len() int {
+	return len(s.ids)
+}
+
+// Dragging indica si se está dibujando el rectángulo
+func (s *Selection) Dragging() bool {
+	return s.dragging
+}
+
+// Begin empieza el rectángulo en pos
+func (s *Selection) Begin(pos utils.Vector2D) {
+	s.dragging = true
+	s.start = pos
+	s.end = pos
+}
+
+// Drag extiende el rectángulo hasta pos
+func (s *Selection) Drag(pos utils.Vector2D) {
+	s.end = pos
+}
+
+// rect retorna el rectángulo normalizado
+func (s *Selection) rect() (minX, minY, maxX, maxY float64) {
+	return math.Min(s.start.X, s.end.X), math.Min(s.start.Y, s.end.Y),
+		math.Max(s.start.X, s.end.X), math.Max(s.start.Y, s.end.Y)
+}
+
+// End cierra el rectángulo y selecciona las luciérnagas que quedaron dentro
+// según states; retorna cuántas eligió
+func (s *Selection) End(states []core.FireflyState) int {
+	s.dragging = false
+	clear(s.ids)
+	s.order = ""
+
+	minX, minY, maxX, maxY := s.rect()
+	if maxX-minX < minSelectionDrag && maxY-minY < minSelectionDrag {
+		return 0
+	}
+	for _, state := range states {
+		p := state.Position
+		if p.X >= minX && p.X <= maxX && p.Y >= minY && p.Y <= maxY {
+			s.ids[state.ID] = true
+		}
+	}
+	return len(s.ids)
+}
+
+// Order arma la orden para el grupo; Release además vacía la selección
+func (s *Selection) Order(kind manager.GroupOrderKind, pos utils.Vector2D) manager.GroupOrder {
+	ids := make([]int, 0, len(s.ids))
+	for id := range s.ids {
+		ids = append(ids, id)
+	}
+
+	s.order = kind
+	if kind == manager.GroupRelease {
+		clear(s.ids)
+		s.order = ""
+	}
+	return manager.GroupOrder{Kind: kind, IDs: ids, Position: pos}
+}
+
+// Draw dibuja el rectángulo en curso y un anillo alrededor de cada
+// seleccionada; quita de la selección las que ya no están en states
+func (s *Selection) Draw(world *ebiten.Image, states []core.FireflyState) {
+	if s.dragging {
+		minX, minY, maxX, maxY := s.rect()
+		w, h := float32(maxX-minX), float32(maxY-minY)
+		vector.DrawFilledRect(world, float32(minX), float32(minY), w, h, color.RGBA{R: 120, G: 200, B: 255, A: 30}, false)
+		vector.StrokeRect(world, float32(minX), float32(minY), w, h, 1, color.RGBA{R: 120, G: 200, B: 255, A: 200}, false)
+	}
+	if len(s.ids) == 0 {
+		return
+	}
+
+	ring := color.RGBA{R: 120, G: 220, B: 255, A: 220}
+	switch s.order {
+	case manager.GroupFreeze:
+		ring = color.RGBA{R: 200, G: 230, B: 255, A: 255}
+	case manager.GroupAttract:
+		ring = color.RGBA{R: 255, G: 200, B: 120, A: 220}
+	}
+
+	radius := float32(config.Get().Fireflies.Size * 2.5)
+	seen := make(map[int]bool, len(s.ids))
+	for _, state := range states {
+		if !s.ids[state.ID] {
+			continue
+		}
+		seen[state.ID] = true
+		vector.StrokeCircle(world, float32(state.Position.X), float32(state.Position.Y), radius, 1.5, ring, true)
+	}
+	for id := range s.ids {
+		if !seen[id] {
+			delete(s.ids, id)
+		}
+	}
+}
+
+// Status es la línea de ayuda mientras hay luciérnagas seleccionadas
+func (s *Selection) Status() string {
+	return fmt.Sprintf("Seleccionadas: %d — A atraer · F congelar · X soltar", len(s.ids))
+}
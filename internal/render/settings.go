@@ -0,0 +1,252 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/internal/theme"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// settingRow es un parámetro ajustable; los toggles son sliders de pasos enteros
+type settingRow struct {
+	label  string
+	min    float64
+	max    float64
+	step   float64
+	get    func() float64
+	set    func(float64)
+	format func(float64) string
+}
+
+const (
+	settingsTop       = 170
+	settingsRowHeight = 52
+	settingsLabelX    = 160
+	settingsSliderX   = 480
+	settingsSliderW   = 300
+)
+
+var qualityNames = []string{"Círculos", "Sprites", "Bloom"}
+
+// SettingsScene ajusta los parámetros de la simulación con sliders.
+// Los cambios se aplican de inmediato a la partida en curso (si la hay)
+// a través del canal de comandos del manager.
+type SettingsScene struct {
+	app      *App
+	back     Scene
+	rows     []settingRow
+	selected int
+	dragging bool
+}
+
+// NewSettingsScene crea la pantalla de configuración; back es la escena a la que se vuelve
+func NewSettingsScene(app *App, back Scene) *SettingsScene {
+	s := &SettingsScene{app: app, back: back}
+
+	s.rows = []settingRow{
+		{
+			label: "Luciérnagas máximas", min: 10, max: float64(config.Get().Fireflies.Max), step: 5,
+			get:    func() float64 { return float64(app.settings.MaxFireflies) },
+			set:    func(v float64) { app.settings.MaxFireflies = int(v) },
+			format: func(v float64) string { return fmt.Sprintf("%.0f", v) },
+		},
+		{
+			label: "Intervalo de aparición", min: config.MinSpawnInterval.Seconds(), max: 5, step: 0.1,
+			get:    func() float64 { return app.settings.SpawnInterval.Seconds() },
+			set:    func(v float64) { app.settings.SpawnInterval = time.Duration(v * float64(time.Second)) },
+			format: func(v float64) string { return i18n.Decimal(v, 1) + " s" },
+		},
+		{
+			label: "Fuerza del viento", min: 0, max: config.Get().Wind.MaxStrength, step: 0.1,
+			get:    func() float64 { return app.settings.WindStrength },
+			set:    func(v float64) { app.settings.WindStrength = v },
+			format: func(v float64) string { return i18n.Decimal(v, 1) },
+		},
+		{
+			label: "Radio de faroles", min: config.LanternRadiusMin, max: config.LanternRadiusMax, step: 10,
+			get:    func() float64 { return app.settings.LanternRadius },
+			set:    func(v float64) { app.settings.LanternRadius = v },
+			format: func(v float64) string { return fmt.Sprintf("%.0f px", v) },
+		},
+		{
+			label: "Calidad de render", min: config.QualityCircles, max: config.QualityBloom, step: 1,
+			get:    func() float64 { return float64(app.quality) },
+			set:    func(v float64) { app.quality = int(v) },
+			format: func(v float64) string { return i18n.T(qualityNames[int(v)]) },
+		},
+		{
+			// El primer paso, debajo del mínimo, es la escala automática
+			label: "Escala de la interfaz", min: uiScaleMin - uiScaleStep, max: uiScaleMax, step: uiScaleStep,
+			get: func() float64 {
+				if app.prefs.UIScale == 0 {
+					return uiScaleMin - uiScaleStep
+				}
+				return app.prefs.UIScale
+			},
+			set: func(v float64) {
+				if v < uiScaleMin {
+					v = 0
+				}
+				app.prefs.UIScale = v
+			},
+			format: func(v float64) string {
+				if v < uiScaleMin {
+					return i18n.T("Auto (x%g)", app.autoScale)
+				}
+				return fmt.Sprintf("x%g", v)
+			},
+		},
+		{
+			label: "Idioma", min: 0, max: float64(len(i18n.Languages()) - 1), step: 1,
+			get: func() float64 { return float64(slices.Index(i18n.Languages(), i18n.Current())) },
+			set: func(v float64) {
+				lang := i18n.Languages()[int(v)]
+				_ = i18n.Set(string(lang))
+				app.prefs.Language = string(lang)
+			},
+			format: func(v float64) string { return i18n.Languages()[int(v)].Name() },
+		},
+		{
+			label: "Tema", min: 0, max: float64(len(theme.Skins()) - 1), step: 1,
+			get: func() float64 {
+				id := theme.CurrentSkin().ID
+				return float64(slices.IndexFunc(theme.Skins(), func(s theme.Skin) bool { return s.ID == id }))
+			},
+			set: func(v float64) {
+				id := theme.Skins()[int(v)].ID
+				_ = theme.SetSkin(id)
+				app.prefs.Skin = id
+			},
+			format: func(v float64) string { return i18n.T(theme.Skins()[int(v)].Name) },
+		},
+		{
+			label: "Paleta", min: 0, max: float64(len(theme.IDs()) - 1), step: 1,
+			get: func() float64 { return float64(slices.Index(theme.IDs(), theme.Current().ID)) },
+			set: func(v float64) {
+				id := theme.IDs()[int(v)]
+				_ = theme.Set(id)
+				app.prefs.Palette = id
+			},
+			format: func(v float64) string {
+				p, _ := theme.Lookup(theme.IDs()[int(v)])
+				return i18n.T(p.Name)
+			},
+		},
+	}
+
+	return s
+}
+
+// Update procesa teclado (flechas) y mouse (click y arrastre sobre los sliders)
+func (s *SettingsScene) Update() error {
+	h := s.app.inputHandler
+
+	if h.IsKeyJustPressed(ebiten.KeyEscape) || h.IsKeyJustPressed(ebiten.KeyEnter) {
+		s.app.closeSettings(s.back)
+		return nil
+	}
+
+	if h.IsKeyJustPressed(ebiten.KeyArrowDown) {
+		s.selected = (s.selected + 1) % len(s.rows)
+	}
+	if h.IsKeyJustPressed(ebiten.KeyArrowUp) {
+		s.selected = (s.selected + len(s.rows) - 1) % len(s.rows)
+	}
+	if h.IsKeyJustPressed(ebiten.KeyArrowRight) {
+		s.adjust(s.selected, 1)
+	}
+	if h.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+		s.adjust(s.selected, -1)
+	}
+
+	// Restaurar valores por defecto
+	if h.IsKeyJustPressed(ebiten.KeyR) {
+		s.app.settings = manager.DefaultSettings()
+		s.app.quality = config.Get().Render.Quality
+		s.app.prefs.UIScale = 0
+		s.app.applySettings()
+	}
+
+	pointer := h.Pointer()
+	mx, my := pointer.X, pointer.Y
+	if pointer.JustPressed {
+		row := (my - settingsTop) / settingsRowHeight
+		if my >= settingsTop && row < len(s.rows) && mx >= settingsSliderX && mx <= settingsSliderX+settingsSliderW {
+			s.selected = row
+			s.dragging = true
+		}
+	}
+	if !pointer.Pressed {
+		s.dragging = false
+	}
+	if s.dragging {
+		t := utils.Clamp(float64(mx-settingsSliderX)/settingsSliderW, 0, 1)
+		s.setValue(s.selected, s.rows[s.selected].min+t*(s.rows[s.selected].max-s.rows[s.selected].min))
+	}
+
+	return nil
+}
+
+// adjust mueve el slider un paso en la dirección indicada
+func (s *SettingsScene) adjust(i, dir int) {
+	row := s.rows[i]
+	s.setValue(i, row.get()+float64(dir)*row.step)
+}
+
+// setValue redondea al paso del slider y aplica solo si el valor cambió
+func (s *SettingsScene) setValue(i int, v float64) {
+	row := s.rows[i]
+	v = row.min + math.Round((v-row.min)/row.step)*row.step
+	v = utils.Clamp(v, row.min, row.max)
+	if v == row.get() {
+		return
+	}
+	row.set(v)
+	s.app.applySettings()
+}
+
+// Draw dibuja una fila por parámetro con su slider
+func (s *SettingsScene) Draw(screen *ebiten.Image) {
+	drawSceneBackground(screen)
+
+	ui := s.app.uiRenderer
+	ui.drawTitleCentered(screen, i18n.T("Configuración"), 100, color.RGBA{R: 150, G: 200, B: 255, A: 255})
+
+	textColor := color.RGBA{R: 220, G: 220, B: 220, A: 255}
+	selectedColor := color.RGBA{R: 255, G: 255, B: 150, A: 255}
+	trackColor := color.RGBA{R: 60, G: 60, B: 90, A: 255}
+	fillColor := color.RGBA{R: 120, G: 160, B: 255, A: 255}
+
+	for i, row := range s.rows {
+		y := float32(settingsTop + i*settingsRowHeight)
+		clr := textColor
+		if i == s.selected {
+			clr = selectedColor
+		}
+
+		ui.drawText(screen, i18n.T(row.label), settingsLabelX, float64(y)+8, clr)
+
+		// Barra del slider y perilla
+		value := row.get()
+		t := float32((value - row.min) / (row.max - row.min))
+		trackY := y + 16
+		vector.DrawFilledRect(screen, settingsSliderX, trackY, settingsSliderW, 6, trackColor, false)
+		vector.DrawFilledRect(screen, settingsSliderX, trackY, settingsSliderW*t, 6, fillColor, false)
+		vector.DrawFilledCircle(screen, settingsSliderX+settingsSliderW*t, trackY+3, 9, clr, true)
+
+		ui.drawText(screen, row.format(value), settingsSliderX+settingsSliderW+24, float64(y)+8, clr)
+	}
+
+	hint := i18n.T("↑↓: Elegir  ←→ / Arrastrar: Ajustar  R: Restaurar  ESC: Volver")
+	ui.drawTextCentered(screen, hint, float64(settingsTop+len(s.rows)*settingsRowHeight+40), color.RGBA{R: 160, G: 160, B: 160, A: 255})
+	ui.drawTextCentered(screen, i18n.T("El radio se aplica a los faroles nuevos"), float64(settingsTop+len(s.rows)*settingsRowHeight+70), color.RGBA{R: 140, G: 140, B: 140, A: 255})
+}
@@ -0,0 +1,127 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/theme"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const spriteSize = 64
+
+// FireflyBatch acumula todas las luciérnagas del frame en un solo DrawTriangles32
+// usando sprites de brillo pre-renderizados en un atlas.
+// Los slices de vértices e índices se reutilizan entre frames.
+type FireflyBatch struct {
+	atlas    *ebiten.Image
+	glowRect image.Rectangle
+	coreRect image.Rectangle
+	vertices []ebiten.Vertex
+	indices  []uint32
+	// size y halo son el tamaño de las luciérnagas y la escala del halo de
+	// la skin, leídos una vez por frame en Begin y no por luciérnaga
+	size float64
+	halo float64
+}
+
+// NewFireflyBatch genera el atlas de sprites (halo gaussiano + núcleo suave)
+func NewFireflyBatch() *FireflyBatch {
+	img := image.NewRGBA(image.Rect(0, 0, spriteSize*2, spriteSize))
+	center := float64(spriteSize) / 2
+
+	for y := 0; y < spriteSize; y++ {
+		for x := 0; x < spriteSize; x++ {
+			dx := (float64(x) + 0.5 - center) / center
+			dy := (float64(y) + 0.5 - center) / center
+			d := math.Sqrt(dx*dx + dy*dy)
+
+			// Halo: caída gaussiana
+			glow := math.Exp(-d * d * 4)
+			if d >= 1 {
+				glow = 0
+			}
+			a := uint8(255 * glow)
+			img.SetRGBA(x, y, color.RGBA{R: a, G: a, B: a, A: a})
+
+			// Núcleo: disco con borde suavizado
+			disk := utils.Clamp((1-d)*center/2, 0, 1)
+			a = uint8(255 * disk)
+			img.SetRGBA(x+spriteSize, y, color.RGBA{R: a, G: a, B: a, A: a})
+		}
+	}
+
+	return &FireflyBatch{
+		atlas:    ebiten.NewImageFromImage(img),
+		glowRect: image.Rect(0, 0, spriteSize, spriteSize),
+		coreRect: image.Rect(spriteSize, 0, spriteSize*2, spriteSize),
+	}
+}
+
+// Begin vacía el lote conservando la capacidad reservada y toma el tamaño
+// y el halo del frame
+func (b *FireflyBatch) Begin() {
+	b.vertices = b.vertices[:0]
+	b.indices = b.indices[:0]
+	b.size = config.Get().Fireflies.Size
+	b.halo = theme.CurrentSkin().Glow.Halo
+}
+
+// AddFirefly agrega los quads de una luciérnaga; withHalo=false deja solo el núcleo
+func (b *FireflyBatch) AddFirefly(state core.FireflyState, withHalo bool) {
+	x := float32(state.Position.X)
+	y := float32(state.Position.Y)
+	clr := stateColor(state)
+
+	if withHalo && state.Brightness > 0.1 {
+		haloRadius := float32(b.size * 2.8 * (0.4 + 0.6*state.Brightness) * b.halo)
+		b.addQuad(b.glowRect, x, y, haloRadius, utils.WithAlpha(clr, uint8(float64(clr.A)*0.6)))
+	}
+
+	coreRadius := float32(b.size * state.Brightness)
+	if coreRadius < 2 {
+		coreRadius = 2
+	}
+	b.addQuad(b.coreRect, x, y, coreRadius, clr)
+
+	if state.Brightness > 0.7 {
+		centerColor := color.RGBA{R: 255, G: 255, B: 255, A: uint8(255 * state.Brightness)}
+		b.addQuad(b.coreRect, x, y, coreRadius*0.5, centerColor)
+	}
+}
+
+// Flush dibuja todo el lote en una sola llamada
+func (b *FireflyBatch) Flush(dst *ebiten.Image) {
+	if len(b.indices) == 0 {
+		return
+	}
+
+	op := &ebiten.DrawTrianglesOptions{}
+	op.Filter = ebiten.FilterLinear
+	dst.DrawTriangles32(b.vertices, b.indices, b.atlas, op)
+}
+
+// addQuad agrega un sprite centrado en (x, y) con el radio y color indicados
+func (b *FireflyBatch) addQuad(src image.Rectangle, x, y, radius float32, clr color.RGBA) {
+	base := uint32(len(b.vertices))
+
+	r := float32(clr.R) / 255
+	g := float32(clr.G) / 255
+	bl := float32(clr.B) / 255
+	a := float32(clr.A) / 255
+
+	sx0, sy0 := float32(src.Min.X), float32(src.Min.Y)
+	sx1, sy1 := float32(src.Max.X), float32(src.Max.Y)
+
+	b.vertices = append(b.vertices,
+		ebiten.Vertex{DstX: x - radius, DstY: y - radius, SrcX: sx0, SrcY: sy0, ColorR: r, ColorG: g, ColorB: bl, ColorA: a},
+		ebiten.Vertex{DstX: x + radius, DstY: y - radius, SrcX: sx1, SrcY: sy0, ColorR: r, ColorG: g, ColorB: bl, ColorA: a},
+		ebiten.Vertex{DstX: x - radius, DstY: y + radius, SrcX: sx0, SrcY: sy1, ColorR: r, ColorG: g, ColorB: bl, ColorA: a},
+		ebiten.Vertex{DstX: x + radius, DstY: y + radius, SrcX: sx1, SrcY: sy1, ColorR: r, ColorG: g, ColorB: bl, ColorA: a},
+	)
+	b.indices = append(b.indices, base, base+1, base+2, base+1, base+3, base+2)
+}
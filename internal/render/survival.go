@@ -0,0 +1,82 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+)
+
+// ModeSurvival es el jardín sin reposición automática y con oleadas de
+// murciélagos cada vez más grandes; termina cuando se apaga la última
+// luciérnaga
+const ModeSurvival GameMode = "supervivencia"
+
+const (
+	// survivalFirstWave es cuánto tarda en llegar la primera oleada
+	survivalFirstWave = 20 * time.Second
+	// survivalWaveInterval es el tiempo entre oleadas
+	survivalWaveInterval = 25 * time.Second
+	// survivalFirstBats son los murciélagos de la primera oleada; cada una
+	// trae uno más
+	survivalFirstBats = 2
+)
+
+// Survival cuenta el tiempo sobrevivido (sin la pausa) y cuándo llega la
+// próxima oleada
+type Survival struct {
+	elapsed  time.Duration
+	nextWave time.Duration
+	waves    int
+}
+
+// NewSurvival empieza con la primera oleada en camino
+func NewSurvival() *Survival {
+	return &Survival{nextWave: survivalFirstWave}
+}
+
+// Tick avanza el reloj; retorna cuántos murciélagos soltar (0 si todavía no
+// toca una oleada)
+func (s *Survival) Tick(dt float64) int {
+	step := time.Duration(dt * float64(time.Second))
+	s.elapsed += step
+	s.nextWave -= step
+	if s.nextWave > 0 {
+		return 0
+	}
+
+	s.nextWave = survivalWaveInterval
+	s.waves++
+	return survivalFirstBats + s.waves - 1
+}
+
+// Elapsed retorna el tiempo sobrevivido
+func (s *Survival) Elapsed() time.Duration {
+	return s.elapsed
+}
+
+// Waves retorna cuántas oleadas llegaron
+func (s *Survival) Waves() int {
+	return s.waves
+}
+
+// DrawPanel dibuja el tiempo sobrevivido y la próxima oleada arriba al centro
+func (s *Survival) DrawPanel(screen *ebiten.Image, ui *UIRenderer, population int) {
+	sw, _ := config.ScreenSize()
+	label := fmt.Sprintf("⏳ %s   Oleada %d en %s   Luciérnagas: %d",
+		formatClock(s.elapsed), s.waves+1, formatClock(max(s.nextWave, 0)), population)
+
+	width := float32(440)
+	x := (float32(sw) - width) / 2
+	vector.DrawFilledRect(screen, x, 44, width, 28, color.RGBA{R: 30, G: 20, B: 40, A: 200}, false)
+	vector.StrokeRect(screen, x, 44, width, 28, 1, color.RGBA{R: 170, G: 130, B: 200, A: 255}, false)
+
+	textColor := color.RGBA{R: 230, G: 230, B: 255, A: 255}
+	if population <= 5 {
+		textColor = color.RGBA{R: 255, G: 140, B: 120, A: 255}
+	}
+	ui.drawText(screen, label, float64(x)+12, 49, textColor)
+}
@@ -0,0 +1,120 @@
+package render
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+var (
+	obstacleFill   = color.RGBA{R: 24, G: 32, B: 26, A: 255}
+	obstacleStroke = color.RGBA{R: 60, G: 84, B: 62, A: 255}
+	pondFill       = color.RGBA{R: 8, G: 22, B: 48, A: 235}
+	pondRim        = color.RGBA{R: 50, G: 100, B: 160, A: 170}
+	worldEdge      = color.RGBA{R: 80, G: 90, B: 120, A: 160}
+	windZoneFill   = color.RGBA{R: 20, G: 40, B: 50, A: 60}
+	windZoneStroke = color.RGBA{R: 90, G: 170, B: 190, A: 110}
+	windZoneArrow  = color.RGBA{R: 120, G: 200, B: 220, A: 120}
+)
+
+const (
+	// windZoneSpacing es la separación entre las flechas de una zona de
+	// viento; avanzan a windZoneDrift píxeles por segundo
+	windZoneSpacing = 64.0
+	windZoneDrift   = 24.0
+)
+
+// DrawTerrain dibuja el terreno del mapa debajo de todo lo demás: el borde
+// del mundo, las zonas de viento, los estanques con un brillo que ondula y
+// los obstáculos
+func (r *Renderer) DrawTerrain(world *ebiten.Image, terrain *core.Terrain, now time.Time) {
+	if terrain == nil {
+		return
+	}
+
+	width, height := config.WorldSize()
+	vector.StrokeRect(world, 1, 1, float32(width)-2, float32(height)-2, 2, worldEdge, false)
+
+	t := float64(now.UnixMilli()) / 1000
+	for _, z := range terrain.WindZones {
+		drawWindZone(world, z, t)
+	}
+
+	for i, a := range terrain.Ponds {
+		drawArea(world, a, pondFill, pondRim)
+
+		// Dos ondas que se abren y se apagan desde el centro
+		c := a.Center()
+		size := a.R
+		if !a.Circle() {
+			size = min(a.W, a.H) / 2
+		}
+		for k := 0; k < 2; k++ {
+			phase := math.Mod(t*0.25+float64(i)*0.37+float64(k)*0.5, 1)
+			alpha := uint8(60 * (1 - phase))
+			ripple := color.RGBA{R: alpha / 2, G: alpha, B: alpha * 2, A: alpha}
+			vector.StrokeCircle(world, float32(c.X), float32(c.Y), float32(size*phase*0.8), 1, ripple, true)
+		}
+	}
+
+	for _, a := range terrain.Obstacles {
+		drawArea(world, a, obstacleFill, obstacleStroke)
+	}
+}
+
+// DrawPondReflections dibuja el reflejo de las luciérnagas que vuelan sobre
+// un estanque, un poco más abajo y apagado
+func (r *Renderer) DrawPondReflections(world *ebiten.Image, terrain *core.Terrain, states []core.FireflyState) {
+	if terrain == nil || len(terrain.Ponds) == 0 {
+		return
+	}
+
+	for _, s := range states {
+		for _, a := range terrain.Ponds {
+			if !a.Contains(s.Position) {
+				continue
+			}
+			clr := scaleAlpha(fireflyColor(s.Brightness), float32(0.15+0.3*s.Brightness))
+			vector.DrawFilledCircle(world, float32(s.Position.X), float32(s.Position.Y+8), 3, clr, true)
+			break
+		}
+	}
+}
+
+// drawArea rellena un área del mapa y marca su borde
+func drawArea(world *ebiten.Image, a core.Area, fill, stroke color.RGBA) {
+	if a.Circle() {
+		vector.DrawFilledCircle(world, float32(a.X), float32(a.Y), float32(a.R), fill, true)
+		vector.StrokeCircle(world, float32(a.X), float32(a.Y), float32(a.R), 2, stroke, true)
+		return
+	}
+	vector.DrawFilledRect(world, float32(a.X), float32(a.Y), float32(a.W), float32(a.H), fill, false)
+	vector.StrokeRect(world, float32(a.X), float32(a.Y), float32(a.W), float32(a.H), 2, stroke, false)
+}
+
+// drawWindZone dibuja una zona de viento con flechas que avanzan en su
+// dirección
+func drawWindZone(world *ebiten.Image, z core.WindZone, t float64) {
+	drawArea(world, z.Area, windZoneFill, windZoneStroke)
+
+	dir := z.Force().Normalize()
+	shift := dir.Mul(math.Mod(t*windZoneDrift, windZoneSpacing))
+	left, top, right, bottom := z.X, z.Y, z.X+z.W, z.Y+z.H
+	if z.Circle() {
+		left, top, right, bottom = z.X-z.R, z.Y-z.R, z.X+z.R, z.Y+z.R
+	}
+	for y := top - windZoneSpacing/2; y < bottom; y += windZoneSpacing {
+		for x := left - windZoneSpacing/2; x < right; x += windZoneSpacing {
+			from := utils.Vector2D{X: x, Y: y}.Add(shift)
+			if z.Contains(from) {
+				drawForceArrow(world, from, dir.Mul(18), windZoneArrow)
+			}
+		}
+	}
+}
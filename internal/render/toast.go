@@ -0,0 +1,171 @@
+package render
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	text "github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/manager"
+)
+
+const (
+	// toastDuration es cuánto permanece visible cada aviso
+	toastDuration = 4 * time.Second
+	// toastFade es el tramo final en que el aviso se desvanece
+	toastFade = 600 * time.Millisecond
+	// toastInbox es el buffer de avisos pendientes de mostrar
+	toastInbox = 32
+	// toastMax es cuántos avisos se apilan a la vez; los más viejos se van
+	toastMax = 5
+)
+
+type toast struct {
+	notice  manager.Notice
+	expires time.Time
+}
+
+// Toasts son avisos breves en pantalla. Push, Warn y Notify pueden llamarse
+// desde cualquier goroutine (por ejemplo al terminar una escritura a disco):
+// el aviso entra por un canal y solo el render toca la lista. Follow suma
+// otra fuente, como los avisos del manager.
+type Toasts struct {
+	inbox   chan manager.Notice
+	sources []<-chan manager.Notice
+	items   []toast
+}
+
+// NewToasts crea la cola de avisos vacía
+func NewToasts() *Toasts {
+	inbox := make(chan manager.Notice, toastInbox)
+	return &Toasts{inbox: inbox, sources: []<-chan manager.Notice{inbox}}
+}
+
+// Follow muestra también los avisos que lleguen por ch; debe llamarse antes
+// del primer Draw
+func (t *Toasts) Follow(ch <-chan manager.Notice) {
+	t.sources = append(t.sources, ch)
+}
+
+// Push agrega un aviso informativo ya traducido
+func (t *Toasts) Push(message string) {
+	t.Notify(manager.Notice{Level: manager.NoticeInfo, Format: message})
+}
+
+// Warn agrega un aviso ya traducido de algo que falló o no se pudo hacer
+func (t *Toasts) Warn(message string) {
+	t.Notify(manager.Notice{Level: manager.NoticeWarning, Format: message})
+}
+
+// Notify agrega un aviso sin bloquear; si hay demasiados pendientes se
+// descarta
+func (t *Toasts) Notify(notice manager.Notice) {
+	select {
+	case t.inbox <- notice:
+	default:
+	}
+}
+
+// receive pasa a la lista los avisos pendientes de todas las fuentes
+func (t *Toasts) receive(now time.Time) {
+	for _, ch := range t.sources {
+		for drained := false; !drained; {
+			select {
+			case notice := <-ch:
+				t.add(notice, now)
+			default:
+				drained = true
+			}
+		}
+	}
+}
+
+// add agrega el aviso; si el mismo mensaje ya está en pantalla solo le
+// renueva el tiempo, así un límite alcanzado varias veces no llena la pila
+func (t *Toasts) add(notice manager.Notice, now time.Time) {
+	expires := now.Add(toastDuration)
+	message := noticeText(notice)
+	for i := range t.items {
+		if t.items[i].notice.Level == notice.Level && noticeText(t.items[i].notice) == message {
+			t.items[i].expires = expires
+			return
+		}
+	}
+
+	t.items = append(t.items, toast{notice: notice, expires: expires})
+	if len(t.items) > toastMax {
+		t.items = t.items[len(t.items)-toastMax:]
+	}
+}
+
+// active recibe los avisos nuevos, descarta los vencidos y retorna los vigentes
+func (t *Toasts) active(now time.Time) []toast {
+	t.receive(now)
+
+	alive := t.items[:0]
+	for _, item := range t.items {
+		if now.Before(item.expires) {
+			alive = append(alive, item)
+		}
+	}
+	t.items = alive
+
+	return alive
+}
+
+// toastBorder es el color del borde según la importancia del aviso
+func toastBorder(level manager.NoticeLevel) color.RGBA {
+	switch level {
+	case manager.NoticeSuccess:
+		return color.RGBA{R: 140, G: 220, B: 140, A: 255}
+	case manager.NoticeWarning:
+		return color.RGBA{R: 240, G: 170, B: 90, A: 255}
+	default:
+		return color.RGBA{R: 120, G: 170, B: 220, A: 255}
+	}
+}
+
+// fade atenúa el color por alpha (0 a 1); se escalan todos los canales
+// porque Ebiten toma los colores premultiplicados
+func fade(c color.RGBA, alpha float64) color.RGBA {
+	scale := func(v uint8) uint8 { return uint8(float64(v) * alpha) }
+	return color.RGBA{R: scale(c.R), G: scale(c.G), B: scale(c.B), A: scale(c.A)}
+}
+
+// Draw apila los avisos sobre el panel de objetivos, el más nuevo abajo;
+// cada uno se desvanece al final de su tiempo
+func (t *Toasts) Draw(screen *ebiten.Image, ui *UIRenderer) {
+	sw, sh := config.ScreenSize()
+	now := time.Now()
+	items := t.active(now)
+
+	y := float64(sh) - 140
+	for i := len(items) - 1; i >= 0; i-- {
+		alpha := min(float64(items[i].expires.Sub(now))/float64(toastFade), 1)
+		message := noticeText(items[i].notice)
+		width := text.Advance(message, ui.fontFace) + 24
+		x := (float64(sw) - width) / 2
+
+		vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), 26, fade(color.RGBA{R: 20, G: 30, B: 50, A: 220}, alpha), false)
+		vector.StrokeRect(screen, float32(x), float32(y), float32(width), 26, 1, fade(toastBorder(items[i].notice.Level), alpha), false)
+		ui.drawText(screen, message, x+12, y+4, fade(color.RGBA{R: 230, G: 240, B: 255, A: 255}, alpha))
+
+		y -= 32
+	}
+}
+
+// noticeText traduce el aviso al idioma actual; los argumentos de texto que
+// estén en el catálogo (como el nombre de un logro) también se traducen
+func noticeText(notice manager.Notice) string {
+	args := make([]any, len(notice.Args))
+	for i, arg := range notice.Args {
+		if s, ok := arg.(string); ok {
+			arg = i18n.T(s)
+		}
+		args[i] = arg
+	}
+	return i18n.T(notice.Format, args...)
+}
@@ -0,0 +1,160 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/input"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/internal/theme"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// toolsBurst son las luciérnagas que suelta el botón de ráfaga
+	toolsBurst  = 10
+	toolsWidth  = 250
+	toolsHeight = 236
+)
+
+// ToolsPanel es el panel de herramientas (tecla T): botones y sliders que
+// actúan sobre la partida en curso a través del canal de comandos. Los
+// cambios de los sliders valen para esta partida; los que se guardan se
+// eligen en Configuración.
+type ToolsPanel struct {
+	visible bool
+	rect    Rect
+	widgets *WidgetSet
+	// screen es el tamaño de pantalla con que se ubicaron los widgets
+	screen [2]int
+}
+
+// NewToolsPanel arma el panel enlazado a g
+func NewToolsPanel(g *Game) *ToolsPanel {
+	p := &ToolsPanel{}
+	p.place(g)
+	return p
+}
+
+// place arma los widgets en la esquina inferior derecha de la pantalla
+// actual; Game lo vuelve a llamar cuando la ventana cambia de tamaño
+func (p *ToolsPanel) place(g *Game) {
+	sw, sh := config.ScreenSize()
+	if p.screen == [2]int{sw, sh} {
+		return
+	}
+	p.screen = [2]int{sw, sh}
+
+	x := float32(sw - toolsWidth - 10)
+	y := float32(sh - toolsHeight - 80)
+	inner := float32(toolsWidth - 20)
+	half := (inner - 10) / 2
+
+	settings := func(change func(*manager.Settings)) {
+		s := g.manager.GetSettings()
+		change(&s)
+		g.manager.UpdateSettings(s)
+	}
+
+	p.rect = Rect{X: x, Y: y, W: toolsWidth, H: toolsHeight}
+	p.widgets = NewWidgetSet(
+		&Button{
+			Rect:  Rect{X: x + 10, Y: y + 34, W: half, H: 32},
+			Label: fmt.Sprintf("Soltar %d", toolsBurst),
+			OnClick: func() {
+				sw, sh := config.ScreenSize()
+				center := g.camera.ScreenToWorld(float64(sw)/2, float64(sh)/2)
+				g.manager.Send(manager.Command{
+					Type: manager.CommandSpawnBurst,
+					Data: manager.BurstRequest{Position: center, Count: toolsBurst},
+				})
+			},
+		},
+		&Button{
+			Rect:    Rect{X: x + 20 + half, Y: y + 34, W: half, H: 32},
+			Label:   "Quitar faroles",
+			OnClick: func() { g.manager.Send(manager.Command{Type: manager.CommandClearLanterns}) },
+		},
+		&Slider{
+			Rect:   Rect{X: x + 10, Y: y + 78, W: inner, H: 40},
+			Label:  "Viento",
+			Min:    0,
+			Max:    config.Get().Wind.MaxStrength,
+			Step:   0.1,
+			Get:    func() float64 { return g.manager.GetSettings().WindStrength },
+			Set:    func(v float64) { settings(func(s *manager.Settings) { s.WindStrength = v }) },
+			Format: func(v float64) string { return i18n.Decimal(v, 1) },
+		},
+		&Slider{
+			Rect:  Rect{X: x + 10, Y: y + 126, W: inner, H: 40},
+			Label: "Aparición",
+			Min:   config.MinSpawnInterval.Seconds(),
+			Max:   5,
+			Step:  0.1,
+			Get:   func() float64 { return g.manager.GetSettings().SpawnInterval.Seconds() },
+			Set: func(v float64) {
+				settings(func(s *manager.Settings) { s.SpawnInterval = time.Duration(v * float64(time.Second)) })
+			},
+			Format: func(v float64) string { return i18n.T("cada %s s", i18n.Decimal(v, 1)) },
+		},
+		&Checkbox{
+			Rect:  Rect{X: x + 10, Y: y + 176, W: inner, H: 22},
+			Label: "Mapa de calor",
+			Get:   g.heatmap.IsVisible,
+			Set:   func(bool) { g.heatmap.Toggle() },
+		},
+		&Checkbox{
+			Rect:  Rect{X: x + 10, Y: y + 204, W: inner, H: 22},
+			Label: "Gráficas",
+			Get:   g.graphPanel.IsExpanded,
+			Set:   func(bool) { g.graphPanel.Toggle() },
+		},
+	)
+}
+
+// Toggle muestra u oculta el panel
+func (p *ToolsPanel) Toggle() {
+	p.visible = !p.visible
+}
+
+// IsVisible indica si el panel está abierto
+func (p *ToolsPanel) IsVisible() bool {
+	return p.visible
+}
+
+// Bounds retorna el rectángulo del panel en su lugar por defecto
+func (p *ToolsPanel) Bounds() Rect {
+	return p.rect
+}
+
+// Update reparte el puntero entre los widgets; local pasa el punto de
+// pantalla a las coordenadas del panel. Debe llamarse antes que el resto
+// del input del juego para que sus clicks no atraigan luciérnagas.
+func (p *ToolsPanel) Update(h *input.Handler, local func(x, y int) (int, int)) {
+	if !p.visible {
+		return
+	}
+	p.widgets.Update(h, local)
+	// El fondo del panel tampoco es jardín
+	if ptr := h.Pointer(); p.rect.Contains(local(ptr.X, ptr.Y)) {
+		h.CapturePointer()
+	}
+}
+
+// Draw dibuja el panel y sus widgets
+func (p *ToolsPanel) Draw(screen *ebiten.Image, ui *UIRenderer) {
+	if !p.visible {
+		return
+	}
+
+	r := p.rect
+	vector.DrawFilledRect(screen, r.X, r.Y, r.W, r.H, color.RGBA{R: 0, G: 0, B: 0, A: 170}, false)
+	vector.StrokeRect(screen, r.X, r.Y, r.W, r.H, 1, color.RGBA{R: 100, G: 120, B: 150, A: 200}, false)
+	ui.drawText(screen, i18n.T("🛠 HERRAMIENTAS (T)"), float64(r.X)+10, float64(r.Y)+6, utils.ArrayToRGBA(theme.Current().Accent))
+	p.widgets.Draw(screen, ui)
+}
@@ -0,0 +1,80 @@
+package render
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/input"
+)
+
+const (
+	touchButtonWidth  = 120
+	touchButtonHeight = 48
+	touchButtonGap    = 10
+)
+
+// touchButton dispara una acción como si se presionara su tecla
+type touchButton struct {
+	label  string
+	action input.Action
+}
+
+// TouchBar es la columna de botones que reemplaza al teclado cuando se juega
+// con la pantalla táctil (navegador, tablets). Solo aparece después del
+// primer toque.
+type TouchBar struct {
+	buttons []touchButton
+}
+
+// NewTouchBar crea la barra con las acciones de la partida
+func NewTouchBar() *TouchBar {
+	return &TouchBar{
+		buttons: []touchButton{
+			{label: "Farol", action: input.ActionLantern},
+			{label: "Ráfaga", action: input.ActionBurst},
+			{label: "Viento", action: input.ActionWind},
+			{label: "Pausa", action: input.ActionPause},
+			{label: "Salir", action: input.ActionEndGame},
+		},
+	}
+}
+
+// buttonRect ubica los botones en la esquina inferior derecha, el primero arriba
+func (b *TouchBar) buttonRect(i int) (x, y, w, h float32) {
+	sw, sh := config.ScreenSize()
+	x = float32(sw - touchButtonWidth - 10)
+	bottom := float32(sh - 10)
+	y = bottom - float32(len(b.buttons)-i)*(touchButtonHeight+touchButtonGap) + touchButtonGap
+	return x, y, touchButtonWidth, touchButtonHeight
+}
+
+// Update convierte los toques sobre un botón en su acción; debe llamarse
+// antes que la escena para que esos toques no cuenten como clicks
+func (b *TouchBar) Update(h *input.Handler) {
+	if !h.TouchUsed() {
+		return
+	}
+
+	for id, pos := range h.JustTouched() {
+		tx, ty := float32(pos[0]), float32(pos[1])
+		for i, button := range b.buttons {
+			x, y, w, bh := b.buttonRect(i)
+			if tx >= x && tx <= x+w && ty >= y && ty <= y+bh {
+				h.ConsumeTouch(id)
+				h.PressAction(button.action)
+				break
+			}
+		}
+	}
+}
+
+// Draw dibuja los botones si se está usando la pantalla táctil
+func (b *TouchBar) Draw(screen *ebiten.Image, ui *UIRenderer, h *input.Handler) {
+	if !h.TouchUsed() {
+		return
+	}
+
+	for i, button := range b.buttons {
+		x, y, w, bh := b.buttonRect(i)
+		ui.DrawButton(screen, x, y, w, bh, button.label, false)
+	}
+}
@@ -0,0 +1,152 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/input"
+	"github.com/yourusername/firefly-garden/internal/manager"
+)
+
+// ModeTutorial es el jardín con los pasos guiados para jugadores nuevos
+const ModeTutorial GameMode = "tutorial"
+
+const (
+	// tutorialEventBuffer es el buffer de la suscripción al bus de eventos
+	tutorialEventBuffer = 64
+	// tutorialCheerDuration es cuánto se muestra el "¡Bien!" al cumplir un paso
+	tutorialCheerDuration = 1500 * time.Millisecond
+)
+
+// tutorialStep es una indicación del tutorial. Si until está el paso se
+// cumple cuando el manager publica ese evento; si no, con Enter.
+type tutorialStep struct {
+	title string
+	lines []string
+	until manager.EventType
+	hud   bool
+}
+
+var tutorialSteps = []tutorialStep{
+	{
+		title: "Atraer",
+		lines: []string{
+			"Hacé click en el jardín (o apoyá un dedo) y mantenelo:",
+			"las luciérnagas vuelan hacia el cursor.",
+		},
+		until: manager.EventAttraction,
+	},
+	{
+		title: "Faroles",
+		lines: []string{
+			"Apuntá a un lugar libre y presioná L (o mantené el dedo):",
+			"el farol suelta una ráfaga y bajo su luz nadie las caza.",
+		},
+		until: manager.EventLanternAdd,
+	},
+	{
+		title: "Viento",
+		lines: []string{
+			"Presioná W (o deslizá con dos dedos) para cambiar el viento",
+			"y mirá cómo las arrastra hacia un lado.",
+		},
+		until: manager.EventWind,
+	},
+	{
+		title: "El HUD",
+		lines: []string{
+			"Arriba a la izquierda: luciérnagas vivas, faroles, viento y el",
+			"objetivo al que repone el spawner. Enter para seguir.",
+		},
+		hud: true,
+	},
+	{
+		title: "¡Listo!",
+		lines: []string{
+			"Ya sabés lo básico. Probá los niveles o la supervivencia.",
+			"Enter para terminar el tutorial.",
+		},
+	},
+}
+
+// Tutorial sigue los pasos escuchando el bus de eventos del manager: no
+// toca el código del juego, solo mira lo que el jugador ya hizo
+type Tutorial struct {
+	events      <-chan manager.Event
+	unsubscribe func()
+	step        int
+	cheer       time.Time
+}
+
+// NewTutorial se suscribe a los eventos de fm; debe crearse antes de Start
+// para no perder el primero
+func NewTutorial(fm *manager.FireflyManager) *Tutorial {
+	events, unsubscribe := fm.Events().Subscribe(tutorialEventBuffer)
+	return &Tutorial{events: events, unsubscribe: unsubscribe}
+}
+
+// Update consume los eventos pendientes sin bloquear y avanza si alguno
+// cumple el paso actual; retorna true al terminar el último
+func (t *Tutorial) Update(h *input.Handler) bool {
+	for drained := false; !drained; {
+		select {
+		case e := <-t.events:
+			if t.step < len(tutorialSteps) && tutorialSteps[t.step].until == e.Type {
+				t.advance()
+			}
+		default:
+			drained = true
+		}
+	}
+
+	if t.step < len(tutorialSteps) && tutorialSteps[t.step].until == "" && h.IsKeyJustPressed(ebiten.KeyEnter) {
+		t.advance()
+	}
+	return t.step == len(tutorialSteps)
+}
+
+// advance pasa al paso siguiente
+func (t *Tutorial) advance() {
+	t.step++
+	t.cheer = time.Now()
+}
+
+// Close cancela la suscripción
+func (t *Tutorial) Close() {
+	t.unsubscribe()
+}
+
+// Draw dibuja el paso actual arriba al centro y, en el paso del HUD, un
+// marco alrededor de hud, el panel donde está ahora
+func (t *Tutorial) Draw(screen *ebiten.Image, ui *UIRenderer, hud Rect) {
+	if t.step >= len(tutorialSteps) {
+		return
+	}
+	step := tutorialSteps[t.step]
+
+	sw, _ := config.ScreenSize()
+	width := float32(620)
+	height := float32(34 + 22*len(step.lines))
+	x := (float32(sw) - width) / 2
+	vector.DrawFilledRect(screen, x, 44, width, height, color.RGBA{R: 20, G: 30, B: 45, A: 220}, false)
+	vector.StrokeRect(screen, x, 44, width, height, 1, color.RGBA{R: 255, G: 230, B: 140, A: 255}, false)
+
+	title := fmt.Sprintf("📖 Paso %d/%d — %s", t.step+1, len(tutorialSteps), step.title)
+	if time.Since(t.cheer) < tutorialCheerDuration {
+		title += "   ✔ ¡Bien!"
+	}
+	ui.drawText(screen, title, float64(x)+12, 50, color.RGBA{R: 255, G: 230, B: 140, A: 255})
+	for i, line := range step.lines {
+		ui.drawText(screen, line, float64(x)+12, 74+float64(i)*22, color.RGBA{R: 220, G: 230, B: 240, A: 255})
+	}
+
+	if step.hud {
+		// Un poco más grande que el panel, con un borde que late
+		pulse := uint8(155 + 100*(time.Now().UnixMilli()%1000)/1000)
+		vector.StrokeRect(screen, hud.X-4, hud.Y-4, hud.W+8, hud.H+8, 3, color.RGBA{R: 255, G: 230, B: 140, A: pulse}, false)
+	}
+}
@@ -5,14 +5,18 @@ import (
 	_ "embed"
 	"fmt"
 	"image/color"
-	"log"
 	"runtime"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	text "github.com/hajimehoshi/ebiten/v2/text/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/yourusername/firefly-garden/internal/config"
 	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/internal/theme"
 	"github.com/yourusername/firefly-garden/pkg/utils"
 )
 
@@ -29,12 +33,12 @@ type UIRenderer struct {
 func NewUIRenderer() *UIRenderer {
 	// Crear fuente básica de Go (tamaño normal) usando TTF embebido
 	if len(goTTF) == 0 {
-		log.Fatalf("fuente embebida vacía: asegúrate de que internal/render/assets/Go-Regular.ttf exista")
+		logging.Fatal("fuente embebida vacía: asegúrate de que internal/render/assets/Go-Regular.ttf exista")
 	}
 
 	src, err := text.NewGoTextFaceSource(bytes.NewReader(goTTF))
 	if err != nil {
-		log.Fatalf("Error al crear fuente (normal): %v", err)
+		logging.Fatal("error al crear fuente", "size", "normal", "err", err)
 	}
 	fontFace := &text.GoTextFace{
 		Source: src,
@@ -44,7 +48,7 @@ func NewUIRenderer() *UIRenderer {
 	// Crear fuente grande para mensajes
 	largeSrc, err := text.NewGoTextFaceSource(bytes.NewReader(goTTF))
 	if err != nil {
-		log.Fatalf("Error al crear fuente (grande): %v", err)
+		logging.Fatal("error al crear fuente", "size", "grande", "err", err)
 	}
 	largeFace := &text.GoTextFace{
 		Source: largeSrc,
@@ -57,19 +61,20 @@ func NewUIRenderer() *UIRenderer {
 	}
 }
 
-// DrawHUD dibuja el HUD principal con información del juego
-func (u *UIRenderer) DrawHUD(screen *ebiten.Image, fireflyCount, lanternCount int, wind *core.Wind, fps float64, isPaused bool) {
+// DrawHUD dibuja el HUD principal con información del juego; clusters es la
+// última detección de cúmulos (nil si no hay, como en la vista remota)
+func (u *UIRenderer) DrawHUD(screen *ebiten.Image, fireflyCount, lanternCount, objective int, wind core.WindSnapshot, fps float64, qualityTier string, clusters *manager.ClusterSet, isPaused bool) {
 	padding := 10.0
 	lineHeight := 22.0
 	y := padding
 
 	// Panel semi-transparente de fondo
-	panelHeight := float32(lineHeight * 7)
-	panelColor := color.RGBA{R: 0, G: 0, B: 0, A: 150}
+	panelHeight := float32(lineHeight * 9)
+	panelColor := utils.ArrayToRGBA(theme.Current().Panel)
 	vector.DrawFilledRect(screen, float32(padding), float32(y), 300, panelHeight, panelColor, false)
 
 	// Título
-	u.drawText(screen, "🌙 JARDÍN DE LUCIÉRNAGAS", padding+10, y+4, color.RGBA{R: 255, G: 255, B: 200, A: 255})
+	u.drawText(screen, i18n.T("🌙 JARDÍN DE LUCIÉRNAGAS"), padding+10, y+4, color.RGBA{R: 255, G: 255, B: 200, A: 255})
 	y += lineHeight
 
 	// Separador
@@ -77,48 +82,87 @@ func (u *UIRenderer) DrawHUD(screen *ebiten.Image, fireflyCount, lanternCount in
 	y += lineHeight * 0.5
 
 	// Estadísticas
-	textColor := utils.ArrayToRGBA(config.UITextColor)
+	textColor := utils.ArrayToRGBA(theme.Current().UIText)
 
-	u.drawText(screen, fmt.Sprintf("Luciérnagas: %d / %d", fireflyCount, config.MaxFireflies), padding+10, y, textColor)
+	u.drawText(screen, i18n.T("Luciérnagas: %s / %s", i18n.Number(fireflyCount), i18n.Number(config.Get().Fireflies.Max)), padding+10, y, textColor)
 	y += lineHeight
 
-	u.drawText(screen, fmt.Sprintf("Faroles: %d / %d", lanternCount, config.MaxLanterns), padding+10, y, textColor)
+	u.drawText(screen, i18n.T("Faroles: %s / %s", i18n.Number(lanternCount), i18n.Number(config.Get().Lanterns.Max)), padding+10, y, textColor)
 	y += lineHeight
 
-	u.drawText(screen, fmt.Sprintf("Viento: %s", wind.GetDirectionName()), padding+10, y, textColor)
+	u.drawText(screen, i18n.T("Viento: %s", windName(wind.Direction)), padding+10, y, textColor)
 	y += lineHeight
 
-	u.drawText(screen, fmt.Sprintf("Objetivo: %d", config.ObjectiveCount), padding+10, y, textColor)
+	u.drawText(screen, i18n.T("Objetivo: %s", i18n.Number(objective)), padding+10, y, textColor)
 	y += lineHeight
 
-	u.drawText(screen, fmt.Sprintf("FPS: %.1f  Goroutines: %d", fps, runtime.NumGoroutine()), padding+10, y, textColor)
+	u.drawText(screen, i18n.T("FPS: %s  Goroutines: %s", i18n.Decimal(fps, 1), i18n.Number(runtime.NumGoroutine())), padding+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("Calidad: %s", qualityTier), padding+10, y, textColor)
 	y += lineHeight
 
 	// Estadística de estados descartados por canal
 	dropped := core.GetDroppedStates()
-	u.drawText(screen, fmt.Sprintf("Descartados: %d", dropped), padding+10, y, color.RGBA{R: 240, G: 200, B: 120, A: 255})
+	u.drawText(screen, i18n.T("Descartados: %s", i18n.Number(int(dropped))), padding+10, y, color.RGBA{R: 240, G: 200, B: 120, A: 255})
 	y += lineHeight
 
+	if clusters != nil {
+		u.drawText(screen, i18n.T("Cúmulos: %s (mayor: %s)", i18n.Number(len(clusters.Clusters)), i18n.Number(clusters.Largest())), padding+10, y, textColor)
+		y += lineHeight
+	}
+
 	// Estado de pausa
 	if isPaused {
 		pauseColor := color.RGBA{R: 255, G: 100, B: 100, A: 255}
-		u.drawText(screen, "⏸ PAUSADO", padding+10, y, pauseColor)
+		u.drawText(screen, i18n.T("⏸ PAUSADO"), padding+10, y, pauseColor)
+	}
+}
+
+// windNames nombra las direcciones del viento en la interfaz; core las
+// nombra en inglés para los logs y los archivos
+var windNames = map[core.WindDirection]string{
+	core.WindNone:      "Calma",
+	core.WindNorth:     "Norte",
+	core.WindSouth:     "Sur",
+	core.WindEast:      "Este",
+	core.WindWest:      "Oeste",
+	core.WindNorthEast: "Noreste",
+	core.WindNorthWest: "Noroeste",
+	core.WindSouthEast: "Sureste",
+	core.WindSouthWest: "Suroeste",
+}
+
+// windName retorna el nombre traducido de la dirección del viento
+func windName(d core.WindDirection) string {
+	if name, ok := windNames[d]; ok {
+		return i18n.T(name)
 	}
+	return d.String()
+}
+
+// burstPatternNames nombra las formas de ráfaga en los avisos
+var burstPatternNames = map[manager.BurstPattern]string{
+	manager.BurstCluster: "nube",
+	manager.BurstRing:    "anillo",
+	manager.BurstSpiral:  "espiral",
+	manager.BurstWind:    "línea con el viento",
 }
 
 // DrawControls dibuja la guía de controles
 func (u *UIRenderer) DrawControls(screen *ebiten.Image) {
-	x := float64(config.ScreenWidth - 320)
+	sw, _ := config.ScreenSize()
+	x := float64(sw - 320)
 	y := 10.0
 	lineHeight := 22.0
 
 	// Panel de fondo
-	panelHeight := float32(lineHeight * 8)
-	panelColor := color.RGBA{R: 0, G: 0, B: 0, A: 150}
+	panelHeight := float32(lineHeight * 34)
+	panelColor := utils.ArrayToRGBA(theme.Current().Panel)
 	vector.DrawFilledRect(screen, float32(x), float32(y), 300, panelHeight, panelColor, false)
 
 	// Título
-	u.drawText(screen, "⌨️  CONTROLES", x+10, y+5, color.RGBA{R: 150, G: 200, B: 255, A: 255})
+	u.drawText(screen, i18n.T("⌨️  CONTROLES"), x+10, y+5, utils.ArrayToRGBA(theme.Current().Accent))
 	y += lineHeight
 
 	// Separador
@@ -128,35 +172,114 @@ func (u *UIRenderer) DrawControls(screen *ebiten.Image) {
 	// Controles
 	textColor := color.RGBA{R: 200, G: 200, B: 200, A: 255}
 
-	u.drawText(screen, "Click Izq: Atraer luciernagas", x+10, y, textColor)
+	u.drawText(screen, i18n.T("Click Izq: Atraer luciernagas"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("L: Colocar farol (genera ráfaga)"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("K: Generar ráfaga cerca del cursor"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("B: Forma de la ráfaga"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("W: Cambiar direccion viento"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("Shift+W: Vista del viento"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("P: Pausar/Reanudar"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("Flechas / + -: Mover cámara / Zoom"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("H: Mapa de calor"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("F10: Foto de larga exposición"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("F12: Captura (Ctrl: sin interfaz)"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("M: Modo musical"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("U: Modo rumor"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("E: Elección de líder"), x+10, y, textColor)
 	y += lineHeight
 
-	u.drawText(screen, "L: Colocar farol (genera ráfaga)", x+10, y, textColor)
+	u.drawText(screen, i18n.T("G: Calidad (círculos/sprites/bloom)"), x+10, y, textColor)
 	y += lineHeight
 
-	u.drawText(screen, "K: Generar ráfaga cerca del cursor", x+10, y, textColor)
+	u.drawText(screen, i18n.T("F3: Overlay de depuración"), x+10, y, textColor)
 	y += lineHeight
 
-	u.drawText(screen, "W: Cambiar direccion viento", x+10, y, textColor)
+	u.drawText(screen, i18n.T("F7: Fuerzas de cada luciérnaga"), x+10, y, textColor)
 	y += lineHeight
 
-	u.drawText(screen, "P: Pausar/Reanudar", x+10, y, textColor)
+	u.drawText(screen, i18n.T("F8: Tiempo de dibujo por etapa"), x+10, y, textColor)
 	y += lineHeight
 
-	u.drawText(screen, "ESC: Salir", x+10, y, textColor)
+	u.drawText(screen, i18n.T("Z: Luciérnagas por zona"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("C: Resaltar cúmulos"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("V: Grabar luciérnaga (fantasma)"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("F2: Pintar campo de fuerzas"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("`: Consola (label 12,44)"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("F4: Gráficas (últimos 60 s)"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("F5: Capturar trace + CPU (5 s)"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("F6: Flujo de canales"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("Tab: Registro de eventos"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("T: Herramientas"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("F1: Ocultar interfaz (Ctrl: reordenar)"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("O: Configuración"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("F9: Exportar estadísticas"), x+10, y, textColor)
+	y += lineHeight
+
+	u.drawText(screen, i18n.T("ESC: Terminar partida"), x+10, y, textColor)
 }
 
 // DrawPauseOverlay dibuja un overlay cuando el juego está pausado
 func (u *UIRenderer) DrawPauseOverlay(screen *ebiten.Image) {
+	sw, sh := config.ScreenSize()
 	// Overlay semi-transparente
 	overlayColor := color.RGBA{R: 0, G: 0, B: 0, A: 180}
-	vector.DrawFilledRect(screen, 0, 0, float32(config.ScreenWidth), float32(config.ScreenHeight), overlayColor, false)
+	vector.DrawFilledRect(screen, 0, 0, float32(sw), float32(sh), overlayColor, false)
 
 	// Texto grande de pausa
-	centerX := float64(config.ScreenWidth / 2)
-	centerY := float64(config.ScreenHeight / 2)
+	centerX := float64(sw / 2)
+	centerY := float64(sh / 2)
 
-	pauseText := "⏸  JUEGO PAUSADO"
+	pauseText := i18n.T("⏸  JUEGO PAUSADO")
 
 	// Medir texto para centrarlo
 	textWidth := text.Advance(pauseText, u.largeFace)
@@ -167,7 +290,36 @@ func (u *UIRenderer) DrawPauseOverlay(screen *ebiten.Image) {
 	text.Draw(screen, pauseText, u.largeFace, op)
 
 	// Mensaje secundario
-	u.drawTextCentered(screen, "Presiona P para continuar", centerY+40, color.RGBA{R: 200, G: 200, B: 200, A: 255})
+	u.drawTextCentered(screen, i18n.T("Presiona P para continuar"), centerY+40, color.RGBA{R: 200, G: 200, B: 200, A: 255})
+}
+
+// DrawReplayBanner indica que se está viendo una repetición y su avance
+func (u *UIRenderer) DrawReplayBanner(screen *ebiten.Image, speed int, elapsed, total time.Duration, finished bool) {
+	sw, _ := config.ScreenSize()
+	label := i18n.T("▶ REPETICIÓN x%d  %s / %s  (1/2/4: velocidad)", speed, formatClock(elapsed), formatClock(total))
+	if finished {
+		label = i18n.T("■ REPETICIÓN TERMINADA  %s", formatClock(total))
+	}
+
+	vector.DrawFilledRect(screen, 0, 0, float32(sw), 24, color.RGBA{R: 60, G: 20, B: 20, A: 200}, false)
+	u.drawTextCentered(screen, label, 4, color.RGBA{R: 255, G: 180, B: 180, A: 255})
+}
+
+// formatClock formatea una duración como mm:ss
+func formatClock(d time.Duration) string {
+	secs := int(d.Seconds())
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
+
+// drawTitleCentered dibuja un título grande centrado horizontalmente
+func (u *UIRenderer) drawTitleCentered(screen *ebiten.Image, txt string, y float64, clr color.RGBA) {
+	sw, _ := config.ScreenSize()
+	textWidth := text.Advance(txt, u.largeFace)
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(sw)/2-textWidth/2, y)
+	op.ColorScale.ScaleWithColor(clr)
+	text.Draw(screen, txt, u.largeFace, op)
 }
 
 // DrawButton dibuja un botón interactivo
@@ -194,9 +346,10 @@ func (u *UIRenderer) DrawButton(screen *ebiten.Image, x, y, width, height float3
 }
 
 // DrawObjectivePanel dibuja el panel de objetivos del juego
-func (u *UIRenderer) DrawObjectivePanel(screen *ebiten.Image, fireflyCount int) {
-	x := float64(config.ScreenWidth/2 - 150)
-	y := float64(config.ScreenHeight - 100)
+func (u *UIRenderer) DrawObjectivePanel(screen *ebiten.Image, fireflyCount, objective int) {
+	sw, sh := config.ScreenSize()
+	x := float64(sw/2 - 150)
+	y := float64(sh - 100)
 	width := float32(300)
 	height := float32(80)
 
@@ -209,16 +362,15 @@ func (u *UIRenderer) DrawObjectivePanel(screen *ebiten.Image, fireflyCount int)
 	vector.StrokeRect(screen, float32(x), float32(y), width, height, 2, borderColor, false)
 
 	// Título
-	u.drawTextCentered(screen, "🎯 OBJETIVO", y+15, color.RGBA{R: 255, G: 255, B: 150, A: 255})
+	u.drawTextCentered(screen, i18n.T("🎯 OBJETIVO"), y+15, color.RGBA{R: 255, G: 255, B: 150, A: 255})
 
 	// Progreso
-	objective := config.ObjectiveCount
 	progress := float64(fireflyCount) / float64(objective)
 	if progress > 1.0 {
 		progress = 1.0
 	}
 
-	progressText := fmt.Sprintf("Mantén %d+ luciérnagas", objective)
+	progressText := i18n.Plural(objective, "Mantén %s+ luciérnaga", "Mantén %s+ luciérnagas")
 	u.drawTextCentered(screen, progressText, y+40, color.RGBA{R: 200, G: 200, B: 200, A: 255})
 
 	// Barra de progreso
@@ -256,7 +408,8 @@ func (u *UIRenderer) drawText(screen *ebiten.Image, txt string, x, y float64, cl
 
 // drawTextCentered dibuja texto centrado horizontalmente
 func (u *UIRenderer) drawTextCentered(screen *ebiten.Image, txt string, y float64, clr color.RGBA) {
+	sw, _ := config.ScreenSize()
 	textWidth := text.Advance(txt, u.fontFace)
-	x := float64(config.ScreenWidth)/2 - textWidth/2
+	x := float64(sw)/2 - textWidth/2
 	u.drawText(screen, txt, x, y, clr)
 }
\ No newline at end of file
@@ -0,0 +1,38 @@
+package render
+
+import (
+	"math"
+
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// uiScaleMin y uiScaleMax acotan la escala de la interfaz
+	uiScaleMin = 0.75
+	uiScaleMax = 2.0
+	// uiScaleStep es el paso del slider y del redondeo automático
+	uiScaleStep = 0.25
+)
+
+// autoUIScale elige la escala de la interfaz según cuánto se ve la pantalla
+// lógica. Ebiten mide la ventana en píxeles independientes del dispositivo
+// (ya divididos por el DeviceScaleFactor del monitor), así que si la ventana
+// es más chica que el mínimo de screenLayout el juego se encoge y el texto
+// de 16 px queda ilegible: la escala lo compensa. Nunca achica.
+func autoUIScale(outsideWidth, outsideHeight int) float64 {
+	if outsideWidth <= 0 || outsideHeight <= 0 {
+		return 1
+	}
+	width, height := screenLayout(outsideWidth, outsideHeight)
+	shrink := max(float64(width)/float64(outsideWidth), float64(height)/float64(outsideHeight))
+	return utils.Clamp(math.Round(shrink/uiScaleStep)*uiScaleStep, 1, uiScaleMax)
+}
+
+// UIScale retorna la escala a usar: la de las preferencias si el usuario
+// eligió una (distinta de 0), si no la automática
+func UIScale(pref float64, outsideWidth, outsideHeight int) float64 {
+	if pref > 0 {
+		return utils.Clamp(pref, uiScaleMin, uiScaleMax)
+	}
+	return autoUIScale(outsideWidth, outsideHeight)
+}
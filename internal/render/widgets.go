@@ -0,0 +1,203 @@
+package render
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/i18n"
+	"github.com/yourusername/firefly-garden/internal/input"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// Rect es un rectángulo en coordenadas de pantalla
+type Rect struct {
+	X, Y, W, H float32
+}
+
+// Contains indica si el punto cae dentro del rectángulo
+func (r Rect) Contains(x, y int) bool {
+	px, py := float32(x), float32(y)
+	return px >= r.X && px <= r.X+r.W && py >= r.Y && py <= r.Y+r.H
+}
+
+// Widget es un control de la capa de widgets. Los widgets guardan su
+// rectángulo y sus valores se leen y escriben con funciones (value binding):
+// no copian el estado que controlan. Las etiquetas se traducen al dibujar. WidgetSet decide quién recibe el
+// puntero; el widget solo reacciona.
+type Widget interface {
+	Bounds() Rect
+	// Press se llama al apretar sobre el widget; Drag, mientras siga
+	// apretado aunque el puntero salga; Release, al soltar, con inside si
+	// se soltó encima
+	Press(x, y int)
+	Drag(x, y int)
+	Release(inside bool)
+	Draw(screen *ebiten.Image, ui *UIRenderer, hover, pressed bool)
+}
+
+// WidgetSet es un grupo de widgets con hit-testing: lleva cuál está bajo el
+// puntero y cuál se apretó, que se queda con el puntero hasta soltarlo
+type WidgetSet struct {
+	widgets []Widget
+	hover   Widget
+	active  Widget
+}
+
+// NewWidgetSet crea el grupo con los widgets indicados
+func NewWidgetSet(widgets ...Widget) *WidgetSet {
+	return &WidgetSet{widgets: widgets}
+}
+
+// Update reparte el puntero del frame; local pasa el punto de pantalla a
+// las coordenadas de los widgets (el panel que los contiene puede estar
+// movido o escalado). Si el puntero está sobre un widget o arrastrando uno,
+// lo captura en el handler para que la escena no lo tome como click.
+func (s *WidgetSet) Update(h *input.Handler, local func(x, y int) (int, int)) {
+	p := h.Pointer()
+	p.X, p.Y = local(p.X, p.Y)
+
+	s.hover = nil
+	for _, w := range s.widgets {
+		if w.Bounds().Contains(p.X, p.Y) {
+			s.hover = w
+			break
+		}
+	}
+
+	switch {
+	case s.active != nil && p.Pressed:
+		s.active.Drag(p.X, p.Y)
+	case s.active != nil:
+		s.active.Release(s.active == s.hover)
+		s.active = nil
+	case s.hover != nil && p.JustPressed:
+		s.active = s.hover
+		s.active.Press(p.X, p.Y)
+	}
+
+	if s.hover != nil || s.active != nil {
+		h.CapturePointer()
+	}
+}
+
+// Draw dibuja todos los widgets
+func (s *WidgetSet) Draw(screen *ebiten.Image, ui *UIRenderer) {
+	for _, w := range s.widgets {
+		w.Draw(screen, ui, w == s.hover, w == s.active)
+	}
+}
+
+// Button ejecuta OnClick al soltarlo encima
+type Button struct {
+	Rect    Rect
+	Label   string
+	OnClick func()
+}
+
+func (b *Button) Bounds() Rect   { return b.Rect }
+func (b *Button) Press(x, y int) {}
+func (b *Button) Drag(x, y int)  {}
+
+func (b *Button) Release(inside bool) {
+	if inside && b.OnClick != nil {
+		b.OnClick()
+	}
+}
+
+func (b *Button) Draw(screen *ebiten.Image, ui *UIRenderer, hover, pressed bool) {
+	r := b.Rect
+	if pressed {
+		// Hundido un píxel mientras se aprieta
+		r.Y++
+	}
+	ui.DrawButton(screen, r.X, r.Y, r.W, r.H, i18n.T(b.Label), hover || pressed)
+}
+
+// Slider ajusta un valor entre Min y Max en pasos de Step; Get y Set lo
+// enlazan con lo que controla
+type Slider struct {
+	Rect   Rect
+	Label  string
+	Min    float64
+	Max    float64
+	Step   float64
+	Get    func() float64
+	Set    func(float64)
+	Format func(float64) string
+}
+
+// sliderTrackY es la altura de la barra dentro del rectángulo: arriba va
+// la etiqueta
+const sliderTrackY = 26
+
+func (s *Slider) Bounds() Rect        { return s.Rect }
+func (s *Slider) Press(x, y int)      { s.Drag(x, y) }
+func (s *Slider) Release(inside bool) {}
+
+// Drag lleva el valor a la posición del puntero, redondeado al paso; solo
+// llama a Set si cambió
+func (s *Slider) Drag(x, y int) {
+	t := utils.Clamp(float64(float32(x)-s.Rect.X)/float64(s.Rect.W), 0, 1)
+	v := s.Min + t*(s.Max-s.Min)
+	v = s.Min + math.Round((v-s.Min)/s.Step)*s.Step
+	v = utils.Clamp(v, s.Min, s.Max)
+	if v != s.Get() {
+		s.Set(v)
+	}
+}
+
+func (s *Slider) Draw(screen *ebiten.Image, ui *UIRenderer, hover, pressed bool) {
+	textColor := color.RGBA{R: 220, G: 220, B: 220, A: 255}
+	if hover || pressed {
+		textColor = color.RGBA{R: 255, G: 255, B: 150, A: 255}
+	}
+
+	value := s.Get()
+	label := i18n.T(s.Label)
+	if s.Format != nil {
+		label += ": " + s.Format(value)
+	}
+	ui.drawText(screen, label, float64(s.Rect.X), float64(s.Rect.Y), textColor)
+
+	t := float32(utils.Clamp((value-s.Min)/(s.Max-s.Min), 0, 1))
+	trackY := s.Rect.Y + sliderTrackY
+	vector.DrawFilledRect(screen, s.Rect.X, trackY, s.Rect.W, 6, color.RGBA{R: 60, G: 60, B: 90, A: 255}, false)
+	vector.DrawFilledRect(screen, s.Rect.X, trackY, s.Rect.W*t, 6, color.RGBA{R: 120, G: 160, B: 255, A: 255}, false)
+	vector.DrawFilledCircle(screen, s.Rect.X+s.Rect.W*t, trackY+3, 8, textColor, true)
+}
+
+// Checkbox alterna un valor booleano al soltarlo encima
+type Checkbox struct {
+	Rect  Rect
+	Label string
+	Get   func() bool
+	Set   func(bool)
+}
+
+func (c *Checkbox) Bounds() Rect   { return c.Rect }
+func (c *Checkbox) Press(x, y int) {}
+func (c *Checkbox) Drag(x, y int)  {}
+
+func (c *Checkbox) Release(inside bool) {
+	if inside {
+		c.Set(!c.Get())
+	}
+}
+
+func (c *Checkbox) Draw(screen *ebiten.Image, ui *UIRenderer, hover, pressed bool) {
+	border := color.RGBA{R: 150, G: 150, B: 200, A: 255}
+	textColor := color.RGBA{R: 220, G: 220, B: 220, A: 255}
+	if hover || pressed {
+		border = color.RGBA{R: 255, G: 255, B: 150, A: 255}
+		textColor = border
+	}
+
+	box := c.Rect.H - 4
+	vector.StrokeRect(screen, c.Rect.X, c.Rect.Y+2, box, box, 2, border, false)
+	if c.Get() {
+		vector.DrawFilledRect(screen, c.Rect.X+4, c.Rect.Y+6, box-8, box-8, color.RGBA{R: 120, G: 200, B: 140, A: 255}, false)
+	}
+	ui.drawText(screen, i18n.T(c.Label), float64(c.Rect.X+box+10), float64(c.Rect.Y)+2, textColor)
+}
@@ -0,0 +1,194 @@
+package render
+
+import (
+	"image/color"
+	"math/rand"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/render/particles"
+	"github.com/yourusername/firefly-garden/internal/theme"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// WindView es cómo se muestra el viento
+type WindView int
+
+const (
+	WindArrows WindView = iota
+	WindStreamlines
+	WindDust
+	WindHidden
+)
+
+// windViewNames nombra las vistas del viento en los avisos
+var windViewNames = [...]string{
+	WindArrows:      "flechas",
+	WindStreamlines: "líneas de corriente",
+	WindDust:        "polvo",
+	WindHidden:      "oculto",
+}
+
+// Next retorna la vista siguiente; después de la última vuelve a la primera
+func (v WindView) Next() WindView {
+	return (v + 1) % (WindHidden + 1)
+}
+
+const (
+	// streamSeeds son las semillas que arrastra el viento en la vista de
+	// líneas de corriente, y streamTrail los puntos que recuerda cada una
+	streamSeeds = 48
+	streamTrail = 18
+	// streamSpeed convierte la fuerza del viento en px/s de las semillas
+	streamSpeed = 60
+	// streamLife es lo que vive una semilla antes de volver a sembrarse
+	streamLife = 3 * time.Second
+	// dustRate son las motas por segundo de la vista de polvo
+	dustRate     = 40
+	dustCapacity = 512
+)
+
+// dustEmitter son motas quietas que solo mueve el viento
+var dustEmitter = particles.Emitter{
+	Life:  particles.Range{Min: 2, Max: 4},
+	Size:  particles.Range{Min: 0.7, Max: 1.4},
+	Color: color.RGBA{R: 200, G: 210, B: 235, A: 110},
+	Drag:  0.6,
+	Drift: 60,
+	Alpha: particles.Pulse,
+}
+
+// streamSeed es un punto que el viento arrastra dejando su recorrido. El
+// recorrido es un anillo fijo para no reservar memoria por frame.
+type streamSeed struct {
+	trail [streamTrail]utils.Vector2D
+	head  int
+	len   int
+	age   time.Duration
+}
+
+// reset siembra la semilla en un punto al azar del mundo; la edad inicial
+// también es al azar para que no vuelvan a sembrarse todas juntas
+func (s *streamSeed) reset(width, height float64) {
+	s.trail[0] = utils.NewVector2D(rand.Float64()*width, rand.Float64()*height)
+	s.head, s.len = 0, 1
+	s.age = time.Duration(rand.Int63n(int64(streamLife)))
+}
+
+// pos retorna el punto más reciente
+func (s *streamSeed) pos() utils.Vector2D {
+	return s.trail[s.head]
+}
+
+// push agrega un punto al recorrido, pisando el más viejo
+func (s *streamSeed) push(p utils.Vector2D) {
+	s.head = (s.head + 1) % streamTrail
+	s.trail[s.head] = p
+	s.len = min(s.len+1, streamTrail)
+}
+
+// WindOverlay dibuja el viento en la vista elegida (Shift+W): las flechas
+// de siempre, líneas de corriente que siguen semillas arrastradas por el
+// campo del viento, polvo que flota con él, o nada. Solo la usa el hilo de
+// Ebiten.
+type WindOverlay struct {
+	view  WindView
+	seeds [streamSeeds]streamSeed
+	dust  *particles.System
+	rate  particles.Stream
+
+	// field es el viento del frame; se arma una sola vez
+	field particles.Field
+	wind  core.WindSnapshot
+	now   time.Time
+}
+
+// NewWindOverlay arranca con las flechas
+func NewWindOverlay() *WindOverlay {
+	o := &WindOverlay{
+		dust: particles.NewSystem(dustCapacity),
+		rate: particles.Stream{Rate: dustRate},
+	}
+	o.field = o.windAt
+	width, height := config.WorldSize()
+	for i := range o.seeds {
+		o.seeds[i].reset(width, height)
+	}
+	return o
+}
+
+// Cycle pasa a la vista siguiente y la retorna
+func (o *WindOverlay) Cycle() WindView {
+	o.view = o.view.Next()
+	o.dust.Clear()
+	return o.view
+}
+
+// Update avanza la vista actual dt segundos con el viento de wind en now
+func (o *WindOverlay) Update(dt float64, wind core.WindSnapshot, now time.Time) {
+	o.wind, o.now = wind, now
+	switch o.view {
+	case WindStreamlines:
+		o.advect(dt)
+	case WindDust:
+		width, height := config.WorldSize()
+		for range o.rate.Due(dt) {
+			o.dust.Emit(&dustEmitter, utils.NewVector2D(rand.Float64()*width, rand.Float64()*height), 1)
+		}
+		o.dust.Update(dt, o.field)
+	}
+}
+
+// advect mueve cada semilla con el viento de su posición; la que se fue del
+// mundo o cumplió su vida vuelve a sembrarse
+func (o *WindOverlay) advect(dt float64) {
+	width, height := config.WorldSize()
+	step := time.Duration(dt * float64(time.Second))
+	for i := range o.seeds {
+		s := &o.seeds[i]
+		next := s.pos().Add(o.field(s.pos()).Mul(streamSpeed * dt))
+		s.age += step
+		if s.age >= streamLife || next.X < 0 || next.Y < 0 || next.X > width || next.Y > height {
+			s.reset(width, height)
+			s.age = 0
+			continue
+		}
+		s.push(next)
+	}
+}
+
+// Draw dibuja la vista actual sobre el mundo
+func (o *WindOverlay) Draw(world *ebiten.Image, r *Renderer, wind core.WindSnapshot) {
+	switch o.view {
+	case WindArrows:
+		r.DrawWind(world, wind)
+	case WindStreamlines:
+		o.drawStreamlines(world)
+	case WindDust:
+		o.dust.Draw(world)
+	}
+}
+
+// drawStreamlines dibuja el recorrido de cada semilla, más opaco cerca de
+// la punta
+func (o *WindOverlay) drawStreamlines(world *ebiten.Image) {
+	base := utils.ArrayToRGBA(theme.Current().Wind)
+	for i := range o.seeds {
+		s := &o.seeds[i]
+		for j := 1; j < s.len; j++ {
+			from := s.trail[(s.head-j+streamTrail)%streamTrail]
+			to := s.trail[(s.head-j+1+streamTrail)%streamTrail]
+			fade := 1 - float64(j)/streamTrail
+			clr := utils.WithAlpha(base, uint8(min(float64(base.A)*2*fade, 255)))
+			vector.StrokeLine(world, float32(from.X), float32(from.Y), float32(to.X), float32(to.Y), 1.5, clr, false)
+		}
+	}
+}
+
+// windAt es el viento del frame en pos
+func (o *WindOverlay) windAt(pos utils.Vector2D) utils.Vector2D {
+	return windForce(o.wind, o.now, pos)
+}
@@ -0,0 +1,76 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/internal/theme"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// zoneCellWidth y zoneCellHeight son el tamaño de cada zona en el widget
+	zoneCellWidth  = 56
+	zoneCellHeight = 34
+)
+
+// ZonePanel es una grilla chica con las zonas del jardín: cuántas
+// luciérnagas hay en cada una y su brillo promedio, que tiñe la celda.
+// La zona de la meta del nivel, si hay una, va remarcada.
+type ZonePanel struct {
+	visible bool
+}
+
+func NewZonePanel() *ZonePanel {
+	return &ZonePanel{}
+}
+
+func (z *ZonePanel) Toggle() {
+	z.visible = !z.visible
+}
+
+func (z *ZonePanel) IsVisible() bool {
+	return z.visible
+}
+
+// Bounds queda arriba del minimapa, en la esquina inferior izquierda
+func (z *ZonePanel) Bounds() Rect {
+	_, sh := config.ScreenSize()
+	width := float32(zoneCellWidth*config.ZoneCols + 20)
+	height := float32(zoneCellHeight*config.ZoneRows + 20)
+	return Rect{X: 10, Y: float32(sh-config.MinimapHeight-20) - height, W: width, H: height}
+}
+
+// Draw dibuja la grilla; highlight es la zona a remarcar o -1
+func (z *ZonePanel) Draw(screen *ebiten.Image, ui *UIRenderer, stats manager.ZoneStats, highlight int) {
+	b := z.Bounds()
+	vector.DrawFilledRect(screen, b.X, b.Y, b.W, b.H, utils.ArrayToRGBA(theme.Current().Panel), false)
+
+	densest := stats.Densest()
+	for i, s := range stats {
+		col, row := i%config.ZoneCols, i/config.ZoneCols
+		x := b.X + 10 + float32(col*zoneCellWidth)
+		y := b.Y + 10 + float32(row*zoneCellHeight)
+
+		glow := uint8(40 + 180*utils.Clamp(s.Brightness, 0, 1))
+		vector.DrawFilledRect(screen, x+1, y+1, zoneCellWidth-2, zoneCellHeight-2, color.RGBA{R: glow, G: glow, B: glow / 3, A: 200}, false)
+
+		border := color.RGBA{R: 90, G: 90, B: 110, A: 255}
+		width := float32(1)
+		switch {
+		case i == highlight:
+			border, width = color.RGBA{R: 100, G: 255, B: 100, A: 255}, 2
+		case s.Count > 0 && i == densest:
+			border = color.RGBA{R: 255, G: 220, B: 120, A: 255}
+		}
+		vector.StrokeRect(screen, x+1, y+1, zoneCellWidth-2, zoneCellHeight-2, width, border, false)
+
+		label := fmt.Sprintf("%s %d", strings.ToUpper(config.ZoneName(i)), s.Count)
+		ui.drawText(screen, label, float64(x)+5, float64(y)+8, color.RGBA{R: 240, G: 240, B: 240, A: 255})
+	}
+}
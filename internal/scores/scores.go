@@ -0,0 +1,137 @@
+// Package scores guarda el historial de partidas terminadas para la tabla
+// de récords. Cada resultado es una línea JSON agregada al final del
+// archivo, así una partida nunca reescribe las anteriores.
+package scores
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Entry es el resultado de una partida. Seed es la semilla del generador
+// compartido: volver a jugar con ella repite las condiciones iniciales.
+type Entry struct {
+	Date     time.Time `json:"date"`
+	Mode     string    `json:"mode"`
+	Score    int       `json:"score"`
+	Seconds  float64   `json:"duration_seconds"`
+	Peak     int       `json:"peak_population"`
+	Seed     int64     `json:"seed"`
+	Lanterns int       `json:"lanterns,omitempty"`
+	// Challenge es la fecha del desafío diario (vacío en otros modos)
+	Challenge string `json:"challenge,omitempty"`
+}
+
+// Duration retorna la duración de la partida
+func (e Entry) Duration() time.Duration {
+	return time.Duration(e.Seconds * float64(time.Second))
+}
+
+// SortKey es la columna por la que se ordena la tabla
+type SortKey int
+
+const (
+	ByScore SortKey = iota
+	ByDuration
+	ByPeak
+	ByDate
+)
+
+// Sort ordena de mayor a menor (la más reciente primero para ByDate); los
+// empates quedan en orden de fecha
+func Sort(entries []Entry, key SortKey) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch key {
+		case ByDuration:
+			return a.Seconds > b.Seconds
+		case ByPeak:
+			return a.Peak > b.Peak
+		case ByDate:
+			return a.Date.After(b.Date)
+		default:
+			return a.Score > b.Score
+		}
+	})
+}
+
+// Best retorna el mejor puntaje del desafío diario de esa fecha (0 si
+// todavía no se jugó)
+func Best(entries []Entry, challenge string) int {
+	best := 0
+	for _, e := range entries {
+		if e.Challenge == challenge {
+			best = max(best, e.Score)
+		}
+	}
+	return best
+}
+
+// Path retorna $XDG_CONFIG_HOME/firefly-garden/scores.jsonl
+// (o el equivalente del sistema operativo)
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "firefly-garden", "scores.jsonl"), nil
+}
+
+// Load lee el historial; si no existe retorna una lista vacía. Las líneas
+// ilegibles (por ejemplo, un corte a mitad de escritura) se saltean.
+func Load() ([]Entry, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// Append agrega un resultado al final del historial
+func Append(e Entry) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
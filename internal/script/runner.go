@@ -0,0 +1,247 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/pkg/garden"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// pollInterval es cada cuánto await vuelve a mirar el jardín
+	pollInterval = 100 * time.Millisecond
+	// retryInterval es la espera antes de reintentar con la cola de comandos llena
+	retryInterval = 10 * time.Millisecond
+)
+
+// Target es lo único que un escenario ve del jardín: la cola de comandos y
+// sus contadores. *garden.Garden la implementa.
+type Target interface {
+	Command(cmd garden.Command) error
+	Status() garden.Status
+}
+
+// Runner ejecuta un escenario en su propia goroutine
+type Runner struct {
+	script *Script
+	print  func(string)
+	log    *slog.Logger
+	done   chan struct{}
+	err    error
+}
+
+func NewRunner(s *Script) *Runner {
+	return &Runner{
+		script: s,
+		log:    logging.For("script").With("script", s.Name),
+		done:   make(chan struct{}),
+	}
+}
+
+// SetPrint recibe además los mensajes de 'log' y el resultado final (por
+// ejemplo para mostrarlos en pantalla). Debe llamarse antes de Start.
+func (r *Runner) SetPrint(fn func(string)) {
+	r.print = fn
+}
+
+// Start lanza el escenario; termina al llegar al final, al fallar una
+// verificación o al cancelarse ctx
+func (r *Runner) Start(ctx context.Context, target Target) {
+	go func() {
+		defer close(r.done)
+
+		r.log.Info("escenario iniciado")
+		r.err = r.exec(ctx, target, r.script.stmts)
+
+		switch {
+		case r.err == nil:
+			r.log.Info("escenario completado")
+			r.say("Escenario completado")
+		case errors.Is(r.err, context.Canceled):
+			r.log.Info("escenario cancelado")
+		default:
+			r.log.Error("escenario fallido", "err", r.err)
+			r.say("Escenario fallido: " + r.err.Error())
+		}
+	}()
+}
+
+// Done se cierra al terminar el escenario
+func (r *Runner) Done() <-chan struct{} {
+	return r.done
+}
+
+// Err retorna el resultado; solo es válido después de Done
+func (r *Runner) Err() error {
+	return r.err
+}
+
+func (r *Runner) say(msg string) {
+	if r.print != nil {
+		r.print(msg)
+	}
+}
+
+func (r *Runner) exec(ctx context.Context, target Target, stmts []stmt) error {
+	for _, s := range stmts {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := r.step(ctx, target, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Runner) step(ctx context.Context, target Target, s stmt) error {
+	switch s.op {
+	case "spawn":
+		pos := resolve(s.x, s.y)
+		if s.count == 1 {
+			return r.send(ctx, target, s, garden.Command{Kind: garden.SpawnFirefly, Position: pos})
+		}
+		return r.send(ctx, target, s, garden.Command{Kind: garden.SpawnBurst, Position: pos, Count: s.count})
+
+	case "lantern":
+		return r.send(ctx, target, s, garden.Command{Kind: garden.AddLantern, Position: resolve(s.x, s.y)})
+
+	case "remove":
+		return r.send(ctx, target, s, garden.Command{Kind: garden.RemoveLantern})
+
+	case "move":
+		ids := target.Status().LanternIDs
+		if s.count > len(ids) {
+			return &Error{Line: s.line, Msg: fmt.Sprintf("no existe el farol %d (hay %d)", s.count, len(ids))}
+		}
+		id := ids[s.count-1]
+		return r.send(ctx, target, s, garden.Command{Kind: garden.MoveLantern, ID: id, Position: resolve(s.x, s.y)})
+
+	case "attract":
+		return r.send(ctx, target, s, garden.Command{Kind: garden.SetAttraction, Position: resolve(s.x, s.y)})
+
+	case "release":
+		return r.send(ctx, target, s, garden.Command{Kind: garden.ClearAttraction})
+
+	case "wind":
+		if s.text == "cycle" {
+			return r.send(ctx, target, s, garden.Command{Kind: garden.CycleWind})
+		}
+		return r.send(ctx, target, s, garden.Command{Kind: garden.SetWind, Wind: s.text})
+
+	case "wait":
+		return sleep(ctx, s.dur)
+
+	case "log":
+		r.log.Info(s.text, "line", s.line)
+		r.say(s.text)
+
+	case "assert":
+		if value, ok := evaluate(s.cond, target.Status()); !ok {
+			return &Error{Line: s.line, Msg: fmt.Sprintf("assert %s: valor actual %g", s.cond, value)}
+		}
+
+	case "await":
+		deadline := time.Now().Add(s.dur)
+		for {
+			value, ok := evaluate(s.cond, target.Status())
+			if ok {
+				break
+			}
+			if time.Now().After(deadline) {
+				return &Error{Line: s.line, Msg: fmt.Sprintf("await %s: no se cumplió en %v (valor actual %g)", s.cond, s.dur, value)}
+			}
+			if err := sleep(ctx, pollInterval); err != nil {
+				return err
+			}
+		}
+
+	case "repeat":
+		for i := 0; i < s.count; i++ {
+			if err := r.exec(ctx, target, s.body); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// send encola el comando; si la cola está llena reintenta en vez de perderlo,
+// porque el escenario depende de que cada orden se aplique
+func (r *Runner) send(ctx context.Context, target Target, s stmt, cmd garden.Command) error {
+	for {
+		err := target.Command(cmd)
+		if !errors.Is(err, garden.ErrCommandQueueFull) {
+			if err != nil {
+				return &Error{Line: s.line, Msg: err.Error()}
+			}
+			r.log.Debug("comando enviado", "line", s.line, "op", s.op)
+			return nil
+		}
+		if err := sleep(ctx, retryInterval); err != nil {
+			return err
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// resolve elige las coordenadas "random" dentro de la pantalla
+func resolve(x, y coord) utils.Vector2D {
+	pos := utils.Vector2D{X: x.value, Y: y.value}
+	width, height := config.WorldSize()
+	if x.random {
+		pos.X = utils.RandomFloat(0, width)
+	}
+	if y.random {
+		pos.Y = utils.RandomFloat(0, height)
+	}
+	return pos
+}
+
+// evaluate retorna el valor actual de la métrica y si cumple la condición
+func evaluate(c condition, status garden.Status) (float64, bool) {
+	var value float64
+	switch c.metric {
+	case "population":
+		value = float64(status.Population)
+	case "lanterns":
+		value = float64(len(status.LanternIDs))
+	case "dropped":
+		value = float64(status.Dropped)
+	case "cap":
+		value = float64(status.SpawnCap)
+	}
+
+	switch c.op {
+	case "==":
+		return value, value == c.value
+	case "!=":
+		return value, value != c.value
+	case "<":
+		return value, value < c.value
+	case "<=":
+		return value, value <= c.value
+	case ">":
+		return value, value > c.value
+	default:
+		return value, value >= c.value
+	}
+}
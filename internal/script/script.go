@@ -0,0 +1,346 @@
+// Package script interpreta escenarios: archivos de texto con una orden por
+// línea que generan oleadas, mueven faroles, cambian el viento y verifican
+// condiciones a lo largo del tiempo. Un escenario solo actúa a través de la
+// API de comandos de pkg/garden, igual que cualquier otro front-end.
+//
+//	# Demo para clase
+//	wind east
+//	lantern 200 150
+//	repeat 3
+//	    spawn 10 at random
+//	    wait 2s
+//	end
+//	await population >= 30 within 10s
+//	move 1 600 400
+//	assert dropped == 0
+package script
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/manager"
+)
+
+// Error indica la línea del escenario que falló al leerse o ejecutarse
+type Error struct {
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("línea %d: %s", e.Line, e.Msg)
+}
+
+// coord es una coordenada fija o elegida al azar al ejecutarse
+type coord struct {
+	value  float64
+	random bool
+}
+
+// condition compara una métrica del jardín con un valor
+type condition struct {
+	metric string
+	op     string
+	value  float64
+}
+
+func (c condition) String() string {
+	return fmt.Sprintf("%s %s %g", c.metric, c.op, c.value)
+}
+
+type stmt struct {
+	line  int
+	op    string
+	count int
+	x, y  coord
+	dur   time.Duration
+	text  string
+	cond  condition
+	body  []stmt
+}
+
+// Script es un escenario ya validado, listo para ejecutarse
+type Script struct {
+	Name  string
+	stmts []stmt
+}
+
+// metrics son los valores que pueden usar assert y await
+var metrics = map[string]bool{
+	"population": true,
+	"lanterns":   true,
+	"dropped":    true,
+	"cap":        true,
+}
+
+var operators = map[string]bool{
+	"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+}
+
+// Load lee y valida un escenario desde un archivo
+func Load(path string) (*Script, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Parse(path, file)
+}
+
+// FromMap arma el escenario con los eventos programados de un mapa; nil si
+// el mapa no trae eventos
+func FromMap(m *manager.GardenMap) (*Script, error) {
+	if len(m.Events) == 0 {
+		return nil, nil
+	}
+	return Parse(m.Name, strings.NewReader(strings.Join(m.Events, "\n")))
+}
+
+// Parse valida el escenario completo antes de ejecutar nada, así un error
+// de sintaxis al final del archivo no deja la demo a medias
+func Parse(name string, r io.Reader) (*Script, error) {
+	p := &parser{scanner: bufio.NewScanner(r)}
+
+	stmts, closed, err := p.block()
+	if err != nil {
+		return nil, err
+	}
+	if closed {
+		return nil, &Error{Line: p.line, Msg: "'end' sin 'repeat'"}
+	}
+
+	return &Script{Name: name, stmts: stmts}, nil
+}
+
+type parser struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// block lee sentencias hasta el fin del archivo o un 'end'; closed indica
+// que terminó en 'end'
+func (p *parser) block() ([]stmt, bool, error) {
+	var stmts []stmt
+
+	for p.scanner.Scan() {
+		p.line++
+
+		line := p.scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if fields[0] == "end" {
+			if len(fields) != 1 {
+				return nil, false, p.errorf("'end' no lleva argumentos")
+			}
+			return stmts, true, nil
+		}
+
+		s, err := p.stmt(fields)
+		if err != nil {
+			return nil, false, err
+		}
+		stmts = append(stmts, s)
+	}
+
+	if err := p.scanner.Err(); err != nil {
+		return nil, false, err
+	}
+	return stmts, false, nil
+}
+
+func (p *parser) stmt(fields []string) (stmt, error) {
+	s := stmt{line: p.line, op: fields[0]}
+	args := fields[1:]
+
+	switch s.op {
+	case "spawn":
+		// spawn N [at X Y]
+		if len(args) != 1 && len(args) != 4 && !(len(args) == 3 && args[1] == "at" && args[2] == "random") {
+			return s, p.errorf("uso: spawn N [at X Y | at random]")
+		}
+		count, err := p.count(args[0])
+		if err != nil {
+			return s, err
+		}
+		s.count = count
+		s.x, s.y = coord{random: true}, coord{random: true}
+		if len(args) == 4 {
+			if args[1] != "at" {
+				return s, p.errorf("uso: spawn N [at X Y | at random]")
+			}
+			if s.x, s.y, err = p.point(args[2:]); err != nil {
+				return s, err
+			}
+		}
+
+	case "lantern", "attract":
+		// lantern X Y / attract X Y
+		if len(args) != 2 {
+			return s, p.errorf("uso: %s X Y", s.op)
+		}
+		var err error
+		if s.x, s.y, err = p.point(args); err != nil {
+			return s, err
+		}
+
+	case "move":
+		// move I X Y: I es el farol en orden de colocación, desde 1
+		if len(args) != 3 {
+			return s, p.errorf("uso: move I X Y")
+		}
+		index, err := p.count(args[0])
+		if err != nil {
+			return s, err
+		}
+		s.count = index
+		if s.x, s.y, err = p.point(args[1:]); err != nil {
+			return s, err
+		}
+
+	case "remove", "release":
+		if len(args) != 0 {
+			return s, p.errorf("'%s' no lleva argumentos", s.op)
+		}
+
+	case "wind":
+		// wind DIRECCIÓN | wind cycle
+		if len(args) != 1 {
+			return s, p.errorf("uso: wind north|south|east|west|northeast|...|cycle")
+		}
+		s.text = strings.ToLower(args[0])
+		if _, ok := core.ParseWindDirection(s.text); !ok && s.text != "cycle" {
+			return s, p.errorf("dirección de viento desconocida %q", args[0])
+		}
+
+	case "wait":
+		if len(args) != 1 {
+			return s, p.errorf("uso: wait DURACIÓN")
+		}
+		dur, err := p.duration(args[0])
+		if err != nil {
+			return s, err
+		}
+		s.dur = dur
+
+	case "log":
+		s.text = strings.Join(args, " ")
+
+	case "assert":
+		// assert MÉTRICA OP VALOR
+		if len(args) != 3 {
+			return s, p.errorf("uso: assert MÉTRICA OP VALOR")
+		}
+		cond, err := p.condition(args)
+		if err != nil {
+			return s, err
+		}
+		s.cond = cond
+
+	case "await":
+		// await MÉTRICA OP VALOR within DURACIÓN
+		if len(args) != 5 || args[3] != "within" {
+			return s, p.errorf("uso: await MÉTRICA OP VALOR within DURACIÓN")
+		}
+		cond, err := p.condition(args[:3])
+		if err != nil {
+			return s, err
+		}
+		dur, err := p.duration(args[4])
+		if err != nil {
+			return s, err
+		}
+		s.cond, s.dur = cond, dur
+
+	case "repeat":
+		if len(args) != 1 {
+			return s, p.errorf("uso: repeat N ... end")
+		}
+		count, err := p.count(args[0])
+		if err != nil {
+			return s, err
+		}
+		s.count = count
+
+		start := p.line
+		body, closed, err := p.block()
+		if err != nil {
+			return s, err
+		}
+		if !closed {
+			return s, &Error{Line: start, Msg: "'repeat' sin 'end'"}
+		}
+		s.body = body
+
+	default:
+		return s, p.errorf("orden desconocida %q", s.op)
+	}
+
+	return s, nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &Error{Line: p.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) count(arg string) (int, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 {
+		return 0, p.errorf("se esperaba un entero positivo, no %q", arg)
+	}
+	return n, nil
+}
+
+func (p *parser) duration(arg string) (time.Duration, error) {
+	d, err := time.ParseDuration(arg)
+	if err != nil || d < 0 {
+		return 0, p.errorf("duración inválida %q", arg)
+	}
+	return d, nil
+}
+
+// point lee "X Y"; cada coordenada puede ser un número o "random"
+func (p *parser) point(args []string) (coord, coord, error) {
+	var c [2]coord
+	for i, arg := range args[:2] {
+		if arg == "random" {
+			c[i].random = true
+			continue
+		}
+		v, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return c[0], c[1], p.errorf("coordenada inválida %q", arg)
+		}
+		c[i].value = v
+	}
+	return c[0], c[1], nil
+}
+
+func (p *parser) condition(args []string) (condition, error) {
+	cond := condition{metric: args[0], op: args[1]}
+	if !metrics[cond.metric] {
+		return cond, p.errorf("métrica desconocida %q (population, lanterns, dropped, cap)", cond.metric)
+	}
+	if !operators[cond.op] {
+		return cond, p.errorf("operador desconocido %q", cond.op)
+	}
+	v, err := strconv.ParseFloat(args[2], 64)
+	if err != nil {
+		return cond, p.errorf("valor inválido %q", args[2])
+	}
+	cond.value = v
+	return cond, nil
+}
@@ -0,0 +1,104 @@
+package sound
+
+import (
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// effectsEventBuffer es el buffer de la suscripción al bus; si se llena se
+// pierden sonidos, nunca eventos de otros suscriptores
+const effectsEventBuffer = 64
+
+// Effects hace sonar los eventos del jardín: una campanita al nacer cada
+// luciérnaga, un tono cálido al colocar un farol y una ráfaga al cambiar
+// el viento. Se suscribe al bus como el registro de eventos y consume sin
+// bloquear en Update.
+type Effects struct {
+	events      <-chan manager.Event
+	unsubscribe func()
+	mixer       *Mixer
+	chimes      [][]float32
+	lantern     []float32
+	whoosh      []float32
+	last        map[manager.EventType]time.Time
+}
+
+// NewEffects sintetiza los efectos y se suscribe a los eventos de fm; debe
+// crearse antes de Start para que suenen las luciérnagas iniciales
+func NewEffects(fm *manager.FireflyManager, mixer *Mixer) *Effects {
+	events, unsubscribe := fm.Events().Subscribe(effectsEventBuffer)
+	e := &Effects{
+		events:      events,
+		unsubscribe: unsubscribe,
+		mixer:       mixer,
+		lantern:     WarmTone(196),
+		whoosh:      Whoosh(),
+		last:        make(map[manager.EventType]time.Time),
+	}
+	for _, freq := range pentatonic {
+		e.chimes = append(e.chimes, Chime(freq))
+	}
+	return e
+}
+
+// Close cancela la suscripción
+func (e *Effects) Close() {
+	e.unsubscribe()
+}
+
+// Update consume los eventos pendientes; pan ubica un punto del mundo en
+// la pantalla (-1 a 1, de izquierda a derecha). Con el sonido apagado los
+// eventos se descartan igual para no llenar el buffer.
+func (e *Effects) Update(pan func(p utils.Vector2D) float64) {
+	cfg := config.Get().Sound
+	now := time.Now()
+	for drained := false; !drained; {
+		select {
+		case ev := <-e.events:
+			if cfg.Enabled {
+				e.play(ev, pan, cfg, now)
+			}
+		default:
+			drained = true
+		}
+	}
+}
+
+// play elige el efecto del evento y lo hace sonar si pasó el espaciado
+// mínimo desde el último del mismo tipo
+func (e *Effects) play(ev manager.Event, pan func(p utils.Vector2D) float64, cfg config.SoundConfig, now time.Time) {
+	var samples []float32
+	var where *utils.Vector2D
+	switch ev.Type {
+	case manager.EventSpawn:
+		// Cada luciérnaga tiene su nota, así una ráfaga suena como un arpegio
+		samples = e.chimes[ev.ID%len(e.chimes)]
+		if ev.Firefly != nil {
+			where = &ev.Firefly.Position
+		}
+	case manager.EventLanternAdd:
+		samples = e.lantern
+		if ev.Lantern != nil {
+			where = &ev.Lantern.Position
+		}
+	case manager.EventWind:
+		samples = e.whoosh
+	default:
+		return
+	}
+
+	if now.Sub(e.last[ev.Type]) < cfg.Spacing.Duration {
+		return
+	}
+
+	position := 0.0
+	if where != nil {
+		position = pan(*where)
+	}
+	if e.mixer.Play(samples, position, cfg.Volume, cfg.MaxVoices) {
+		e.last[ev.Type] = now
+	}
+}
@@ -0,0 +1,103 @@
+// Package sound sintetiza los efectos de sonido del jardín y los mezcla con
+// el audio de Ebiten. Los efectos se disparan desde el bus de eventos del
+// manager y nunca bloquean el Update: la mezcla ocurre en la goroutine de
+// audio de Ebiten.
+package sound
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// voice es un efecto sonando: lee la muestra mono y la reparte entre los
+// dos canales según el paneo, en float32 estéreo como espera NewPlayerF32
+type voice struct {
+	samples     []float32
+	pos         int
+	left, right float32
+}
+
+func (v *voice) Read(p []byte) (int, error) {
+	n := 0
+	for ; n+8 <= len(p) && v.pos < len(v.samples); n += 8 {
+		s := v.samples[v.pos]
+		binary.LittleEndian.PutUint32(p[n:], math.Float32bits(s*v.left))
+		binary.LittleEndian.PutUint32(p[n+4:], math.Float32bits(s*v.right))
+		v.pos++
+	}
+	if v.pos >= len(v.samples) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Mixer reproduce muestras con paneo estéreo. Play solo crea el player y
+// vuelve enseguida; las voces terminadas se liberan en la llamada siguiente.
+type Mixer struct {
+	ctx    *audio.Context
+	voices []*audio.Player
+}
+
+// NewMixer usa el contexto de audio del proceso; Ebiten admite uno solo,
+// así que se crea la primera vez y se comparte entre partidas
+func NewMixer() *Mixer {
+	ctx := audio.CurrentContext()
+	if ctx == nil {
+		ctx = audio.NewContext(SampleRate)
+	}
+	return &Mixer{ctx: ctx}
+}
+
+// Play hace sonar samples con paneo pan (-1 izquierda, 1 derecha) y el
+// volumen indicado. Si ya suenan maxVoices voces el efecto se descarta y
+// retorna false.
+func (m *Mixer) Play(samples []float32, pan, volume float64, maxVoices int) bool {
+	m.prune()
+	if len(m.voices) >= maxVoices {
+		return false
+	}
+
+	// Paneo de igual potencia: al centro cada canal lleva ~0.7
+	angle := (utils.Clamp(pan, -1, 1) + 1) * math.Pi / 4
+	v := &voice{samples: samples, left: float32(math.Cos(angle)), right: float32(math.Sin(angle))}
+	player, err := m.ctx.NewPlayerF32(v)
+	if err != nil {
+		return false
+	}
+	player.SetVolume(volume)
+	player.Play()
+	m.voices = append(m.voices, player)
+	return true
+}
+
+// Playing retorna cuántas voces siguen sonando
+func (m *Mixer) Playing() int {
+	m.prune()
+	return len(m.voices)
+}
+
+// prune cierra las voces que ya terminaron
+func (m *Mixer) prune() {
+	alive := m.voices[:0]
+	for _, p := range m.voices {
+		if p.IsPlaying() {
+			alive = append(alive, p)
+		} else {
+			p.Close()
+		}
+	}
+	clear(m.voices[len(alive):])
+	m.voices = alive
+}
+
+// Close corta todas las voces
+func (m *Mixer) Close() {
+	for _, p := range m.voices {
+		p.Close()
+	}
+	m.voices = nil
+}
@@ -0,0 +1,130 @@
+package sound
+
+import (
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+const (
+	// musicPeak es el brillo mínimo para que el pico de un destello suene
+	musicPeak = 0.9
+	// musicChord es cuántas notas distintas pueden empezar en un mismo
+	// frame: un destello sincronizado suena como un acorde, no como ruido
+	musicChord = 3
+	// musicOctaves es el rango de notas, de abajo (grave) a arriba (agudo)
+	musicOctaves = 2
+)
+
+// Music es el modo musical: cada luciérnaga que llega al pico de su
+// destello toca una nota de la escala pentatónica, más aguda cuanto más
+// arriba está. Las notas pasan por un balde que se llena a MusicRate por
+// segundo, un tope de notas por frame y su propio mezclador con a lo sumo
+// MusicVoices voces, así cien luciérnagas no se vuelven ruido.
+type Music struct {
+	mixer   *Mixer
+	notes   [][]float32
+	enabled bool
+	// prev y rising guardan el brillo anterior de cada luciérnaga y si
+	// venía subiendo; next se reutiliza para no alocar en cada frame
+	prev, next map[int]float64
+	rising     map[int]bool
+	tokens     float64
+	last       time.Time
+}
+
+// NewMusic sintetiza las notas; arranca apagado
+func NewMusic() *Music {
+	m := &Music{
+		mixer:  NewMixer(),
+		prev:   make(map[int]float64),
+		next:   make(map[int]float64),
+		rising: make(map[int]bool),
+	}
+	for octave := 0; octave < musicOctaves; octave++ {
+		for _, freq := range pentatonic {
+			m.notes = append(m.notes, Bell(freq/2*float64(int(1)<<octave)))
+		}
+	}
+	return m
+}
+
+// Toggle prende o apaga el modo y retorna el estado nuevo
+func (m *Music) Toggle() bool {
+	m.enabled = !m.enabled
+	m.tokens = 0
+	clear(m.prev)
+	clear(m.rising)
+	if !m.enabled {
+		m.mixer.Close()
+	}
+	return m.enabled
+}
+
+// Enabled indica si el modo está activo
+func (m *Music) Enabled() bool {
+	return m.enabled
+}
+
+// Close corta las notas que estén sonando
+func (m *Music) Close() {
+	m.mixer.Close()
+}
+
+// Update busca los picos de brillo en el snapshot del frame y toca sus
+// notas; pan ubica cada luciérnaga en el ancho de la pantalla
+func (m *Music) Update(states []core.FireflyState, pan func(p utils.Vector2D) float64) {
+	if !m.enabled {
+		return
+	}
+	cfg := config.Get().Sound
+
+	now := time.Now()
+	if !m.last.IsZero() {
+		m.tokens = min(m.tokens+now.Sub(m.last).Seconds()*cfg.MusicRate, cfg.MusicRate/2)
+	}
+	m.last = now
+
+	_, height := config.WorldSize()
+	var played [musicChord]int
+	chord := 0
+	clear(m.next)
+	for _, s := range states {
+		m.next[s.ID] = s.Brightness
+		prev, seen := m.prev[s.ID]
+		if !seen {
+			continue
+		}
+		peaked := m.rising[s.ID] && s.Brightness < prev && prev >= musicPeak
+		m.rising[s.ID] = s.Brightness > prev
+		if !peaked || !cfg.Enabled || chord == musicChord || m.tokens < 1 {
+			continue
+		}
+
+		// Arriba agudo, abajo grave; una nota ya tocada en este frame no
+		// se repite
+		note := int(utils.Clamp((1-s.Position.Y/height)*float64(len(m.notes)), 0, float64(len(m.notes)-1)))
+		repeated := false
+		for _, p := range played[:chord] {
+			repeated = repeated || p == note
+		}
+		if repeated {
+			continue
+		}
+		if m.mixer.Play(m.notes[note], pan(s.Position), cfg.Volume*0.7, cfg.MusicVoices) {
+			played[chord] = note
+			chord++
+			m.tokens--
+		}
+	}
+
+	// Las que ya no están dejan de seguirse
+	for id := range m.rising {
+		if _, alive := m.next[id]; !alive {
+			delete(m.rising, id)
+		}
+	}
+	m.prev, m.next = m.next, m.prev
+}
@@ -0,0 +1,84 @@
+package sound
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// SampleRate es la frecuencia de muestreo de todos los efectos
+const SampleRate = 44100
+
+// pentatonic son las notas (Hz) de una escala pentatónica mayor de Do;
+// cualquier combinación suena consonante
+var pentatonic = []float64{523.25, 587.33, 659.25, 783.99, 880.00}
+
+// samples retorna la cantidad de muestras de una duración en segundos
+func samples(seconds float64) int {
+	return int(seconds * SampleRate)
+}
+
+// envelope es una envolvente de ataque lineal y caída exponencial; decay es
+// el tiempo en que la amplitud baja a un tercio
+func envelope(t, attack, decay float64) float64 {
+	if t < attack {
+		return t / attack
+	}
+	return math.Exp(-(t - attack) / decay)
+}
+
+// Chime es una campanita suave: la fundamental con un parcial una octava y
+// media arriba que se apaga antes
+func Chime(freq float64) []float32 {
+	out := make([]float32, samples(0.6))
+	for i := range out {
+		t := float64(i) / SampleRate
+		v := math.Sin(2*math.Pi*freq*t)*envelope(t, 0.004, 0.18) +
+			0.3*math.Sin(2*math.Pi*freq*3*t)*envelope(t, 0.004, 0.06)
+		out[i] = float32(0.35 * v)
+	}
+	return out
+}
+
+// Bell es la nota del modo musical: más suave y corta que Chime, con un
+// ataque lento para que muchas juntas no chasqueen
+func Bell(freq float64) []float32 {
+	out := make([]float32, samples(0.7))
+	for i := range out {
+		t := float64(i) / SampleRate
+		v := math.Sin(2*math.Pi*freq*t) + 0.2*math.Sin(2*math.Pi*freq*2*t)
+		out[i] = float32(0.25 * v * envelope(t, 0.012, 0.25))
+	}
+	return out
+}
+
+// WarmTone es un tono grave y redondo, con ataque lento y pocos armónicos,
+// para los faroles
+func WarmTone(freq float64) []float32 {
+	out := make([]float32, samples(0.9))
+	for i := range out {
+		t := float64(i) / SampleRate
+		v := math.Sin(2*math.Pi*freq*t) +
+			0.4*math.Sin(2*math.Pi*freq*2*t) +
+			0.15*math.Sin(2*math.Pi*freq*3*t)
+		out[i] = float32(0.3 * v * envelope(t, 0.04, 0.3))
+	}
+	return out
+}
+
+// Whoosh es ruido filtrado con un pasabajos que se abre y se cierra, como
+// una ráfaga de viento. El ruido sale de un generador propio con semilla
+// fija: no toca el generador compartido de la simulación.
+func Whoosh() []float32 {
+	out := make([]float32, samples(0.8))
+	rng := rand.New(rand.NewPCG(1, 2))
+	var low float64
+	for i := range out {
+		t := float64(i) / float64(len(out))
+		// La ráfaga sube y baja; el filtro se abre con ella
+		swell := math.Sin(math.Pi * t)
+		cutoff := 0.02 + 0.12*swell
+		low += cutoff * (rng.Float64()*2 - 1 - low)
+		out[i] = float32(0.7 * low * swell * swell)
+	}
+	return out
+}
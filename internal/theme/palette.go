@@ -0,0 +1,147 @@
+// Package theme decide cómo se ve el jardín. El render no lee los colores de
+// config directamente: pide la paleta activa, que es la del tema elegido
+// (Skin: colores, fondo y brillo, leídos de archivos JSON) o una de las
+// paletas accesibles (daltonismo y alto contraste), que tienen prioridad
+// sobre los colores del tema.
+package theme
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Palette son los colores de un tema, en RGBA como en config.ColorsConfig
+type Palette struct {
+	ID   string
+	Name string
+
+	Background  [4]uint8
+	FireflyDim  [4]uint8
+	FireflyFull [4]uint8
+	Lantern     [4]uint8
+	Wind        [4]uint8
+
+	// UIText es el texto del HUD, Panel el fondo de los paneles y Accent
+	// sus títulos
+	UIText [4]uint8
+	Panel  [4]uint8
+	Accent [4]uint8
+}
+
+// DefaultID es la paleta del tema activo
+const DefaultID = "normal"
+
+// accessible son las paletas alternativas. Las de daltonismo parten de la
+// paleta de Okabe-Ito: luciérnagas, faroles y viento quedan en tonos que
+// ese tipo de visión distingue entre sí y contra el fondo.
+var accessible = []Palette{
+	{
+		ID: "deuteranopia", Name: "Deuteranopía",
+		Background:  [4]uint8{10, 15, 35, 255},
+		FireflyDim:  [4]uint8{200, 190, 60, 100},
+		FireflyFull: [4]uint8{240, 228, 66, 255},
+		Lantern:     [4]uint8{86, 180, 233, 200},
+		Wind:        [4]uint8{204, 121, 167, 90},
+		UIText:      [4]uint8{255, 255, 255, 255},
+		Panel:       [4]uint8{0, 0, 0, 150},
+		Accent:      [4]uint8{86, 180, 233, 255},
+	},
+	{
+		ID: "protanopia", Name: "Protanopía",
+		Background:  [4]uint8{10, 15, 35, 255},
+		FireflyDim:  [4]uint8{200, 190, 60, 100},
+		FireflyFull: [4]uint8{240, 228, 66, 255},
+		Lantern:     [4]uint8{86, 180, 233, 200},
+		Wind:        [4]uint8{210, 210, 210, 80},
+		UIText:      [4]uint8{255, 255, 255, 255},
+		Panel:       [4]uint8{0, 0, 0, 150},
+		Accent:      [4]uint8{86, 180, 233, 255},
+	},
+	{
+		ID: "tritanopia", Name: "Tritanopía",
+		Background:  [4]uint8{15, 15, 25, 255},
+		FireflyDim:  [4]uint8{200, 90, 120, 100},
+		FireflyFull: [4]uint8{255, 140, 160, 255},
+		Lantern:     [4]uint8{0, 200, 180, 200},
+		Wind:        [4]uint8{220, 220, 220, 80},
+		UIText:      [4]uint8{255, 255, 255, 255},
+		Panel:       [4]uint8{0, 0, 0, 150},
+		Accent:      [4]uint8{255, 140, 160, 255},
+	},
+	{
+		ID: "contraste", Name: "Alto contraste",
+		Background:  [4]uint8{0, 0, 0, 255},
+		FireflyDim:  [4]uint8{255, 255, 0, 160},
+		FireflyFull: [4]uint8{255, 255, 255, 255},
+		Lantern:     [4]uint8{0, 255, 255, 230},
+		Wind:        [4]uint8{255, 0, 255, 140},
+		UIText:      [4]uint8{255, 255, 255, 255},
+		Panel:       [4]uint8{0, 0, 0, 230},
+		Accent:      [4]uint8{255, 255, 0, 255},
+	},
+}
+
+// current es la paleta elegida; nil es la del tema, que se arma en cada
+// Current para seguir los cambios de tema y las recargas de config.json
+var current atomic.Pointer[Palette]
+
+// Default retorna la paleta del tema activo
+func Default() Palette {
+	c := CurrentSkin().colors()
+	return Palette{
+		ID: DefaultID, Name: "Normal",
+		Background:  c.Background,
+		FireflyDim:  c.FireflyDim,
+		FireflyFull: c.FireflyFull,
+		Lantern:     c.Lantern,
+		Wind:        c.Wind,
+		UIText:      c.UIText,
+		Panel:       [4]uint8{0, 0, 0, 150},
+		Accent:      [4]uint8{150, 200, 255, 255},
+	}
+}
+
+// IDs retorna las paletas disponibles, la del tema primero
+func IDs() []string {
+	ids := []string{DefaultID}
+	for _, p := range accessible {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+// Set activa la paleta id
+func Set(id string) error {
+	if id == DefaultID {
+		current.Store(nil)
+		return nil
+	}
+	for i := range accessible {
+		if accessible[i].ID == id {
+			current.Store(&accessible[i])
+			return nil
+		}
+	}
+	return fmt.Errorf("paleta desconocida %q", id)
+}
+
+// Lookup retorna la paleta id sin activarla
+func Lookup(id string) (Palette, bool) {
+	if id == DefaultID {
+		return Default(), true
+	}
+	for _, p := range accessible {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return Palette{}, false
+}
+
+// Current retorna la paleta activa; se puede llamar desde cualquier goroutine
+func Current() Palette {
+	if p := current.Load(); p != nil {
+		return *p
+	}
+	return Default()
+}
@@ -0,0 +1,266 @@
+package theme
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+)
+
+//go:embed skins/*.json
+var embeddedSkins embed.FS
+
+// BackgroundStyle es cómo se pinta el fondo del jardín
+type BackgroundStyle string
+
+const (
+	// BackgroundGradient es el color de fondo con bandas tenues de Tint
+	BackgroundGradient BackgroundStyle = "gradient"
+	// BackgroundStars agrega al degradado Stars estrellas que titilan
+	BackgroundStars BackgroundStyle = "stars"
+	// BackgroundSolid es solo el color de fondo
+	BackgroundSolid BackgroundStyle = "solid"
+)
+
+// Background es el fondo de un tema
+type Background struct {
+	Style BackgroundStyle `json:"style"`
+	Tint  [4]uint8        `json:"tint"`
+	Stars int             `json:"stars,omitempty"`
+}
+
+// Glow es el brillo de las luciérnagas: Halo escala el radio de los halos y
+// los parámetros de bloom reemplazan a los de la configuración. Los valores
+// en cero dejan los de la configuración.
+type Glow struct {
+	Halo           float64 `json:"halo"`
+	BloomIntensity float64 `json:"bloom_intensity"`
+	BloomThreshold float64 `json:"bloom_threshold"`
+}
+
+// Skin es un tema visual del jardín: colores, fondo y brillo. Se lee de un
+// archivo JSON; el nombre del archivo sin extensión es su ID.
+//
+//	{"name": "Noche de invierno",
+//	 "colors": {"background": [8, 14, 30, 255], "firefly_full": [220, 240, 255, 255]},
+//	 "background": {"style": "stars", "tint": [40, 60, 90, 20], "stars": 120},
+//	 "glow": {"halo": 1.2, "bloom_intensity": 1.4}}
+//
+// Los colores que falten (en cero) son los de la configuración.
+type Skin struct {
+	ID         string              `json:"-"`
+	Name       string              `json:"name"`
+	Colors     config.ColorsConfig `json:"colors"`
+	Background Background          `json:"background"`
+	Glow       Glow                `json:"glow"`
+}
+
+// ClassicID es el tema de siempre: todo sale de la configuración
+const ClassicID = "clasico"
+
+// classic retorna el tema de siempre
+func classic() Skin {
+	return Skin{
+		ID:         ClassicID,
+		Name:       "Clásico",
+		Background: Background{Style: BackgroundGradient, Tint: [4]uint8{20, 25, 50, 20}},
+		Glow:       Glow{Halo: 1},
+	}
+}
+
+var (
+	skinsMu sync.RWMutex
+	skins   []Skin
+)
+
+// currentSkin es el tema elegido; nil es el clásico
+var currentSkin atomic.Pointer[Skin]
+
+func init() {
+	skins = append(skins, classic())
+
+	entries, err := embeddedSkins.ReadDir("skins")
+	if err != nil {
+		panic("temas embebidos: " + err.Error())
+	}
+	for _, e := range entries {
+		data, err := embeddedSkins.ReadFile("skins/" + e.Name())
+		if err != nil {
+			panic("temas embebidos: " + err.Error())
+		}
+		skin, err := ParseSkin(strings.TrimSuffix(e.Name(), ".json"), data)
+		if err != nil {
+			panic("tema embebido inválido: " + err.Error())
+		}
+		skins = append(skins, *skin)
+	}
+}
+
+// ParseSkin decodifica un tema rechazando campos desconocidos y lo valida
+func ParseSkin(id string, data []byte) (*Skin, error) {
+	skin := Skin{ID: id}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&skin); err != nil {
+		return nil, fmt.Errorf("%s: %w", id, err)
+	}
+	if err := skin.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", id, err)
+	}
+	return &skin, nil
+}
+
+// Validate rechaza temas sin nombre o con valores fuera de rango
+func (s *Skin) Validate() error {
+	var errs []error
+	if s.Name == "" {
+		errs = append(errs, errors.New("falta el nombre"))
+	}
+	switch s.Background.Style {
+	case "", BackgroundGradient, BackgroundStars, BackgroundSolid:
+	default:
+		errs = append(errs, fmt.Errorf("estilo de fondo desconocido %q", s.Background.Style))
+	}
+	if s.Background.Stars < 0 || s.Background.Stars > 1000 {
+		errs = append(errs, fmt.Errorf("stars debe estar entre 0 y 1000 (es %d)", s.Background.Stars))
+	}
+	if s.Glow.Halo < 0 || s.Glow.Halo > 4 {
+		errs = append(errs, fmt.Errorf("glow.halo debe estar entre 0 y 4 (es %g)", s.Glow.Halo))
+	}
+	if s.Glow.BloomIntensity < 0 || s.Glow.BloomThreshold < 0 || s.Glow.BloomThreshold > 1 {
+		errs = append(errs, errors.New("bloom_intensity debe ser positiva y bloom_threshold estar entre 0 y 1"))
+	}
+	return errors.Join(errs...)
+}
+
+// LoadSkins lee todos los *.json de dir y los agrega a los temas
+// disponibles; un tema con el ID de otro lo reemplaza
+func LoadSkins(dir string) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+
+	loaded := make([]Skin, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 0, err
+		}
+		skin, err := ParseSkin(strings.TrimSuffix(filepath.Base(path), ".json"), data)
+		if err != nil {
+			return 0, err
+		}
+		loaded = append(loaded, *skin)
+	}
+
+	skinsMu.Lock()
+	defer skinsMu.Unlock()
+	for _, skin := range loaded {
+		if i := skinIndex(skin.ID); i >= 0 {
+			skins[i] = skin
+		} else {
+			skins = append(skins, skin)
+		}
+	}
+	return len(loaded), nil
+}
+
+// skinIndex busca el tema id; debe llamarse con skinsMu tomado
+func skinIndex(id string) int {
+	for i := range skins {
+		if skins[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Skins retorna los temas disponibles, el clásico primero
+func Skins() []Skin {
+	skinsMu.RLock()
+	defer skinsMu.RUnlock()
+	return append([]Skin(nil), skins...)
+}
+
+// SetSkin activa el tema id; se puede llamar en plena partida
+func SetSkin(id string) error {
+	skinsMu.RLock()
+	defer skinsMu.RUnlock()
+	i := skinIndex(id)
+	if i < 0 {
+		return fmt.Errorf("tema desconocido %q", id)
+	}
+	if id == ClassicID {
+		currentSkin.Store(nil)
+		return nil
+	}
+	skin := skins[i]
+	currentSkin.Store(&skin)
+	return nil
+}
+
+// CurrentSkin retorna el tema activo con los valores faltantes completados
+func CurrentSkin() Skin {
+	p := currentSkin.Load()
+	if p == nil {
+		return classic()
+	}
+
+	skin := *p
+	def := classic()
+	if skin.Background.Style == "" {
+		skin.Background.Style = def.Background.Style
+	}
+	if skin.Background.Tint == ([4]uint8{}) {
+		skin.Background.Tint = def.Background.Tint
+	}
+	if skin.Glow.Halo == 0 {
+		skin.Glow.Halo = def.Glow.Halo
+	}
+	return skin
+}
+
+// BloomIntensity retorna la intensidad del bloom del tema o la de la
+// configuración
+func (s Skin) BloomIntensity() float64 {
+	if s.Glow.BloomIntensity > 0 {
+		return s.Glow.BloomIntensity
+	}
+	return config.Get().Render.BloomIntensity
+}
+
+// BloomThreshold retorna el umbral del bloom del tema o el de la
+// configuración
+func (s Skin) BloomThreshold() float64 {
+	if s.Glow.BloomThreshold > 0 {
+		return s.Glow.BloomThreshold
+	}
+	return config.Get().Render.BloomThreshold
+}
+
+// colors retorna los colores del tema completando con los de la
+// configuración
+func (s Skin) colors() config.ColorsConfig {
+	c := config.Get().Colors
+	pick := func(dst *[4]uint8, v [4]uint8) {
+		if v != ([4]uint8{}) {
+			*dst = v
+		}
+	}
+	pick(&c.Background, s.Colors.Background)
+	pick(&c.FireflyDim, s.Colors.FireflyDim)
+	pick(&c.FireflyFull, s.Colors.FireflyFull)
+	pick(&c.Lantern, s.Colors.Lantern)
+	pick(&c.Wind, s.Colors.Wind)
+	pick(&c.UIText, s.Colors.UIText)
+	return c
+}
@@ -0,0 +1,61 @@
+// Package tracing conecta OpenTelemetry con un colector OTLP. Los paquetes
+// que trazan piden su tracer a otel y no saben si la exportación está
+// prendida: sin Setup, el proveedor global no graba nada.
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/logging"
+)
+
+// shutdownTimeout es lo que se espera a que salgan los spans pendientes
+const shutdownTimeout = 5 * time.Second
+
+// Setup arranca la exportación según cfg y registra el proveedor global.
+// La función retornada vacía y cierra el exportador; con la exportación
+// apagada no hace nada.
+func Setup(cfg config.TracingConfig, service string) (func(), error) {
+	if !cfg.Enabled {
+		return func() {}, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	// La conexión es perezosa: un colector caído no impide arrancar
+	exporter, err := otlptracegrpc.New(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", service))),
+	)
+	otel.SetTracerProvider(provider)
+
+	log := logging.For("tracing")
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Warn("exportación de trazas", "err", err)
+	}))
+	log.Info("trazas OTLP activas", "endpoint", cfg.Endpoint, "sample_ratio", cfg.SampleRatio)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			log.Warn("no se pudieron enviar las últimas trazas", "err", err)
+		}
+	}, nil
+}
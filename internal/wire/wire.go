@@ -0,0 +1,179 @@
+// Package wire es el formato binario de los estados y los snapshots de
+// luciérnagas, la alternativa compacta al JSON para archivos y red con
+// miles de luciérnagas. Cada bloque empieza con una cabecera de 4 bytes
+// mágicos y la versión (uint16); los números van en little endian.
+package wire
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// Version es la versión del formato que escribe este paquete
+const Version = 1
+
+// Magic identifica el contenido de un bloque
+type Magic [4]byte
+
+var (
+	// MagicStates es un slice de FireflyState (AppendStates)
+	MagicStates = Magic{'F', 'F', 'S', 'T'}
+	// MagicGarden es un snapshot completo del jardín (manager.WriteSnapshot)
+	MagicGarden = Magic{'F', 'F', 'G', 'S'}
+)
+
+// HeaderSize son los bytes de la cabecera
+const HeaderSize = 6
+
+const (
+	// stateSize es un FireflyState: ID, x, y (float32), brillo, edad,
+	// banderas y ronda del rumor
+	stateSize = 4 + 4 + 4 + 1 + 1 + 1 + 2
+	// fireflySize es un FireflySnapshot: ID y nueve float64
+	fireflySize = 4 + 9*8
+
+	flagAlive  = 1 << 0
+	flagLeader = 1 << 1
+)
+
+var (
+	// ErrFormat indica datos que no son de este formato o están truncados
+	ErrFormat = errors.New("formato binario inválido")
+	// ErrVersion indica un bloque escrito por una versión más nueva
+	ErrVersion = errors.New("versión de formato binario no soportada")
+)
+
+// AppendHeader agrega la cabecera de un bloque
+func AppendHeader(buf []byte, magic Magic) []byte {
+	buf = append(buf, magic[:]...)
+	return binary.LittleEndian.AppendUint16(buf, Version)
+}
+
+// HasMagic indica si data empieza con la cabecera de magic, de cualquier
+// versión
+func HasMagic(data []byte, magic Magic) bool {
+	return len(data) >= HeaderSize && Magic(data[:4]) == magic
+}
+
+// ReadHeader valida la cabecera y retorna el resto del bloque
+func ReadHeader(data []byte, magic Magic) ([]byte, error) {
+	if !HasMagic(data, magic) {
+		return nil, fmt.Errorf("%w: se esperaba %q", ErrFormat, magic[:])
+	}
+	if v := binary.LittleEndian.Uint16(data[4:]); v > Version {
+		return nil, fmt.Errorf("%w: %d (se lee hasta %d)", ErrVersion, v, Version)
+	}
+	return data[HeaderSize:], nil
+}
+
+// AppendStates agrega un bloque con los estados: 17 bytes por luciérnaga.
+// La posición pasa a float32 y el brillo y la edad a un byte; las fuerzas
+// y los descartes no viajan.
+func AppendStates(buf []byte, states []core.FireflyState) []byte {
+	buf = AppendHeader(buf, MagicStates)
+	buf = binary.AppendUvarint(buf, uint64(len(states)))
+	for _, s := range states {
+		var flags byte
+		if s.IsAlive {
+			flags |= flagAlive
+		}
+		if s.Leader {
+			flags |= flagLeader
+		}
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(s.ID))
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(s.Position.X)))
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(s.Position.Y)))
+		buf = append(buf, unitByte(s.Brightness), unitByte(s.Age), flags)
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(min(s.Gossip, math.MaxUint16)))
+	}
+	return buf
+}
+
+// DecodeStates lee un bloque escrito con AppendStates y lo agrega a dst
+func DecodeStates(dst []core.FireflyState, data []byte) ([]core.FireflyState, error) {
+	data, err := ReadHeader(data, MagicStates)
+	if err != nil {
+		return dst, err
+	}
+	n, data, err := readCount(data, stateSize)
+	if err != nil {
+		return dst, err
+	}
+
+	for i := 0; i < n; i++ {
+		rec := data[i*stateSize:]
+		flags := rec[14]
+		dst = append(dst, core.FireflyState{
+			ID: int(binary.LittleEndian.Uint32(rec)),
+			Position: utils.Vector2D{
+				X: float64(math.Float32frombits(binary.LittleEndian.Uint32(rec[4:]))),
+				Y: float64(math.Float32frombits(binary.LittleEndian.Uint32(rec[8:]))),
+			},
+			Brightness: float64(rec[12]) / 255,
+			Age:        float64(rec[13]) / 255,
+			IsAlive:    flags&flagAlive != 0,
+			Leader:     flags&flagLeader != 0,
+			Gossip:     int(binary.LittleEndian.Uint16(rec[15:])),
+		})
+	}
+	return dst, nil
+}
+
+// AppendFireflies agrega las luciérnagas de un snapshot sin cabecera (van
+// dentro de otro bloque): la cantidad y 76 bytes por luciérnaga, sin
+// perder precisión para que la simulación siga igual al restaurarla
+func AppendFireflies(buf []byte, fireflies []core.FireflySnapshot) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(fireflies)))
+	for _, f := range fireflies {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(f.ID))
+		for _, v := range [...]float64{f.Position.X, f.Position.Y, f.Velocity.X, f.Velocity.Y, f.Brightness, f.BlinkPhase, f.BlinkCycle, f.Age, f.Lifespan} {
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+		}
+	}
+	return buf
+}
+
+// DecodeFireflies lee lo escrito con AppendFireflies y retorna lo que sobra
+func DecodeFireflies(data []byte) ([]core.FireflySnapshot, []byte, error) {
+	n, data, err := readCount(data, fireflySize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fireflies := make([]core.FireflySnapshot, n)
+	for i := range fireflies {
+		rec := data[i*fireflySize:]
+		f := func(k int) float64 { return math.Float64frombits(binary.LittleEndian.Uint64(rec[4+8*k:])) }
+		fireflies[i] = core.FireflySnapshot{
+			ID:         int(binary.LittleEndian.Uint32(rec)),
+			Position:   utils.Vector2D{X: f(0), Y: f(1)},
+			Velocity:   utils.Vector2D{X: f(2), Y: f(3)},
+			Brightness: f(4),
+			BlinkPhase: f(5),
+			BlinkCycle: f(6),
+			Age:        f(7),
+			Lifespan:   f(8),
+		}
+	}
+	return fireflies, data[n*fireflySize:], nil
+}
+
+// readCount lee una cantidad de registros de size bytes y verifica que
+// estén todos
+func readCount(data []byte, size int) (int, []byte, error) {
+	n, read := binary.Uvarint(data)
+	if read <= 0 || n > uint64(len(data)-read)/uint64(size) {
+		return 0, nil, fmt.Errorf("%w: bloque truncado", ErrFormat)
+	}
+	return int(n), data[read:], nil
+}
+
+// unitByte lleva un valor de 0 a 1 a un byte
+func unitByte(v float64) byte {
+	return byte(math.Round(utils.Clamp(v, 0, 1) * 255))
+}
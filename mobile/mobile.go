@@ -0,0 +1,42 @@
+// Package mobile es el punto de entrada para Android e iOS. Se compila con
+// ebitenmobile, que genera la biblioteca (.aar o .xcframework) con una vista
+// lista para usar en la app nativa:
+//
+//	ebitenmobile bind -target android -javapkg com.fireflygarden -o garden.aar ./mobile
+//	ebitenmobile bind -target ios -o Garden.xcframework ./mobile
+//
+// Se juega con el dedo: tocar atrae, mantener presionado coloca un farol y
+// deslizar con dos dedos cambia el viento.
+package mobile
+
+import (
+	"log/slog"
+
+	"github.com/hajimehoshi/ebiten/v2/mobile"
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/logging"
+	"github.com/yourusername/firefly-garden/internal/prefs"
+	"github.com/yourusername/firefly-garden/internal/render"
+)
+
+func init() {
+	cfg := config.Mobile()
+	config.Set(cfg)
+
+	if _, err := logging.Setup(logging.Options{Level: slog.LevelInfo}); err != nil {
+		logging.Fatal("no se pudo configurar el log", "err", err)
+	}
+
+	// Android no define un directorio de configuración para Go: en ese caso
+	// se juega con las preferencias predeterminadas
+	userPrefs, err := prefs.Load(cfg.Render.Quality)
+	if err != nil {
+		logging.For("mobile").Info("preferencias no disponibles, usando las predeterminadas", "err", err)
+	}
+
+	mobile.SetGame(render.NewApp(userPrefs, render.SessionOptions{}))
+}
+
+// Dummy existe porque ebitenmobile necesita al menos una función exportada
+// para generar la biblioteca
+func Dummy() {}
@@ -0,0 +1,319 @@
+// Package garden expone la simulación sin dependencia de Ebiten para que
+// otros front-ends (terminal, web, pruebas) puedan embeberla.
+package garden
+
+import (
+	"errors"
+	"time"
+
+	"github.com/yourusername/firefly-garden/internal/config"
+	"github.com/yourusername/firefly-garden/internal/core"
+	"github.com/yourusername/firefly-garden/internal/manager"
+	"github.com/yourusername/firefly-garden/pkg/utils"
+)
+
+// ErrCommandQueueFull se retorna cuando el canal de comandos está lleno
+var ErrCommandQueueFull = errors.New("garden: cola de comandos llena")
+
+// ErrStopped se retorna al mandar una orden a un jardín ya detenido
+var ErrStopped = errors.New("garden: jardín detenido")
+
+// ErrUnknownCommand se retorna para un CommandKind no soportado
+var ErrUnknownCommand = errors.New("garden: comando desconocido")
+
+// FireflyState es el estado publicado por cada luciérnaga
+type FireflyState = core.FireflyState
+
+// LanternState es una copia de solo lectura de un farol
+type LanternState struct {
+	ID        int
+	Position  utils.Vector2D
+	Radius    float64
+	Intensity float64
+}
+
+// WindState describe el viento actual
+type WindState struct {
+	Direction string
+	Force     utils.Vector2D
+}
+
+// Snapshot es una foto consistente del jardín para dibujar o analizar
+type Snapshot struct {
+	Time      time.Time
+	Fireflies []FireflyState
+	Lanterns  []LanternState
+	Wind      WindState
+	Dropped   uint64
+	SpawnCap  int
+	// Sequence cuenta los cambios, no los ticks: crece con cada estado que
+	// aplica el agregador, y dos fotos con la misma Sequence tienen las
+	// mismas luciérnagas. Tick es el tick de la simulación del estado más
+	// nuevo (en modo sincrónico, la cantidad de Step) y StateTime su hora.
+	Sequence  uint64
+	Tick      uint64
+	StateTime time.Time
+}
+
+// Status son los contadores del jardín. A diferencia de Snapshot no lee los
+// faroles que anima Tick, así que puede pedirse desde cualquier goroutine.
+type Status struct {
+	Population int
+	LanternIDs []int
+	Dropped    uint64
+	SpawnCap   int
+}
+
+// CommandKind identifica una orden para la simulación
+type CommandKind int
+
+const (
+	SpawnFirefly CommandKind = iota
+	SpawnBurst
+	SetAttraction
+	ClearAttraction
+	CycleWind
+	AddLantern
+	RemoveLantern
+	SetWind
+	MoveLantern
+)
+
+// Command es una orden enviada al jardín; Position, Count, Wind e ID se usan
+// según el tipo (Wind es un nombre como "north"; ID identifica el farol a mover)
+type Command struct {
+	Kind     CommandKind
+	Position utils.Vector2D
+	Count    int
+	Wind     string
+	ID       int
+}
+
+// Clock es la fuente de tiempo de la simulación (ver SetClock)
+type Clock = core.Clock
+
+// FakeClock es un reloj que solo avanza con Advance: la simulación corre tan
+// rápido como se lo avance y sin depender de la hora del sistema
+type FakeClock = core.FakeClock
+
+// NewFakeClock crea un reloj simulado detenido en start
+func NewFakeClock(start time.Time) *FakeClock {
+	return core.NewFakeClock(start)
+}
+
+// ErrUnknownWind se retorna cuando SetWind recibe un nombre no válido
+var ErrUnknownWind = errors.New("garden: dirección de viento desconocida")
+
+// Event es un hecho de la simulación (nacimiento, muerte, farol, viento...)
+type Event = manager.Event
+
+// Options ajusta un jardín nuevo; los campos en cero dejan lo que diga la
+// configuración del proceso. Esa configuración es una sola: New le aplica
+// Fireflies, MaxFireflies y TPS, así que dos jardines del mismo proceso
+// comparten la del último que se creó. Cada jardín cuenta sus descartes.
+type Options struct {
+	// Fireflies es la población inicial
+	Fireflies int
+	// MaxFireflies es el tope de población
+	MaxFireflies int
+	// TPS son los ticks por segundo de cada luciérnaga
+	TPS int
+	// Seed, si no es 0, siembra el generador compartido antes de crear el
+	// jardín: con la misma semilla y modo sincrónico se repite idéntico
+	Seed int64
+	// Clock reemplaza al reloj del sistema (ver SetClock)
+	Clock Clock
+	// Sync crea el jardín en modo sincrónico (ver EnableSync)
+	Sync bool
+}
+
+// Garden es una simulación completa e independiente del render
+type Garden struct {
+	fm *manager.FireflyManager
+}
+
+// New crea un jardín detenido con opts; llamar Start para lanzar las
+// goroutines. Retorna error si las opciones dejan una configuración inválida.
+func New(opts Options) (*Garden, error) {
+	cfg := config.Get().Clone()
+	if opts.Fireflies > 0 {
+		cfg.Fireflies.Initial = opts.Fireflies
+	}
+	if opts.MaxFireflies > 0 {
+		cfg.Fireflies.Max = opts.MaxFireflies
+	}
+	if opts.TPS > 0 {
+		cfg.SimulationTPS = opts.TPS
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	config.Set(cfg)
+
+	// El viento elige su dirección al crearse: se siembra antes
+	if opts.Seed != 0 {
+		utils.Seed(opts.Seed)
+	}
+	g := &Garden{fm: manager.NewFireflyManager()}
+	if opts.Clock != nil {
+		g.fm.SetClock(opts.Clock)
+	}
+	if opts.Sync {
+		g.fm.EnableSync()
+	}
+	return g, nil
+}
+
+// Wrap expone con esta API un manager creado por otro front-end. Quien lo
+// creó sigue siendo responsable de Start y Stop.
+func Wrap(fm *manager.FireflyManager) *Garden {
+	return &Garden{fm: fm}
+}
+
+// SetClock cambia el reloj de las luciérnagas, el viento y el spawner; debe
+// llamarse antes de Start
+func (g *Garden) SetClock(clock Clock) {
+	g.fm.SetClock(clock)
+}
+
+// EnableSync deja el jardín en modo sincrónico: las luciérnagas solo avanzan
+// con Step, sin tickers reales, viento automático ni spawner. Debe llamarse
+// antes de Start.
+func (g *Garden) EnableSync() {
+	g.fm.EnableSync()
+}
+
+// Step avanza un tick de dt a cada luciérnaga y el pulso de los faroles; al
+// retornar, Snapshot ya refleja el paso. Solo en modo sincrónico.
+func (g *Garden) Step(dt float64) int {
+	return g.fm.Step(dt)
+}
+
+// Advance mueve d el FakeClock instalado con SetClock y espera, acotado, a
+// que las luciérnagas publiquen el tick; sin FakeClock no hace nada
+func (g *Garden) Advance(d time.Duration) {
+	g.fm.Advance(d)
+}
+
+// Start lanza el agregador, el viento, el spawner y las luciérnagas iniciales
+func (g *Garden) Start() {
+	g.fm.Start()
+}
+
+// Stop cancela todas las goroutines y espera a que terminen
+func (g *Garden) Stop() {
+	g.fm.Stop()
+}
+
+// Tick avanza los elementos que el front-end anima por frame (pulso de faroles).
+// Las luciérnagas avanzan solas en sus goroutines a config.Get().SimulationTPS.
+func (g *Garden) Tick(dt float64) {
+	g.fm.UpdateLanterns(dt)
+}
+
+// Snapshot retorna el estado actual del jardín
+func (g *Garden) Snapshot() Snapshot {
+	wind := g.fm.GetWindSnapshot()
+	lanterns := g.fm.GetLanterns()
+	fireflies, meta := g.fm.GetSnapshotWithMeta()
+
+	snap := Snapshot{
+		Time:      g.fm.Clock().Now(),
+		Fireflies: fireflies,
+		Lanterns:  make([]LanternState, 0, len(lanterns)),
+		Wind: WindState{
+			Direction: wind.DirectionName(),
+			Force:     wind.Force,
+		},
+		Dropped:   g.fm.GetDroppedStates(),
+		SpawnCap:  g.fm.GetSpawnCap(),
+		Sequence:  meta.Sequence,
+		Tick:      meta.Tick,
+		StateTime: meta.Timestamp,
+	}
+
+	for _, lantern := range lanterns {
+		snap.Lanterns = append(snap.Lanterns, LanternState{
+			ID:        lantern.ID(),
+			Position:  lantern.Position,
+			Radius:    lantern.Radius,
+			Intensity: lantern.GetIntensity(),
+		})
+	}
+
+	return snap
+}
+
+// Status retorna los contadores actuales; LanternIDs va en orden de colocación
+func (g *Garden) Status() Status {
+	lanterns := g.fm.GetLanterns()
+
+	status := Status{
+		Population: g.fm.GetFireflyCount(),
+		LanternIDs: make([]int, 0, len(lanterns)),
+		Dropped:    g.fm.GetDroppedStates(),
+		SpawnCap:   g.fm.GetSpawnCap(),
+	}
+	for _, lantern := range lanterns {
+		status.LanternIDs = append(status.LanternIDs, lantern.ID())
+	}
+
+	return status
+}
+
+// Command encola una orden sin bloquear; después de Stop retorna ErrStopped
+func (g *Garden) Command(cmd Command) error {
+	var mc manager.Command
+
+	switch cmd.Kind {
+	case SpawnFirefly:
+		mc = manager.Command{Type: manager.CommandSpawnFirefly, Data: cmd.Position}
+	case SpawnBurst:
+		count := cmd.Count
+		if count <= 0 {
+			count = config.Get().Spawn.BurstCount
+		}
+		mc = manager.Command{Type: manager.CommandSpawnBurst, Data: manager.BurstRequest{Position: cmd.Position, Count: count}}
+	case SetAttraction:
+		mc = manager.Command{Type: manager.CommandSetAttraction, Data: cmd.Position}
+	case ClearAttraction:
+		mc = manager.Command{Type: manager.CommandClearAttraction}
+	case CycleWind:
+		mc = manager.Command{Type: manager.CommandUpdateWind}
+	case AddLantern:
+		mc = manager.Command{Type: manager.CommandAddLantern, Data: cmd.Position}
+	case RemoveLantern:
+		mc = manager.Command{Type: manager.CommandRemoveLantern}
+	case SetWind:
+		dir, ok := core.ParseWindDirection(cmd.Wind)
+		if !ok {
+			return ErrUnknownWind
+		}
+		mc = manager.Command{Type: manager.CommandSetWind, Data: dir}
+	case MoveLantern:
+		mc = manager.Command{Type: manager.CommandMoveLantern, Data: manager.LanternMove{ID: cmd.ID, Position: cmd.Position}}
+	default:
+		return ErrUnknownCommand
+	}
+
+	if !g.fm.Enqueue(mc) {
+		if g.fm.Stopped() {
+			return ErrStopped
+		}
+		return ErrCommandQueueFull
+	}
+	return nil
+}
+
+// Size retorna las dimensiones actuales del mundo simulado; con ventana
+// siguen a su tamaño
+func (g *Garden) Size() (width, height float64) {
+	return config.WorldSize()
+}
+
+// Subscribe retorna un canal con los eventos del jardín y la función para
+// desuscribirse, que lo cierra. Si el suscriptor se atrasa más de buffer
+// eventos, los siguientes se descartan para él.
+func (g *Garden) Subscribe(buffer int) (<-chan Event, func()) {
+	return g.fm.Events().Subscribe(buffer)
+}
@@ -2,7 +2,6 @@ package utils
 
 import (
 	"math"
-	"math/rand"
 )
 
 type Vector2D struct {
@@ -57,7 +56,7 @@ func Distance(v1, v2 Vector2D) float64 {
 }
 
 func RandomFloat(min, max float64) float64 {
-	return min + rand.Float64()*(max-min)
+	return min + randomFloat64()*(max-min)
 }
 
 func RandomVector2D(minX, maxX, minY, maxY float64) Vector2D {
@@ -68,7 +67,7 @@ func RandomVector2D(minX, maxX, minY, maxY float64) Vector2D {
 }
 
 func RandomUnitVector() Vector2D {
-	angle := rand.Float64() * 2 * math.Pi
+	angle := randomFloat64() * 2 * math.Pi
 	return Vector2D{
 		X: math.Cos(angle),
 		Y: math.Sin(angle),
@@ -89,6 +88,13 @@ func Lerp(a, b, t float64) float64 {
 	return a + (b-a)*t
 }
 
+func LerpVector(a, b Vector2D, t float64) Vector2D {
+	return Vector2D{
+		X: Lerp(a.X, b.X, t),
+		Y: Lerp(a.Y, b.Y, t),
+	}
+}
+
 func WrapAround(pos Vector2D, width, height float64) Vector2D {
 	x := pos.X
 	y := pos.Y
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Generador compartido con semilla conocida, para poder guardarla en los
+// snapshots y reproducir sesiones. rand.Rand no es seguro entre goroutines.
+var (
+	rngMux  sync.Mutex
+	rng     *rand.Rand
+	rngSeed int64
+)
+
+func init() {
+	Seed(time.Now().UnixNano())
+}
+
+// Seed reinicia el generador con la semilla indicada
+func Seed(seed int64) {
+	rngMux.Lock()
+	defer rngMux.Unlock()
+
+	rngSeed = seed
+	rng = rand.New(rand.NewSource(seed))
+}
+
+// CurrentSeed retorna la semilla con la que se inició el generador
+func CurrentSeed() int64 {
+	rngMux.Lock()
+	defer rngMux.Unlock()
+
+	return rngSeed
+}
+
+func randomFloat64() float64 {
+	rngMux.Lock()
+	defer rngMux.Unlock()
+
+	return rng.Float64()
+}